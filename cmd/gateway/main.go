@@ -2,28 +2,52 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
 	"log"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/ayush-pandya/Graphql/internal/attachments"
+	"github.com/ayush-pandya/Graphql/internal/cache"
 	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/config"
+	postgres "github.com/ayush-pandya/Graphql/internal/database"
 	"github.com/ayush-pandya/Graphql/internal/graphql"
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/persisted"
+	"github.com/ayush-pandya/Graphql/internal/querycost"
+	"github.com/ayush-pandya/Graphql/internal/ratelimit"
+	"github.com/ayush-pandya/Graphql/internal/rest"
 	database "github.com/ayush-pandya/Graphql/internal/service"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
 
+	gqlgen "github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	cfg := config.MustLoad("gateway")
+	trusted, err := trustedPeers(cfg.Tenancy)
+	if err != nil {
+		log.Fatalf("Invalid configuration: tenancy.trusted_proxies: %v", err)
+	}
+
 	log.Println("🚀 Starting GraphQL Gateway Server...")
 
 	// Connect to database (optional)
-	db, err := database.Connect()
+	db, err := database.Connect(databaseConfig(cfg.Database))
 	if err != nil {
 		log.Printf("Database connection failed (continuing without DB): %v", err)
 		db = nil
@@ -32,7 +56,7 @@ func main() {
 	}
 
 	// Connect to gRPC microservices
-	ticketServiceURL := getEnv("TICKET_SERVICE_URL", "localhost:50051")
+	ticketServiceURL := cfg.TicketService.URL
 	log.Printf("🔌 Connecting to Ticket Service at %s", ticketServiceURL)
 
 	ticketClient, err := clients.NewTicketClient(ticketServiceURL)
@@ -53,36 +77,135 @@ func main() {
 		}()
 	}
 
+	// Operational endpoints, served on the admin listener only
+	admin := http.NewServeMux()
+
+	var ticketService clients.TicketService
+	if ticketClient != nil {
+		ticketService = ticketClient
+		if cfg.TicketCache.Enabled {
+			metrics := cache.NewMetrics()
+			admin.Handle("/debug/vars", metrics.Handler("ticket_cache"))
+			ticketService = clients.NewCachedTicketClient(ticketClient,
+				cache.NewLRU(cfg.TicketCache.Size), cfg.TicketCache.TTL, metrics)
+			log.Printf("🗄️  Ticket read cache enabled (ttl: %s, size: %d)", cfg.TicketCache.TTL, cfg.TicketCache.Size)
+		}
+	}
+
 	// Create GraphQL resolver with gRPC clients
-	resolver := graphql.NewResolverWithGRPC(db, ticketClient)
+	signer := attachments.NewSigner(attachmentSigningKey(cfg.Attachments), cfg.Attachments.URLTTL, cfg.Attachments.PublicURL)
+	resolver := graphql.NewResolverWithGRPC(db, ticketService).WithAttachmentSigner(signer)
 	log.Println("✅ GraphQL Resolver created with gRPC clients")
 
 	// Create GraphQL server
-	srv := handler.NewDefaultServer(graphql.NewExecutableSchema(graphql.Config{Resolvers: resolver}))
+	srv := handler.New(graphql.NewExecutableSchema(graphql.Config{Resolvers: resolver}))
 	log.Println("✅ GraphQL Schema created")
 
 	// Configure server
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
-	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.MultipartForm{
+		// Leave room for the operations and map parts around the file
+		MaxUploadSize: cfg.Attachments.MaxSize + 1<<20,
+	})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
 	srv.Use(extension.Introspection{})
+
+	persistedQueries, err := persistedQueryExtension(cfg.PersistedQueries, db)
+	if err != nil {
+		log.Fatalf("Failed to set up persisted queries: %v", err)
+	}
+	if persistedQueries != nil {
+		srv.Use(persistedQueries)
+		log.Printf("📌 Persisted queries enabled (mode: %s)", cfg.PersistedQueries.Mode)
+	}
+
+	srv.Use(querycost.New(cfg.QueryLimits.MaxDepth, cfg.QueryLimits.MaxComplexity))
+	srv.Use(&cache.CacheControl{})
+	srv.Use(idempotency.Extension{})
+
+	var queryHandler http.Handler = cache.HeaderMiddleware(srv)
+	var queries, mutations *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		queries = ratelimit.NewLimiter(cfg.RateLimit.QueryRate, cfg.RateLimit.QueryBurst)
+		mutations = ratelimit.NewLimiter(cfg.RateLimit.MutationRate, cfg.RateLimit.MutationBurst)
+		srv.Use(ratelimit.Extension{Queries: queries, Mutations: mutations})
+		queryHandler = ratelimit.Middleware(queryHandler, cfg.RateLimit.TrustProxy, trusted)
+		log.Printf("🚦 Rate limiting enabled: %.1f queries/s, %.1f mutations/s per client",
+			cfg.RateLimit.QueryRate, cfg.RateLimit.MutationRate)
+	}
+	queryHandler = idempotency.Middleware(queryHandler)
+	queryHandler = ratelimit.IdentityMiddleware(queryHandler, trusted)
+	queryHandler = tenant.Middleware(queryHandler, cfg.Tenancy.Header, cfg.Tenancy.Required, trusted)
+	log.Printf("🏢 Organization taken from the %s header (required: %t)", cfg.Tenancy.Header, cfg.Tenancy.Required)
+	switch {
+	case cfg.Tenancy.TrustAllPeers:
+		log.Printf("⚠️  Any client may set the %s header; set tenancy.trusted_proxies to the proxy that authenticates callers", cfg.Tenancy.Header)
+	case len(trusted) == 0:
+		log.Printf("⚠️  No client may set the %s header, every request acts for the %q organization; set tenancy.trusted_proxies to the proxy that authenticates callers",
+			cfg.Tenancy.Header, tenant.Default)
+	}
 	log.Println("✅ GraphQL Server configured")
 
-	// Setup HTTP routes
-	http.Handle("/query", srv)
-	http.Handle("/", playground.Handler("GraphQL Gateway", "/query"))
+	// Setup HTTP routes on a mux of our own, so nothing registered on the
+	// default mux is served publicly
+	mux := http.NewServeMux()
+	mux.Handle("/query", queryHandler)
+	mux.Handle("/", playground.Handler("GraphQL Gateway", "/query"))
+	if ticketService != nil {
+		mux.Handle(attachments.DownloadPath, attachments.DownloadHandler(ticketService, signer))
+
+		// The REST API shares the GraphQL API's rate limits and keeps its
+		// ticket cache fresh
+		var opts []grpc.DialOption
+		if cached, ok := ticketService.(*clients.CachedTicketClient); ok {
+			opts = append(opts, grpc.WithChainUnaryInterceptor(cached.UnaryClientInterceptor()))
+		}
+		restHandler, err := rest.NewHandler(ticketServiceURL, opts...)
+		if err != nil {
+			log.Fatalf("Failed to set up REST API: %v", err)
+		}
+		defer restHandler.Close()
+
+		var handler http.Handler = ratelimit.IdentityMiddleware(restHandler, trusted)
+		if cfg.RateLimit.Enabled {
+			handler = ratelimit.RequestMiddleware(handler, queries, mutations, cfg.RateLimit.TrustProxy, trusted)
+		}
+		mux.Handle(rest.Prefix, tenant.Middleware(handler, cfg.Tenancy.Header, cfg.Tenancy.Required, trusted))
+		mux.Handle(rest.OpenAPIPath, rest.OpenAPIHandler())
+		log.Println("✅ REST API configured")
+	}
 
 	// Start server
 	server := &http.Server{
-		Addr:    ":8080",
-		Handler: nil,
+		Addr:    cfg.Gateway.Addr,
+		Handler: mux,
+	}
+	var adminServer *http.Server
+	if cfg.Gateway.AdminAddr != "" {
+		adminServer = &http.Server{
+			Addr:    cfg.Gateway.AdminAddr,
+			Handler: admin,
+		}
+		go func() {
+			log.Printf("📈 Metrics available at %s/debug/vars", cfg.Gateway.AdminAddr)
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Admin server failed to start: %v", err)
+			}
+		}()
 	}
 
 	go func() {
-		log.Println("🌐 GraphQL Gateway Server starting on http://localhost:8080")
-		log.Println("📊 GraphQL Playground available at http://localhost:8080")
-		log.Println("🔍 GraphQL API endpoint at http://localhost:8080/query")
+		log.Printf("🌐 GraphQL Gateway Server starting on %s", cfg.Gateway.Addr)
+		log.Printf("📊 GraphQL Playground available at %s/", cfg.Gateway.Addr)
+		log.Printf("🔍 GraphQL API endpoint at %s/query", cfg.Gateway.Addr)
+		if ticketService != nil {
+			log.Printf("🧭 REST API at %s%s (OpenAPI document at %s%s)", cfg.Gateway.Addr, rest.Prefix, cfg.Gateway.Addr, rest.OpenAPIPath)
+		}
 		log.Println("🔄 Gateway communicates with microservices via gRPC")
 
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -103,14 +226,69 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			log.Printf("Admin server forced to shutdown: %v", err)
+		}
+	}
 
 	log.Println("👋 GraphQL Gateway stopped")
 }
 
-// getEnv gets an environment variable with a fallback default
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// persistedQueryExtension builds the persisted query extension for the configured mode
+func persistedQueryExtension(cfg config.PersistedQueriesConfig, db *sql.DB) (gqlgen.HandlerExtension, error) {
+	switch cfg.Mode {
+	case "allowlist":
+		manifest, err := persisted.LoadManifest(cfg.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("📜 Loaded %d approved operations from %s", len(manifest.Operations), cfg.Manifest)
+		return persisted.Allowlist{Manifest: manifest}, nil
+	case "apq":
+		if cfg.Store == "postgres" {
+			if db == nil {
+				return nil, fmt.Errorf("postgres persisted query store requires a database connection")
+			}
+			return extension.AutomaticPersistedQuery{Cache: persisted.NewPostgresCache(db, cfg.TTL, cfg.CacheSize)}, nil
+		}
+		return extension.AutomaticPersistedQuery{Cache: lru.New[string](cfg.CacheSize)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// attachmentSigningKey returns the configured URL signing key, or a random one
+// when none is set (download URLs then stop working after a restart)
+func attachmentSigningKey(cfg config.AttachmentsConfig) []byte {
+	if cfg.SigningKey != "" {
+		return []byte(cfg.SigningKey)
+	}
+	log.Println("⚠️  ATTACHMENTS_SIGNING_KEY is not set - using a random key, download URLs will not survive restarts")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatalf("Failed to generate attachment signing key: %v", err)
+	}
+	return key
+}
+
+// databaseConfig converts the database settings into a postgres.Config
+func databaseConfig(d config.DatabaseConfig) postgres.Config {
+	return postgres.Config{
+		Host:     d.Host,
+		Port:     d.Port,
+		User:     d.User,
+		Password: d.Password,
+		DBName:   d.Name,
+		SSLMode:  d.SSLMode,
+	}
+}
+
+// trustedPeers returns the parsed trusted proxies, or every address when all
+// peers are trusted
+func trustedPeers(t config.TenancyConfig) ([]netip.Prefix, error) {
+	if t.TrustAllPeers {
+		return tenant.AllPeers, nil
 	}
-	return defaultValue
+	return tenant.ParseTrusted(t.TrustedProxies)
 }
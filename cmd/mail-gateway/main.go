@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/config"
+	"github.com/ayush-pandya/Graphql/internal/inbound"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	cfg := config.MustLoad("mail-gateway")
+	if cfg.Inbound.Organization != "" {
+		if _, err := tenant.Parse(cfg.Inbound.Organization, false); err != nil {
+			log.Fatalf("Invalid configuration: inbound.organization %q: %v", cfg.Inbound.Organization, err)
+		}
+	}
+
+	log.Println("🚀 Starting Mail Gateway...")
+
+	// Tickets and comments are filed through the ticket service, which only
+	// opens email threads to callers with the inbound token
+	if cfg.Inbound.Token == "" {
+		log.Fatal("inbound.token must be set, to the token the ticket service is started with")
+	}
+	log.Printf("🔌 Connecting to Ticket Service at %s", cfg.TicketService.URL)
+	ticketClient, err := clients.NewTicketClient(cfg.TicketService.URL,
+		grpc.WithChainUnaryInterceptor(inbound.UnaryClientInterceptor(cfg.Inbound.Token)))
+	if err != nil {
+		log.Fatalf("Failed to connect to ticket service: %v", err)
+	}
+	defer func() {
+		if err := ticketClient.Close(); err != nil {
+			log.Printf("Error closing ticket client: %v", err)
+		}
+	}()
+
+	processor := inbound.NewProcessor(ticketClient, inbound.ProcessorOptions{
+		ProjectKey:   cfg.Inbound.ProjectKey,
+		Organization: cfg.Inbound.Organization,
+		Tags:         cfg.Inbound.Tags,
+	})
+	server := inbound.NewSMTPServer(processor, inbound.SMTPOptions{
+		Domain:         cfg.Inbound.Domain,
+		Recipients:     cfg.Inbound.Recipients,
+		MaxMessageSize: cfg.Inbound.MaxMessageSize,
+		Timeout:        cfg.Inbound.Timeout,
+	})
+
+	go func() {
+		log.Printf("📬 Accepting email over SMTP on %s", cfg.Inbound.SMTPAddr)
+		if project := cfg.Inbound.ProjectKey; project != "" {
+			log.Printf("🎫 Filing new tickets in project %s", project)
+		}
+
+		if err := server.ListenAndServe(cfg.Inbound.SMTPAddr); err != nil && !errors.Is(err, inbound.ErrServerClosed) {
+			log.Fatalf("Mail gateway failed to start: %v", err)
+		}
+	}()
+
+	// Graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("🛑 Shutting down Mail Gateway...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Mail gateway forced to shutdown: %v", err)
+	}
+
+	log.Println("👋 Mail Gateway stopped")
+}
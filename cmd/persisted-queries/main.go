@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/persisted"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// taggedTemplate matches gql`...` and graphql`...` template literals
+var taggedTemplate = regexp.MustCompile("(?s)\\b(?:gql|graphql)\\s*`([^`]*)`")
+
+// scriptExtensions are the client source files scanned for tagged templates
+var scriptExtensions = map[string]bool{
+	".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".mjs": true, ".vue": true,
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: persisted-queries <command> [flags]
+
+Commands:
+  extract   extract GraphQL operations from a client codebase into a manifest
+  list      list the operations in a manifest
+
+Run 'persisted-queries <command> -h' for command flags.
+`)
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "extract":
+		extract(os.Args[2:])
+	case "list":
+		list(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+// extract scans a directory for operations and writes them to a manifest
+func extract(args []string) {
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	dir := flags.String("dir", ".", "client source directory to scan")
+	out := flags.String("out", "persisted-queries.json", "manifest file to write")
+	schemaPath := flags.String("schema", "schema.graphql", "schema used to validate operations (empty to skip)")
+	merge := flags.Bool("merge", false, "keep operations already present in the output manifest")
+	flags.Parse(args)
+
+	var schema *ast.Schema
+	if *schemaPath != "" {
+		input, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			log.Fatalf("Failed to read schema: %v", err)
+		}
+		schema, err = gqlparser.LoadSchema(&ast.Source{Name: *schemaPath, Input: string(input)})
+		if err != nil {
+			log.Fatalf("Failed to parse schema: %v", err)
+		}
+	}
+
+	manifest := persisted.NewManifest()
+	if *merge {
+		if existing, err := persisted.LoadManifest(*out); err == nil {
+			manifest = existing
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			log.Fatalf("Failed to load existing manifest: %v", err)
+		}
+	}
+
+	found, failed := 0, 0
+	err := filepath.WalkDir(*dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".") && path != *dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		for _, document := range documentsIn(path) {
+			if err := checkDocument(schema, path, document); err != nil {
+				log.Printf("❌ %s: %v", path, err)
+				failed++
+				continue
+			}
+			hash, err := manifest.Add(document)
+			if err != nil {
+				log.Printf("❌ %s: %v", path, err)
+				failed++
+				continue
+			}
+			log.Printf("✅ %s: %s", path, hash[:12])
+			found++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to scan %s: %v", *dir, err)
+	}
+
+	if failed > 0 {
+		log.Fatalf("%d operations failed validation, manifest not written", failed)
+	}
+
+	if err := manifest.Save(*out); err != nil {
+		log.Fatalf("%v", err)
+	}
+	log.Printf("📦 Wrote %d operations (%d found in %s) to %s", len(manifest.Operations), found, *dir, *out)
+}
+
+// documentsIn returns the GraphQL documents contained in a file
+func documentsIn(path string) []string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".graphql" && ext != ".gql" && !scriptExtensions[ext] {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("⚠️  Failed to read %s: %v", path, err)
+		return nil
+	}
+
+	if ext == ".graphql" || ext == ".gql" {
+		return []string{strings.TrimSpace(string(data))}
+	}
+
+	var documents []string
+	for _, match := range taggedTemplate.FindAllStringSubmatch(string(data), -1) {
+		document := strings.TrimSpace(match[1])
+		if strings.Contains(document, "${") {
+			log.Printf("⚠️  %s: skipping template with interpolation, inline fragments before extracting", path)
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+// checkDocument makes sure a document is an executable operation valid against the schema
+func checkDocument(schema *ast.Schema, path, document string) error {
+	doc, err := parser.ParseQuery(&ast.Source{Name: path, Input: document})
+	if err != nil {
+		return err
+	}
+	if len(doc.Operations) == 0 {
+		return fmt.Errorf("document contains no operation")
+	}
+	if schema != nil {
+		if errs := validator.Validate(schema, doc); len(errs) > 0 {
+			return errs
+		}
+	}
+	return nil
+}
+
+// list prints the operations in a manifest
+func list(args []string) {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	path := flags.String("manifest", "persisted-queries.json", "manifest file to read")
+	flags.Parse(args)
+
+	manifest, err := persisted.LoadManifest(*path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	for _, hash := range manifest.Hashes() {
+		query := manifest.Operations[hash]
+		firstLine, _, _ := strings.Cut(query, "\n")
+		fmt.Printf("%s  %s\n", hash, firstLine)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/ayush-pandya/Graphql/internal/config"
+	postgres "github.com/ayush-pandya/Graphql/internal/database"
 	"github.com/ayush-pandya/Graphql/internal/graphql"
 	database "github.com/ayush-pandya/Graphql/internal/service"
 
@@ -31,6 +33,10 @@ func createTestTicket(resolver *graphql.Resolver) (*graphql.Ticket, error) {
 		nil,
 		"user-123",
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ticket: %v", err)
@@ -43,7 +49,7 @@ func createTestTicket(resolver *graphql.Resolver) (*graphql.Ticket, error) {
 // retrieveTicket gets a ticket from the database by ID
 func retrieveTicket(resolver *graphql.Resolver, ticketID string) (*graphql.Ticket, error) {
 	log.Printf("Attempting to retrieve ticket with ID: %s", ticketID)
-	ticket, err := resolver.Query().Ticket(context.Background(), ticketID)
+	ticket, err := resolver.Query().Ticket(context.Background(), &ticketID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve ticket: %v", err)
 	}
@@ -58,8 +64,10 @@ func retrieveTicket(resolver *graphql.Resolver, ticketID string) (*graphql.Ticke
 }
 
 func main() {
+	cfg := config.MustLoad("server")
+
 	// Connect to database (optional - the resolvers work without it for now)
-	db, err := database.Connect()
+	db, err := database.Connect(databaseConfig(cfg.Database))
 	if err != nil {
 		log.Printf("Database connection failed (continuing without DB): %v", err)
 		db = nil // Set to nil so server can still start
@@ -83,13 +91,27 @@ func main() {
 	srv.Use(extension.Introspection{})
 	log.Println("✅ GraphQL transports configured")
 
-	// Setup routes
-	http.Handle("/query", srv)
-	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
+	// Setup routes on a mux of our own, so nothing registered on the default
+	// mux is served publicly
+	mux := http.NewServeMux()
+	mux.Handle("/query", srv)
+	mux.Handle("/", playground.Handler("GraphQL playground", "/query"))
+
+	log.Printf("🚀 GraphQL server starting on %s", cfg.Gateway.Addr)
+	log.Printf("📊 GraphQL Playground available at %s/", cfg.Gateway.Addr)
+	log.Printf("🔍 GraphQL endpoint at %s/query", cfg.Gateway.Addr)
 
-	log.Println("🚀 GraphQL server starting on http://localhost:8080")
-	log.Println("📊 GraphQL Playground available at http://localhost:8080")
-	log.Println("🔍 GraphQL endpoint at http://localhost:8080/query")
+	log.Fatal(http.ListenAndServe(cfg.Gateway.Addr, mux))
+}
 
-	log.Fatal(http.ListenAndServe(":8080", nil))
+// databaseConfig converts the database settings into a postgres.Config
+func databaseConfig(d config.DatabaseConfig) postgres.Config {
+	return postgres.Config{
+		Host:     d.Host,
+		Port:     d.Port,
+		User:     d.User,
+		Password: d.Password,
+		DBName:   d.Name,
+		SSLMode:  d.SSLMode,
+	}
 }
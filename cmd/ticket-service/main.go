@@ -5,212 +5,254 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
-
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/attachments"
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/config"
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/grpcweb"
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/inbound"
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/outbox"
+	"github.com/ayush-pandya/Graphql/internal/ratelimit"
+	"github.com/ayush-pandya/Graphql/internal/sla"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
 	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/grpc/reflection"
 )
 
-// ticketServer implements the TicketService gRPC service
-type ticketServer struct {
-	ticketpb.UnimplementedTicketServiceServer
-	tickets map[string]*ticketpb.Ticket
-	mu      sync.RWMutex
-	counter int64
-}
-
-// newTicketServer creates a new ticket server with some sample data
-func newTicketServer() *ticketServer {
-	server := &ticketServer{
-		tickets: make(map[string]*ticketpb.Ticket),
-		counter: 0,
-	}
-
-	// Add some sample tickets
-	now := timestamppb.Now()
-	sampleTickets := []*ticketpb.Ticket{
-		{
-			Id:          "ticket-1",
-			Title:       "Fix login bug",
-			Description: "Users cannot log in with their email",
-			Status:      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
-			Priority:    ticketpb.TicketPriority_TICKET_PRIORITY_HIGH,
-			AssigneeId:  "user-123",
-			Tags:        []string{"bug", "urgent"},
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		},
-		{
-			Id:          "ticket-2",
-			Title:       "Add dark mode",
-			Description: "Implement dark mode for better user experience",
-			Status:      ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS,
-			Priority:    ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM,
-			AssigneeId:  "user-456",
-			Tags:        []string{"feature", "ui"},
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		},
-	}
-
-	for _, ticket := range sampleTickets {
-		server.tickets[ticket.Id] = ticket
-	}
-
-	return server
+// stores are the stores the ticket service keeps its data in
+type stores struct {
+	tickets       store.TicketStore
+	metadata      attachments.MetadataStore
+	webhooks      webhooks.Store
+	notifications notifications.Store
+	comments      comments.Store
+	idempotency   idempotency.Store
 }
 
-// CreateTicket creates a new ticket
-func (s *ticketServer) CreateTicket(ctx context.Context, req *ticketpb.CreateTicketRequest) (*ticketpb.CreateTicketResponse, error) {
-	log.Printf("gRPC Microservice: Creating ticket - Title: %s", req.Title)
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.counter++
-	ticketID := fmt.Sprintf("ticket-%d", s.counter+100) // Start from 100 to avoid conflicts
-
-	now := timestamppb.Now()
-	ticket := &ticketpb.Ticket{
-		Id:          ticketID,
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
-		Priority:    req.Priority,
-		AssigneeId:  req.AssigneeId,
-		Tags:        req.Tags,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+// openStore opens the ticket store selected by the configuration and the
+// attachment metadata, webhook, notification, comment and idempotency key stores
+// that go with it
+func openStore(cfg *config.Config) (*stores, error) {
+	switch cfg.TicketService.Store {
+	case "postgres":
+		dbConfig := databaseConfig(cfg.Database)
+		log.Printf("🔌 Connecting to PostgreSQL at %s:%s", dbConfig.Host, dbConfig.Port)
+
+		db, err := database.NewConnection(dbConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		// Organizations are only isolated by row-level security
+		bypass, err := database.BypassesRowSecurity(context.Background(), db)
+		if err == nil && bypass {
+			err = fmt.Errorf("role %q bypasses row-level security, so organizations would see each other's data; "+
+				"connect as a member of ticket_app (see migrations/018_create_app_role.sql)", dbConfig.User)
+		}
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		tickets := store.NewPostgresStore(db)
+		return &stores{
+			tickets:       tickets,
+			metadata:      tickets.Attachments(),
+			webhooks:      tickets.Webhooks(),
+			notifications: tickets.Notifications(),
+			comments:      tickets.Comments(),
+			idempotency:   tickets.Idempotency(),
+		}, nil
+	case "sqlite":
+		log.Printf("🗄️  Opening SQLite database at %s", cfg.TicketService.SQLitePath)
+
+		db, err := store.OpenSQLite(cfg.TicketService.SQLitePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+		}
+		return &stores{
+			tickets:       db,
+			metadata:      db.Attachments(),
+			webhooks:      db.Webhooks(),
+			notifications: db.Notifications(),
+			comments:      db.Comments(),
+			idempotency:   db.Idempotency(),
+		}, nil
+	default:
+		memory := &stores{
+			metadata:      attachments.NewMemoryMetadata(),
+			webhooks:      webhooks.NewMemoryStore(),
+			notifications: notifications.NewMemoryStore(),
+			comments:      comments.NewMemoryStore(),
+			idempotency:   idempotency.NewMemoryStore(),
+		}
+		if persistence := cfg.TicketService.Persistence; persistence.Dir != "" {
+			log.Printf("💾 Persisting tickets and their event outbox to %s (fsync: %s)", persistence.Dir, persistence.Sync)
+
+			durable, err := store.OpenDurableStore(persistence.Dir, store.DurableOptions{
+				Sync:              store.SyncPolicy(persistence.Sync),
+				SyncInterval:      persistence.SyncInterval,
+				SnapshotInterval:  persistence.SnapshotInterval,
+				SnapshotThreshold: persistence.SnapshotThreshold,
+			}, store.SampleTickets()...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore tickets: %w", err)
+			}
+			memory.tickets = durable
+			return memory, nil
+		}
+		memory.tickets = store.NewMemoryStore(store.SampleTickets()...)
+		return memory, nil
 	}
-
-	s.tickets[ticketID] = ticket
-	log.Printf("gRPC Microservice: Ticket created successfully - ID: %s", ticketID)
-
-	return &ticketpb.CreateTicketResponse{
-		Ticket: ticket,
-	}, nil
 }
 
-// GetTicket retrieves a ticket by ID
-func (s *ticketServer) GetTicket(ctx context.Context, req *ticketpb.GetTicketRequest) (*ticketpb.GetTicketResponse, error) {
-	log.Printf("gRPC Microservice: Getting ticket - ID: %s", req.Id)
-
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	ticket, exists := s.tickets[req.Id]
-	if !exists {
-		return nil, fmt.Errorf("ticket not found: %s", req.Id)
+func main() {
+	cfg := config.MustLoad("ticket-service")
+	trusted, err := trustedPeers(cfg.Tenancy)
+	if err != nil {
+		log.Fatalf("Invalid configuration: tenancy.trusted_proxies: %v", err)
 	}
-
-	log.Printf("gRPC Microservice: Ticket retrieved successfully - ID: %s", req.Id)
-	return &ticketpb.GetTicketResponse{
-		Ticket: ticket,
-	}, nil
-}
-
-// ListTickets retrieves all tickets
-func (s *ticketServer) ListTickets(ctx context.Context, req *ticketpb.ListTicketsRequest) (*ticketpb.ListTicketsResponse, error) {
-	log.Println("gRPC Microservice: Listing tickets")
-
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	tickets := make([]*ticketpb.Ticket, 0, len(s.tickets))
-	for _, ticket := range s.tickets {
-		tickets = append(tickets, ticket)
+	policies, err := sla.ParsePolicies(cfg.SLA.Policies)
+	if err != nil {
+		log.Fatalf("Invalid configuration: sla.policies: %v", err)
 	}
 
-	log.Printf("gRPC Microservice: Listed %d tickets", len(tickets))
-	return &ticketpb.ListTicketsResponse{
-		Tickets: tickets,
-	}, nil
-}
-
-// UpdateTicket updates an existing ticket
-func (s *ticketServer) UpdateTicket(ctx context.Context, req *ticketpb.UpdateTicketRequest) (*ticketpb.UpdateTicketResponse, error) {
-	log.Printf("gRPC Microservice: Updating ticket - ID: %s", req.Id)
+	log.Printf("🚀 Starting Ticket gRPC Microservice (store: %s)...", cfg.TicketService.Store)
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	opened, err := openStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to open ticket store: %v", err)
+	}
+	tickets := opened.tickets
+	defer tickets.Close()
 
-	ticket, exists := s.tickets[req.Id]
-	if !exists {
-		return nil, fmt.Errorf("ticket not found: %s", req.Id)
+	// Open the attachment blob store
+	blobs, err := attachments.NewBlobStore(context.Background(), cfg.Attachments)
+	if err != nil {
+		log.Fatalf("Failed to open attachment store: %v", err)
 	}
 
-	// Update fields
-	if req.Title != "" {
-		ticket.Title = req.Title
+	// Create TCP listener
+	port := cfg.TicketService.Port
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
-	if req.Description != "" {
-		ticket.Description = req.Description
+
+	// Create gRPC server; every call acts for the organization in its metadata
+	// and only the mail gateway may use email threads
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(cfg.Tenancy.Required, trusted),
+			inbound.UnaryServerInterceptor(cfg.Inbound.Token)),
+		grpc.ChainStreamInterceptor(tenant.StreamServerInterceptor(cfg.Tenancy.Required, trusted)),
 	}
-	if req.Status != ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED {
-		ticket.Status = req.Status
+	if cfg.RateLimit.GRPC {
+		queries := ratelimit.NewLimiter(cfg.RateLimit.QueryRate, cfg.RateLimit.QueryBurst)
+		mutations := ratelimit.NewLimiter(cfg.RateLimit.MutationRate, cfg.RateLimit.MutationBurst)
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(ratelimit.UnaryServerInterceptor(queries, mutations, trusted)),
+			grpc.ChainStreamInterceptor(ratelimit.StreamServerInterceptor(queries, mutations, trusted)),
+		)
+		log.Println("🚦 gRPC rate limiting enabled")
 	}
-	if req.Priority != ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
-		ticket.Priority = req.Priority
+	// Calls retried with the same idempotency key replay the first response
+	if retention := cfg.Idempotency.Retention; retention > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(idempotency.UnaryServerInterceptor(opened.idempotency, retention)))
+		log.Printf("🔁 Idempotency keys kept for %s", retention)
 	}
-	if req.AssigneeId != "" {
-		ticket.AssigneeId = req.AssigneeId
+	s := grpc.NewServer(opts...)
+
+	// Events recorded in the store's outbox are relayed to NATS, to the
+	// webhooks subscribed to them, to the notifier and to the event bus, which
+	// logs them. The sinks that may fail go first: a failure hands the event
+	// to every sink again on the next pass.
+	bus := events.NewBus()
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		log.Printf("📣 %s: ticket %s (organization %s)", event.Type, event.Ticket.Id, event.OrganizationID)
+	})
+	dispatcher := webhooks.NewDispatcher(opened.webhooks, webhookOptions(cfg.Webhooks))
+	var channels []notifications.Channel
+	if cfg.Notifications.SMTPHost != "" {
+		channels = append(channels, notifications.NewEmailChannel(emailOptions(cfg.Notifications)))
+		log.Printf("✉️  Emailing notifications through %s:%s", cfg.Notifications.SMTPHost, cfg.Notifications.SMTPPort)
 	}
-	if len(req.Tags) > 0 {
-		ticket.Tags = req.Tags
+	notifier := notifications.NewNotifier(opened.notifications, channels...)
+	var sinks []outbox.Sink
+	if cfg.Outbox.NATSURL != "" {
+		natsSink, err := outbox.NewNATSSink(cfg.Outbox.NATSURL, cfg.Outbox.NATSSubjectPrefix)
+		if err != nil {
+			log.Fatalf("Failed to connect to NATS: %v", err)
+		}
+		defer natsSink.Close()
+		sinks = append(sinks, natsSink)
+		log.Printf("📡 Publishing ticket events to NATS subjects %s.>", cfg.Outbox.NATSSubjectPrefix)
 	}
+	sinks = append(sinks, outbox.SinkFunc(dispatcher.Queue), outbox.SinkFunc(notifier.Notify), outbox.Bus(bus))
+	relay := outbox.NewRelay(tickets, sinks...)
 
-	ticket.UpdatedAt = timestamppb.Now()
-	s.tickets[req.Id] = ticket
-
-	log.Printf("gRPC Microservice: Ticket updated successfully - ID: %s", req.Id)
-	return &ticketpb.UpdateTicketResponse{
-		Ticket: ticket,
-	}, nil
-}
-
-// DeleteTicket deletes a ticket
-func (s *ticketServer) DeleteTicket(ctx context.Context, req *ticketpb.DeleteTicketRequest) (*ticketpb.DeleteTicketResponse, error) {
-	log.Printf("gRPC Microservice: Deleting ticket - ID: %s", req.Id)
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	_, exists := s.tickets[req.Id]
-	if !exists {
-		return &ticketpb.DeleteTicketResponse{Success: false}, nil
+	// Register service
+	ticketService := ticketservice.NewServer(tickets, blobs, opened.metadata, attachments.LimitsFromConfig(cfg.Attachments),
+		sla.NewTracker(policies, cfg.SLA.AtRisk), opened.webhooks, opened.notifications, opened.comments)
+	ticketpb.RegisterTicketServiceServer(s, ticketService)
+	if cfg.TicketService.Reflection {
+		reflection.Register(s)
+		log.Println("🔎 gRPC server reflection enabled")
 	}
 
-	delete(s.tickets, req.Id)
-	log.Printf("gRPC Microservice: Ticket deleted successfully - ID: %s", req.Id)
-
-	return &ticketpb.DeleteTicketResponse{Success: true}, nil
-}
-
-func main() {
-	log.Println("🚀 Starting Ticket gRPC Microservice...")
+	log.Printf("✅ Ticket Service registered with %s store", cfg.TicketService.Store)
 
-	// Create TCP listener
-	lis, err := net.Listen("tcp", ":50051")
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+	// Browsers reach the same server, interceptors included, over gRPC-Web
+	// and Connect on a separate HTTP/1.1 listener
+	var webServer *http.Server
+	if addr := cfg.TicketService.Web.Addr; addr != "" {
+		webServer = &http.Server{
+			Addr:    addr,
+			Handler: grpcweb.NewHandler(s, cfg.TicketService.Web.AllowedOrigins),
+		}
+		go func() {
+			log.Printf("🌍 gRPC-Web and Connect listening on %s", addr)
+			if err := webServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to serve gRPC-Web: %v", err)
+			}
+		}()
 	}
 
-	// Create gRPC server
-	s := grpc.NewServer()
-
-	// Register service
-	ticketService := newTicketServer()
-	ticketpb.RegisterTicketServiceServer(s, ticketService)
-
-	log.Println("✅ Ticket Service registered")
+	// Watch SLA deadlines, relay events and deliver webhooks and notifications
+	// in the background
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	if interval := cfg.SLA.EvaluationInterval; interval > 0 {
+		log.Printf("⏱️  Evaluating SLAs every %s", interval)
+		go ticketService.RunSLAEvaluator(workersCtx, interval)
+	}
+	if interval := cfg.Outbox.RelayInterval; interval > 0 {
+		log.Printf("📤 Relaying outbox events every %s", interval)
+		go relay.Run(workersCtx, interval)
+	}
+	if interval := cfg.Webhooks.DeliveryInterval; interval > 0 {
+		log.Printf("🪝 Delivering webhooks every %s", interval)
+		go dispatcher.Run(workersCtx, interval)
+	}
+	if interval := cfg.Notifications.DeliveryInterval; interval > 0 && len(channels) > 0 {
+		log.Printf("🔔 Sending notifications every %s", interval)
+		go notifier.Run(workersCtx, interval)
+	}
 
 	// Start server in goroutine
 	go func() {
-		log.Println("🌐 Ticket gRPC Microservice listening on :50051")
+		log.Printf("🌐 Ticket gRPC Microservice listening on :%s", port)
 		log.Println("🎫 Ready to handle ticket operations")
 
 		if err := s.Serve(lis); err != nil {
@@ -224,6 +266,59 @@ func main() {
 	<-quit
 
 	log.Println("🛑 Shutting down Ticket gRPC Microservice...")
+	stopWorkers()
+	if webServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := webServer.Shutdown(ctx); err != nil {
+			log.Printf("gRPC-Web server forced to shutdown: %v", err)
+		}
+		cancel()
+	}
 	s.GracefulStop()
 	log.Println("👋 Ticket gRPC Microservice stopped")
 }
+
+// trustedPeers returns the parsed trusted proxies, or every address when all
+// peers are trusted
+func trustedPeers(t config.TenancyConfig) ([]netip.Prefix, error) {
+	if t.TrustAllPeers {
+		return tenant.AllPeers, nil
+	}
+	return tenant.ParseTrusted(t.TrustedProxies)
+}
+
+// databaseConfig converts the database settings into a database.Config
+func databaseConfig(d config.DatabaseConfig) database.Config {
+	return database.Config{
+		Host:     d.Host,
+		Port:     d.Port,
+		User:     d.User,
+		Password: d.Password,
+		DBName:   d.Name,
+		SSLMode:  d.SSLMode,
+	}
+}
+
+// webhookOptions returns the delivery options of the webhook dispatcher
+func webhookOptions(w config.WebhooksConfig) webhooks.Options {
+	return webhooks.Options{
+		MaxAttempts: w.MaxAttempts,
+		Backoff:     w.Backoff,
+		MaxBackoff:  w.MaxBackoff,
+		Timeout:     w.Timeout,
+
+		AllowPrivateNetworks: w.AllowPrivateNetworks,
+	}
+}
+
+// emailOptions returns the options of the notification email channel
+func emailOptions(n config.NotificationsConfig) notifications.EmailOptions {
+	return notifications.EmailOptions{
+		Host:     n.SMTPHost,
+		Port:     n.SMTPPort,
+		Username: n.SMTPUsername,
+		Password: n.SMTPPassword,
+		From:     n.SMTPFrom,
+		Timeout:  n.SMTPTimeout,
+	}
+}
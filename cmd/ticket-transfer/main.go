@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/config"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/transfer"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: ticket-transfer <command> [flags]
+
+Commands:
+  export   write tickets to a CSV or NDJSON file
+  import   create tickets from a CSV or NDJSON file
+
+Run 'ticket-transfer <command> -h' for command flags.
+`)
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "export":
+		export(os.Args[2:])
+	case "import":
+		importTickets(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+// keyValues collects repeated key=value flags
+type keyValues map[string]string
+
+func (kv keyValues) String() string {
+	pairs := make([]string, 0, len(kv))
+	for key, value := range kv {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValues) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("invalid value %q: use key=value", s)
+	}
+	kv[strings.TrimSpace(key)] = value
+	return nil
+}
+
+// connect loads the configuration from the remaining flags and connects to
+// the ticket service, acting for the given organization
+func connect(flags *flag.FlagSet, args []string, organization *string) (context.Context, *clients.TicketClient) {
+	cfg, err := config.Load(flags, args)
+	if errors.Is(err, config.ErrConfigPrinted) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	ctx := context.Background()
+	if *organization != "" {
+		id, err := tenant.Parse(*organization, false)
+		if err != nil {
+			log.Fatalf("Invalid organization %q: %v", *organization, err)
+		}
+		ctx = tenant.WithOrganization(ctx, id)
+	}
+
+	client, err := clients.NewTicketClient(cfg.TicketService.URL)
+	if err != nil {
+		log.Fatalf("Failed to connect to ticket service: %v", err)
+	}
+	return ctx, client
+}
+
+// export writes the tickets matching the filters to a file
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	out := flags.String("out", "-", "file to write, - for stdout")
+	formatName := flags.String("format", "", "csv or ndjson (default: from the file extension, else csv)")
+	organization := flags.String("organization", "", "organization to export from (default: the default organization)")
+	project := flags.String("project", "", "only tickets of the project with this key")
+	tags := flags.String("tags", "", "only tickets carrying all of these comma-separated tags")
+	query := flags.String("query", "", "only tickets whose title or description contains this text")
+	customFields := make(keyValues)
+	flags.Var(customFields, "custom-field", "only tickets with this custom field value, as key=value (repeatable)")
+	ctx, client := connect(flags, args, organization)
+	defer client.Close()
+
+	format, err := transfer.ParseFormat(*formatName, *out)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	req := &ticketpb.ExportTicketsRequest{
+		Query:        *query,
+		ProjectKey:   *project,
+		CustomFields: customFields,
+	}
+	for _, tag := range strings.Split(*tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			req.Tags = append(req.Tags, tag)
+		}
+	}
+
+	// CSV has a column for every custom field the tickets may carry
+	var keys []string
+	if format == transfer.CSV {
+		if keys, err = customFieldKeys(ctx, client, *project); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *out, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	var writer transfer.Writer
+	if format == transfer.CSV {
+		writer = transfer.NewCSVWriter(w, keys)
+	} else {
+		writer = transfer.NewNDJSONWriter(w)
+	}
+
+	exported := 0
+	err = client.ExportTickets(ctx, req, func(records []*ticketpb.TicketRecord) error {
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write ticket %s: %w", record.Id, err)
+			}
+		}
+		exported += len(records)
+		return nil
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		if *out != "-" {
+			os.Remove(*out)
+		}
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	log.Printf("📦 Exported %d tickets as %s to %s", exported, format, *out)
+}
+
+// customFieldKeys returns the keys of the custom fields defined by the
+// project with the given key, or by every project when it is empty
+func customFieldKeys(ctx context.Context, client *clients.TicketClient, projectKey string) ([]string, error) {
+	var projects []*ticketpb.Project
+	if projectKey != "" {
+		project, err := client.GetProject(ctx, "", projectKey)
+		if err != nil {
+			return nil, err
+		}
+		projects = []*ticketpb.Project{project}
+	} else {
+		var err error
+		if projects, err = client.ListProjects(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var keys []string
+	for _, project := range projects {
+		for _, field := range project.CustomFields {
+			if !seen[field.Key] {
+				seen[field.Key] = true
+				keys = append(keys, field.Key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// idModes maps the --id-mode values to ID modes
+var idModes = map[string]ticketpb.ImportIDMode{
+	"remap":    ticketpb.ImportIDMode_IMPORT_ID_MODE_REMAP,
+	"preserve": ticketpb.ImportIDMode_IMPORT_ID_MODE_PRESERVE,
+}
+
+// importTickets creates tickets from a file and reports the outcome of
+// every record
+func importTickets(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	in := flags.String("in", "-", "file to read, - for stdin")
+	formatName := flags.String("format", "", "csv or ndjson (default: from the file extension, else csv)")
+	organization := flags.String("organization", "", "organization to import into (default: the default organization)")
+	mappingSpec := flags.String("map", "", `source columns to read as record fields, e.g. "Summary=title,Issue key=id,Sprint=custom.sprint,Votes=-"`)
+	idMode := flags.String("id-mode", "remap", "remap derives new IDs from the source IDs; preserve keeps them (they must be UUIDs)")
+	dryRun := flags.Bool("dry-run", false, "validate every record and report what would happen without creating tickets")
+	project := flags.String("project", "", "project of the records that name none")
+	reportPath := flags.String("report", "", "CSV file to write the outcome of every record to")
+	ctx, client := connect(flags, args, organization)
+	defer client.Close()
+
+	format, err := transfer.ParseFormat(*formatName, *in)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	mapping, err := transfer.ParseMapping(*mappingSpec)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	mode, ok := idModes[strings.ToLower(*idMode)]
+	if !ok {
+		log.Fatalf("Unknown ID mode %q: use remap or preserve", *idMode)
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "-" {
+		file, err := os.Open(*in)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", *in, err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var reader transfer.Reader
+	if format == transfer.CSV {
+		if reader, err = transfer.NewCSVReader(r, mapping); err != nil {
+			log.Fatalf("%v", err)
+		}
+	} else {
+		reader = transfer.NewNDJSONReader(r, mapping)
+	}
+
+	// Records the reader rejects never reach the service; lines maps the
+	// records sent to the lines they came from
+	var unreadable []*transfer.RecordError
+	var lines []int
+	resp, err := client.ImportTickets(ctx, &ticketpb.ImportOptions{
+		IdMode:     mode,
+		DryRun:     *dryRun,
+		ProjectKey: *project,
+	}, func() (*ticketpb.TicketRecord, error) {
+		for {
+			record, err := reader.Read()
+			var recordErr *transfer.RecordError
+			if errors.As(err, &recordErr) {
+				unreadable = append(unreadable, recordErr)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, reader.Line())
+			return record, nil
+		}
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	if ignored := reader.Ignored(); len(ignored) > 0 {
+		log.Printf("⚠️  Ignored columns: %s (map them with --map)", strings.Join(ignored, ", "))
+	}
+	rows := reportRows(lines, unreadable, resp.Results)
+	for _, row := range rows {
+		switch {
+		case row.err == "":
+		case row.sourceID == "":
+			log.Printf("❌ Line %d: %s", row.line, row.err)
+		default:
+			log.Printf("❌ Line %d (%s): %s", row.line, row.sourceID, row.err)
+		}
+	}
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, rows); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+
+	verb := "Created"
+	if resp.DryRun {
+		verb = "Dry run: would create"
+	}
+	invalid := int(resp.Invalid) + len(unreadable)
+	log.Printf("📦 %s %d tickets, skipped %d existing, %d invalid, %d failed", verb, resp.Created, resp.Skipped, invalid, resp.Failed)
+	if invalid > 0 || resp.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// reportRow is the outcome of one record of the source file
+type reportRow struct {
+	line                    int
+	sourceID, ticketID, key string
+	outcome, err            string
+}
+
+// reportRows merges the records the reader rejected with the results of the
+// records sent, in source order
+func reportRows(lines []int, unreadable []*transfer.RecordError, results []*ticketpb.ImportResult) []reportRow {
+	rows := make([]reportRow, 0, len(unreadable)+len(results))
+	for _, recordErr := range unreadable {
+		rows = append(rows, reportRow{line: recordErr.Line, outcome: "INVALID", err: recordErr.Err.Error()})
+	}
+	for _, result := range results {
+		row := reportRow{
+			sourceID: result.SourceId,
+			ticketID: result.TicketId,
+			key:      result.Key,
+			outcome:  strings.TrimPrefix(result.Outcome.String(), "IMPORT_OUTCOME_"),
+			err:      result.Error,
+		}
+		if i := int(result.Record) - 1; i >= 0 && i < len(lines) {
+			row.line = lines[i]
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].line < rows[j].line })
+	return rows
+}
+
+// writeReport writes the outcome of every record as CSV
+func writeReport(path string, rows []reportRow) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"line", "source_id", "ticket_id", "key", "outcome", "error"})
+	for _, row := range rows {
+		w.Write([]string{strconv.Itoa(row.line), row.sourceID, row.ticketID, row.key, row.outcome, row.err})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+)
+
+// source is an NDJSON file of another tracker, keyed by its own IDs
+const source = `{"id": "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b", "title": "Login broken", "description": "Steps:\n1. Log in, with a comma", "status": "In progress", "priority": "Major", "assignee_id": "bob", "reporter_id": "alice", "tags": ["auth", "web"], "created_at": "2024-05-01T09:30:00Z", "updated_at": "2024-05-02T10:00:00Z", "due_date": "2030-01-01"}
+{"id": "0b9e7d6c-5a4b-4c3d-9e2f-1a0b9c8d7e6f", "title": "Café <menu> & prices", "status": "Done", "created_at": "2024-04-01 08:00", "updated_at": "2024-04-03 08:00"}
+`
+
+// run runs a ticket-transfer command against the service at addr
+func run(t *testing.T, command func([]string), addr string, args ...string) {
+	t.Helper()
+	command(append([]string{"--env-file=" + os.DevNull, "--ticket-service-url=" + addr}, args...))
+}
+
+// tickets returns the tickets of a service, oldest first
+func tickets(t *testing.T, service *servicetest.Service) []*ticketpb.Ticket {
+	t.Helper()
+	tickets, _, err := service.Tickets.List(context.Background(), store.ListOptions{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	slices.SortFunc(tickets, func(a, b *ticketpb.Ticket) int { return a.CreatedAt.AsTime().Compare(b.CreatedAt.AsTime()) })
+	return tickets
+}
+
+func TestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name) }
+	if err := os.WriteFile(path("source.ndjson"), []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	origin := servicetest.Start(t)
+	run(t, importTickets, origin.Addr, "--in", path("source.ndjson"), "--id-mode", "preserve", "--report", path("report.csv"))
+	want := tickets(t, origin)
+	if len(want) != 2 {
+		t.Fatalf("import created %d tickets, want 2", len(want))
+	}
+
+	report, err := os.Open(path("report.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer report.Close()
+	rows, err := csv.NewReader(report).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if len(rows) != 3 || rows[1][0] != "1" || rows[1][4] != "CREATED" || rows[2][0] != "2" || rows[2][4] != "CREATED" {
+		t.Errorf("report %q, want both lines created", rows)
+	}
+
+	// Exports in either format import as the same tickets elsewhere
+	for _, name := range []string{"export.csv", "export.ndjson"} {
+		t.Run(name, func(t *testing.T) {
+			run(t, export, origin.Addr, "--out", path(name))
+
+			copied := servicetest.Start(t)
+			run(t, importTickets, copied.Addr, "--in", path(name), "--id-mode", "preserve")
+			got := tickets(t, copied)
+			if len(got) != len(want) {
+				t.Fatalf("import of the export created %d tickets, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if !proto.Equal(got[i], want[i]) {
+					t.Errorf("ticket %d:\ngot  %v\nwant %v", i+1, got[i], want[i])
+				}
+			}
+
+			// Importing again changes nothing
+			run(t, importTickets, copied.Addr, "--in", path(name), "--id-mode", "preserve")
+			if n := len(tickets(t, copied)); n != len(want) {
+				t.Errorf("second import left %d tickets, want %d", n, len(want))
+			}
+		})
+	}
+}
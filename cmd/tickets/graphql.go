@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ticketFields selects the fields of a ticket the commands print
+const ticketFields = `fragment TicketFields on Ticket {
+  id key title description status priority
+  assignee { id } reporter { id }
+  tags customFields { key value } dueDate sla { status }
+  createdAt updatedAt
+}`
+
+// graphqlAPI talks to the GraphQL gateway
+type graphqlAPI struct {
+	url          string
+	client       *http.Client
+	tenantHeader string
+	organization string
+	apiKey       string
+}
+
+// newGraphQLAPI returns the API of the gateway serving GraphQL at url. A
+// TLS configuration applies to https URLs.
+func newGraphQLAPI(url string, tlsConfig *tls.Config, tenantHeader, organization, apiKey string) *graphqlAPI {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &graphqlAPI{
+		url:          url,
+		client:       &http.Client{Transport: transport},
+		tenantHeader: tenantHeader,
+		organization: organization,
+		apiKey:       apiKey,
+	}
+}
+
+// do runs a GraphQL operation and decodes its data into result
+func (a *graphqlAPI) do(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.organization != "" {
+		req.Header.Set(a.tenantHeader, a.organization)
+	}
+	if a.apiKey != "" {
+		req.Header.Set("X-API-Key", a.apiKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the gateway: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("gateway answered %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if len(envelope.Errors) > 0 {
+		messages := make([]string, len(envelope.Errors))
+		for i, e := range envelope.Errors {
+			messages[i] = e.Message
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+	if err := json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// graphqlTicket is a ticket as the gateway returns it
+type graphqlTicket struct {
+	ID           string               `json:"id"`
+	Key          *string              `json:"key"`
+	Title        string               `json:"title"`
+	Description  *string              `json:"description"`
+	Status       string               `json:"status"`
+	Priority     string               `json:"priority"`
+	Assignee     *struct{ ID string } `json:"assignee"`
+	Reporter     *struct{ ID string } `json:"reporter"`
+	Tags         []string             `json:"tags"`
+	CustomFields []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"customFields"`
+	DueDate   *string                  `json:"dueDate"`
+	SLA       *struct{ Status string } `json:"sla"`
+	CreatedAt string                   `json:"createdAt"`
+	UpdatedAt string                   `json:"updatedAt"`
+}
+
+// proto converts the ticket into its gRPC message
+func (t *graphqlTicket) proto() *ticketpb.Ticket {
+	ticket := &ticketpb.Ticket{
+		Id:        t.ID,
+		Title:     t.Title,
+		Status:    ticketpb.TicketStatus(ticketpb.TicketStatus_value["TICKET_STATUS_"+t.Status]),
+		Priority:  ticketpb.TicketPriority(ticketpb.TicketPriority_value["TICKET_PRIORITY_"+t.Priority]),
+		Tags:      t.Tags,
+		CreatedAt: graphqlTime(&t.CreatedAt),
+		UpdatedAt: graphqlTime(&t.UpdatedAt),
+		DueDate:   graphqlTime(t.DueDate),
+	}
+	if t.Key != nil {
+		ticket.Key = *t.Key
+	}
+	if t.Description != nil {
+		ticket.Description = *t.Description
+	}
+	if t.Assignee != nil {
+		ticket.AssigneeId = t.Assignee.ID
+	}
+	if t.Reporter != nil {
+		ticket.ReporterId = t.Reporter.ID
+	}
+	if len(t.CustomFields) > 0 {
+		ticket.CustomFields = make(map[string]string, len(t.CustomFields))
+		for _, field := range t.CustomFields {
+			ticket.CustomFields[field.Key] = field.Value
+		}
+	}
+	if t.SLA != nil {
+		ticket.Sla = &ticketpb.TicketSLA{
+			Status: ticketpb.SLAStatus(ticketpb.SLAStatus_value["SLA_STATUS_"+t.SLA.Status]),
+		}
+	}
+	return ticket
+}
+
+// graphqlTime parses a time returned by the gateway
+func graphqlTime(value *string) *timestamppb.Timestamp {
+	if value == nil || *value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// enumName returns the GraphQL name of a status or priority, e.g. IN_PROGRESS
+func enumName(value fmt.Stringer, prefix string) string {
+	return strings.TrimPrefix(value.String(), prefix)
+}
+
+// customFieldInputs converts custom field values into GraphQL inputs
+func customFieldInputs(values map[string]string) []map[string]string {
+	inputs := make([]map[string]string, 0, len(values))
+	for key, value := range values {
+		inputs = append(inputs, map[string]string{"key": key, "value": value})
+	}
+	return inputs
+}
+
+func (a *graphqlAPI) CreateTicket(ctx context.Context, req *ticketpb.CreateTicketRequest) (*ticketpb.Ticket, error) {
+	if req.ReporterId == "" {
+		return nil, errors.New("the GraphQL API requires --reporter")
+	}
+	variables := map[string]interface{}{
+		"title":      req.Title,
+		"reporterId": req.ReporterId,
+	}
+	if req.Description != "" {
+		variables["description"] = req.Description
+	}
+	if req.Priority != ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+		variables["priority"] = enumName(req.Priority, "TICKET_PRIORITY_")
+	}
+	if req.AssigneeId != "" {
+		variables["assigneeId"] = req.AssigneeId
+	}
+	if len(req.Tags) > 0 {
+		variables["tags"] = req.Tags
+	}
+	if req.ProjectKey != "" {
+		variables["projectKey"] = req.ProjectKey
+	}
+	if len(req.CustomFields) > 0 {
+		variables["customFields"] = customFieldInputs(req.CustomFields)
+	}
+	if req.DueDate != nil {
+		variables["dueDate"] = req.DueDate.AsTime().Format(time.RFC3339)
+	}
+	if req.RequestId != "" {
+		variables["idempotencyKey"] = req.RequestId
+	}
+
+	var result struct{ CreateTicket graphqlTicket }
+	err := a.do(ctx, `mutation CreateTicket($title: String!, $description: String, $priority: TicketPriority, $assigneeId: ID,
+  $reporterId: ID!, $tags: [String], $projectKey: String, $customFields: [CustomFieldInput!], $dueDate: String,
+  $idempotencyKey: IdempotencyKey) {
+  createTicket(title: $title, description: $description, priority: $priority, assigneeId: $assigneeId, reporterId: $reporterId,
+    tags: $tags, projectKey: $projectKey, customFields: $customFields, dueDate: $dueDate,
+    idempotencyKey: $idempotencyKey) { ...TicketFields }
+}
+`+ticketFields, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.CreateTicket.proto(), nil
+}
+
+func (a *graphqlAPI) GetTicket(ctx context.Context, req *ticketpb.GetTicketRequest) (*ticketpb.Ticket, error) {
+	variables := map[string]interface{}{}
+	if req.Id != "" {
+		variables["id"] = req.Id
+	} else {
+		variables["key"] = req.Key
+	}
+
+	var result struct{ Ticket *graphqlTicket }
+	err := a.do(ctx, `query Ticket($id: ID, $key: String) { ticket(id: $id, key: $key) { ...TicketFields } }
+`+ticketFields, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Ticket == nil {
+		return nil, fmt.Errorf("ticket not found: %s%s", req.Id, req.Key)
+	}
+	return result.Ticket.proto(), nil
+}
+
+// ListTickets lists the newest tickets. The gateway pages no further than
+// the first page and filters by project and custom fields only.
+func (a *graphqlAPI) ListTickets(ctx context.Context, req *ticketpb.ListTicketsRequest) ([]*ticketpb.Ticket, string, error) {
+	switch {
+	case len(req.Tags) > 0:
+		return nil, "", errors.New("the GraphQL API cannot filter by --tags; use --api grpc")
+	case req.Query != "":
+		return nil, "", errors.New("the GraphQL API cannot filter by --query; use --api grpc")
+	case req.PageToken != "":
+		return nil, "", errors.New("the GraphQL API has no --page-token; use --api grpc")
+	}
+	variables := map[string]interface{}{}
+	if req.PageSize > 0 {
+		variables["first"] = req.PageSize
+	}
+	if req.ProjectKey != "" {
+		variables["project"] = req.ProjectKey
+	}
+	if len(req.CustomFields) > 0 {
+		variables["customFields"] = customFieldInputs(req.CustomFields)
+	}
+
+	var result struct{ Tickets []graphqlTicket }
+	err := a.do(ctx, `query Tickets($first: Int = 100, $project: String, $customFields: [CustomFieldInput!]) {
+  tickets(first: $first, project: $project, customFields: $customFields) { ...TicketFields }
+}
+`+ticketFields, variables, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	tickets := make([]*ticketpb.Ticket, len(result.Tickets))
+	for i := range result.Tickets {
+		tickets[i] = result.Tickets[i].proto()
+	}
+	return tickets, "", nil
+}
+
+func (a *graphqlAPI) UpdateTicket(ctx context.Context, req *ticketpb.UpdateTicketRequest) (*ticketpb.Ticket, error) {
+	variables := map[string]interface{}{"id": req.Id}
+	if req.Title != "" {
+		variables["title"] = req.Title
+	}
+	if req.Description != "" {
+		variables["description"] = req.Description
+	}
+	if req.Status != ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED {
+		variables["status"] = enumName(req.Status, "TICKET_STATUS_")
+	}
+	if req.Priority != ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+		variables["priority"] = enumName(req.Priority, "TICKET_PRIORITY_")
+	}
+	if req.AssigneeId != "" {
+		variables["assigneeId"] = req.AssigneeId
+	}
+	if len(req.Tags) > 0 {
+		variables["tags"] = req.Tags
+	}
+	if len(req.CustomFields) > 0 {
+		variables["customFields"] = customFieldInputs(req.CustomFields)
+	}
+	switch {
+	case req.ClearDueDate:
+		// An empty due date clears it
+		variables["dueDate"] = ""
+	case req.DueDate != nil:
+		variables["dueDate"] = req.DueDate.AsTime().Format(time.RFC3339)
+	}
+
+	var result struct{ UpdateTicket graphqlTicket }
+	err := a.do(ctx, `mutation UpdateTicket($id: ID!, $title: String, $description: String, $status: TicketStatus, $priority: TicketPriority,
+  $assigneeId: ID, $tags: [String], $customFields: [CustomFieldInput!], $dueDate: String) {
+  updateTicket(id: $id, title: $title, description: $description, status: $status, priority: $priority, assigneeId: $assigneeId,
+    tags: $tags, customFields: $customFields, dueDate: $dueDate) { ...TicketFields }
+}
+`+ticketFields, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.UpdateTicket.proto(), nil
+}
+
+func (a *graphqlAPI) DeleteTicket(ctx context.Context, id string) error {
+	var result struct{ DeleteTicket *bool }
+	err := a.do(ctx, `mutation DeleteTicket($id: ID!) { deleteTicket(id: $id) }`, map[string]interface{}{"id": id}, &result)
+	if err != nil {
+		return err
+	}
+	if result.DeleteTicket == nil || !*result.DeleteTicket {
+		return fmt.Errorf("ticket not found: %s", id)
+	}
+	return nil
+}
+
+func (a *graphqlAPI) WatchTicket(ctx context.Context, ticketID, userID string, watch bool) error {
+	variables := map[string]interface{}{"ticketId": ticketID, "userId": userID}
+	if watch {
+		var result struct{ WatchTicket struct{ ID string } }
+		return a.do(ctx, `mutation WatchTicket($ticketId: ID!, $userId: ID!) { watchTicket(ticketId: $ticketId, userId: $userId) { id } }`, variables, &result)
+	}
+	var result struct{ UnwatchTicket bool }
+	return a.do(ctx, `mutation UnwatchTicket($ticketId: ID!, $userId: ID!) { unwatchTicket(ticketId: $ticketId, userId: $userId) }`, variables, &result)
+}
+
+func (a *graphqlAPI) Close() error {
+	a.client.CloseIdleConnections()
+	return nil
+}
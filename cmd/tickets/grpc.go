@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// api is the ticket API the commands talk to. Requests and tickets use the
+// gRPC messages whichever API carries them.
+type api interface {
+	CreateTicket(ctx context.Context, req *ticketpb.CreateTicketRequest) (*ticketpb.Ticket, error)
+	GetTicket(ctx context.Context, req *ticketpb.GetTicketRequest) (*ticketpb.Ticket, error)
+	// ListTickets returns a page of tickets and the token of the next page
+	ListTickets(ctx context.Context, req *ticketpb.ListTicketsRequest) ([]*ticketpb.Ticket, string, error)
+	// UpdateTicket leaves the fields of req that are empty unchanged
+	UpdateTicket(ctx context.Context, req *ticketpb.UpdateTicketRequest) (*ticketpb.Ticket, error)
+	DeleteTicket(ctx context.Context, id string) error
+	// WatchTicket makes a user a watcher of a ticket, or stops when watch is false
+	WatchTicket(ctx context.Context, ticketID, userID string, watch bool) error
+	Close() error
+}
+
+// grpcAPI talks to the ticket service
+type grpcAPI struct {
+	conn         *grpc.ClientConn
+	client       ticketpb.TicketServiceClient
+	organization string
+	apiKey       string
+}
+
+// newGRPCAPI connects to the ticket service at target, over TLS when
+// tlsConfig is set
+func newGRPCAPI(target string, tlsConfig *tls.Config, organization, apiKey string) (*grpcAPI, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(tenant.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ticket service: %w", err)
+	}
+	return &grpcAPI{
+		conn:         conn,
+		client:       ticketpb.NewTicketServiceClient(conn),
+		organization: organization,
+		apiKey:       apiKey,
+	}, nil
+}
+
+// outgoing returns the context of a call, carrying the organization and API key
+func (a *grpcAPI) outgoing(ctx context.Context) context.Context {
+	if a.organization != "" {
+		ctx = tenant.WithOrganization(ctx, a.organization)
+	}
+	if a.apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", a.apiKey)
+	}
+	return ctx
+}
+
+func (a *grpcAPI) CreateTicket(ctx context.Context, req *ticketpb.CreateTicketRequest) (*ticketpb.Ticket, error) {
+	resp, err := a.client.CreateTicket(a.outgoing(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ticket, nil
+}
+
+func (a *grpcAPI) GetTicket(ctx context.Context, req *ticketpb.GetTicketRequest) (*ticketpb.Ticket, error) {
+	resp, err := a.client.GetTicket(a.outgoing(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ticket, nil
+}
+
+func (a *grpcAPI) ListTickets(ctx context.Context, req *ticketpb.ListTicketsRequest) ([]*ticketpb.Ticket, string, error) {
+	resp, err := a.client.ListTickets(a.outgoing(ctx), req)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Tickets, resp.NextPageToken, nil
+}
+
+func (a *grpcAPI) UpdateTicket(ctx context.Context, req *ticketpb.UpdateTicketRequest) (*ticketpb.Ticket, error) {
+	resp, err := a.client.UpdateTicket(a.outgoing(ctx), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ticket, nil
+}
+
+func (a *grpcAPI) DeleteTicket(ctx context.Context, id string) error {
+	resp, err := a.client.DeleteTicket(a.outgoing(ctx), &ticketpb.DeleteTicketRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("ticket not found: %s", id)
+	}
+	return nil
+}
+
+func (a *grpcAPI) WatchTicket(ctx context.Context, ticketID, userID string, watch bool) error {
+	var err error
+	if watch {
+		_, err = a.client.WatchTicket(a.outgoing(ctx), &ticketpb.WatchTicketRequest{TicketId: ticketID, UserId: userID})
+	} else {
+		_, err = a.client.UnwatchTicket(a.outgoing(ctx), &ticketpb.UnwatchTicketRequest{TicketId: ticketID, UserId: userID})
+	}
+	return err
+}
+
+func (a *grpcAPI) Close() error {
+	return a.conn.Close()
+}
@@ -0,0 +1,511 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/config"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/transfer"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: tickets <command> [flags] [arguments]
+
+Commands:
+  create       create a ticket
+  get          show a ticket by ID or key
+  list         list tickets, newest first
+  update       change fields of a ticket
+  transition   move a ticket to another status
+  delete       delete tickets
+  watch        make a user a watcher of a ticket, or stop
+
+Commands talk to the ticket service over gRPC, or to the GraphQL gateway with
+--api graphql. Run 'tickets <command> -h' for command flags.
+`)
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	commands := map[string]func([]string){
+		"create":     create,
+		"get":        get,
+		"list":       list,
+		"update":     update,
+		"transition": transition,
+		"delete":     deleteTickets,
+		"watch":      watch,
+	}
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+	}
+	run(os.Args[2:])
+}
+
+// command holds the flags every command shares
+type command struct {
+	flags        *flag.FlagSet
+	api          *string
+	graphqlURL   *string
+	organization *string
+	apiKey       *string
+	output       *string
+	timeout      *time.Duration
+
+	tls                *bool
+	caCert             *string
+	cert               *string
+	key                *string
+	serverName         *string
+	insecureSkipVerify *bool
+}
+
+// newCommand registers the shared flags of a command taking the given
+// arguments
+func newCommand(name, arguments string) *command {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: tickets %s [flags] %s\n\nFlags:\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return &command{
+		flags:        flags,
+		api:          flags.String("api", "grpc", "API to talk to: grpc (the ticket service at --ticket-service-url) or graphql (the gateway at --graphql-url)"),
+		graphqlURL:   flags.String("graphql-url", "", "GraphQL endpoint of the gateway (default: /query on --http-addr at localhost)"),
+		organization: flags.String("organization", "", "organization to act for (default: the default organization)"),
+		apiKey:       flags.String("api-key", "", "API key identifying the caller to rate limits"),
+		output:       flags.String("output", "table", "output format: table, json or yaml"),
+		timeout:      flags.Duration("timeout", 30*time.Second, "time limit of the command"),
+
+		tls:                flags.Bool("tls", false, "use TLS for gRPC; GraphQL uses it for https URLs"),
+		caCert:             flags.String("ca-cert", "", "PEM file of the certificate authorities to trust instead of the system ones"),
+		cert:               flags.String("cert", "", "PEM client certificate for mutual TLS"),
+		key:                flags.String("key", "", "PEM key of the client certificate"),
+		serverName:         flags.String("server-name", "", "server name to verify the certificate against"),
+		insecureSkipVerify: flags.Bool("insecure-skip-verify", false, "accept any server certificate (testing only)"),
+	}
+}
+
+// connect parses the arguments and connects to the selected API. It returns
+// the context to call it with, the API, a printer and the positional
+// arguments; call the returned function when done.
+func (c *command) connect(args []string) (context.Context, api, *printer, []string, func()) {
+	cfg, err := config.Load(c.flags, args)
+	if errors.Is(err, config.ErrConfigPrinted) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	out, err := newPrinter(*c.output)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	organization := ""
+	if *c.organization != "" {
+		if organization, err = tenant.Parse(*c.organization, false); err != nil {
+			log.Fatalf("Invalid organization %q: %v", *c.organization, err)
+		}
+	}
+
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var client api
+	switch *c.api {
+	case "grpc":
+		if tlsConfig == nil && *c.tls {
+			tlsConfig = &tls.Config{}
+		}
+		client, err = newGRPCAPI(cfg.TicketService.URL, tlsConfig, organization, *c.apiKey)
+	case "graphql":
+		url := *c.graphqlURL
+		if url == "" {
+			url = defaultGraphQLURL(cfg.Gateway.Addr)
+		}
+		client = newGraphQLAPI(url, tlsConfig, cfg.Tenancy.Header, organization, *c.apiKey)
+	default:
+		log.Fatalf("Unknown API %q: use grpc or graphql", *c.api)
+	}
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *c.timeout)
+	return ctx, client, out, c.flags.Args(), func() {
+		cancel()
+		client.Close()
+	}
+}
+
+// tlsConfig returns the TLS configuration the flags ask for, or nil when
+// they ask for none
+func (c *command) tlsConfig() (*tls.Config, error) {
+	if *c.caCert == "" && *c.cert == "" && *c.serverName == "" && !*c.insecureSkipVerify {
+		return nil, nil
+	}
+
+	conf := &tls.Config{
+		ServerName:         *c.serverName,
+		InsecureSkipVerify: *c.insecureSkipVerify,
+	}
+	if *c.caCert != "" {
+		pem, err := os.ReadFile(*c.caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", *c.caCert)
+		}
+	}
+	if *c.cert != "" || *c.key != "" {
+		certificate, err := tls.LoadX509KeyPair(*c.cert, *c.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{certificate}
+	}
+	return conf, nil
+}
+
+// defaultGraphQLURL returns the GraphQL endpoint of a gateway listening on addr
+func defaultGraphQLURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://localhost:8080/query"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/query"
+}
+
+// keyValues collects repeated key=value flags
+type keyValues map[string]string
+
+func (kv keyValues) String() string {
+	pairs := make([]string, 0, len(kv))
+	for key, value := range kv {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValues) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("invalid value %q: use key=value", s)
+	}
+	kv[strings.TrimSpace(key)] = value
+	return nil
+}
+
+// splitList splits a comma-separated flag value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseDue parses a due date flag
+func parseDue(value string) (*timestamppb.Timestamp, error) {
+	due, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date %q: use RFC 3339, e.g. 2024-05-01T17:00:00Z", value)
+	}
+	return timestamppb.New(due), nil
+}
+
+// isSet reports whether a flag was given on the command line
+func isSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// args checks the number of positional arguments
+func (c *command) args(args []string, min, max int) {
+	if len(args) < min || max >= 0 && len(args) > max {
+		c.flags.Usage()
+		os.Exit(2)
+	}
+}
+
+// create creates a ticket
+func create(args []string) {
+	c := newCommand("create", "")
+	title := c.flags.String("title", "", "title of the ticket (required)")
+	description := c.flags.String("description", "", "description of the ticket")
+	priority := c.flags.String("priority", "", "LOW, MEDIUM (default), HIGH or CRITICAL")
+	assignee := c.flags.String("assignee", "", "ID of the assignee")
+	reporter := c.flags.String("reporter", "", "ID of the reporter (required by the GraphQL API)")
+	tags := c.flags.String("tags", "", "comma-separated tags")
+	project := c.flags.String("project", "", "key of the project to create the ticket in")
+	due := c.flags.String("due", "", "due date, RFC 3339")
+	idempotencyKey := c.flags.String("idempotency-key", "", "key making a retry return the ticket created first instead of another one")
+	customFields := make(keyValues)
+	c.flags.Var(customFields, "custom-field", "custom field value as key=value (repeatable)")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 0, 0)
+
+	if strings.TrimSpace(*title) == "" {
+		log.Fatalf("--title is required")
+	}
+	req := &ticketpb.CreateTicketRequest{
+		Title:        *title,
+		Description:  *description,
+		AssigneeId:   *assignee,
+		ReporterId:   *reporter,
+		Tags:         splitList(*tags),
+		ProjectKey:   *project,
+		CustomFields: customFields,
+		RequestId:    *idempotencyKey,
+	}
+	var err error
+	if req.Priority, err = transfer.ParsePriority(*priority); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *due != "" {
+		if req.DueDate, err = parseDue(*due); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+
+	ticket, err := client.CreateTicket(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to create ticket: %v", err)
+	}
+	out.ticket(ticket)
+}
+
+// get shows a ticket
+func get(args []string) {
+	c := newCommand("get", "<id|key>")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 1, 1)
+
+	ticket, err := client.GetTicket(ctx, ticketRef(rest[0]))
+	if err != nil {
+		log.Fatalf("Failed to get ticket: %v", err)
+	}
+	out.ticket(ticket)
+}
+
+// ticketRef returns the request looking a ticket up by ID or key
+func ticketRef(ref string) *ticketpb.GetTicketRequest {
+	if _, err := uuid.Parse(ref); err == nil {
+		return &ticketpb.GetTicketRequest{Id: ref}
+	}
+	return &ticketpb.GetTicketRequest{Key: ref}
+}
+
+// ticketID returns the ID of a ticket given by ID or key
+func ticketID(ctx context.Context, client api, ref string) (string, error) {
+	req := ticketRef(ref)
+	if req.Id != "" {
+		return req.Id, nil
+	}
+	ticket, err := client.GetTicket(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return ticket.Id, nil
+}
+
+// list lists tickets
+func list(args []string) {
+	c := newCommand("list", "")
+	pageSize := c.flags.Int("page-size", 0, "tickets per page (default: the server's)")
+	pageToken := c.flags.String("page-token", "", "page to list, as printed after the previous page")
+	all := c.flags.Bool("all", false, "list every page")
+	tags := c.flags.String("tags", "", "only tickets carrying all of these comma-separated tags")
+	query := c.flags.String("query", "", "only tickets whose title or description contains this text")
+	project := c.flags.String("project", "", "only tickets of the project with this key")
+	customFields := make(keyValues)
+	c.flags.Var(customFields, "custom-field", "only tickets with this custom field value, as key=value (repeatable)")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 0, 0)
+
+	req := &ticketpb.ListTicketsRequest{
+		PageSize:     int32(*pageSize),
+		PageToken:    *pageToken,
+		Tags:         splitList(*tags),
+		Query:        *query,
+		ProjectKey:   *project,
+		CustomFields: customFields,
+	}
+	var tickets []*ticketpb.Ticket
+	for {
+		page, next, err := client.ListTickets(ctx, req)
+		if err != nil {
+			log.Fatalf("Failed to list tickets: %v", err)
+		}
+		tickets = append(tickets, page...)
+		if !*all || next == "" {
+			out.tickets(tickets, next)
+			return
+		}
+		req.PageToken = next
+	}
+}
+
+// update changes the fields of a ticket given as flags
+func update(args []string) {
+	c := newCommand("update", "<id|key>")
+	title := c.flags.String("title", "", "new title")
+	description := c.flags.String("description", "", "new description")
+	status := c.flags.String("status", "", "new status: OPEN, IN_PROGRESS, RESOLVED or CLOSED")
+	priority := c.flags.String("priority", "", "new priority: LOW, MEDIUM, HIGH or CRITICAL")
+	assignee := c.flags.String("assignee", "", "ID of the new assignee")
+	tags := c.flags.String("tags", "", "comma-separated tags replacing the current ones")
+	due := c.flags.String("due", "", "new due date, RFC 3339")
+	clearDue := c.flags.Bool("clear-due", false, "remove the due date")
+	customFields := make(keyValues)
+	c.flags.Var(customFields, "custom-field", "custom field value as key=value; an empty value clears the field (repeatable)")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 1, 1)
+
+	id, err := ticketID(ctx, client, rest[0])
+	if err != nil {
+		log.Fatalf("Failed to get ticket: %v", err)
+	}
+	req := &ticketpb.UpdateTicketRequest{
+		Id:           id,
+		Title:        *title,
+		Description:  *description,
+		AssigneeId:   *assignee,
+		Tags:         splitList(*tags),
+		CustomFields: customFields,
+		ClearDueDate: *clearDue,
+	}
+	if req.Status, err = transfer.ParseStatus(*status); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if req.Priority, err = transfer.ParsePriority(*priority); err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *due != "" {
+		if *clearDue {
+			log.Fatalf("--due and --clear-due exclude each other")
+		}
+		if req.DueDate, err = parseDue(*due); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	if isSet(c.flags, "tags") && len(req.Tags) == 0 {
+		log.Fatalf("--tags cannot remove every tag")
+	}
+
+	ticket, err := client.UpdateTicket(ctx, req)
+	if err != nil {
+		log.Fatalf("Failed to update ticket: %v", err)
+	}
+	out.ticket(ticket)
+}
+
+// transition moves a ticket to another status
+func transition(args []string) {
+	c := newCommand("transition", "<id|key> <status>")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 2, 2)
+
+	status, err := transfer.ParseStatus(rest[1])
+	if err != nil || status == ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED {
+		log.Fatalf("Unknown status %q: use OPEN, IN_PROGRESS, RESOLVED or CLOSED", rest[1])
+	}
+	id, err := ticketID(ctx, client, rest[0])
+	if err != nil {
+		log.Fatalf("Failed to get ticket: %v", err)
+	}
+
+	ticket, err := client.UpdateTicket(ctx, &ticketpb.UpdateTicketRequest{Id: id, Status: status})
+	if err != nil {
+		log.Fatalf("Failed to transition ticket: %v", err)
+	}
+	out.ticket(ticket)
+}
+
+// deleteTickets deletes tickets, going on past failures
+func deleteTickets(args []string) {
+	c := newCommand("delete", "<id|key>...")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 1, -1)
+
+	var deleted []string
+	failed := false
+	for _, ref := range rest {
+		id, err := ticketID(ctx, client, ref)
+		if err == nil {
+			err = client.DeleteTicket(ctx, id)
+		}
+		if err != nil {
+			log.Printf("Failed to delete ticket %s: %v", ref, err)
+			failed = true
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+	out.deleted(deleted)
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// watch makes a user a watcher of a ticket, or stops
+func watch(args []string) {
+	c := newCommand("watch", "<id|key>")
+	user := c.flags.String("user", "", "ID of the watching user (required)")
+	stop := c.flags.Bool("stop", false, "stop watching instead")
+	ctx, client, out, rest, done := c.connect(args)
+	defer done()
+	c.args(rest, 1, 1)
+
+	if *user == "" {
+		log.Fatalf("--user is required")
+	}
+	id, err := ticketID(ctx, client, rest[0])
+	if err != nil {
+		log.Fatalf("Failed to get ticket: %v", err)
+	}
+	if err := client.WatchTicket(ctx, id, *user, !*stop); err != nil {
+		log.Fatalf("Failed to change watchers: %v", err)
+	}
+	out.watching(id, *user, !*stop)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+// maxTitleWidth truncates titles in tables
+const maxTitleWidth = 60
+
+// printer writes command results to stdout as a table, JSON or YAML
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// newPrinter returns a printer of the given format
+func newPrinter(format string) (*printer, error) {
+	switch format {
+	case "table", "json", "yaml":
+		return &printer{format: format, w: os.Stdout}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q: use table, json or yaml", format)
+}
+
+// ticketView is a ticket as the commands print it
+type ticketView struct {
+	ID           string            `json:"id" yaml:"id"`
+	Key          string            `json:"key,omitempty" yaml:"key,omitempty"`
+	Title        string            `json:"title" yaml:"title"`
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Status       string            `json:"status" yaml:"status"`
+	Priority     string            `json:"priority" yaml:"priority"`
+	AssigneeID   string            `json:"assignee_id,omitempty" yaml:"assignee_id,omitempty"`
+	ReporterID   string            `json:"reporter_id,omitempty" yaml:"reporter_id,omitempty"`
+	Tags         []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	CustomFields map[string]string `json:"custom_fields,omitempty" yaml:"custom_fields,omitempty"`
+	DueDate      string            `json:"due_date,omitempty" yaml:"due_date,omitempty"`
+	SLA          string            `json:"sla,omitempty" yaml:"sla,omitempty"`
+	CreatedAt    string            `json:"created_at" yaml:"created_at"`
+	UpdatedAt    string            `json:"updated_at" yaml:"updated_at"`
+}
+
+// view converts a ticket for printing
+func view(ticket *ticketpb.Ticket) ticketView {
+	v := ticketView{
+		ID:           ticket.Id,
+		Key:          ticket.Key,
+		Title:        ticket.Title,
+		Description:  ticket.Description,
+		Status:       strings.TrimPrefix(ticket.Status.String(), "TICKET_STATUS_"),
+		Priority:     strings.TrimPrefix(ticket.Priority.String(), "TICKET_PRIORITY_"),
+		AssigneeID:   ticket.AssigneeId,
+		ReporterID:   ticket.ReporterId,
+		Tags:         ticket.Tags,
+		CustomFields: ticket.CustomFields,
+		DueDate:      formatTime(ticket.DueDate),
+		CreatedAt:    formatTime(ticket.CreatedAt),
+		UpdatedAt:    formatTime(ticket.UpdatedAt),
+	}
+	if ticket.Sla != nil {
+		v.SLA = strings.TrimPrefix(ticket.Sla.Status.String(), "SLA_STATUS_")
+	}
+	return v
+}
+
+// formatTime formats a timestamp as RFC 3339, or empty when it is nil
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// encode writes a value as JSON or YAML
+func (p *printer) encode(value interface{}) {
+	var err error
+	if p.format == "json" {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(value)
+	} else {
+		encoder := yaml.NewEncoder(p.w)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(value); err == nil {
+			err = encoder.Close()
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// ticket prints one ticket; tables list its fields one per line
+func (p *printer) ticket(ticket *ticketpb.Ticket) {
+	v := view(ticket)
+	if p.format != "table" {
+		p.encode(v)
+		return
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	row := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "%s:\t%s\n", name, value)
+		}
+	}
+	row("ID", v.ID)
+	row("Key", v.Key)
+	row("Title", v.Title)
+	row("Status", v.Status)
+	row("Priority", v.Priority)
+	row("Assignee", v.AssigneeID)
+	row("Reporter", v.ReporterID)
+	row("Tags", strings.Join(v.Tags, ", "))
+	keys := make([]string, 0, len(v.CustomFields))
+	for key := range v.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		row(key, v.CustomFields[key])
+	}
+	row("Due", v.DueDate)
+	row("SLA", v.SLA)
+	row("Created", v.CreatedAt)
+	row("Updated", v.UpdatedAt)
+	w.Flush()
+	if v.Description != "" {
+		fmt.Fprintf(p.w, "\n%s\n", v.Description)
+	}
+}
+
+// tickets prints a list of tickets and the token of the next page
+func (p *printer) tickets(tickets []*ticketpb.Ticket, next string) {
+	views := make([]ticketView, len(tickets))
+	for i, ticket := range tickets {
+		views[i] = view(ticket)
+	}
+	if p.format != "table" {
+		p.encode(struct {
+			Tickets       []ticketView `json:"tickets" yaml:"tickets"`
+			NextPageToken string       `json:"next_page_token,omitempty" yaml:"next_page_token,omitempty"`
+		}{views, next})
+		return
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tKEY\tSTATUS\tPRIORITY\tASSIGNEE\tTITLE")
+	for _, v := range views {
+		title := v.Title
+		if runes := []rune(title); len(runes) > maxTitleWidth {
+			title = string(runes[:maxTitleWidth-1]) + "…"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", v.ID, dash(v.Key), v.Status, v.Priority, dash(v.AssigneeID), title)
+	}
+	w.Flush()
+	if next != "" {
+		fmt.Fprintf(os.Stderr, "More tickets: --page-token %s (or --all)\n", next)
+	}
+}
+
+// dash stands in for empty table cells
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// deleted prints the IDs of deleted tickets
+func (p *printer) deleted(ids []string) {
+	if p.format != "table" {
+		if ids == nil {
+			ids = []string{}
+		}
+		p.encode(struct {
+			Deleted []string `json:"deleted" yaml:"deleted"`
+		}{ids})
+		return
+	}
+	for _, id := range ids {
+		fmt.Fprintf(p.w, "Deleted %s\n", id)
+	}
+}
+
+// watching prints whether a user now watches a ticket
+func (p *printer) watching(ticketID, userID string, watching bool) {
+	if p.format != "table" {
+		p.encode(struct {
+			TicketID string `json:"ticket_id" yaml:"ticket_id"`
+			UserID   string `json:"user_id" yaml:"user_id"`
+			Watching bool   `json:"watching" yaml:"watching"`
+		}{ticketID, userID, watching})
+		return
+	}
+	if watching {
+		fmt.Fprintf(p.w, "%s is watching %s\n", userID, ticketID)
+	} else {
+		fmt.Fprintf(p.w, "%s stopped watching %s\n", userID, ticketID)
+	}
+}
@@ -0,0 +1,66 @@
+package attachments
+
+import (
+	"context"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Downloader fetches attachment content, typically from the ticket service
+type Downloader interface {
+	DownloadAttachment(ctx context.Context, id string) (*ticketpb.Attachment, io.ReadCloser, error)
+}
+
+// DownloadHandler serves signed attachment downloads under DownloadPath. The
+// signature binds the URL to the organization it was issued for, which the
+// download then acts for.
+func DownloadHandler(downloader Downloader, signer *Signer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, DownloadPath)
+		query := r.URL.Query()
+		organizationID := query.Get("org")
+		if err := signer.Verify(organizationID, id, query.Get("expires"), query.Get("signature")); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		ctx := tenant.WithOrganization(r.Context(), organizationID)
+		attachment, content, err := downloader.DownloadAttachment(ctx, id)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				http.NotFound(w, r)
+				return
+			}
+			log.Printf("❌ Failed to download attachment %s: %v", id, err)
+			http.Error(w, "failed to download attachment", http.StatusBadGateway)
+			return
+		}
+		defer content.Close()
+
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "private, no-store")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if _, err := io.Copy(w, content); err != nil {
+			log.Printf("❌ Failed to stream attachment %s: %v", id, err)
+		}
+	})
+}
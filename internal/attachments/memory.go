@@ -0,0 +1,88 @@
+package attachments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// MemoryMetadata keeps attachment metadata in process
+type MemoryMetadata struct {
+	mu          sync.RWMutex
+	attachments map[string]*database.Attachment
+}
+
+var _ MetadataStore = (*MemoryMetadata)(nil)
+var _ MetadataStore = (*database.AttachmentRepository)(nil)
+var _ MetadataStore = (*store.PostgresAttachments)(nil)
+var _ MetadataStore = (*store.SQLiteAttachments)(nil)
+
+// NewMemoryMetadata creates an empty in-memory metadata store
+func NewMemoryMetadata() *MemoryMetadata {
+	return &MemoryMetadata{attachments: make(map[string]*database.Attachment)}
+}
+
+// Create stores attachment metadata
+func (m *MemoryMetadata) Create(ctx context.Context, attachment *database.Attachment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attachment.OrganizationID = tenant.ID(ctx)
+	copied := *attachment
+	m.attachments[attachment.ID] = &copied
+	return nil
+}
+
+// GetByID retrieves attachment metadata by ID
+func (m *MemoryMetadata) GetByID(ctx context.Context, id string) (*database.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	attachment, exists := m.attachments[id]
+	if !exists || attachment.OrganizationID != tenant.ID(ctx) {
+		return nil, fmt.Errorf("attachment not found: %s", id)
+	}
+	copied := *attachment
+	return &copied, nil
+}
+
+// ListByTicket retrieves the attachments of a ticket, oldest first
+func (m *MemoryMetadata) ListByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	organization := tenant.ID(ctx)
+	var attachments []*database.Attachment
+	for _, attachment := range m.attachments {
+		if attachment.TicketID == ticketID && attachment.OrganizationID == organization {
+			copied := *attachment
+			attachments = append(attachments, &copied)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+// DeleteByTicket removes the metadata of every attachment of a ticket and
+// returns it
+func (m *MemoryMetadata) DeleteByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	organization := tenant.ID(ctx)
+	var deleted []*database.Attachment
+	for id, attachment := range m.attachments {
+		if attachment.TicketID == ticketID && attachment.OrganizationID == organization {
+			deleted = append(deleted, attachment)
+			delete(m.attachments, id)
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,302 @@
+package attachments
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/blob"
+	"github.com/ayush-pandya/Graphql/internal/database"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// chunkSize is the size of the content chunks sent when downloading
+const chunkSize = 64 * 1024
+
+// MetadataStore persists attachment metadata within the caller's
+// organization. store.PostgresAttachments and store.SQLiteAttachments keep it
+// next to the tickets and MemoryMetadata keeps it in process.
+type MetadataStore interface {
+	Create(ctx context.Context, attachment *database.Attachment) error
+	GetByID(ctx context.Context, id string) (*database.Attachment, error)
+	ListByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error)
+	// DeleteByTicket removes the metadata of every attachment of a ticket
+	// and returns it
+	DeleteByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error)
+}
+
+// Limits restricts what can be uploaded
+type Limits struct {
+	MaxSize      int64    // maximum content size in bytes
+	AllowedTypes []string // allowed MIME types, "image/*" style wildcards allowed; empty allows all
+}
+
+// Service implements the attachment RPCs of the ticket service on top of a
+// blob store and a metadata store
+type Service struct {
+	blobs        blob.Store
+	metadata     MetadataStore
+	limits       Limits
+	ticketExists func(ctx context.Context, id string) bool
+}
+
+// NewService creates an attachment service. ticketExists reports whether a
+// ticket is visible to the caller; attachments of other tickets are treated
+// as missing.
+func NewService(blobs blob.Store, metadata MetadataStore, limits Limits, ticketExists func(ctx context.Context, id string) bool) *Service {
+	return &Service{
+		blobs:        blobs,
+		metadata:     metadata,
+		limits:       limits,
+		ticketExists: ticketExists,
+	}
+}
+
+// UploadAttachment receives the metadata and content of an attachment,
+// enforcing the size and MIME limits and computing its SHA-256 checksum
+func (s *Service) UploadAttachment(stream ticketpb.TicketService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive attachment metadata: %v", err)
+	}
+	meta := first.GetMetadata()
+	if meta == nil || meta.TicketId == "" {
+		return status.Error(codes.InvalidArgument, "the first message must carry attachment metadata with a ticket ID")
+	}
+	if !s.ticketExists(ctx, meta.TicketId) {
+		return status.Errorf(codes.NotFound, "ticket not found: %s", meta.TicketId)
+	}
+
+	log.Printf("gRPC: Uploading attachment for ticket %s - Filename: %s", meta.TicketId, meta.Filename)
+
+	content := bufio.NewReaderSize(&uploadReader{stream: stream}, 512)
+	head, err := content.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return status.Errorf(codes.InvalidArgument, "failed to read attachment content: %v", err)
+	}
+
+	contentType := detectContentType(head, meta.ContentType)
+	if !s.limits.allows(contentType) {
+		return status.Errorf(codes.InvalidArgument, "content type %s is not allowed", contentType)
+	}
+
+	id := uuid.New().String()
+	key := meta.TicketId + "/" + id
+	hash := sha256.New()
+	limited := &limitedReader{r: io.TeeReader(content, hash), remaining: s.limits.MaxSize}
+
+	if err := s.blobs.Put(ctx, key, limited, -1, contentType); err != nil {
+		_ = s.blobs.Delete(ctx, key)
+		if limited.exceeded {
+			return status.Errorf(codes.InvalidArgument, "attachment exceeds the maximum size of %d bytes", s.limits.MaxSize)
+		}
+		log.Printf("gRPC: Error storing attachment: %v", err)
+		return status.Errorf(codes.Internal, "failed to store attachment: %v", err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if meta.Sha256 != "" && !strings.EqualFold(meta.Sha256, checksum) {
+		_ = s.blobs.Delete(ctx, key)
+		return status.Errorf(codes.DataLoss, "checksum mismatch: expected %s, got %s", meta.Sha256, checksum)
+	}
+
+	attachment := &database.Attachment{
+		ID:          id,
+		TicketID:    meta.TicketId,
+		Filename:    sanitizeFilename(meta.Filename),
+		ContentType: contentType,
+		Size:        limited.read,
+		SHA256:      checksum,
+		StorageKey:  key,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.metadata.Create(ctx, attachment); err != nil {
+		_ = s.blobs.Delete(ctx, key)
+		log.Printf("gRPC: Error saving attachment metadata: %v", err)
+		return status.Errorf(codes.Internal, "failed to save attachment: %v", err)
+	}
+
+	log.Printf("gRPC: Attachment stored - ID: %s, Size: %d", id, attachment.Size)
+	return stream.SendAndClose(&ticketpb.UploadAttachmentResponse{Attachment: ToProto(attachment)})
+}
+
+// ListAttachments returns the attachments of a ticket
+func (s *Service) ListAttachments(ctx context.Context, req *ticketpb.ListAttachmentsRequest) (*ticketpb.ListAttachmentsResponse, error) {
+	if !s.ticketExists(ctx, req.TicketId) {
+		return nil, status.Errorf(codes.NotFound, "ticket not found: %s", req.TicketId)
+	}
+	attachments, err := s.metadata.ListByTicket(ctx, req.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ticketpb.ListAttachmentsResponse{Attachments: make([]*ticketpb.Attachment, len(attachments))}
+	for i, attachment := range attachments {
+		resp.Attachments[i] = ToProto(attachment)
+	}
+	return resp, nil
+}
+
+// DownloadAttachment streams the metadata followed by the content of an attachment
+func (s *Service) DownloadAttachment(req *ticketpb.DownloadAttachmentRequest, stream ticketpb.TicketService_DownloadAttachmentServer) error {
+	ctx := stream.Context()
+
+	attachment, err := s.metadata.GetByID(ctx, req.Id)
+	if err != nil || !s.ticketExists(ctx, attachment.TicketID) {
+		return status.Errorf(codes.NotFound, "attachment not found: %s", req.Id)
+	}
+
+	content, err := s.blobs.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return status.Errorf(codes.NotFound, "attachment content not found: %s", req.Id)
+		}
+		return status.Errorf(codes.Internal, "failed to open attachment: %v", err)
+	}
+	defer content.Close()
+
+	if err := stream.Send(&ticketpb.DownloadAttachmentResponse{
+		Data: &ticketpb.DownloadAttachmentResponse_Attachment{Attachment: ToProto(attachment)},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&ticketpb.DownloadAttachmentResponse{
+				Data: &ticketpb.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read attachment: %v", err)
+		}
+	}
+}
+
+// DeleteTicket deletes the attachments of a deleted ticket: their metadata,
+// then their content
+func (s *Service) DeleteTicket(ctx context.Context, ticketID string) error {
+	deleted, err := s.metadata.DeleteByTicket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, attachment := range deleted {
+		if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ToProto converts attachment metadata to its protobuf form
+func ToProto(attachment *database.Attachment) *ticketpb.Attachment {
+	return &ticketpb.Attachment{
+		Id:          attachment.ID,
+		TicketId:    attachment.TicketID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Sha256:      attachment.SHA256,
+		CreatedAt:   timestamppb.New(attachment.CreatedAt),
+	}
+}
+
+// allows reports whether a content type passes the MIME allowlist
+func (l Limits) allows(contentType string) bool {
+	if len(l.AllowedTypes) == 0 {
+		return true
+	}
+	for _, allowed := range l.AllowedTypes {
+		if allowed == contentType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// detectContentType sniffs the content type, trusting the declared type only
+// when sniffing cannot tell anything more specific than generic text or binary
+func detectContentType(head []byte, declared string) string {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	declared, _, _ = mime.ParseMediaType(declared)
+	if declared != "" && declared != "application/octet-stream" &&
+		(sniffed == "application/octet-stream" || sniffed == "text/plain") {
+		return declared
+	}
+	return sniffed
+}
+
+// sanitizeFilename strips any directory components from an uploaded filename
+func sanitizeFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" || name == "" {
+		return "attachment"
+	}
+	return name
+}
+
+// uploadReader exposes the chunks of an upload stream as an io.Reader
+type uploadReader struct {
+	stream ticketpb.TicketService_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetMetadata() != nil {
+			return 0, errors.New("unexpected metadata message after the first message")
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// limitedReader fails once more than remaining bytes have been read
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.remaining {
+		l.exceeded = true
+		return n, errors.New("attachment too large")
+	}
+	return n, err
+}
@@ -0,0 +1,28 @@
+package attachments
+
+import (
+	"context"
+
+	"github.com/ayush-pandya/Graphql/internal/blob"
+	"github.com/ayush-pandya/Graphql/internal/config"
+)
+
+// NewBlobStore opens the blob store selected by the configuration
+func NewBlobStore(ctx context.Context, cfg config.AttachmentsConfig) (blob.Store, error) {
+	if cfg.Store == "s3" {
+		return blob.NewS3Store(ctx, blob.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Bucket:    cfg.S3.Bucket,
+			Region:    cfg.S3.Region,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			UseSSL:    cfg.S3.UseSSL,
+		})
+	}
+	return blob.NewLocalStore(cfg.Dir)
+}
+
+// LimitsFromConfig returns the upload limits set in the configuration
+func LimitsFromConfig(cfg config.AttachmentsConfig) Limits {
+	return Limits{MaxSize: cfg.MaxSize, AllowedTypes: cfg.AllowedTypes}
+}
@@ -0,0 +1,20 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when a blob does not exist
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps binary content such as ticket attachments
+type Store interface {
+	// Put stores the content of r under key. size is -1 when unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns a reader for the content stored under key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const cacheControlExtension = "cacheControl"
+
+type headerKey struct{}
+
+// Policy is the cache policy of a response, computed from @cacheControl hints
+type Policy struct {
+	MaxAge int    `json:"maxAge"`
+	Scope  string `json:"scope"`
+}
+
+// CacheControl computes the cache policy of each query from the @cacheControl
+// hints in the schema. A field uses its own hint, then the hint on its return
+// type; root fields and object fields without a hint are not cacheable
+// (maxAge 0) and scalar fields inherit their parent's maxAge. The response's
+// maxAge is the minimum over all fields and its scope is PRIVATE if any hint
+// says so. The policy is returned in the cacheControl response extension and,
+// behind HeaderMiddleware, as a Cache-Control header.
+type CacheControl struct {
+	schema *ast.Schema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+	graphql.ResponseInterceptor
+} = &CacheControl{}
+
+// ExtensionName returns the extension name
+func (c *CacheControl) ExtensionName() string {
+	return "CacheControl"
+}
+
+// Validate keeps a reference to the schema so hints can be read
+func (c *CacheControl) Validate(schema graphql.ExecutableSchema) error {
+	c.schema = schema.Schema()
+	return nil
+}
+
+// MutateOperationContext computes the policy of query operations
+func (c *CacheControl) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil || opCtx.Operation.Operation != ast.Query {
+		return nil
+	}
+
+	policy := &Policy{MaxAge: -1, Scope: "PUBLIC"}
+	c.walk(opCtx.Operation.SelectionSet, 0, policy)
+	if policy.MaxAge < 0 {
+		policy.MaxAge = 0
+	}
+	opCtx.Stats.SetExtension(cacheControlExtension, policy)
+	return nil
+}
+
+// InterceptResponse publishes the policy of successful query responses
+func (c *CacheControl) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp == nil || !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	header, _ := ctx.Value(headerKey{}).(http.Header)
+	policy, ok := graphql.GetOperationContext(ctx).Stats.GetExtension(cacheControlExtension).(*Policy)
+	if !ok || len(resp.Errors) > 0 || policy.MaxAge == 0 {
+		if header != nil {
+			header.Set("Cache-Control", "no-store")
+		}
+		return resp
+	}
+
+	if resp.Extensions == nil {
+		resp.Extensions = make(map[string]interface{})
+	}
+	resp.Extensions[cacheControlExtension] = policy
+	if header != nil {
+		header.Set("Cache-Control", fmt.Sprintf("max-age=%d, %s", policy.MaxAge, strings.ToLower(policy.Scope)))
+	}
+	return resp
+}
+
+// walk folds the hints of a selection set into policy
+func (c *CacheControl) walk(selections ast.SelectionSet, inherited int, policy *Policy) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") || sel.Definition == nil {
+				continue
+			}
+			maxAge := inherited
+			if hint := c.hintFor(sel.Definition); hint != nil {
+				if value, ok := hintArgument(hint, "maxAge").(int64); ok {
+					maxAge = int(value)
+				}
+				if scope, ok := hintArgument(hint, "scope").(string); ok && scope == "PRIVATE" {
+					policy.Scope = "PRIVATE"
+				}
+			} else if len(sel.SelectionSet) > 0 {
+				maxAge = 0
+			}
+			if policy.MaxAge < 0 || maxAge < policy.MaxAge {
+				policy.MaxAge = maxAge
+			}
+			c.walk(sel.SelectionSet, maxAge, policy)
+		case *ast.InlineFragment:
+			c.walk(sel.SelectionSet, inherited, policy)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				c.walk(sel.Definition.SelectionSet, inherited, policy)
+			}
+		}
+	}
+}
+
+// hintFor returns the @cacheControl directive of a field or its return type
+func (c *CacheControl) hintFor(field *ast.FieldDefinition) *ast.Directive {
+	if hint := field.Directives.ForName("cacheControl"); hint != nil {
+		return hint
+	}
+	if c.schema == nil {
+		return nil
+	}
+	if def := c.schema.Types[field.Type.Name()]; def != nil {
+		return def.Directives.ForName("cacheControl")
+	}
+	return nil
+}
+
+// hintArgument returns the value of a directive argument
+func hintArgument(directive *ast.Directive, name string) interface{} {
+	arg := directive.Arguments.ForName(name)
+	if arg == nil {
+		return nil
+	}
+	value, err := arg.Value.Value(nil)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// HeaderMiddleware lets the CacheControl extension set the Cache-Control header
+func HeaderMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), headerKey{}, w.Header())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
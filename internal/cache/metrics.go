@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+)
+
+// Metrics counts cache activity. The counters are kept off the global expvar
+// registry, which would publish them, along with the command line, on any
+// server using the default mux; serve them with Handler instead.
+type Metrics struct {
+	Hits          *expvar.Int
+	Misses        *expvar.Int
+	Invalidations *expvar.Int
+
+	vars *expvar.Map
+}
+
+// NewMetrics creates a set of zeroed counters
+func NewMetrics() *Metrics {
+	metrics := &Metrics{
+		Hits:          new(expvar.Int),
+		Misses:        new(expvar.Int),
+		Invalidations: new(expvar.Int),
+		vars:          new(expvar.Map),
+	}
+	metrics.vars.Set("hits", metrics.Hits)
+	metrics.vars.Set("misses", metrics.Misses)
+	metrics.vars.Set("invalidations", metrics.Invalidations)
+	return metrics
+}
+
+// Handler serves the counters as a JSON object under name, in the format of
+// /debug/vars
+func (m *Metrics) Handler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintf(w, "{%q: %s}\n", name, m.vars)
+	})
+}
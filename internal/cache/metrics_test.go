@@ -0,0 +1,35 @@
+package cache_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/cache"
+)
+
+func TestMetrics(t *testing.T) {
+	// Creating several sets must not register anything globally
+	cache.NewMetrics()
+	metrics := cache.NewMetrics()
+	if v := expvar.Get("ticket_cache"); v != nil {
+		t.Fatalf("metrics are published through expvar: %v", v)
+	}
+
+	metrics.Hits.Add(3)
+	metrics.Misses.Add(1)
+	rec := httptest.NewRecorder()
+	metrics.Handler("ticket_cache").ServeHTTP(rec, httptest.NewRequest("GET", "/debug/vars", nil))
+
+	var got map[string]map[string]int64
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body, err)
+	}
+	want := map[string]int64{"hits": 3, "misses": 1, "invalidations": 0}
+	for name, value := range want {
+		if got["ticket_cache"][name] != value {
+			t.Errorf("%s = %d, want %d", name, got["ticket_cache"][name], value)
+		}
+	}
+}
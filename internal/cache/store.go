@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store is a byte-oriented cache. The in-process LRU is the default; an
+// external store (Redis, memcached, ...) only needs to implement this interface.
+type Store interface {
+	// Get returns the value stored under key if it exists and has not expired
+	Get(ctx context.Context, key string) ([]byte, bool)
+	// Set stores a value for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	// Delete removes the given keys
+	Delete(ctx context.Context, keys ...string)
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(ctx context.Context, prefix string)
+}
+
+// LRU is an in-process Store bounded by entry count with per-entry TTLs
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ Store = (*LRU)(nil)
+
+// NewLRU creates an in-process cache holding at most size entries
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Get returns a cached value, dropping it if it has expired
+func (c *LRU) Get(ctx context.Context, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if c.now().After(e.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return e.value, true
+}
+
+// Set stores a value, evicting the least recently used entry when full
+func (c *LRU) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes the given keys
+func (c *LRU) Delete(ctx context.Context, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+// DeletePrefix removes every key starting with prefix
+func (c *LRU) DeletePrefix(ctx context.Context, prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(element)
+		}
+	}
+}
+
+// remove drops an element; the caller must hold the lock
+func (c *LRU) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry).key)
+}
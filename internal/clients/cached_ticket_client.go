@@ -0,0 +1,352 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/cache"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	ticketKeyPrefix = "tickets:id:"
+	listKeyPrefix   = "tickets:list:"
+)
+
+// CachedTicketClient is a read-through cache in front of a TicketService.
+// Tickets are cached by ID and lists by their normalised request, both under
+// the caller's organization; any create, update or delete invalidates the
+// affected entries of that organization.
+type CachedTicketClient struct {
+	next    TicketService
+	store   cache.Store
+	ttl     time.Duration
+	metrics *cache.Metrics
+}
+
+var _ TicketService = (*CachedTicketClient)(nil)
+
+// NewCachedTicketClient wraps next with a cache
+func NewCachedTicketClient(next TicketService, store cache.Store, ttl time.Duration, metrics *cache.Metrics) *CachedTicketClient {
+	return &CachedTicketClient{
+		next:    next,
+		store:   store,
+		ttl:     ttl,
+		metrics: metrics,
+	}
+}
+
+// CreateTicket creates a ticket and invalidates cached lists
+func (c *CachedTicketClient) CreateTicket(ctx context.Context, title, description string, priority ticketpb.TicketPriority, assigneeID, reporterID string, tags []string, projectKey string, customFields map[string]string, dueDate *timestamppb.Timestamp) (*ticketpb.Ticket, error) {
+	ticket, err := c.next.CreateTicket(ctx, title, description, priority, assigneeID, reporterID, tags, projectKey, customFields, dueDate)
+	if err != nil {
+		return nil, err
+	}
+	c.invalidate(ctx, "")
+	return ticket, nil
+}
+
+// GetTicket returns a cached ticket or loads it from the next service
+func (c *CachedTicketClient) GetTicket(ctx context.Context, id string) (*ticketpb.Ticket, error) {
+	key := ticketKey(ctx, id)
+
+	var ticket ticketpb.Ticket
+	if c.lookup(ctx, key, &ticket) {
+		return &ticket, nil
+	}
+
+	loaded, err := c.next.GetTicket(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.save(ctx, key, loaded)
+	return loaded, nil
+}
+
+// GetTicketByKey passes through to the next service; tickets are cached by ID only
+func (c *CachedTicketClient) GetTicketByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	return c.next.GetTicketByKey(ctx, key)
+}
+
+// ListTickets returns a cached page of tickets or loads it from the next service
+func (c *CachedTicketClient) ListTickets(ctx context.Context, pageSize int32, pageToken, projectKey string, customFields map[string]string) ([]*ticketpb.Ticket, string, error) {
+	req := &ticketpb.ListTicketsRequest{PageSize: pageSize, PageToken: pageToken, ProjectKey: projectKey, CustomFields: customFields}
+	key := listKey(ctx, req)
+
+	var page ticketpb.ListTicketsResponse
+	if c.lookup(ctx, key, &page) {
+		return page.Tickets, page.NextPageToken, nil
+	}
+
+	tickets, next, err := c.next.ListTickets(ctx, pageSize, pageToken, projectKey, customFields)
+	if err != nil {
+		return nil, "", err
+	}
+	c.save(ctx, key, &ticketpb.ListTicketsResponse{Tickets: tickets, NextPageToken: next})
+	return tickets, next, nil
+}
+
+// UpdateTicket updates a ticket and invalidates it and cached lists
+func (c *CachedTicketClient) UpdateTicket(ctx context.Context, id, title, description string, status ticketpb.TicketStatus, priority ticketpb.TicketPriority, assigneeID string, tags []string, customFields map[string]string, dueDate *timestamppb.Timestamp, clearDueDate bool) (*ticketpb.Ticket, error) {
+	ticket, err := c.next.UpdateTicket(ctx, id, title, description, status, priority, assigneeID, tags, customFields, dueDate, clearDueDate)
+	c.invalidate(ctx, id)
+	return ticket, err
+}
+
+// DeleteTicket deletes a ticket and invalidates it and cached lists
+func (c *CachedTicketClient) DeleteTicket(ctx context.Context, id string) (bool, error) {
+	success, err := c.next.DeleteTicket(ctx, id)
+	c.invalidate(ctx, id)
+	return success, err
+}
+
+// BulkUpdateTickets updates several tickets and invalidates them and cached lists
+func (c *CachedTicketClient) BulkUpdateTickets(ctx context.Context, ids []string, patch *ticketpb.TicketPatch, atomic bool) ([]*ticketpb.BulkTicketResult, error) {
+	results, err := c.next.BulkUpdateTickets(ctx, ids, patch, atomic)
+	c.invalidateAll(ctx, ids)
+	return results, err
+}
+
+// BulkDeleteTickets deletes several tickets and invalidates them and cached lists
+func (c *CachedTicketClient) BulkDeleteTickets(ctx context.Context, ids []string, atomic bool) ([]*ticketpb.BulkTicketResult, error) {
+	results, err := c.next.BulkDeleteTickets(ctx, ids, atomic)
+	c.invalidateAll(ctx, ids)
+	return results, err
+}
+
+// CreateProject passes through to the next service
+func (c *CachedTicketClient) CreateProject(ctx context.Context, key, name, description string) (*ticketpb.Project, error) {
+	return c.next.CreateProject(ctx, key, name, description)
+}
+
+// GetProject passes through to the next service
+func (c *CachedTicketClient) GetProject(ctx context.Context, id, key string) (*ticketpb.Project, error) {
+	return c.next.GetProject(ctx, id, key)
+}
+
+// ListProjects passes through to the next service
+func (c *CachedTicketClient) ListProjects(ctx context.Context) ([]*ticketpb.Project, error) {
+	return c.next.ListProjects(ctx)
+}
+
+// SetCustomFields passes through to the next service; definitions do not
+// change cached tickets
+func (c *CachedTicketClient) SetCustomFields(ctx context.Context, projectKey string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error) {
+	return c.next.SetCustomFields(ctx, projectKey, fields)
+}
+
+// ListSLAPolicies passes through to the next service
+func (c *CachedTicketClient) ListSLAPolicies(ctx context.Context) ([]*ticketpb.SLAPolicy, error) {
+	return c.next.ListSLAPolicies(ctx)
+}
+
+// ListTags passes through to the next service
+func (c *CachedTicketClient) ListTags(ctx context.Context, prefix string, limit int32) ([]*ticketpb.TagCount, error) {
+	return c.next.ListTags(ctx, prefix, limit)
+}
+
+// MergeTags merges tags and invalidates every cached ticket and list
+func (c *CachedTicketClient) MergeTags(ctx context.Context, sources []string, target string) (int32, error) {
+	changed, err := c.next.MergeTags(ctx, sources, target)
+	c.invalidateOrganization(ctx)
+	return changed, err
+}
+
+// LinkTickets passes through to the next service
+func (c *CachedTicketClient) LinkTickets(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) (*ticketpb.TicketLink, error) {
+	return c.next.LinkTickets(ctx, sourceID, targetID, linkType)
+}
+
+// UnlinkTickets passes through to the next service
+func (c *CachedTicketClient) UnlinkTickets(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) (bool, error) {
+	return c.next.UnlinkTickets(ctx, sourceID, targetID, linkType)
+}
+
+// ListLinks passes through to the next service
+func (c *CachedTicketClient) ListLinks(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	return c.next.ListLinks(ctx, ticketID)
+}
+
+// UploadAttachment passes through to the next service
+func (c *CachedTicketClient) UploadAttachment(ctx context.Context, ticketID, filename, contentType string, content io.Reader) (*ticketpb.Attachment, error) {
+	return c.next.UploadAttachment(ctx, ticketID, filename, contentType, content)
+}
+
+// ListAttachments passes through to the next service
+func (c *CachedTicketClient) ListAttachments(ctx context.Context, ticketID string) ([]*ticketpb.Attachment, error) {
+	return c.next.ListAttachments(ctx, ticketID)
+}
+
+// DownloadAttachment passes through to the next service
+func (c *CachedTicketClient) DownloadAttachment(ctx context.Context, id string) (*ticketpb.Attachment, io.ReadCloser, error) {
+	return c.next.DownloadAttachment(ctx, id)
+}
+
+// CreateWebhook passes through to the next service; webhooks are not cached
+func (c *CachedTicketClient) CreateWebhook(ctx context.Context, url, secret string, eventTypes []string, filter *ticketpb.WebhookFilter) (*ticketpb.Webhook, error) {
+	return c.next.CreateWebhook(ctx, url, secret, eventTypes, filter)
+}
+
+// UpdateWebhook passes through to the next service
+func (c *CachedTicketClient) UpdateWebhook(ctx context.Context, id, url, secret string, eventTypes []string, filter *ticketpb.WebhookFilter, active *bool) (*ticketpb.Webhook, error) {
+	return c.next.UpdateWebhook(ctx, id, url, secret, eventTypes, filter, active)
+}
+
+// DeleteWebhook passes through to the next service
+func (c *CachedTicketClient) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	return c.next.DeleteWebhook(ctx, id)
+}
+
+// ListWebhooks passes through to the next service
+func (c *CachedTicketClient) ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error) {
+	return c.next.ListWebhooks(ctx)
+}
+
+// ListWebhookDeliveries passes through to the next service
+func (c *CachedTicketClient) ListWebhookDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int32) ([]*ticketpb.WebhookDelivery, error) {
+	return c.next.ListWebhookDeliveries(ctx, webhookID, status, limit)
+}
+
+// RedeliverWebhook passes through to the next service
+func (c *CachedTicketClient) RedeliverWebhook(ctx context.Context, deliveryID string) (*ticketpb.WebhookDelivery, error) {
+	return c.next.RedeliverWebhook(ctx, deliveryID)
+}
+
+// WatchTicket passes through to the next service; watchers are not cached
+func (c *CachedTicketClient) WatchTicket(ctx context.Context, ticketID, userID string) ([]string, error) {
+	return c.next.WatchTicket(ctx, ticketID, userID)
+}
+
+// UnwatchTicket passes through to the next service
+func (c *CachedTicketClient) UnwatchTicket(ctx context.Context, ticketID, userID string) (bool, error) {
+	return c.next.UnwatchTicket(ctx, ticketID, userID)
+}
+
+// ListWatchers passes through to the next service
+func (c *CachedTicketClient) ListWatchers(ctx context.Context, ticketID string) ([]string, error) {
+	return c.next.ListWatchers(ctx, ticketID)
+}
+
+// ListNotifications passes through to the next service; notifications are not cached
+func (c *CachedTicketClient) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int32) ([]*ticketpb.Notification, error) {
+	return c.next.ListNotifications(ctx, userID, unreadOnly, limit)
+}
+
+// MarkNotificationRead passes through to the next service
+func (c *CachedTicketClient) MarkNotificationRead(ctx context.Context, id string) (*ticketpb.Notification, error) {
+	return c.next.MarkNotificationRead(ctx, id)
+}
+
+// GetNotificationPreferences passes through to the next service
+func (c *CachedTicketClient) GetNotificationPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error) {
+	return c.next.GetNotificationPreferences(ctx, userID)
+}
+
+// UpdateNotificationPreferences passes through to the next service
+func (c *CachedTicketClient) UpdateNotificationPreferences(ctx context.Context, userID string, email *string, delivery ticketpb.NotificationDelivery, digestHour *int32, mutedKinds []ticketpb.NotificationKind, setMutedKinds bool) (*ticketpb.NotificationPreferences, error) {
+	return c.next.UpdateNotificationPreferences(ctx, userID, email, delivery, digestHour, mutedKinds, setMutedKinds)
+}
+
+// lookup decodes a cached message into out, recording a hit or miss
+func (c *CachedTicketClient) lookup(ctx context.Context, key string, out proto.Message) bool {
+	data, ok := c.store.Get(ctx, key)
+	if ok {
+		if err := proto.Unmarshal(data, out); err == nil {
+			c.metrics.Hits.Add(1)
+			return true
+		}
+		log.Printf("Ticket cache: dropping undecodable entry %s", key)
+		c.store.Delete(ctx, key)
+	}
+	c.metrics.Misses.Add(1)
+	return false
+}
+
+// save encodes and stores a message
+func (c *CachedTicketClient) save(ctx context.Context, key string, msg proto.Message) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		log.Printf("Ticket cache: failed to encode %s: %v", key, err)
+		return
+	}
+	c.store.Set(ctx, key, data, c.ttl)
+}
+
+// invalidate drops a ticket (if id is set) and every cached list of the organization
+func (c *CachedTicketClient) invalidate(ctx context.Context, id string) {
+	if id != "" {
+		c.store.Delete(ctx, ticketKey(ctx, id))
+	}
+	c.store.DeletePrefix(ctx, listKeyPrefix+tenant.ID(ctx)+":")
+	c.metrics.Invalidations.Add(1)
+}
+
+// invalidateAll drops several tickets and every cached list of the organization
+func (c *CachedTicketClient) invalidateAll(ctx context.Context, ids []string) {
+	for _, id := range ids {
+		c.store.Delete(ctx, ticketKey(ctx, id))
+	}
+	c.invalidate(ctx, "")
+}
+
+// invalidateOrganization drops every cached ticket and list of the organization
+func (c *CachedTicketClient) invalidateOrganization(ctx context.Context) {
+	c.store.DeletePrefix(ctx, ticketKeyPrefix+tenant.ID(ctx)+":")
+	c.invalidate(ctx, "")
+}
+
+// UnaryClientInterceptor invalidates the cache after writes made by other
+// clients of the ticket service, such as the REST API, that go around c
+func (c *CachedTicketClient) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			return err
+		}
+		switch req := req.(type) {
+		case *ticketpb.CreateTicketRequest:
+			c.invalidate(ctx, "")
+		case *ticketpb.MergeTagsRequest:
+			c.invalidateOrganization(ctx)
+		case *ticketpb.UpdateTicketRequest:
+			c.invalidate(ctx, req.Id)
+		case *ticketpb.DeleteTicketRequest:
+			c.invalidate(ctx, req.Id)
+		case *ticketpb.BulkUpdateTicketsRequest:
+			c.invalidateAll(ctx, req.Ids)
+		case *ticketpb.BulkDeleteTicketsRequest:
+			c.invalidateAll(ctx, req.Ids)
+		}
+		return nil
+	}
+}
+
+// ticketKey is the cache key of a ticket in the caller's organization
+func ticketKey(ctx context.Context, id string) string {
+	return ticketKeyPrefix + tenant.ID(ctx) + ":" + id
+}
+
+// listKey normalises a list request in the caller's organization into a cache key
+func listKey(ctx context.Context, req *ticketpb.ListTicketsRequest) string {
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	sum := sha256.Sum256(data)
+	return listKeyPrefix + tenant.ID(ctx) + ":" + hex.EncodeToString(sum[:])
+}
+
+// AddComment passes through to the next service; comments are not cached
+func (c *CachedTicketClient) AddComment(ctx context.Context, ticketID, authorID, body string) (*ticketpb.Comment, error) {
+	return c.next.AddComment(ctx, ticketID, authorID, body)
+}
+
+// ListComments passes through to the next service
+func (c *CachedTicketClient) ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error) {
+	return c.next.ListComments(ctx, ticketID)
+}
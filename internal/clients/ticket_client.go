@@ -3,11 +3,17 @@ package clients
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/ratelimit"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
 	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // TicketClient wraps the gRPC client for the ticket service
@@ -16,10 +22,17 @@ type TicketClient struct {
 	client ticketpb.TicketServiceClient
 }
 
-// NewTicketClient creates a new ticket service client
-func NewTicketClient(address string) (*TicketClient, error) {
-	// Create gRPC connection
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewTicketClient creates a new ticket service client; opts add to the dial
+// options, e.g. interceptors that send credentials
+func NewTicketClient(address string, opts ...grpc.DialOption) (*TicketClient, error) {
+	// Create gRPC connection; calls carry the caller's organization,
+	// identity and idempotency key as metadata
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tenant.UnaryClientInterceptor(), ratelimit.UnaryClientInterceptor(), idempotency.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tenant.StreamClientInterceptor(), ratelimit.StreamClientInterceptor()),
+	}, opts...)
+	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to ticket service: %w", err)
 	}
@@ -41,13 +54,17 @@ func (tc *TicketClient) Close() error {
 }
 
 // CreateTicket creates a new ticket via gRPC
-func (tc *TicketClient) CreateTicket(ctx context.Context, title, description string, priority ticketpb.TicketPriority, assigneeID string, tags []string) (*ticketpb.Ticket, error) {
+func (tc *TicketClient) CreateTicket(ctx context.Context, title, description string, priority ticketpb.TicketPriority, assigneeID, reporterID string, tags []string, projectKey string, customFields map[string]string, dueDate *timestamppb.Timestamp) (*ticketpb.Ticket, error) {
 	req := &ticketpb.CreateTicketRequest{
-		Title:       title,
-		Description: description,
-		Priority:    priority,
-		AssigneeId:  assigneeID,
-		Tags:        tags,
+		Title:        title,
+		Description:  description,
+		Priority:     priority,
+		AssigneeId:   assigneeID,
+		ReporterId:   reporterID,
+		Tags:         tags,
+		ProjectKey:   projectKey,
+		CustomFields: customFields,
+		DueDate:      dueDate,
 	}
 
 	resp, err := tc.client.CreateTicket(ctx, req)
@@ -74,11 +91,25 @@ func (tc *TicketClient) GetTicket(ctx context.Context, id string) (*ticketpb.Tic
 	return resp.Ticket, nil
 }
 
-// ListTickets retrieves all tickets via gRPC
-func (tc *TicketClient) ListTickets(ctx context.Context, pageSize int32, pageToken string) ([]*ticketpb.Ticket, string, error) {
+// GetTicketByKey retrieves a ticket by its project key (e.g. WEB-42) via gRPC
+func (tc *TicketClient) GetTicketByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	resp, err := tc.client.GetTicket(ctx, &ticketpb.GetTicketRequest{Key: key})
+	if err != nil {
+		log.Printf("Error getting ticket via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+
+	return resp.Ticket, nil
+}
+
+// ListTickets retrieves a page of tickets, optionally of a single project and
+// with the given custom field values, via gRPC
+func (tc *TicketClient) ListTickets(ctx context.Context, pageSize int32, pageToken, projectKey string, customFields map[string]string) ([]*ticketpb.Ticket, string, error) {
 	req := &ticketpb.ListTicketsRequest{
-		PageSize:  pageSize,
-		PageToken: pageToken,
+		PageSize:     pageSize,
+		PageToken:    pageToken,
+		ProjectKey:   projectKey,
+		CustomFields: customFields,
 	}
 
 	resp, err := tc.client.ListTickets(ctx, req)
@@ -91,15 +122,18 @@ func (tc *TicketClient) ListTickets(ctx context.Context, pageSize int32, pageTok
 }
 
 // UpdateTicket updates an existing ticket via gRPC
-func (tc *TicketClient) UpdateTicket(ctx context.Context, id, title, description string, status ticketpb.TicketStatus, priority ticketpb.TicketPriority, assigneeID string, tags []string) (*ticketpb.Ticket, error) {
+func (tc *TicketClient) UpdateTicket(ctx context.Context, id, title, description string, status ticketpb.TicketStatus, priority ticketpb.TicketPriority, assigneeID string, tags []string, customFields map[string]string, dueDate *timestamppb.Timestamp, clearDueDate bool) (*ticketpb.Ticket, error) {
 	req := &ticketpb.UpdateTicketRequest{
-		Id:          id,
-		Title:       title,
-		Description: description,
-		Status:      status,
-		Priority:    priority,
-		AssigneeId:  assigneeID,
-		Tags:        tags,
+		Id:           id,
+		Title:        title,
+		Description:  description,
+		Status:       status,
+		Priority:     priority,
+		AssigneeId:   assigneeID,
+		Tags:         tags,
+		CustomFields: customFields,
+		DueDate:      dueDate,
+		ClearDueDate: clearDueDate,
 	}
 
 	resp, err := tc.client.UpdateTicket(ctx, req)
@@ -125,3 +159,568 @@ func (tc *TicketClient) DeleteTicket(ctx context.Context, id string) (bool, erro
 
 	return resp.Success, nil
 }
+
+// BulkUpdateTickets applies a patch to several tickets via gRPC
+func (tc *TicketClient) BulkUpdateTickets(ctx context.Context, ids []string, patch *ticketpb.TicketPatch, atomic bool) ([]*ticketpb.BulkTicketResult, error) {
+	resp, err := tc.client.BulkUpdateTickets(ctx, &ticketpb.BulkUpdateTicketsRequest{Ids: ids, Patch: patch, Atomic: atomic})
+	if err != nil {
+		log.Printf("Error bulk updating tickets via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to bulk update tickets: %w", err)
+	}
+
+	return resp.Results, nil
+}
+
+// BulkDeleteTickets deletes several tickets via gRPC
+func (tc *TicketClient) BulkDeleteTickets(ctx context.Context, ids []string, atomic bool) ([]*ticketpb.BulkTicketResult, error) {
+	resp, err := tc.client.BulkDeleteTickets(ctx, &ticketpb.BulkDeleteTicketsRequest{Ids: ids, Atomic: atomic})
+	if err != nil {
+		log.Printf("Error bulk deleting tickets via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to bulk delete tickets: %w", err)
+	}
+
+	return resp.Results, nil
+}
+
+// ExportTickets streams the tickets matching the request via gRPC, handing
+// each page of records to fn
+func (tc *TicketClient) ExportTickets(ctx context.Context, req *ticketpb.ExportTicketsRequest, fn func([]*ticketpb.TicketRecord) error) error {
+	stream, err := tc.client.ExportTickets(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to start ticket export: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Error exporting tickets via gRPC: %v", err)
+			return fmt.Errorf("failed to export tickets: %w", err)
+		}
+		if err := fn(resp.Tickets); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportTickets streams the records returned by next via gRPC until it
+// returns io.EOF, then returns the import report
+func (tc *TicketClient) ImportTickets(ctx context.Context, opts *ticketpb.ImportOptions, next func() (*ticketpb.TicketRecord, error)) (*ticketpb.ImportTicketsResponse, error) {
+	stream, err := tc.client.ImportTickets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start ticket import: %w", err)
+	}
+
+	if err := stream.Send(&ticketpb.ImportTicketsRequest{
+		Data: &ticketpb.ImportTicketsRequest_Options{Options: opts},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send import options: %w", err)
+	}
+
+	for {
+		record, readErr := next()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+		if err := stream.Send(&ticketpb.ImportTicketsRequest{
+			Data: &ticketpb.ImportTicketsRequest_Ticket{Ticket: record},
+		}); err != nil {
+			// The server aborted the stream; CloseAndRecv reports why
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Printf("Error importing tickets via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to import tickets: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CreateProject creates a new project via gRPC
+func (tc *TicketClient) CreateProject(ctx context.Context, key, name, description string) (*ticketpb.Project, error) {
+	req := &ticketpb.CreateProjectRequest{
+		Key:         key,
+		Name:        name,
+		Description: description,
+	}
+
+	resp, err := tc.client.CreateProject(ctx, req)
+	if err != nil {
+		log.Printf("Error creating project via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return resp.Project, nil
+}
+
+// GetProject retrieves a project by ID, or by key when id is empty, via gRPC
+func (tc *TicketClient) GetProject(ctx context.Context, id, key string) (*ticketpb.Project, error) {
+	resp, err := tc.client.GetProject(ctx, &ticketpb.GetProjectRequest{Id: id, Key: key})
+	if err != nil {
+		log.Printf("Error getting project via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return resp.Project, nil
+}
+
+// ListProjects retrieves every project via gRPC
+func (tc *TicketClient) ListProjects(ctx context.Context) ([]*ticketpb.Project, error) {
+	resp, err := tc.client.ListProjects(ctx, &ticketpb.ListProjectsRequest{})
+	if err != nil {
+		log.Printf("Error listing projects via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return resp.Projects, nil
+}
+
+// SetCustomFields replaces the custom field definitions of a project via gRPC
+func (tc *TicketClient) SetCustomFields(ctx context.Context, projectKey string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error) {
+	resp, err := tc.client.SetCustomFields(ctx, &ticketpb.SetCustomFieldsRequest{ProjectKey: projectKey, Fields: fields})
+	if err != nil {
+		log.Printf("Error setting custom fields via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to set custom fields: %w", err)
+	}
+
+	return resp.Project, nil
+}
+
+// ListTags retrieves the tag catalogue via gRPC
+func (tc *TicketClient) ListTags(ctx context.Context, prefix string, limit int32) ([]*ticketpb.TagCount, error) {
+	resp, err := tc.client.ListTags(ctx, &ticketpb.ListTagsRequest{Prefix: prefix, Limit: limit})
+	if err != nil {
+		log.Printf("Error listing tags via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return resp.Tags, nil
+}
+
+// ListSLAPolicies retrieves the SLA policies via gRPC
+func (tc *TicketClient) ListSLAPolicies(ctx context.Context) ([]*ticketpb.SLAPolicy, error) {
+	resp, err := tc.client.ListSLAPolicies(ctx, &ticketpb.ListSLAPoliciesRequest{})
+	if err != nil {
+		log.Printf("Error listing SLA policies via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list SLA policies: %w", err)
+	}
+
+	return resp.Policies, nil
+}
+
+// MergeTags replaces the source tags with target on every ticket via gRPC
+func (tc *TicketClient) MergeTags(ctx context.Context, sources []string, target string) (int32, error) {
+	resp, err := tc.client.MergeTags(ctx, &ticketpb.MergeTagsRequest{Sources: sources, Target: target})
+	if err != nil {
+		log.Printf("Error merging tags via gRPC: %v", err)
+		return 0, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	return resp.UpdatedTickets, nil
+}
+
+// LinkTickets links two tickets via gRPC
+func (tc *TicketClient) LinkTickets(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) (*ticketpb.TicketLink, error) {
+	req := &ticketpb.LinkTicketsRequest{
+		SourceId: sourceID,
+		TargetId: targetID,
+		Type:     linkType,
+	}
+
+	resp, err := tc.client.LinkTickets(ctx, req)
+	if err != nil {
+		log.Printf("Error linking tickets via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to link tickets: %w", err)
+	}
+
+	return resp.Link, nil
+}
+
+// UnlinkTickets removes a link between two tickets via gRPC
+func (tc *TicketClient) UnlinkTickets(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) (bool, error) {
+	req := &ticketpb.UnlinkTicketsRequest{
+		SourceId: sourceID,
+		TargetId: targetID,
+		Type:     linkType,
+	}
+
+	resp, err := tc.client.UnlinkTickets(ctx, req)
+	if err != nil {
+		log.Printf("Error unlinking tickets via gRPC: %v", err)
+		return false, fmt.Errorf("failed to unlink tickets: %w", err)
+	}
+
+	return resp.Success, nil
+}
+
+// ListLinks retrieves the links from or to a ticket via gRPC
+func (tc *TicketClient) ListLinks(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	resp, err := tc.client.ListLinks(ctx, &ticketpb.ListLinksRequest{TicketId: ticketID})
+	if err != nil {
+		log.Printf("Error listing links via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	return resp.Links, nil
+}
+
+// UploadAttachment streams a file to the ticket service in chunks
+func (tc *TicketClient) UploadAttachment(ctx context.Context, ticketID, filename, contentType string, content io.Reader) (*ticketpb.Attachment, error) {
+	stream, err := tc.client.UploadAttachment(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start attachment upload: %w", err)
+	}
+
+	if err := stream.Send(&ticketpb.UploadAttachmentRequest{
+		Data: &ticketpb.UploadAttachmentRequest_Metadata{Metadata: &ticketpb.AttachmentMetadata{
+			TicketId:    ticketID,
+			Filename:    filename,
+			ContentType: contentType,
+		}},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send attachment metadata: %w", err)
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&ticketpb.UploadAttachmentRequest{
+				Data: &ticketpb.UploadAttachmentRequest_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				// The server aborted the stream; CloseAndRecv reports why
+				break
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read attachment: %w", readErr)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Printf("Error uploading attachment via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	return resp.Attachment, nil
+}
+
+// ListAttachments retrieves the attachments of a ticket via gRPC
+func (tc *TicketClient) ListAttachments(ctx context.Context, ticketID string) ([]*ticketpb.Attachment, error) {
+	resp, err := tc.client.ListAttachments(ctx, &ticketpb.ListAttachmentsRequest{TicketId: ticketID})
+	if err != nil {
+		log.Printf("Error listing attachments via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	return resp.Attachments, nil
+}
+
+// DownloadAttachment opens an attachment for reading. The caller must close
+// the returned reader.
+func (tc *TicketClient) DownloadAttachment(ctx context.Context, id string) (*ticketpb.Attachment, io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := tc.client.DownloadAttachment(ctx, &ticketpb.DownloadAttachmentRequest{Id: id})
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	attachment := first.GetAttachment()
+	if attachment == nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to download attachment: missing metadata")
+	}
+
+	return attachment, &downloadReader{stream: stream, cancel: cancel}, nil
+}
+
+// uploadChunkSize is the size of the content chunks sent when uploading
+const uploadChunkSize = 64 * 1024
+
+// downloadReader exposes the chunks of a download stream as an io.ReadCloser
+type downloadReader struct {
+	stream ticketpb.TicketService_DownloadAttachmentClient
+	cancel context.CancelFunc
+	buf    []byte
+}
+
+func (r *downloadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		resp, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = resp.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *downloadReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// CreateWebhook subscribes a URL to ticket events via gRPC
+func (tc *TicketClient) CreateWebhook(ctx context.Context, url, secret string, eventTypes []string, filter *ticketpb.WebhookFilter) (*ticketpb.Webhook, error) {
+	req := &ticketpb.CreateWebhookRequest{
+		Url:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Filter:     filter,
+	}
+
+	resp, err := tc.client.CreateWebhook(ctx, req)
+	if err != nil {
+		log.Printf("Error creating webhook via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return resp.Webhook, nil
+}
+
+// UpdateWebhook changes the settings of a webhook via gRPC; active is left
+// unchanged when nil
+func (tc *TicketClient) UpdateWebhook(ctx context.Context, id, url, secret string, eventTypes []string, filter *ticketpb.WebhookFilter, active *bool) (*ticketpb.Webhook, error) {
+	req := &ticketpb.UpdateWebhookRequest{
+		Id:         id,
+		Url:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Filter:     filter,
+	}
+	if active != nil {
+		req.Active = wrapperspb.Bool(*active)
+	}
+
+	resp, err := tc.client.UpdateWebhook(ctx, req)
+	if err != nil {
+		log.Printf("Error updating webhook via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return resp.Webhook, nil
+}
+
+// DeleteWebhook deletes a webhook and its deliveries via gRPC
+func (tc *TicketClient) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	resp, err := tc.client.DeleteWebhook(ctx, &ticketpb.DeleteWebhookRequest{Id: id})
+	if err != nil {
+		log.Printf("Error deleting webhook via gRPC: %v", err)
+		return false, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return resp.Success, nil
+}
+
+// ListWebhooks retrieves every webhook via gRPC
+func (tc *TicketClient) ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error) {
+	resp, err := tc.client.ListWebhooks(ctx, &ticketpb.ListWebhooksRequest{})
+	if err != nil {
+		log.Printf("Error listing webhooks via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return resp.Webhooks, nil
+}
+
+// ListWebhookDeliveries retrieves the deliveries of a webhook via gRPC
+func (tc *TicketClient) ListWebhookDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int32) ([]*ticketpb.WebhookDelivery, error) {
+	req := &ticketpb.ListWebhookDeliveriesRequest{
+		WebhookId: webhookID,
+		Status:    status,
+		Limit:     limit,
+	}
+
+	resp, err := tc.client.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		log.Printf("Error listing webhook deliveries via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return resp.Deliveries, nil
+}
+
+// RedeliverWebhook queues a webhook delivery again via gRPC
+func (tc *TicketClient) RedeliverWebhook(ctx context.Context, deliveryID string) (*ticketpb.WebhookDelivery, error) {
+	resp, err := tc.client.RedeliverWebhook(ctx, &ticketpb.RedeliverWebhookRequest{DeliveryId: deliveryID})
+	if err != nil {
+		log.Printf("Error redelivering webhook via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+
+	return resp.Delivery, nil
+}
+
+// WatchTicket makes a user a watcher of a ticket via gRPC and returns every
+// watcher of the ticket
+func (tc *TicketClient) WatchTicket(ctx context.Context, ticketID, userID string) ([]string, error) {
+	resp, err := tc.client.WatchTicket(ctx, &ticketpb.WatchTicketRequest{TicketId: ticketID, UserId: userID})
+	if err != nil {
+		log.Printf("Error watching ticket via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to watch ticket: %w", err)
+	}
+
+	return resp.WatcherIds, nil
+}
+
+// UnwatchTicket stops a user watching a ticket via gRPC
+func (tc *TicketClient) UnwatchTicket(ctx context.Context, ticketID, userID string) (bool, error) {
+	resp, err := tc.client.UnwatchTicket(ctx, &ticketpb.UnwatchTicketRequest{TicketId: ticketID, UserId: userID})
+	if err != nil {
+		log.Printf("Error unwatching ticket via gRPC: %v", err)
+		return false, fmt.Errorf("failed to unwatch ticket: %w", err)
+	}
+
+	return resp.Success, nil
+}
+
+// ListWatchers retrieves the watchers of a ticket via gRPC
+func (tc *TicketClient) ListWatchers(ctx context.Context, ticketID string) ([]string, error) {
+	resp, err := tc.client.ListWatchers(ctx, &ticketpb.ListWatchersRequest{TicketId: ticketID})
+	if err != nil {
+		log.Printf("Error listing watchers via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list watchers: %w", err)
+	}
+
+	return resp.WatcherIds, nil
+}
+
+// ListNotifications retrieves the notifications of a user via gRPC
+func (tc *TicketClient) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int32) ([]*ticketpb.Notification, error) {
+	req := &ticketpb.ListNotificationsRequest{
+		UserId:     userID,
+		UnreadOnly: unreadOnly,
+		Limit:      limit,
+	}
+
+	resp, err := tc.client.ListNotifications(ctx, req)
+	if err != nil {
+		log.Printf("Error listing notifications via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return resp.Notifications, nil
+}
+
+// MarkNotificationRead marks a notification read via gRPC
+func (tc *TicketClient) MarkNotificationRead(ctx context.Context, id string) (*ticketpb.Notification, error) {
+	resp, err := tc.client.MarkNotificationRead(ctx, &ticketpb.MarkNotificationReadRequest{Id: id})
+	if err != nil {
+		log.Printf("Error marking notification read via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	return resp.Notification, nil
+}
+
+// GetNotificationPreferences retrieves the notification preferences of a
+// user via gRPC
+func (tc *TicketClient) GetNotificationPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error) {
+	resp, err := tc.client.GetNotificationPreferences(ctx, &ticketpb.GetNotificationPreferencesRequest{UserId: userID})
+	if err != nil {
+		log.Printf("Error getting notification preferences via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return resp.Preferences, nil
+}
+
+// UpdateNotificationPreferences changes the notification preferences of a
+// user via gRPC; email and digestHour are left unchanged when nil, and the
+// muted kinds unless setMutedKinds is set
+func (tc *TicketClient) UpdateNotificationPreferences(ctx context.Context, userID string, email *string, delivery ticketpb.NotificationDelivery, digestHour *int32, mutedKinds []ticketpb.NotificationKind, setMutedKinds bool) (*ticketpb.NotificationPreferences, error) {
+	req := &ticketpb.UpdateNotificationPreferencesRequest{
+		UserId:        userID,
+		Delivery:      delivery,
+		SetMutedKinds: setMutedKinds,
+		MutedKinds:    mutedKinds,
+	}
+	if email != nil {
+		req.Email = wrapperspb.String(*email)
+	}
+	if digestHour != nil {
+		req.DigestHour = wrapperspb.Int32(*digestHour)
+	}
+
+	resp, err := tc.client.UpdateNotificationPreferences(ctx, req)
+	if err != nil {
+		log.Printf("Error updating notification preferences via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return resp.Preferences, nil
+}
+
+// AddComment adds a comment to a ticket via gRPC
+func (tc *TicketClient) AddComment(ctx context.Context, ticketID, authorID, body string) (*ticketpb.Comment, error) {
+	req := &ticketpb.AddCommentRequest{
+		TicketId: ticketID,
+		AuthorId: authorID,
+		Body:     body,
+	}
+
+	resp, err := tc.client.AddComment(ctx, req)
+	if err != nil {
+		log.Printf("Error adding comment via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return resp.Comment, nil
+}
+
+// ListComments retrieves the comments of a ticket via gRPC
+func (tc *TicketClient) ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error) {
+	resp, err := tc.client.ListComments(ctx, &ticketpb.ListCommentsRequest{TicketId: ticketID})
+	if err != nil {
+		log.Printf("Error listing comments via gRPC: %v", err)
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return resp.Comments, nil
+}
+
+// FindEmailThread retrieves the ticket whose email thread holds one of the
+// given Message-IDs via gRPC
+func (tc *TicketClient) FindEmailThread(ctx context.Context, messageIDs []string) (string, error) {
+	resp, err := tc.client.FindEmailThread(ctx, &ticketpb.FindEmailThreadRequest{MessageIds: messageIDs})
+	if err != nil {
+		return "", fmt.Errorf("failed to find email thread: %w", err)
+	}
+
+	return resp.TicketId, nil
+}
+
+// RecordEmailMessage adds an email to the thread of a ticket via gRPC and
+// reports false when it was already recorded
+func (tc *TicketClient) RecordEmailMessage(ctx context.Context, messageID, ticketID string) (bool, error) {
+	resp, err := tc.client.RecordEmailMessage(ctx, &ticketpb.RecordEmailMessageRequest{
+		MessageId: messageID,
+		TicketId:  ticketID,
+	})
+	if err != nil {
+		log.Printf("Error recording email message via gRPC: %v", err)
+		return false, fmt.Errorf("failed to record email message: %w", err)
+	}
+
+	return resp.Recorded, nil
+}
@@ -0,0 +1,53 @@
+package clients
+
+import (
+	"context"
+	"io"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TicketService is the set of ticket operations used by the GraphQL resolvers.
+// TicketClient implements it over gRPC; CachedTicketClient wraps another
+// implementation with a read-through cache.
+type TicketService interface {
+	CreateTicket(ctx context.Context, title, description string, priority ticketpb.TicketPriority, assigneeID, reporterID string, tags []string, projectKey string, customFields map[string]string, dueDate *timestamppb.Timestamp) (*ticketpb.Ticket, error)
+	GetTicket(ctx context.Context, id string) (*ticketpb.Ticket, error)
+	GetTicketByKey(ctx context.Context, key string) (*ticketpb.Ticket, error)
+	ListTickets(ctx context.Context, pageSize int32, pageToken, projectKey string, customFields map[string]string) ([]*ticketpb.Ticket, string, error)
+	UpdateTicket(ctx context.Context, id, title, description string, status ticketpb.TicketStatus, priority ticketpb.TicketPriority, assigneeID string, tags []string, customFields map[string]string, dueDate *timestamppb.Timestamp, clearDueDate bool) (*ticketpb.Ticket, error)
+	DeleteTicket(ctx context.Context, id string) (bool, error)
+	BulkUpdateTickets(ctx context.Context, ids []string, patch *ticketpb.TicketPatch, atomic bool) ([]*ticketpb.BulkTicketResult, error)
+	BulkDeleteTickets(ctx context.Context, ids []string, atomic bool) ([]*ticketpb.BulkTicketResult, error)
+	CreateProject(ctx context.Context, key, name, description string) (*ticketpb.Project, error)
+	GetProject(ctx context.Context, id, key string) (*ticketpb.Project, error)
+	ListProjects(ctx context.Context) ([]*ticketpb.Project, error)
+	SetCustomFields(ctx context.Context, projectKey string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error)
+	ListTags(ctx context.Context, prefix string, limit int32) ([]*ticketpb.TagCount, error)
+	ListSLAPolicies(ctx context.Context) ([]*ticketpb.SLAPolicy, error)
+	MergeTags(ctx context.Context, sources []string, target string) (int32, error)
+	LinkTickets(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) (*ticketpb.TicketLink, error)
+	UnlinkTickets(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) (bool, error)
+	ListLinks(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error)
+	UploadAttachment(ctx context.Context, ticketID, filename, contentType string, content io.Reader) (*ticketpb.Attachment, error)
+	ListAttachments(ctx context.Context, ticketID string) ([]*ticketpb.Attachment, error)
+	DownloadAttachment(ctx context.Context, id string) (*ticketpb.Attachment, io.ReadCloser, error)
+	CreateWebhook(ctx context.Context, url, secret string, eventTypes []string, filter *ticketpb.WebhookFilter) (*ticketpb.Webhook, error)
+	UpdateWebhook(ctx context.Context, id, url, secret string, eventTypes []string, filter *ticketpb.WebhookFilter, active *bool) (*ticketpb.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) (bool, error)
+	ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int32) ([]*ticketpb.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, deliveryID string) (*ticketpb.WebhookDelivery, error)
+	WatchTicket(ctx context.Context, ticketID, userID string) ([]string, error)
+	UnwatchTicket(ctx context.Context, ticketID, userID string) (bool, error)
+	ListWatchers(ctx context.Context, ticketID string) ([]string, error)
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int32) ([]*ticketpb.Notification, error)
+	MarkNotificationRead(ctx context.Context, id string) (*ticketpb.Notification, error)
+	GetNotificationPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, userID string, email *string, delivery ticketpb.NotificationDelivery, digestHour *int32, mutedKinds []ticketpb.NotificationKind, setMutedKinds bool) (*ticketpb.NotificationPreferences, error)
+	AddComment(ctx context.Context, ticketID, authorID, body string) (*ticketpb.Comment, error)
+	ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error)
+}
+
+var _ TicketService = (*TicketClient)(nil)
@@ -0,0 +1,31 @@
+// Package comments keeps the remarks made on tickets and the email thread
+// each ticket belongs to, so replies to a ticket's emails land on it
+package comments
+
+import (
+	"context"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+// Store persists comments and email threads. Every method acts for the
+// organization of ctx (see tenant.ID) and returns store.ErrNotFound for
+// unknown IDs.
+type Store interface {
+	// AddComment stores a new comment, setting OrganizationId from ctx
+	AddComment(ctx context.Context, comment *ticketpb.Comment) error
+	// ListComments returns the comments of a ticket, oldest first
+	ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error)
+
+	// RecordMessage adds an email to the thread of a ticket and reports
+	// false when the message was already recorded
+	RecordMessage(ctx context.Context, messageID, ticketID string, at time.Time) (bool, error)
+	// FindThread returns the ticket of the first of messageIDs that was
+	// recorded
+	FindThread(ctx context.Context, messageIDs []string) (string, error)
+
+	// DeleteTicket removes the comments and the email thread of a deleted
+	// ticket
+	DeleteTicket(ctx context.Context, ticketID string) error
+}
@@ -0,0 +1,107 @@
+package comments
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+)
+
+// messageKey identifies an email of an organization
+type messageKey struct {
+	organizationID string
+	messageID      string
+}
+
+// MemoryStore keeps comments and email threads in process. Like
+// store.MemoryStore it copies comments on the way in and out.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	comments []*ticketpb.Comment // in the order they were added
+	messages map[messageKey]string
+}
+
+var _ Store = (*MemoryStore)(nil)
+var _ Store = (*store.SQLiteComments)(nil)
+var _ Store = (*store.PostgresComments)(nil)
+
+// NewMemoryStore creates an empty in-memory comment store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{messages: make(map[messageKey]string)}
+}
+
+// AddComment stores a new comment
+func (m *MemoryStore) AddComment(ctx context.Context, comment *ticketpb.Comment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment.OrganizationId = tenant.ID(ctx)
+	m.comments = append(m.comments, proto.Clone(comment).(*ticketpb.Comment))
+	return nil
+}
+
+// ListComments returns the comments of a ticket
+func (m *MemoryStore) ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var comments []*ticketpb.Comment
+	for _, comment := range m.comments {
+		if comment.OrganizationId == tenant.ID(ctx) && comment.TicketId == ticketID {
+			comments = append(comments, proto.Clone(comment).(*ticketpb.Comment))
+		}
+	}
+	return comments, nil
+}
+
+// RecordMessage adds an email to the thread of a ticket
+func (m *MemoryStore) RecordMessage(ctx context.Context, messageID, ticketID string, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := messageKey{organizationID: tenant.ID(ctx), messageID: messageID}
+	if _, ok := m.messages[key]; ok {
+		return false, nil
+	}
+	m.messages[key] = ticketID
+	return true, nil
+}
+
+// FindThread returns the ticket of the first recorded message
+func (m *MemoryStore) FindThread(ctx context.Context, messageIDs []string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, messageID := range messageIDs {
+		if ticketID, ok := m.messages[messageKey{organizationID: tenant.ID(ctx), messageID: messageID}]; ok {
+			return ticketID, nil
+		}
+	}
+	return "", store.ErrNotFound
+}
+
+// DeleteTicket removes the comments and the email thread of a ticket
+func (m *MemoryStore) DeleteTicket(ctx context.Context, ticketID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.comments[:0]
+	for _, comment := range m.comments {
+		if comment.OrganizationId != tenant.ID(ctx) || comment.TicketId != ticketID {
+			kept = append(kept, comment)
+		}
+	}
+	clear(m.comments[len(kept):])
+	m.comments = kept
+
+	for key, id := range m.messages {
+		if key.organizationID == tenant.ID(ctx) && id == ticketID {
+			delete(m.messages, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,521 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the single configuration shared by every binary in the repo.
+//
+// Values are layered in this order, later sources winning:
+// built-in defaults, config file (YAML or TOML), .env file, environment, flags.
+// Each field declares its environment variables (`env`, first name wins when
+// several are set), its command-line flag (`flag`) and whether it must be
+// redacted when printed (`secret`). Secrets may also be read from the file
+// named by their variable with a _FILE suffix, e.g. DB_PASSWORD_FILE. The settings are plain values; each binary
+// converts them into the options of the packages it runs.
+type Config struct {
+	Database         DatabaseConfig         `yaml:"database" toml:"database"`
+	Gateway          GatewayConfig          `yaml:"gateway" toml:"gateway"`
+	TicketService    TicketServiceConfig    `yaml:"ticket_service" toml:"ticket_service"`
+	RateLimit        RateLimitConfig        `yaml:"rate_limit" toml:"rate_limit"`
+	QueryLimits      QueryLimitsConfig      `yaml:"query_limits" toml:"query_limits"`
+	PersistedQueries PersistedQueriesConfig `yaml:"persisted_queries" toml:"persisted_queries"`
+	TicketCache      TicketCacheConfig      `yaml:"ticket_cache" toml:"ticket_cache"`
+	Attachments      AttachmentsConfig      `yaml:"attachments" toml:"attachments"`
+	Tenancy          TenancyConfig          `yaml:"tenancy" toml:"tenancy"`
+	SLA              SLAConfig              `yaml:"sla" toml:"sla"`
+	Webhooks         WebhooksConfig         `yaml:"webhooks" toml:"webhooks"`
+	Outbox           OutboxConfig           `yaml:"outbox" toml:"outbox"`
+	Idempotency      IdempotencyConfig      `yaml:"idempotency" toml:"idempotency"`
+	Notifications    NotificationsConfig    `yaml:"notifications" toml:"notifications"`
+	Inbound          InboundConfig          `yaml:"inbound" toml:"inbound"`
+}
+
+// DatabaseConfig holds PostgreSQL connection settings
+type DatabaseConfig struct {
+	Host     string `yaml:"host" toml:"host" env:"DB_HOST,PG_HOST" flag:"db-host" usage:"PostgreSQL host"`
+	Port     string `yaml:"port" toml:"port" env:"DB_PORT,PG_PORT" flag:"db-port" usage:"PostgreSQL port"`
+	User     string `yaml:"user" toml:"user" env:"DB_USER,PG_USER" flag:"db-user" usage:"PostgreSQL user"`
+	Password string `yaml:"password" toml:"password" env:"DB_PASSWORD,PG_PASSWORD" flag:"db-password" usage:"PostgreSQL password" secret:"true"`
+	Name     string `yaml:"name" toml:"name" env:"DB_NAME,PG_DB" flag:"db-name" usage:"PostgreSQL database name"`
+	SSLMode  string `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE,PG_SSLMODE" flag:"db-sslmode" usage:"PostgreSQL sslmode"`
+}
+
+// GatewayConfig holds settings for the GraphQL HTTP servers
+type GatewayConfig struct {
+	Addr string `yaml:"addr" toml:"addr" env:"HTTP_ADDR" flag:"http-addr" usage:"GraphQL HTTP listen address"`
+	// AdminAddr serves operational endpoints such as cache metrics apart from
+	// the public API; keep it on a private interface
+	AdminAddr string `yaml:"admin_addr" toml:"admin_addr" env:"ADMIN_ADDR" flag:"admin-addr" usage:"listen address for the gateway's metrics endpoint; empty disables it"`
+}
+
+// TicketServiceConfig holds settings for the ticket gRPC service and its clients
+type TicketServiceConfig struct {
+	Port        string            `yaml:"port" toml:"port" env:"GRPC_PORT" flag:"grpc-port" usage:"ticket service gRPC listen port"`
+	URL         string            `yaml:"url" toml:"url" env:"TICKET_SERVICE_URL" flag:"ticket-service-url" usage:"ticket service address used by clients"`
+	Store       string            `yaml:"store" toml:"store" env:"TICKET_STORE" flag:"store" usage:"ticket storage backend: memory, postgres or sqlite"`
+	SQLitePath  string            `yaml:"sqlite_path" toml:"sqlite_path" env:"SQLITE_PATH" flag:"sqlite-path" usage:"database file used by the sqlite store"`
+	Persistence PersistenceConfig `yaml:"persistence" toml:"persistence"`
+	Reflection  bool              `yaml:"reflection" toml:"reflection" env:"GRPC_REFLECTION" flag:"grpc-reflection" usage:"serve gRPC server reflection so tools like grpcurl can discover the API"`
+	Web         GRPCWebConfig     `yaml:"web" toml:"web"`
+}
+
+// GRPCWebConfig controls the HTTP/1.1 listener serving the ticket service to
+// browsers over gRPC-Web and the Connect protocol
+type GRPCWebConfig struct {
+	Addr           string   `yaml:"addr" toml:"addr" env:"GRPC_WEB_ADDR" flag:"grpc-web-addr" usage:"HTTP/1.1 listen address for gRPC-Web and Connect clients, e.g. :8081 (empty disables)"`
+	AllowedOrigins []string `yaml:"allowed_origins" toml:"allowed_origins" env:"GRPC_WEB_ALLOWED_ORIGINS" flag:"grpc-web-allowed-origins" usage:"comma-separated browser origins allowed to call the gRPC-Web listener (* allows any)"`
+}
+
+// PersistenceConfig controls the write-ahead log and snapshots of the memory store
+type PersistenceConfig struct {
+	Dir               string        `yaml:"dir" toml:"dir" env:"TICKET_DATA_DIR" flag:"data-dir" usage:"directory for the memory store's write-ahead log and snapshots (empty keeps tickets in memory only)"`
+	Sync              string        `yaml:"sync" toml:"sync" env:"WAL_SYNC" flag:"wal-sync" usage:"when to fsync the write-ahead log: always, interval or never"`
+	SyncInterval      time.Duration `yaml:"sync_interval" toml:"sync_interval" env:"WAL_SYNC_INTERVAL" flag:"wal-sync-interval" usage:"fsync interval when wal-sync is interval"`
+	SnapshotInterval  time.Duration `yaml:"snapshot_interval" toml:"snapshot_interval" env:"SNAPSHOT_INTERVAL" flag:"snapshot-interval" usage:"how often the write-ahead log is compacted into a snapshot (0 disables)"`
+	SnapshotThreshold int           `yaml:"snapshot_threshold" toml:"snapshot_threshold" env:"SNAPSHOT_THRESHOLD" flag:"snapshot-threshold" usage:"write-ahead log records that trigger a snapshot (0 disables)"`
+}
+
+// RateLimitConfig holds the token-bucket budgets applied per client
+type RateLimitConfig struct {
+	Enabled       bool    `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED" flag:"rate-limit" usage:"enable rate limiting in the gateway"`
+	QueryRate     float64 `yaml:"query_rate" toml:"query_rate" env:"RATE_LIMIT_QUERY_RATE" flag:"rate-limit-query-rate" usage:"queries per second allowed per client"`
+	QueryBurst    int     `yaml:"query_burst" toml:"query_burst" env:"RATE_LIMIT_QUERY_BURST" flag:"rate-limit-query-burst" usage:"query burst size per client"`
+	MutationRate  float64 `yaml:"mutation_rate" toml:"mutation_rate" env:"RATE_LIMIT_MUTATION_RATE" flag:"rate-limit-mutation-rate" usage:"mutations per second allowed per client"`
+	MutationBurst int     `yaml:"mutation_burst" toml:"mutation_burst" env:"RATE_LIMIT_MUTATION_BURST" flag:"rate-limit-mutation-burst" usage:"mutation burst size per client"`
+	TrustProxy    bool    `yaml:"trust_proxy" toml:"trust_proxy" env:"RATE_LIMIT_TRUST_PROXY" flag:"rate-limit-trust-proxy" usage:"use X-Forwarded-For, as appended by tenancy.trusted_proxies, to identify clients"`
+	GRPC          bool    `yaml:"grpc" toml:"grpc" env:"RATE_LIMIT_GRPC" flag:"rate-limit-grpc" usage:"enable the rate limit interceptor on the ticket services"`
+}
+
+// QueryLimitsConfig bounds the depth and complexity of GraphQL operations
+type QueryLimitsConfig struct {
+	MaxDepth      int `yaml:"max_depth" toml:"max_depth" env:"GRAPHQL_MAX_DEPTH" flag:"graphql-max-depth" usage:"maximum GraphQL operation depth (0 disables)"`
+	MaxComplexity int `yaml:"max_complexity" toml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" flag:"graphql-max-complexity" usage:"maximum GraphQL operation complexity (0 disables)"`
+}
+
+// PersistedQueriesConfig selects how the gateway handles persisted queries
+type PersistedQueriesConfig struct {
+	Mode      string        `yaml:"mode" toml:"mode" env:"PERSISTED_QUERIES_MODE" flag:"persisted-queries" usage:"persisted query mode: apq, allowlist or off"`
+	Store     string        `yaml:"store" toml:"store" env:"PERSISTED_QUERIES_STORE" flag:"persisted-queries-store" usage:"APQ store: memory or postgres"`
+	CacheSize int           `yaml:"cache_size" toml:"cache_size" env:"PERSISTED_QUERIES_CACHE_SIZE" flag:"persisted-queries-cache-size" usage:"maximum number of APQ queries kept; 0 does not limit the postgres store"`
+	TTL       time.Duration `yaml:"ttl" toml:"ttl" env:"PERSISTED_QUERIES_TTL" flag:"persisted-queries-ttl" usage:"how long the postgres APQ store keeps unused queries; 0 keeps them"`
+	Manifest  string        `yaml:"manifest" toml:"manifest" env:"PERSISTED_QUERIES_MANIFEST" flag:"persisted-queries-manifest" usage:"operation manifest used in allowlist mode"`
+}
+
+// TicketCacheConfig controls the gateway's read-through cache for ticket reads
+type TicketCacheConfig struct {
+	Enabled bool          `yaml:"enabled" toml:"enabled" env:"TICKET_CACHE_ENABLED" flag:"ticket-cache" usage:"cache ticket reads in the gateway"`
+	TTL     time.Duration `yaml:"ttl" toml:"ttl" env:"TICKET_CACHE_TTL" flag:"ticket-cache-ttl" usage:"how long cached tickets are kept"`
+	Size    int           `yaml:"size" toml:"size" env:"TICKET_CACHE_SIZE" flag:"ticket-cache-size" usage:"maximum number of cached entries"`
+}
+
+// AttachmentsConfig controls where ticket attachments are stored and how they are served
+type AttachmentsConfig struct {
+	Store        string              `yaml:"store" toml:"store" env:"ATTACHMENTS_STORE" flag:"attachments-store" usage:"attachment blob store: local or s3"`
+	Dir          string              `yaml:"dir" toml:"dir" env:"ATTACHMENTS_DIR" flag:"attachments-dir" usage:"directory used by the local attachment store"`
+	MaxSize      int64               `yaml:"max_size" toml:"max_size" env:"ATTACHMENTS_MAX_SIZE" flag:"attachments-max-size" usage:"maximum attachment size in bytes"`
+	AllowedTypes []string            `yaml:"allowed_types" toml:"allowed_types" env:"ATTACHMENTS_ALLOWED_TYPES" flag:"attachments-allowed-types" usage:"comma-separated MIME types allowed for attachments (image/* style wildcards)"`
+	S3           AttachmentsS3Config `yaml:"s3" toml:"s3"`
+	SigningKey   string              `yaml:"signing_key" toml:"signing_key" env:"ATTACHMENTS_SIGNING_KEY" flag:"attachments-signing-key" usage:"HMAC key for signed download URLs (random when empty)" secret:"true"`
+	URLTTL       time.Duration       `yaml:"url_ttl" toml:"url_ttl" env:"ATTACHMENTS_URL_TTL" flag:"attachments-url-ttl" usage:"how long signed download URLs stay valid"`
+	PublicURL    string              `yaml:"public_url" toml:"public_url" env:"ATTACHMENTS_PUBLIC_URL" flag:"attachments-public-url" usage:"base URL of the gateway used in download URLs"`
+}
+
+// AttachmentsS3Config holds the settings of an S3-compatible attachment store
+type AttachmentsS3Config struct {
+	Endpoint  string `yaml:"endpoint" toml:"endpoint" env:"S3_ENDPOINT" flag:"s3-endpoint" usage:"S3 endpoint (host:port)"`
+	Bucket    string `yaml:"bucket" toml:"bucket" env:"S3_BUCKET" flag:"s3-bucket" usage:"S3 bucket for attachments"`
+	Region    string `yaml:"region" toml:"region" env:"S3_REGION" flag:"s3-region" usage:"S3 region"`
+	AccessKey string `yaml:"access_key" toml:"access_key" env:"S3_ACCESS_KEY" flag:"s3-access-key" usage:"S3 access key"`
+	SecretKey string `yaml:"secret_key" toml:"secret_key" env:"S3_SECRET_KEY" flag:"s3-secret-key" usage:"S3 secret key" secret:"true"`
+	UseSSL    bool   `yaml:"use_ssl" toml:"use_ssl" env:"S3_USE_SSL" flag:"s3-use-ssl" usage:"use HTTPS for S3"`
+}
+
+// TenancyConfig controls how the organization of each request is determined
+type TenancyConfig struct {
+	Header   string `yaml:"header" toml:"header" env:"TENANT_HEADER" flag:"tenant-header" usage:"HTTP header naming the caller's organization"`
+	Required bool   `yaml:"required" toml:"required" env:"TENANT_REQUIRED" flag:"tenant-required" usage:"reject requests that name no organization instead of serving the default organization"`
+	// TrustedProxies are the peers allowed to name an organization and the
+	// rate-limited client: the proxy in front of the gateway and the gateways
+	// in front of the ticket service. An empty list trusts none.
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies" env:"TENANT_TRUSTED_PROXIES" flag:"tenant-trusted-proxies" usage:"comma-separated IPs or CIDRs allowed to name the organization and the rate-limited client (empty trusts none)"`
+	// TrustAllPeers trusts every peer as if it were listed, for deployments
+	// where only the network keeps other clients away
+	TrustAllPeers bool `yaml:"trust_all_peers" toml:"trust_all_peers" env:"TENANT_TRUST_ALL_PEERS" flag:"tenant-trust-all-peers" usage:"let every client name the organization and the rate-limited client, in place of trusted_proxies"`
+}
+
+// SLAConfig holds the SLA policies and the evaluator that watches their deadlines
+type SLAConfig struct {
+	Policies           []string      `yaml:"policies" toml:"policies" env:"SLA_POLICIES" flag:"sla-policies" usage:"comma-separated PRIORITY=response/resolution targets, e.g. CRITICAL=1h/4h (0 disables a target)"`
+	AtRisk             float64       `yaml:"at_risk" toml:"at_risk" env:"SLA_AT_RISK" flag:"sla-at-risk" usage:"share of a deadline's window left when a ticket becomes at risk"`
+	EvaluationInterval time.Duration `yaml:"evaluation_interval" toml:"evaluation_interval" env:"SLA_EVALUATION_INTERVAL" flag:"sla-evaluation-interval" usage:"how often the ticket service looks for at-risk and breached tickets (0 disables)"`
+}
+
+// WebhooksConfig controls how the ticket service delivers webhooks
+type WebhooksConfig struct {
+	DeliveryInterval     time.Duration `yaml:"delivery_interval" toml:"delivery_interval" env:"WEBHOOK_DELIVERY_INTERVAL" flag:"webhook-delivery-interval" usage:"how often the ticket service sends due webhook deliveries (0 disables)"`
+	MaxAttempts          int           `yaml:"max_attempts" toml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" flag:"webhook-max-attempts" usage:"attempts before a webhook delivery moves to the dead-letter list"`
+	Backoff              time.Duration `yaml:"backoff" toml:"backoff" env:"WEBHOOK_BACKOFF" flag:"webhook-backoff" usage:"wait after the first failed webhook attempt, doubled after every further one"`
+	MaxBackoff           time.Duration `yaml:"max_backoff" toml:"max_backoff" env:"WEBHOOK_MAX_BACKOFF" flag:"webhook-max-backoff" usage:"longest wait between two webhook attempts"`
+	Timeout              time.Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOK_TIMEOUT" flag:"webhook-timeout" usage:"timeout of one webhook attempt"`
+	AllowPrivateNetworks bool          `yaml:"allow_private_networks" toml:"allow_private_networks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" flag:"webhook-allow-private-networks" usage:"deliver webhooks to loopback, private and link-local addresses too"`
+}
+
+// OutboxConfig controls how the ticket service relays recorded ticket events
+type OutboxConfig struct {
+	RelayInterval     time.Duration `yaml:"relay_interval" toml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL" flag:"outbox-relay-interval" usage:"how often the ticket service relays events from its outbox (0 disables)"`
+	NATSURL           string        `yaml:"nats_url" toml:"nats_url" env:"NATS_URL" flag:"nats-url" usage:"NATS server to publish ticket events to (empty disables)"`
+	NATSSubjectPrefix string        `yaml:"nats_subject_prefix" toml:"nats_subject_prefix" env:"NATS_SUBJECT_PREFIX" flag:"nats-subject-prefix" usage:"prefix of the NATS subjects of ticket events"`
+}
+
+// IdempotencyConfig controls how long the ticket service remembers the
+// responses of calls made with an idempotency key
+type IdempotencyConfig struct {
+	Retention time.Duration `yaml:"retention" toml:"retention" env:"IDEMPOTENCY_RETENTION" flag:"idempotency-retention" usage:"how long a retry with the same idempotency key returns the first response (0 disables idempotency keys)"`
+}
+
+// NotificationsConfig controls how the ticket service sends notifications
+type NotificationsConfig struct {
+	DeliveryInterval time.Duration `yaml:"delivery_interval" toml:"delivery_interval" env:"NOTIFICATION_DELIVERY_INTERVAL" flag:"notification-delivery-interval" usage:"how often the ticket service sends due notifications (0 disables)"`
+	SMTPHost         string        `yaml:"smtp_host" toml:"smtp_host" env:"SMTP_HOST" flag:"smtp-host" usage:"SMTP server to email notifications through (empty disables email)"`
+	SMTPPort         string        `yaml:"smtp_port" toml:"smtp_port" env:"SMTP_PORT" flag:"smtp-port" usage:"SMTP server port"`
+	SMTPUsername     string        `yaml:"smtp_username" toml:"smtp_username" env:"SMTP_USERNAME" flag:"smtp-username" usage:"SMTP user (empty skips authentication)"`
+	SMTPPassword     string        `yaml:"smtp_password" toml:"smtp_password" env:"SMTP_PASSWORD" flag:"smtp-password" usage:"SMTP password" secret:"true"`
+	SMTPFrom         string        `yaml:"smtp_from" toml:"smtp_from" env:"SMTP_FROM" flag:"smtp-from" usage:"sender address of notification emails"`
+	SMTPTimeout      time.Duration `yaml:"smtp_timeout" toml:"smtp_timeout" env:"SMTP_TIMEOUT" flag:"smtp-timeout" usage:"timeout of sending one notification email"`
+}
+
+// InboundConfig controls the mail gateway, which files email as tickets
+type InboundConfig struct {
+	SMTPAddr       string        `yaml:"smtp_addr" toml:"smtp_addr" env:"INBOUND_SMTP_ADDR" flag:"inbound-smtp-addr" usage:"address the mail gateway accepts SMTP on"`
+	Domain         string        `yaml:"domain" toml:"domain" env:"INBOUND_DOMAIN" flag:"inbound-domain" usage:"host name the mail gateway greets SMTP clients with"`
+	Recipients     []string      `yaml:"recipients" toml:"recipients" env:"INBOUND_RECIPIENTS" flag:"inbound-recipients" usage:"comma-separated addresses (or @domain) mail is accepted for (empty accepts any)"`
+	ProjectKey     string        `yaml:"project" toml:"project" env:"INBOUND_PROJECT" flag:"inbound-project" usage:"project tickets created from email go to (empty creates them outside projects)"`
+	Organization   string        `yaml:"organization" toml:"organization" env:"INBOUND_ORGANIZATION" flag:"inbound-organization" usage:"organization email is filed for (empty files it for the default organization)"`
+	Tags           []string      `yaml:"tags" toml:"tags" env:"INBOUND_TAGS" flag:"inbound-tags" usage:"comma-separated tags of tickets created from email"`
+	MaxMessageSize int64         `yaml:"max_message_size" toml:"max_message_size" env:"INBOUND_MAX_MESSAGE_SIZE" flag:"inbound-max-message-size" usage:"size in bytes of the largest email accepted"`
+	Timeout        time.Duration `yaml:"timeout" toml:"timeout" env:"INBOUND_TIMEOUT" flag:"inbound-timeout" usage:"timeout of each SMTP command and of filing each email"`
+	// Token is shared by the mail gateway and the ticket service, which
+	// refuses email thread calls without it
+	Token string `yaml:"token" toml:"token" env:"INBOUND_TOKEN" flag:"inbound-token" usage:"secret the mail gateway presents to use the ticket service's email threads (empty disables them)" secret:"true"`
+}
+
+// Default returns the configuration used when nothing else is provided
+func Default() *Config {
+	return &Config{
+		Database: DatabaseConfig{
+			Host:    "localhost",
+			Port:    "5432",
+			Name:    "ticketdb",
+			SSLMode: "disable",
+		},
+		Gateway: GatewayConfig{
+			Addr: ":8080",
+		},
+		TicketService: TicketServiceConfig{
+			Port:       "50051",
+			URL:        "localhost:50051",
+			Store:      "memory",
+			SQLitePath: "data/tickets.db",
+			Persistence: PersistenceConfig{
+				Sync:              "always",
+				SyncInterval:      time.Second,
+				SnapshotInterval:  5 * time.Minute,
+				SnapshotThreshold: 10000,
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:       true,
+			QueryRate:     10,
+			QueryBurst:    50,
+			MutationRate:  1,
+			MutationBurst: 10,
+		},
+		QueryLimits: QueryLimitsConfig{
+			MaxDepth:      10,
+			MaxComplexity: 1000,
+		},
+		PersistedQueries: PersistedQueriesConfig{
+			Mode:      "apq",
+			Store:     "memory",
+			CacheSize: 1000,
+			TTL:       30 * 24 * time.Hour,
+			Manifest:  "persisted-queries.json",
+		},
+		TicketCache: TicketCacheConfig{
+			TTL:  30 * time.Second,
+			Size: 1000,
+		},
+		Attachments: AttachmentsConfig{
+			Store:        "local",
+			Dir:          "data/attachments",
+			MaxSize:      10 << 20,
+			AllowedTypes: []string{"image/*", "text/*", "application/pdf", "application/json", "application/zip"},
+			S3: AttachmentsS3Config{
+				Bucket: "attachments",
+				Region: "us-east-1",
+			},
+			URLTTL:    15 * time.Minute,
+			PublicURL: "http://localhost:8080",
+		},
+		Tenancy: TenancyConfig{
+			Header: "X-Organization-ID",
+		},
+		SLA: SLAConfig{
+			Policies:           []string{"CRITICAL=1h/4h", "HIGH=4h/24h", "MEDIUM=8h/72h", "LOW=24h/168h"},
+			AtRisk:             0.25,
+			EvaluationInterval: time.Minute,
+		},
+		Webhooks: WebhooksConfig{
+			DeliveryInterval: 2 * time.Second,
+			MaxAttempts:      8,
+			Backoff:          30 * time.Second,
+			MaxBackoff:       time.Hour,
+			Timeout:          10 * time.Second,
+		},
+		Outbox: OutboxConfig{
+			RelayInterval:     500 * time.Millisecond,
+			NATSSubjectPrefix: "tickets",
+		},
+		Idempotency: IdempotencyConfig{
+			Retention: 24 * time.Hour,
+		},
+		Notifications: NotificationsConfig{
+			DeliveryInterval: 30 * time.Second,
+			SMTPPort:         "587",
+			SMTPFrom:         "tickets@localhost",
+			SMTPTimeout:      30 * time.Second,
+		},
+		Inbound: InboundConfig{
+			SMTPAddr:       ":2525",
+			Domain:         "localhost",
+			Tags:           []string{"email"},
+			MaxMessageSize: 25 << 20,
+			Timeout:        5 * time.Minute,
+		},
+	}
+}
+
+// Validate checks the configuration and reports every problem found. Values
+// that other packages parse, such as the trusted proxies and the SLA policies,
+// are checked by the binaries that hand them over.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("database.host must not be empty"))
+	}
+	if err := validatePort("database.port", c.Database.Port); err != nil {
+		errs = append(errs, err)
+	}
+	// There is no default user: require one wherever PostgreSQL is used
+	if c.Database.User == "" && (c.TicketService.Store == "postgres" || (c.PersistedQueries.Mode == "apq" && c.PersistedQueries.Store == "postgres")) {
+		errs = append(errs, errors.New("database.user must be set when a store is postgres"))
+	}
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("database.name must not be empty"))
+	}
+	switch c.Database.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("database.sslmode %q is not a valid PostgreSQL sslmode", c.Database.SSLMode))
+	}
+
+	if _, port, err := net.SplitHostPort(c.Gateway.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("gateway.addr %q: %w", c.Gateway.Addr, err))
+	} else if err := validatePort("gateway.addr", port); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Gateway.AdminAddr != "" {
+		if _, port, err := net.SplitHostPort(c.Gateway.AdminAddr); err != nil {
+			errs = append(errs, fmt.Errorf("gateway.admin_addr %q: %w", c.Gateway.AdminAddr, err))
+		} else if err := validatePort("gateway.admin_addr", port); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := validatePort("ticket_service.port", c.TicketService.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if c.TicketService.URL == "" {
+		errs = append(errs, errors.New("ticket_service.url must not be empty"))
+	}
+	switch c.TicketService.Store {
+	case "memory", "postgres":
+	case "sqlite":
+		if c.TicketService.SQLitePath == "" {
+			errs = append(errs, errors.New("ticket_service.sqlite_path must not be empty when store is sqlite"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("ticket_service.store %q must be memory, postgres or sqlite", c.TicketService.Store))
+	}
+	switch persistence := c.TicketService.Persistence; persistence.Sync {
+	case "always", "never":
+	case "interval":
+		if persistence.SyncInterval <= 0 {
+			errs = append(errs, errors.New("ticket_service.persistence.sync_interval must be positive"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("ticket_service.persistence.sync %q must be always, interval or never", persistence.Sync))
+	}
+	if c.TicketService.Persistence.SnapshotInterval < 0 || c.TicketService.Persistence.SnapshotThreshold < 0 {
+		errs = append(errs, errors.New("ticket_service.persistence snapshot_interval and snapshot_threshold must not be negative"))
+	}
+	if addr := c.TicketService.Web.Addr; addr != "" {
+		if _, port, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf("ticket_service.web.addr %q: %w", addr, err))
+		} else if err := validatePort("ticket_service.web.addr", port); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if c.RateLimit.Enabled || c.RateLimit.GRPC {
+		if c.RateLimit.QueryRate <= 0 || c.RateLimit.MutationRate <= 0 {
+			errs = append(errs, errors.New("rate_limit rates must be greater than zero"))
+		}
+		if c.RateLimit.QueryBurst < 1 || c.RateLimit.MutationBurst < 1 {
+			errs = append(errs, errors.New("rate_limit bursts must be at least 1"))
+		}
+	}
+
+	if c.QueryLimits.MaxDepth < 0 || c.QueryLimits.MaxComplexity < 0 {
+		errs = append(errs, errors.New("query_limits must not be negative"))
+	}
+
+	switch c.PersistedQueries.Mode {
+	case "apq":
+		if c.PersistedQueries.Store != "memory" && c.PersistedQueries.Store != "postgres" {
+			errs = append(errs, fmt.Errorf("persisted_queries.store %q must be memory or postgres", c.PersistedQueries.Store))
+		}
+		if c.PersistedQueries.Store == "memory" && c.PersistedQueries.CacheSize < 1 {
+			errs = append(errs, errors.New("persisted_queries.cache_size must be at least 1"))
+		}
+		if c.PersistedQueries.CacheSize < 0 || c.PersistedQueries.TTL < 0 {
+			errs = append(errs, errors.New("persisted_queries cache_size and ttl must not be negative"))
+		}
+	case "allowlist":
+		if c.PersistedQueries.Manifest == "" {
+			errs = append(errs, errors.New("persisted_queries.manifest is required in allowlist mode"))
+		}
+	case "off":
+	default:
+		errs = append(errs, fmt.Errorf("persisted_queries.mode %q must be apq, allowlist or off", c.PersistedQueries.Mode))
+	}
+
+	if c.TicketCache.Enabled && (c.TicketCache.TTL <= 0 || c.TicketCache.Size < 1) {
+		errs = append(errs, errors.New("ticket_cache ttl and size must be positive"))
+	}
+
+	switch c.Attachments.Store {
+	case "local":
+		if c.Attachments.Dir == "" {
+			errs = append(errs, errors.New("attachments.dir is required for the local store"))
+		}
+	case "s3":
+		if c.Attachments.S3.Endpoint == "" || c.Attachments.S3.Bucket == "" {
+			errs = append(errs, errors.New("attachments.s3.endpoint and attachments.s3.bucket are required for the s3 store"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("attachments.store %q must be local or s3", c.Attachments.Store))
+	}
+	if c.Attachments.MaxSize < 1 {
+		errs = append(errs, errors.New("attachments.max_size must be positive"))
+	}
+	if c.Attachments.URLTTL <= 0 {
+		errs = append(errs, errors.New("attachments.url_ttl must be positive"))
+	}
+
+	if strings.TrimSpace(c.Tenancy.Header) == "" {
+		errs = append(errs, errors.New("tenancy.header must not be empty"))
+	}
+	if c.Tenancy.Required && len(c.Tenancy.TrustedProxies) == 0 && !c.Tenancy.TrustAllPeers {
+		errs = append(errs, errors.New("tenancy.required needs tenancy.trusted_proxies, or tenancy.trust_all_peers to let every client name the organization"))
+	}
+
+	if c.SLA.AtRisk < 0 || c.SLA.AtRisk >= 1 {
+		errs = append(errs, errors.New("sla.at_risk must be at least 0 and less than 1"))
+	}
+	if c.SLA.EvaluationInterval < 0 {
+		errs = append(errs, errors.New("sla.evaluation_interval must not be negative"))
+	}
+
+	if c.Webhooks.DeliveryInterval < 0 {
+		errs = append(errs, errors.New("webhooks.delivery_interval must not be negative"))
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		errs = append(errs, errors.New("webhooks.max_attempts must be positive"))
+	}
+	if c.Webhooks.Backoff <= 0 {
+		errs = append(errs, errors.New("webhooks.backoff must be positive"))
+	}
+	if c.Webhooks.MaxBackoff < c.Webhooks.Backoff {
+		errs = append(errs, errors.New("webhooks.max_backoff must not be less than webhooks.backoff"))
+	}
+	if c.Webhooks.Timeout <= 0 {
+		errs = append(errs, errors.New("webhooks.timeout must be positive"))
+	}
+
+	if c.Outbox.RelayInterval < 0 {
+		errs = append(errs, errors.New("outbox.relay_interval must not be negative"))
+	}
+	if c.Outbox.NATSURL != "" && strings.TrimSpace(c.Outbox.NATSSubjectPrefix) == "" {
+		errs = append(errs, errors.New("outbox.nats_subject_prefix must not be empty when outbox.nats_url is set"))
+	}
+
+	if c.Idempotency.Retention < 0 {
+		errs = append(errs, errors.New("idempotency.retention must not be negative"))
+	}
+
+	if c.Notifications.DeliveryInterval < 0 {
+		errs = append(errs, errors.New("notifications.delivery_interval must not be negative"))
+	}
+	if c.Notifications.SMTPHost != "" {
+		if err := validatePort("notifications.smtp_port", c.Notifications.SMTPPort); err != nil {
+			errs = append(errs, err)
+		}
+		if _, err := mail.ParseAddress(c.Notifications.SMTPFrom); err != nil {
+			errs = append(errs, fmt.Errorf("notifications.smtp_from %q is not a valid address", c.Notifications.SMTPFrom))
+		}
+		if c.Notifications.SMTPTimeout <= 0 {
+			errs = append(errs, errors.New("notifications.smtp_timeout must be positive"))
+		}
+	}
+
+	if strings.TrimSpace(c.Inbound.SMTPAddr) == "" {
+		errs = append(errs, errors.New("inbound.smtp_addr must not be empty"))
+	}
+	if strings.TrimSpace(c.Inbound.Domain) == "" {
+		errs = append(errs, errors.New("inbound.domain must not be empty"))
+	}
+	for _, recipient := range c.Inbound.Recipients {
+		if strings.HasPrefix(recipient, "@") && len(recipient) > 1 && !strings.Contains(recipient[1:], "@") {
+			continue
+		}
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			errs = append(errs, fmt.Errorf("inbound.recipients: %q is not an address or @domain", recipient))
+		}
+	}
+	if c.Inbound.MaxMessageSize < 1 {
+		errs = append(errs, errors.New("inbound.max_message_size must be positive"))
+	}
+	if c.Inbound.Timeout <= 0 {
+		errs = append(errs, errors.New("inbound.timeout must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validatePort checks that value is a TCP port number
+func validatePort(name, value string) error {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("%s %q is not a valid port", name, value)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrConfigPrinted is returned by Load when --print-config was requested and
+// the configuration has been written instead of being used
+var ErrConfigPrinted = errors.New("configuration printed")
+
+const redacted = "********"
+
+// field is a single configurable leaf of Config
+type field struct {
+	path   string
+	value  reflect.Value
+	env    []string
+	flag   string
+	usage  string
+	secret bool
+}
+
+// MustLoad loads the configuration for a binary from os.Args, exiting when
+// --print-config was given or the configuration is invalid
+func MustLoad(name string) *Config {
+	cfg, err := Load(flag.NewFlagSet(name, flag.ExitOnError), os.Args[1:])
+	if errors.Is(err, ErrConfigPrinted) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	return cfg
+}
+
+// Load registers the configuration flags on fs, parses args and resolves the
+// configuration. Binaries that need flags of their own register them on fs
+// before calling Load.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+	fields := collectFields(reflect.ValueOf(cfg).Elem(), "")
+
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file")
+	envFile := fs.String("env-file", ".env", "path to a .env file")
+	printConfig := fs.Bool("print-config", false, "print the resolved configuration (secrets redacted) and exit")
+
+	flagValues := make(map[string]string)
+	for _, f := range fields {
+		if f.flag == "" {
+			continue
+		}
+		name := f.flag
+		set := func(s string) error {
+			flagValues[name] = s
+			return nil
+		}
+		if f.value.Kind() == reflect.Bool {
+			fs.BoolFunc(name, f.usage, set)
+		} else {
+			fs.Func(name, f.usage, set)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	dotenv, err := readDotEnv(*envFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		value, ok := lookup(f.env, dotenv, os.LookupEnv)
+		if !ok && f.secret {
+			if value, ok, err = lookupFile(f.env, dotenv, os.LookupEnv); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.path, err)
+			}
+		}
+		if ok {
+			if err := setValue(f.value, value); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.path, err)
+			}
+		}
+		if value, ok := flagValues[f.flag]; ok {
+			if err := setValue(f.value, value); err != nil {
+				return nil, fmt.Errorf("--%s: %w", f.flag, err)
+			}
+		}
+	}
+
+	// Print before validating, so an invalid configuration can be inspected
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if *printConfig {
+		return cfg, ErrConfigPrinted
+	}
+
+	return cfg, nil
+}
+
+// Print writes the configuration as YAML with secrets redacted
+func (c *Config) Print(w io.Writer) error {
+	copied := *c
+	for _, f := range collectFields(reflect.ValueOf(&copied).Elem(), "") {
+		if f.secret && !f.value.IsZero() {
+			f.value.SetString(redacted)
+		}
+	}
+
+	out, err := yaml.Marshal(&copied)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// collectFields walks the config struct and returns its leaf fields
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "-" || !sf.IsExported() {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		fv := v.Field(i)
+		if sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(fv, path)...)
+			continue
+		}
+
+		f := field{
+			path:   path,
+			value:  fv,
+			flag:   sf.Tag.Get("flag"),
+			usage:  sf.Tag.Get("usage"),
+			secret: sf.Tag.Get("secret") == "true",
+		}
+		if env := sf.Tag.Get("env"); env != "" {
+			f.env = strings.Split(env, ",")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// lookup returns the first variable found, preferring the process
+// environment over the .env file
+func lookup(names []string, dotenv map[string]string, getenv func(string) (string, bool)) (string, bool) {
+	for _, name := range names {
+		if value, ok := getenv(name); ok {
+			return value, true
+		}
+	}
+	for _, name := range names {
+		if value, ok := dotenv[name]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// lookupFile reads the file named by the first <name>_FILE variable found,
+// so secrets can be mounted as files rather than set in the environment
+func lookupFile(names []string, dotenv map[string]string, getenv func(string) (string, bool)) (string, bool, error) {
+	fileNames := make([]string, len(names))
+	for i, name := range names {
+		fileNames[i] = name + "_FILE"
+	}
+	path, ok := lookup(fileNames, dotenv, getenv)
+	if !ok {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return string(data), true, nil
+}
+
+// setValue parses raw into the field according to its type
+func setValue(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+// loadFile overlays a YAML or TOML file onto cfg
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	case ".toml":
+		_, err = toml.Decode(string(data), cfg)
+	default:
+		return fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
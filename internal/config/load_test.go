@@ -0,0 +1,178 @@
+package config_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/config"
+)
+
+// load resolves the configuration from args alone, writing anything printed
+// to the returned string
+func load(t *testing.T, args ...string) (*config.Config, string, error) {
+	t.Helper()
+	out, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = out
+	defer func() { os.Stdout = stdout }()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg, loadErr := config.Load(fs, append([]string{"--env-file=" + os.DevNull}, args...))
+
+	printed, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg, string(printed), loadErr
+}
+
+// write creates a file in a temporary directory and returns its path
+func write(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPrecedence(t *testing.T) {
+	file := write(t, "config.yaml", `
+database:
+  host: file-host
+  name: file-name
+  port: "1111"
+gateway:
+  addr: ":1111"
+`)
+	dotenv := write(t, ".env", "DB_NAME=dotenv-name\nDB_PORT=2222\nHTTP_ADDR=:2222\n")
+	t.Setenv("DB_PORT", "3333")
+	t.Setenv("HTTP_ADDR", ":3333")
+
+	// Each source overrides the ones before it: file, .env, environment, flags
+	cfg, _, err := load(t, "--config="+file, "--env-file="+dotenv, "--http-addr=:4444")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got := []string{cfg.Database.Host, cfg.Database.Name, cfg.Database.Port, cfg.Gateway.Addr}
+	if want := []string{"file-host", "dotenv-name", "3333", ":4444"}; !slices.Equal(got, want) {
+		t.Errorf("host, name, port and address %q, want %q", got, want)
+	}
+	if cfg.Database.SSLMode != "disable" {
+		t.Errorf("sslmode %q, want the default kept", cfg.Database.SSLMode)
+	}
+}
+
+func TestSecretFile(t *testing.T) {
+	t.Setenv("DB_PASSWORD_FILE", write(t, "db_password", "s3cret\n"))
+	dotenv := write(t, ".env", "SMTP_PASSWORD_FILE="+write(t, "smtp_password", "hunter2"))
+	cfg, _, err := load(t, "--env-file="+dotenv)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Database.Password != "s3cret" || cfg.Notifications.SMTPPassword != "hunter2" {
+		t.Errorf("passwords %q and %q, want them read from their files", cfg.Database.Password, cfg.Notifications.SMTPPassword)
+	}
+
+	// The variable itself wins over its file
+	t.Setenv("DB_PASSWORD", "direct")
+	if cfg, _, err = load(t); err != nil || cfg.Database.Password != "direct" {
+		t.Errorf("Load with DB_PASSWORD set = %v, password %q; want the variable", err, cfg.Database.Password)
+	}
+
+	// Files only stand in for secrets
+	t.Setenv("DB_HOST_FILE", write(t, "db_host", "file-host"))
+	if cfg, _, err = load(t); err != nil || cfg.Database.Host != "localhost" {
+		t.Errorf("Load with DB_HOST_FILE set = %v, host %q; want it ignored", err, cfg.Database.Host)
+	}
+
+	t.Setenv("INBOUND_TOKEN_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, _, err := load(t); err == nil || !strings.Contains(err.Error(), "inbound.token") {
+		t.Errorf("Load with a missing secret file = %v, want an error naming inbound.token", err)
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	_, printed, err := load(t, "--print-config", "--db-user=tickets", "--db-password=hunter2", "--s3-secret-key=AKIAsecret")
+	if !errors.Is(err, config.ErrConfigPrinted) {
+		t.Fatalf("Load = %v, want ErrConfigPrinted", err)
+	}
+	for _, secret := range []string{"hunter2", "AKIAsecret"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("printed configuration shows the secret %q:\n%s", secret, printed)
+		}
+	}
+	if n := strings.Count(printed, "********"); n != 2 {
+		t.Errorf("printed %d redacted values, want the 2 secrets set:\n%s", n, printed)
+	}
+	if !strings.Contains(printed, "user: tickets") {
+		t.Errorf("printed configuration lacks the database user:\n%s", printed)
+	}
+}
+
+func TestPrintInvalidConfig(t *testing.T) {
+	_, printed, err := load(t, "--print-config", "--webhook-max-attempts=0")
+	if err == nil || !strings.Contains(err.Error(), "webhooks.max_attempts") {
+		t.Fatalf("Load = %v, want the validation error", err)
+	}
+	if !strings.Contains(printed, "max_attempts: 0") {
+		t.Fatalf("the invalid configuration was not printed:\n%s", printed)
+	}
+}
+
+func TestDatabaseUser(t *testing.T) {
+	cfg, _, err := load(t)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Database.User != "" || cfg.Database.Password != "" {
+		t.Errorf("default database credentials %q / %q, want none", cfg.Database.User, cfg.Database.Password)
+	}
+
+	if _, _, err := load(t, "--store=postgres"); err == nil || !strings.Contains(err.Error(), "database.user") {
+		t.Errorf("Load with the postgres store and no user = %v, want an error", err)
+	}
+	if _, _, err := load(t, "--store=postgres", "--db-user=tickets"); err != nil {
+		t.Errorf("Load with the postgres store and a user: %v", err)
+	}
+}
+
+func TestRequiredTenancyTrust(t *testing.T) {
+	if _, _, err := load(t, "--tenant-required"); err == nil || !strings.Contains(err.Error(), "tenancy.trusted_proxies") {
+		t.Errorf("Load with a required organization and no trusted proxies = %v, want an error", err)
+	}
+	cfg, _, err := load(t, "--tenant-required", "--tenant-trusted-proxies=10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("Load with trusted proxies: %v", err)
+	}
+	if got := cfg.Tenancy.TrustedProxies; !slices.Equal(got, []string{"10.0.0.0/8"}) {
+		t.Errorf("trusted proxies %q, want [10.0.0.0/8]", got)
+	}
+	cfg, _, err = load(t, "--tenant-required", "--tenant-trust-all-peers")
+	if err != nil {
+		t.Fatalf("Load trusting all peers: %v", err)
+	}
+	if !cfg.Tenancy.TrustAllPeers {
+		t.Error("trust_all_peers is off, want every peer trusted")
+	}
+}
+
+func TestOptInListeners(t *testing.T) {
+	cfg, _, err := load(t)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.TicketService.Reflection || cfg.TicketService.Web.Addr != "" {
+		t.Errorf("reflection %t and gRPC-Web address %q by default, want both off", cfg.TicketService.Reflection, cfg.TicketService.Web.Addr)
+	}
+}
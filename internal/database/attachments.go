@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Attachment represents a ticket attachment in the database
+type Attachment struct {
+	ID             string
+	OrganizationID string
+	TicketID       string
+	Filename       string
+	ContentType    string
+	Size           int64
+	SHA256         string
+	StorageKey     string
+	CreatedAt      time.Time
+}
+
+// attachmentColumns is the column list scanned by scanAttachment
+const attachmentColumns = `id, organization_id, ticket_id, filename, content_type, size, sha256, storage_key, created_at`
+
+// scanAttachment scans a row selected with attachmentColumns
+func scanAttachment(row rowScanner) (*Attachment, error) {
+	var attachment Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.OrganizationID,
+		&attachment.TicketID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.SHA256,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// AttachmentRepository handles attachment database operations. Run it inside
+// WithOrganization so row-level security scopes it to one organization.
+type AttachmentRepository struct {
+	db DBTX
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db DBTX) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// Create stores attachment metadata
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *Attachment) error {
+	query := `
+		INSERT INTO attachments (` + attachmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		attachment.ID,
+		attachment.OrganizationID,
+		attachment.TicketID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.SHA256,
+		attachment.StorageKey,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves attachment metadata by ID
+func (r *AttachmentRepository) GetByID(ctx context.Context, id string) (*Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1`
+
+	attachment, err := scanAttachment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("attachment not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListByTicket retrieves the attachments of a ticket, oldest first
+func (r *AttachmentRepository) ListByTicket(ctx context.Context, ticketID string) ([]*Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE ticket_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return scanAttachments(rows)
+}
+
+// DeleteByTicket removes the metadata of every attachment of a ticket and
+// returns it, so the caller can delete the content
+func (r *AttachmentRepository) DeleteByTicket(ctx context.Context, ticketID string) ([]*Attachment, error) {
+	query := `DELETE FROM attachments WHERE ticket_id = $1 RETURNING ` + attachmentColumns
+
+	rows, err := r.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete attachments: %w", err)
+	}
+	return scanAttachments(rows)
+}
+
+// scanAttachments scans and closes rows selected with attachmentColumns
+func scanAttachments(rows *sql.Rows) ([]*Attachment, error) {
+	defer rows.Close()
+
+	var attachments []*Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attachments: %w", err)
+	}
+
+	return attachments, nil
+}
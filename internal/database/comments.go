@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Comment represents a remark on a ticket in the database
+type Comment struct {
+	ID             string
+	OrganizationID string
+	TicketID       string
+	AuthorID       string
+	Body           string
+	CreatedAt      time.Time
+}
+
+// CommentRepository handles comment and email thread database operations.
+// Run it inside WithOrganization so row-level security scopes it to one
+// organization.
+type CommentRepository struct {
+	db DBTX
+}
+
+// NewCommentRepository creates a new comment repository
+func NewCommentRepository(db DBTX) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create creates a new comment
+func (r *CommentRepository) Create(ctx context.Context, comment *Comment) error {
+	query := `
+		INSERT INTO comments (id, organization_id, ticket_id, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		comment.ID,
+		comment.OrganizationID,
+		comment.TicketID,
+		comment.AuthorID,
+		comment.Body,
+		comment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+// List retrieves the comments of a ticket, oldest first
+func (r *CommentRepository) List(ctx context.Context, ticketID string) ([]*Comment, error) {
+	query := `
+		SELECT id, organization_id, ticket_id, author_id, body, created_at
+		FROM comments WHERE ticket_id = $1
+		ORDER BY created_at, id::text`
+
+	rows, err := r.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*Comment
+	for rows.Next() {
+		var comment Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.OrganizationID,
+			&comment.TicketID,
+			&comment.AuthorID,
+			&comment.Body,
+			&comment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// RecordMessage adds an email to the thread of a ticket and reports false
+// when the message was already recorded
+func (r *CommentRepository) RecordMessage(ctx context.Context, organizationID, messageID, ticketID string, at time.Time) (bool, error) {
+	query := `
+		INSERT INTO email_messages (organization_id, message_id, ticket_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, organizationID, messageID, ticketID, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to record email message: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// FindThread retrieves the ticket of the first of messageIDs that was
+// recorded
+func (r *CommentRepository) FindThread(ctx context.Context, messageIDs []string) (string, error) {
+	query := `
+		SELECT ticket_id FROM email_messages
+		WHERE message_id = ANY($1)
+		ORDER BY array_position($1, message_id)
+		LIMIT 1`
+
+	var ticketID string
+	err := r.db.QueryRowContext(ctx, query, pq.Array(messageIDs)).Scan(&ticketID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find email thread: %w", err)
+	}
+	return ticketID, nil
+}
+
+// DeleteTicket removes the comments and the email thread of a ticket
+func (r *CommentRepository) DeleteTicket(ctx context.Context, ticketID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM comments WHERE ticket_id = $1`, ticketID); err != nil {
+		return fmt.Errorf("failed to delete comments: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM email_messages WHERE ticket_id = $1`, ticketID); err != nil {
+		return fmt.Errorf("failed to delete email messages: %w", err)
+	}
+	return nil
+}
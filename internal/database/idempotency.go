@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IdempotencyKey represents an idempotency key of a ticket service call in
+// the database
+type IdempotencyKey struct {
+	OrganizationID string
+	Key            string
+	Method         string
+	RequestHash    []byte
+	Response       []byte
+	CreatedAt      time.Time
+	CompletedAt    sql.NullTime
+	ExpiresAt      time.Time
+}
+
+// IdempotencyRepository handles idempotency key database operations. Run it
+// inside WithOrganization so row-level security scopes it to one
+// organization.
+type IdempotencyRepository struct {
+	db DBTX
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db DBTX) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Purge removes the keys expired at now
+func (r *IdempotencyRepository) Purge(ctx context.Context, now time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now); err != nil {
+		return fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return nil
+}
+
+// Reserve inserts a pending key, replacing one that expired or has been
+// pending since before staleBefore, and reports false when the key is held
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key *IdempotencyKey, staleBefore time.Time) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (organization_id, key, method, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (organization_id, key) DO UPDATE SET
+			method = EXCLUDED.method,
+			request_hash = EXCLUDED.request_hash,
+			response = NULL,
+			created_at = EXCLUDED.created_at,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at < $7)
+		RETURNING key`
+
+	var reserved string
+	err := r.db.QueryRowContext(ctx, query,
+		key.OrganizationID,
+		key.Key,
+		key.Method,
+		key.RequestHash,
+		key.CreatedAt,
+		key.ExpiresAt,
+		staleBefore,
+	).Scan(&reserved)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return true, nil
+}
+
+// Get retrieves a key
+func (r *IdempotencyRepository) Get(ctx context.Context, key string) (*IdempotencyKey, error) {
+	query := `
+		SELECT organization_id, key, method, request_hash, response, created_at, completed_at, expires_at
+		FROM idempotency_keys WHERE key = $1`
+
+	var row IdempotencyKey
+	err := r.db.QueryRowContext(ctx, query, key).Scan(
+		&row.OrganizationID,
+		&row.Key,
+		&row.Method,
+		&row.RequestHash,
+		&row.Response,
+		&row.CreatedAt,
+		&row.CompletedAt,
+		&row.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &row, nil
+}
+
+// Complete saves the response of a key
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, response []byte, completedAt time.Time) error {
+	query := `UPDATE idempotency_keys SET response = $2, completed_at = $3 WHERE key = $1`
+	if _, err := r.db.ExecContext(ctx, query, key, response, completedAt); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release removes a key that is still pending
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE key = $1 AND completed_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
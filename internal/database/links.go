@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicate is wrapped by repository errors for rows that already exist
+var ErrDuplicate = errors.New("already exists")
+
+// TicketLink represents a typed link between two tickets in the database
+type TicketLink struct {
+	SourceID  string
+	TargetID  string
+	LinkType  string
+	CreatedAt time.Time
+}
+
+// LinkRepository handles ticket link database operations. Run it inside
+// WithOrganization so row-level security scopes it to one organization.
+type LinkRepository struct {
+	db DBTX
+}
+
+// NewLinkRepository creates a new link repository
+func NewLinkRepository(db DBTX) *LinkRepository {
+	return &LinkRepository{db: db}
+}
+
+// Create stores a link
+func (r *LinkRepository) Create(ctx context.Context, link *TicketLink) error {
+	query := `
+		INSERT INTO ticket_links (source_id, target_id, link_type, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := r.db.ExecContext(ctx, query, link.SourceID, link.TargetID, link.LinkType, link.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505": // unique_violation
+				return fmt.Errorf("link %s -> %s: %w", link.SourceID, link.TargetID, ErrDuplicate)
+			case "23503", "42501": // foreign_key_violation, or row-level security hiding a ticket of another organization
+				return fmt.Errorf("linked ticket: %w", ErrNotFound)
+			}
+		}
+		return fmt.Errorf("failed to create link: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a link
+func (r *LinkRepository) Delete(ctx context.Context, sourceID, targetID, linkType string) error {
+	query := `DELETE FROM ticket_links WHERE source_id = $1 AND target_id = $2 AND link_type = $3`
+
+	result, err := r.db.ExecContext(ctx, query, sourceID, targetID, linkType)
+	if err != nil {
+		return fmt.Errorf("failed to delete link: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("link %s -> %s: %w", sourceID, targetID, ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListByTicket retrieves the links from or to a ticket, oldest first
+func (r *LinkRepository) ListByTicket(ctx context.Context, ticketID string) ([]*TicketLink, error) {
+	query := `
+		SELECT source_id, target_id, link_type, created_at
+		FROM ticket_links
+		WHERE source_id = $1 OR target_id = $1
+		ORDER BY created_at, source_id, target_id`
+
+	rows, err := r.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*TicketLink
+	for rows.Next() {
+		var link TicketLink
+		if err := rows.Scan(&link.SourceID, &link.TargetID, &link.LinkType, &link.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		links = append(links, &link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate links: %w", err)
+	}
+
+	return links, nil
+}
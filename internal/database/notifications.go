@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Notification represents an in-app inbox entry in the database
+type Notification struct {
+	ID             string
+	OrganizationID string
+	UserID         string
+	Kind           string
+	TicketID       string
+	EventID        string
+	Message        string
+	CreatedAt      time.Time
+	ReadAt         sql.NullTime
+	DeliverAt      sql.NullTime
+	DeliveredAt    sql.NullTime
+}
+
+// NotificationPreferences represents a user's notification settings in the
+// database
+type NotificationPreferences struct {
+	OrganizationID string
+	UserID         string
+	Email          string
+	Delivery       string
+	DigestHour     int32
+	MutedKinds     []string
+	UpdatedAt      time.Time
+}
+
+// notificationColumns is the column list scanned by scanNotification
+const notificationColumns = `id, organization_id, user_id, kind, ticket_id, event_id, message, ` +
+	`created_at, read_at, deliver_at, delivered_at`
+
+// scanNotification scans a row selected with notificationColumns
+func scanNotification(row rowScanner) (*Notification, error) {
+	var notification Notification
+	err := row.Scan(
+		&notification.ID,
+		&notification.OrganizationID,
+		&notification.UserID,
+		&notification.Kind,
+		&notification.TicketID,
+		&notification.EventID,
+		&notification.Message,
+		&notification.CreatedAt,
+		&notification.ReadAt,
+		&notification.DeliverAt,
+		&notification.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// NotificationRepository handles watcher, notification and preference
+// database operations. Run it inside WithOrganization so row-level security
+// scopes it to one organization.
+type NotificationRepository struct {
+	db DBTX
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db DBTX) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Watch adds a watcher to a ticket unless the user already watches it
+func (r *NotificationRepository) Watch(ctx context.Context, organizationID, ticketID, userID string, at time.Time) error {
+	query := `
+		INSERT INTO ticket_watchers (organization_id, ticket_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, organizationID, ticketID, userID, at); err != nil {
+		return fmt.Errorf("failed to watch ticket: %w", err)
+	}
+	return nil
+}
+
+// Unwatch removes a watcher and reports whether the user was watching
+func (r *NotificationRepository) Unwatch(ctx context.Context, ticketID, userID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM ticket_watchers WHERE ticket_id = $1 AND user_id = $2`, ticketID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwatch ticket: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// Watchers retrieves the watchers of a ticket in the order they started
+// watching
+func (r *NotificationRepository) Watchers(ctx context.Context, ticketID string) ([]string, error) {
+	query := `SELECT user_id FROM ticket_watchers WHERE ticket_id = $1 ORDER BY created_at, user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchers: %w", err)
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var user string
+		if err := rows.Scan(&user); err != nil {
+			return nil, fmt.Errorf("failed to scan watcher: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate watchers: %w", err)
+	}
+
+	return users, nil
+}
+
+// DeleteWatchers removes every watcher of a ticket
+func (r *NotificationRepository) DeleteWatchers(ctx context.Context, ticketID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM ticket_watchers WHERE ticket_id = $1`, ticketID); err != nil {
+		return fmt.Errorf("failed to delete watchers: %w", err)
+	}
+	return nil
+}
+
+// Create creates a new notification, unless its user already has one of
+// the event
+func (r *NotificationRepository) Create(ctx context.Context, notification *Notification) error {
+	query := `
+		INSERT INTO notifications (` + notificationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (organization_id, user_id, event_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		notification.ID,
+		notification.OrganizationID,
+		notification.UserID,
+		notification.Kind,
+		notification.TicketID,
+		notification.EventID,
+		notification.Message,
+		notification.CreatedAt,
+		notification.ReadAt,
+		notification.DeliverAt,
+		notification.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// List retrieves up to limit notifications of a user, newest first, only
+// the unread ones when unreadOnly is set
+func (r *NotificationRepository) List(ctx context.Context, userID string, unreadOnly bool, limit int) ([]*Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id::text DESC
+		LIMIT $3`
+
+	return r.list(ctx, query, userID, unreadOnly, limit)
+}
+
+// ListDue retrieves up to limit notifications whose delivery is at or
+// before now, earliest first. Run it inside WithAllOrganizations to look
+// across organizations.
+func (r *NotificationRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications
+		WHERE deliver_at <= $1
+		ORDER BY deliver_at, id::text
+		LIMIT $2`
+
+	return r.list(ctx, query, now, limit)
+}
+
+func (r *NotificationRepository) list(ctx context.Context, query string, args ...interface{}) ([]*Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkRead sets the read time of a notification unless it is already read
+// and returns the notification
+func (r *NotificationRepository) MarkRead(ctx context.Context, id string, at time.Time) (*Notification, error) {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, $2)
+		WHERE id = $1
+		RETURNING ` + notificationColumns
+
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, id, at))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("notification not found: %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return notification, nil
+}
+
+// MarkDelivered sets the delivery time of notifications and clears their
+// pending delivery
+func (r *NotificationRepository) MarkDelivered(ctx context.Context, ids []string, at time.Time) error {
+	query := `UPDATE notifications SET deliver_at = NULL, delivered_at = $2 WHERE id = ANY($1::uuid[])`
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(ids), at); err != nil {
+		return fmt.Errorf("failed to mark notifications delivered: %w", err)
+	}
+	return nil
+}
+
+// GetPreferences retrieves the preferences of a user
+func (r *NotificationRepository) GetPreferences(ctx context.Context, userID string) (*NotificationPreferences, error) {
+	query := `
+		SELECT organization_id, user_id, email, delivery, digest_hour, muted_kinds, updated_at
+		FROM notification_preferences WHERE user_id = $1`
+
+	var preferences NotificationPreferences
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&preferences.OrganizationID,
+		&preferences.UserID,
+		&preferences.Email,
+		&preferences.Delivery,
+		&preferences.DigestHour,
+		pq.Array(&preferences.MutedKinds),
+		&preferences.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("notification preferences not found: %s: %w", userID, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return &preferences, nil
+}
+
+// SetPreferences creates or replaces the preferences of a user
+func (r *NotificationRepository) SetPreferences(ctx context.Context, preferences *NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (organization_id, user_id, email, delivery, digest_hour, muted_kinds, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET email = EXCLUDED.email, delivery = EXCLUDED.delivery, digest_hour = EXCLUDED.digest_hour,
+			muted_kinds = EXCLUDED.muted_kinds, updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		preferences.OrganizationID,
+		preferences.UserID,
+		preferences.Email,
+		preferences.Delivery,
+		preferences.DigestHour,
+		pq.Array(preferences.MutedKinds),
+		preferences.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set notification preferences: %w", err)
+	}
+	return nil
+}
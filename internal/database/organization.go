@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBTX is implemented by *sql.DB and *sql.Tx, so repositories can run inside
+// a transaction
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// WithOrganization runs fn in a transaction whose row-level security policies
+// only admit the rows of organizationID (see migrations/008). The setting is
+// local to the transaction, so it never leaks to the next user of the pooled
+// connection.
+func WithOrganization(ctx context.Context, db *sql.DB, organizationID string, fn func(tx DBTX) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.organization_id', $1, true)`, organizationID); err != nil {
+		return fmt.Errorf("failed to set organization: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithAllOrganizations runs fn in a read-only transaction that may read the
+// rows of every organization through the *_all_organizations policies (see
+// migrations/011). It exists for background workers such as the SLA
+// evaluator and the webhook dispatcher; changes still go through
+// WithOrganization.
+func WithAllOrganizations(ctx context.Context, db *sql.DB, fn func(tx DBTX) error) error {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.all_organizations', 'on', true)`); err != nil {
+		return fmt.Errorf("failed to read across organizations: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// BypassesRowSecurity reports whether the connected role ignores row-level
+// security, as superusers and BYPASSRLS roles do. Organizations are not
+// isolated from each other for such a role (see migrations/018).
+func BypassesRowSecurity(ctx context.Context, db DBTX) (bool, error) {
+	var bypass bool
+	err := db.QueryRowContext(ctx, `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`).Scan(&bypass)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the database role: %w", err)
+	}
+	return bypass, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OutboxEvent is a ticket event recorded by the transaction that caused it
+type OutboxEvent struct {
+	Sequence       int64
+	EventID        string
+	OrganizationID string
+	EventType      string
+	OccurredAt     time.Time
+	Payload        string // JSON encoding of the event
+}
+
+// OutboxRepository handles outbox database operations. Insert inside the
+// WithOrganization transaction of the write that caused the event.
+type OutboxRepository struct {
+	db DBTX
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db DBTX) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Insert records an event; the database assigns its sequence number
+func (r *OutboxRepository) Insert(ctx context.Context, event *OutboxEvent) error {
+	query := `
+		INSERT INTO outbox (event_id, organization_id, event_type, occurred_at, payload)
+		VALUES ($1, $2, $3, $4, $5::jsonb)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.EventID,
+		event.OrganizationID,
+		event.EventType,
+		event.OccurredAt,
+		event.Payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
+}
+
+// ListPending retrieves up to limit events in sequence order. Run it inside
+// WithAllOrganizations to look across organizations.
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) ([]*OutboxEvent, error) {
+	query := `
+		SELECT sequence, event_id, organization_id, event_type, occurred_at, payload
+		FROM outbox
+		ORDER BY sequence
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*OutboxEvent
+	for rows.Next() {
+		var event OutboxEvent
+		err := rows.Scan(
+			&event.Sequence,
+			&event.EventID,
+			&event.OrganizationID,
+			&event.EventType,
+			&event.OccurredAt,
+			&event.Payload,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		pending = append(pending, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate events: %w", err)
+	}
+
+	return pending, nil
+}
+
+// Delete removes a published event; deleting a missing event is a no-op
+func (r *OutboxRepository) Delete(ctx context.Context, eventID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return nil
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // Config holds database configuration
@@ -48,123 +50,257 @@ func NewConnection(config Config) (*sql.DB, error) {
 	return db, nil
 }
 
+// ErrNotFound is wrapped by repository errors for missing rows
+var ErrNotFound = errors.New("not found")
+
 // Ticket represents a ticket in the database
 type Ticket struct {
-	ID          string
-	Title       string
-	Description sql.NullString
-	Status      string
-	Priority    string
-	AssigneeID  sql.NullString
-	Tags        []string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	ReporterID  string
+	ID             string
+	Title          string
+	Description    sql.NullString
+	Status         string
+	Priority       string
+	AssigneeID     sql.NullString
+	Tags           []string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ReporterID     sql.NullString
+	ProjectID      sql.NullString
+	Key            sql.NullString
+	OrganizationID string
+	CustomFields   map[string]string
+	DueDate        sql.NullTime
+	SLA            TicketSLA
+}
+
+// TicketSLA holds the SLA columns of a ticket; Status is NULL for tickets without an SLA
+type TicketSLA struct {
+	FirstResponseDueAt sql.NullTime
+	ResolutionDueAt    sql.NullTime
+	FirstRespondedAt   sql.NullTime
+	ResolvedAt         sql.NullTime
+	Status             sql.NullString
+	NextCheckAt        sql.NullTime
+}
+
+// ticketColumns is the column list scanned by scanTicket
+const ticketColumns = `id, title, description, status, priority, assignee_id, tags, created_at, updated_at, reporter_id, project_id, key, organization_id, custom_fields, ` +
+	`due_date, sla_first_response_due_at, sla_resolution_due_at, sla_first_responded_at, sla_resolved_at, sla_status, sla_next_check_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTicket scans a row selected with ticketColumns
+func scanTicket(row rowScanner) (*Ticket, error) {
+	var ticket Ticket
+	var customFields []byte
+	err := row.Scan(
+		&ticket.ID,
+		&ticket.Title,
+		&ticket.Description,
+		&ticket.Status,
+		&ticket.Priority,
+		&ticket.AssigneeID,
+		pq.Array(&ticket.Tags),
+		&ticket.CreatedAt,
+		&ticket.UpdatedAt,
+		&ticket.ReporterID,
+		&ticket.ProjectID,
+		&ticket.Key,
+		&ticket.OrganizationID,
+		&customFields,
+		&ticket.DueDate,
+		&ticket.SLA.FirstResponseDueAt,
+		&ticket.SLA.ResolutionDueAt,
+		&ticket.SLA.FirstRespondedAt,
+		&ticket.SLA.ResolvedAt,
+		&ticket.SLA.Status,
+		&ticket.SLA.NextCheckAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(customFields, &ticket.CustomFields); err != nil {
+		return nil, fmt.Errorf("invalid custom fields: %w", err)
+	}
+	return &ticket, nil
 }
 
-// TicketRepository handles ticket database operations
+// customFieldsJSON encodes custom field values for the custom_fields JSONB column
+func customFieldsJSON(values map[string]string) string {
+	if len(values) == 0 {
+		return "{}"
+	}
+	data, _ := json.Marshal(values) // string maps always encode
+	return string(data)
+}
+
+// TicketRepository handles ticket database operations. Run it inside
+// WithOrganization so row-level security scopes it to one organization.
 type TicketRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewTicketRepository creates a new ticket repository
-func NewTicketRepository(db *sql.DB) *TicketRepository {
+func NewTicketRepository(db DBTX) *TicketRepository {
 	return &TicketRepository{db: db}
 }
 
-// Create creates a new ticket
+// Create creates a new ticket. A ticket with a ProjectID is given the
+// project's next key; the UPDATE locks the project row, so concurrent
+// creates in the same project are numbered one after another.
 func (r *TicketRepository) Create(ctx context.Context, ticket *Ticket) (*Ticket, error) {
 	query := `
-		INSERT INTO tickets (id, title, description, status, priority, assignee_id, tags, created_at, updated_at, reporter_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, created_at, updated_at`
-
-	var createdTicket Ticket
-	createdTicket.ID = ticket.ID
-	createdTicket.Title = ticket.Title
-	createdTicket.Description = ticket.Description
-	createdTicket.Status = ticket.Status
-	createdTicket.Priority = ticket.Priority
-	createdTicket.AssigneeID = ticket.AssigneeID
-	createdTicket.Tags = ticket.Tags
-	createdTicket.CreatedAt = ticket.CreatedAt
-	createdTicket.UpdatedAt = ticket.UpdatedAt
-	createdTicket.ReporterID = ticket.ReporterID
-	log.Println("createdTicket", createdTicket)
-
-	// Convert tags to JSON
-	tagsJSON, err := json.Marshal(ticket.Tags)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal tags to JSON: %w", err)
-	}
-
-	err = r.db.QueryRowContext(ctx, query,
-		createdTicket.ID,
+		INSERT INTO tickets (id, title, description, status, priority, assignee_id, tags, created_at, updated_at, reporter_id, organization_id, custom_fields,
+			due_date, sla_first_response_due_at, sla_resolution_due_at, sla_first_responded_at, sla_resolved_at, sla_status, sla_next_check_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING ` + ticketColumns
+	args := []interface{}{
+		ticket.ID,
 		ticket.Title,
 		ticket.Description,
 		ticket.Status,
 		ticket.Priority,
 		ticket.AssigneeID,
-		string(tagsJSON),
-		createdTicket.CreatedAt,
-		createdTicket.UpdatedAt,
-		createdTicket.ReporterID,
-	).Scan(&createdTicket.ID, &createdTicket.CreatedAt, &createdTicket.UpdatedAt)
+		pq.Array(ticket.Tags),
+		ticket.CreatedAt,
+		ticket.UpdatedAt,
+		ticket.ReporterID,
+		ticket.OrganizationID,
+		customFieldsJSON(ticket.CustomFields),
+		ticket.DueDate,
+		ticket.SLA.FirstResponseDueAt,
+		ticket.SLA.ResolutionDueAt,
+		ticket.SLA.FirstRespondedAt,
+		ticket.SLA.ResolvedAt,
+		ticket.SLA.Status,
+		ticket.SLA.NextCheckAt,
+	}
+	if ticket.ProjectID.Valid {
+		query = `
+			WITH numbered AS (
+				UPDATE projects SET last_number = last_number + 1
+				WHERE id = $20
+				RETURNING id, key || '-' || last_number AS key
+			)
+			INSERT INTO tickets (id, title, description, status, priority, assignee_id, tags, created_at, updated_at, reporter_id, organization_id, custom_fields,
+				due_date, sla_first_response_due_at, sla_resolution_due_at, sla_first_responded_at, sla_resolved_at, sla_status, sla_next_check_at, project_id, key)
+			SELECT $1::uuid, $2, $3, $4, $5, $6, $7::text[], $8::timestamptz, $9::timestamptz, $10, $11, $12::jsonb,
+				$13::timestamptz, $14::timestamptz, $15::timestamptz, $16::timestamptz, $17::timestamptz, $18, $19::timestamptz, numbered.id, numbered.key
+			FROM numbered
+			RETURNING ` + ticketColumns
+		args = append(args, ticket.ProjectID)
+	}
 
+	created, err := scanTicket(r.db.QueryRowContext(ctx, query, args...))
 	if err != nil {
+		if err == sql.ErrNoRows {
+			// Only the numbered insert can return no row
+			return nil, fmt.Errorf("project not found: %s: %w", ticket.ProjectID.String, ErrNotFound)
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+			return nil, fmt.Errorf("ticket %s: %w", ticket.ID, ErrDuplicate)
+		}
 		return nil, fmt.Errorf("failed to create ticket: %w", err)
 	}
 
-	return &createdTicket, nil
+	return created, nil
 }
 
 // GetByID retrieves a ticket by ID
 func (r *TicketRepository) GetByID(ctx context.Context, id string) (*Ticket, error) {
-	query := `
-		SELECT id, title, description, status, priority, assignee_id, tags, created_at, updated_at
-		FROM tickets WHERE id = $1`
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE id = $1`
 
-	var ticket Ticket
-	var tagsJSON string
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&ticket.ID,
-		&ticket.Title,
-		&ticket.Description,
-		&ticket.Status,
-		&ticket.Priority,
-		&ticket.AssigneeID,
-		&tagsJSON,
-		&ticket.CreatedAt,
-		&ticket.UpdatedAt,
-	)
+	ticket, err := scanTicket(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ticket not found: %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
 
+	return ticket, nil
+}
+
+// GetByIDForUpdate retrieves a ticket by ID and locks its row until the
+// transaction ends, so a change sees the state it replaces
+func (r *TicketRepository) GetByIDForUpdate(ctx context.Context, id string) (*Ticket, error) {
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE id = $1 FOR UPDATE`
+
+	ticket, err := scanTicket(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("ticket not found: %s", id)
+			return nil, fmt.Errorf("ticket not found: %s: %w", id, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get ticket: %w", err)
 	}
 
-	// Unmarshal tags from JSON
-	if tagsJSON != "" {
-		err = json.Unmarshal([]byte(tagsJSON), &ticket.Tags)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal tags from JSON: %w", err)
+	return ticket, nil
+}
+
+// GetByKey retrieves a ticket by its project key
+func (r *TicketRepository) GetByKey(ctx context.Context, key string) (*Ticket, error) {
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE key = $1`
+
+	ticket, err := scanTicket(r.db.QueryRowContext(ctx, query, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ticket not found: %s: %w", key, ErrNotFound)
 		}
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
 	}
 
-	return &ticket, nil
+	return ticket, nil
 }
 
-// List retrieves all tickets with pagination
-func (r *TicketRepository) List(ctx context.Context, limit, offset int) ([]*Ticket, error) {
-	query := `
-		SELECT id, title, description, status, priority, assignee_id, tags, created_at, updated_at
-		FROM tickets
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`
+// TicketFilter selects the tickets returned by List
+type TicketFilter struct {
+	Limit          int
+	AfterCreatedAt time.Time // with AfterID, only tickets sorting after this position
+	AfterID        string
+	Tags           []string // tickets carrying every tag
+	Query          string   // case-insensitive substring of the title or description
+	ProjectID      string   // only tickets of this project
+	// Only tickets whose custom fields have these values; served by the GIN
+	// index on custom_fields
+	CustomFields map[string]string
+}
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+// List retrieves a page of tickets, newest first
+func (r *TicketRepository) List(ctx context.Context, filter TicketFilter) ([]*Ticket, error) {
+	var conditions []string
+	args := []interface{}{filter.Limit}
+	if filter.AfterID != "" {
+		args = append(args, filter.AfterCreatedAt, filter.AfterID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id::text) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+	if filter.ProjectID != "" {
+		args = append(args, filter.ProjectID)
+		conditions = append(conditions, fmt.Sprintf("project_id = $%d", len(args)))
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags))
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d", len(args)))
+	}
+	if len(filter.CustomFields) > 0 {
+		args = append(args, customFieldsJSON(filter.CustomFields))
+		conditions = append(conditions, fmt.Sprintf("custom_fields @> $%d::jsonb", len(args)))
+	}
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d)", len(args), len(args)))
+	}
+
+	query := `SELECT ` + ticketColumns + ` FROM tickets`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id::text DESC LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tickets: %w", err)
 	}
@@ -172,32 +308,40 @@ func (r *TicketRepository) List(ctx context.Context, limit, offset int) ([]*Tick
 
 	var tickets []*Ticket
 	for rows.Next() {
-		var ticket Ticket
-		var tagsJSON string
-		err := rows.Scan(
-			&ticket.ID,
-			&ticket.Title,
-			&ticket.Description,
-			&ticket.Status,
-			&ticket.Priority,
-			&ticket.AssigneeID,
-			&tagsJSON,
-			&ticket.CreatedAt,
-			&ticket.UpdatedAt,
-		)
+		ticket, err := scanTicket(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ticket: %w", err)
 		}
+		tickets = append(tickets, ticket)
+	}
 
-		// Unmarshal tags from JSON
-		if tagsJSON != "" {
-			err = json.Unmarshal([]byte(tagsJSON), &ticket.Tags)
-			if err != nil {
-				return nil, fmt.Errorf("failed to unmarshal tags from JSON: %w", err)
-			}
-		}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tickets: %w", err)
+	}
 
-		tickets = append(tickets, &ticket)
+	return tickets, nil
+}
+
+// GetByIDsForUpdate retrieves the tickets with the given IDs and locks their
+// rows until the transaction ends. Rows are locked in ID order, so bulk
+// changes of overlapping tickets wait for each other instead of deadlocking.
+// Tickets that do not exist are left out.
+func (r *TicketRepository) GetByIDsForUpdate(ctx context.Context, ids []string) (map[string]*Ticket, error) {
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets := make(map[string]*Ticket, len(ids))
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets[ticket.ID] = ticket
 	}
 
 	if err := rows.Err(); err != nil {
@@ -207,7 +351,9 @@ func (r *TicketRepository) List(ctx context.Context, limit, offset int) ([]*Tick
 	return tickets, nil
 }
 
-// Update updates an existing ticket
+// Update updates an existing ticket. Supported keys are title, description,
+// status, priority, assignee_id, tags, custom_fields, due_date, sla (a
+// TicketSLA) and updated_at.
 func (r *TicketRepository) Update(ctx context.Context, id string, updates map[string]interface{}) (*Ticket, error) {
 	// Build dynamic query based on provided updates
 	setParts := []string{}
@@ -216,19 +362,25 @@ func (r *TicketRepository) Update(ctx context.Context, id string, updates map[st
 
 	for field, value := range updates {
 		switch field {
-		case "title", "description", "status", "priority", "assignee_id":
+		case "title", "description", "status", "priority", "assignee_id", "due_date", "updated_at":
 			setParts = append(setParts, fmt.Sprintf("%s = $%d", field, argIndex))
 			args = append(args, value)
 			argIndex++
 		case "tags":
 			setParts = append(setParts, fmt.Sprintf("tags = $%d", argIndex))
-			// Convert tags to JSON
-			tagsJSON, err := json.Marshal(value.([]string))
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal tags to JSON: %w", err)
-			}
-			args = append(args, string(tagsJSON))
+			args = append(args, pq.Array(value.([]string)))
+			argIndex++
+		case "custom_fields":
+			setParts = append(setParts, fmt.Sprintf("custom_fields = $%d::jsonb", argIndex))
+			args = append(args, customFieldsJSON(value.(map[string]string)))
 			argIndex++
+		case "sla":
+			sla := value.(TicketSLA)
+			setParts = append(setParts, fmt.Sprintf(
+				"sla_first_response_due_at = $%d, sla_resolution_due_at = $%d, sla_first_responded_at = $%d, sla_resolved_at = $%d, sla_status = $%d, sla_next_check_at = $%d",
+				argIndex, argIndex+1, argIndex+2, argIndex+3, argIndex+4, argIndex+5))
+			args = append(args, sla.FirstResponseDueAt, sla.ResolutionDueAt, sla.FirstRespondedAt, sla.ResolvedAt, sla.Status, sla.NextCheckAt)
+			argIndex += 6
 		}
 	}
 
@@ -236,58 +388,61 @@ func (r *TicketRepository) Update(ctx context.Context, id string, updates map[st
 		return r.GetByID(ctx, id) // No updates, return existing ticket
 	}
 
-	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
-	args = append(args, time.Now())
-	argIndex++
-
-	// Simplified approach - rebuild the query properly
-	setClause := ""
-	for i, part := range setParts {
-		if i > 0 {
-			setClause += ", "
-		}
-		setClause += part
+	if _, ok := updates["updated_at"]; !ok {
+		setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
+		args = append(args, time.Now())
+		argIndex++
 	}
 
 	query := fmt.Sprintf(`
-		UPDATE tickets 
+		UPDATE tickets
 		SET %s
 		WHERE id = $%d
-		RETURNING id, title, description, status, priority, assignee_id, tags, created_at, updated_at`,
-		setClause, argIndex)
+		RETURNING `+ticketColumns,
+		strings.Join(setParts, ", "), argIndex)
 
 	args = append(args, id)
 
-	var ticket Ticket
-	var tagsJSON string
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(
-		&ticket.ID,
-		&ticket.Title,
-		&ticket.Description,
-		&ticket.Status,
-		&ticket.Priority,
-		&ticket.AssigneeID,
-		&tagsJSON,
-		&ticket.CreatedAt,
-		&ticket.UpdatedAt,
-	)
-
+	ticket, err := scanTicket(r.db.QueryRowContext(ctx, query, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("ticket not found: %s", id)
+			return nil, fmt.Errorf("ticket not found: %s: %w", id, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to update ticket: %w", err)
 	}
 
-	// Unmarshal tags from JSON
-	if tagsJSON != "" {
-		err = json.Unmarshal([]byte(tagsJSON), &ticket.Tags)
+	return ticket, nil
+}
+
+// ListSLADue retrieves the tickets whose SLA next check is at or before now,
+// earliest first. Run it inside WithAllOrganizations to look across
+// organizations.
+func (r *TicketRepository) ListSLADue(ctx context.Context, now time.Time, limit int) ([]*Ticket, error) {
+	query := `SELECT ` + ticketColumns + ` FROM tickets
+		WHERE sla_next_check_at <= $1
+		ORDER BY sla_next_check_at, id::text
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLA due tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var tickets []*Ticket
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal tags from JSON: %w", err)
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
 		}
+		tickets = append(tickets, ticket)
 	}
 
-	return &ticket, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tickets: %w", err)
+	}
+
+	return tickets, nil
 }
 
 // Delete deletes a ticket by ID
@@ -305,8 +460,124 @@ func (r *TicketRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("ticket not found: %s", id)
+		return fmt.Errorf("ticket not found: %s: %w", id, ErrNotFound)
 	}
 
 	return nil
 }
+
+// TagCount is the number of tickets carrying a tag
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// TagCounts returns the tags starting with prefix (case-insensitive) and the
+// number of tickets carrying each, most used first
+func (r *TicketRepository) TagCounts(ctx context.Context, prefix string, limit int) ([]TagCount, error) {
+	query := `
+		SELECT tag, COUNT(DISTINCT id)
+		FROM tickets, unnest(tags) AS tag
+		WHERE tag ILIKE $1
+		GROUP BY tag
+		ORDER BY COUNT(DISTINCT id) DESC, tag
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, escapeLike(prefix)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []TagCount
+	for rows.Next() {
+		var count TagCount
+		if err := rows.Scan(&count.Tag, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tag counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetByTagsForUpdate retrieves the tickets having any of tags and locks
+// their rows until the transaction ends, in ID order like GetByIDsForUpdate
+func (r *TicketRepository) GetByTagsForUpdate(ctx context.Context, tags []string) (map[string]*Ticket, error) {
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE tags && $1::text[] ORDER BY id FOR UPDATE`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(tags))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets := make(map[string]*Ticket)
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets[ticket.ID] = ticket
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// MergeTags replaces every source tag with target, keeping the first
+// occurrence of each tag, and returns the tickets changed
+func (r *TicketRepository) MergeTags(ctx context.Context, sources []string, target string, updatedAt time.Time) ([]*Ticket, error) {
+	// tags && $1 is served by the GIN index on tags
+	query := `
+		WITH merged AS (
+			SELECT id AS merged_id, ARRAY(
+				SELECT tag FROM (
+					SELECT CASE WHEN u.tag = ANY($1::text[]) THEN $2::text ELSE u.tag END AS tag, MIN(u.pos) AS pos
+					FROM unnest(tickets.tags) WITH ORDINALITY AS u(tag, pos)
+					GROUP BY 1
+				) deduplicated
+				ORDER BY pos
+			) AS merged_tags
+			FROM tickets
+			WHERE tags && $1::text[]
+		)
+		UPDATE tickets
+		SET tags = merged.merged_tags, updated_at = $3
+		FROM merged
+		WHERE tickets.id = merged.merged_id AND tickets.tags IS DISTINCT FROM merged.merged_tags
+		RETURNING ` + ticketColumns
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(sources), target, updatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tickets []*Ticket
+	for rows.Next() {
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate merged tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s (backslash is the default escape)
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
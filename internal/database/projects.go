@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Project represents a project in the database
+type Project struct {
+	ID             string
+	Key            string
+	Name           string
+	Description    sql.NullString
+	LastNumber     int64
+	CreatedAt      time.Time
+	OrganizationID string
+	CustomFields   []CustomFieldDefinition
+}
+
+// CustomFieldDefinition is a custom field of a project, stored in the
+// custom_fields JSONB column of projects
+type CustomFieldDefinition struct {
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required,omitempty"`
+}
+
+// definitionsJSON encodes custom field definitions for the custom_fields JSONB column
+func definitionsJSON(fields []CustomFieldDefinition) string {
+	if len(fields) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(fields) // plain structs always encode
+	return string(data)
+}
+
+// projectColumns is the column list scanned by scanProject
+const projectColumns = `id, key, name, description, last_number, created_at, organization_id, custom_fields`
+
+// scanProject scans a row selected with projectColumns
+func scanProject(row rowScanner) (*Project, error) {
+	var project Project
+	var customFields []byte
+	err := row.Scan(
+		&project.ID,
+		&project.Key,
+		&project.Name,
+		&project.Description,
+		&project.LastNumber,
+		&project.CreatedAt,
+		&project.OrganizationID,
+		&customFields,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(customFields, &project.CustomFields); err != nil {
+		return nil, fmt.Errorf("invalid custom field definitions: %w", err)
+	}
+	return &project, nil
+}
+
+// ProjectRepository handles project database operations. Run it inside
+// WithOrganization so row-level security scopes it to one organization.
+type ProjectRepository struct {
+	db DBTX
+}
+
+// NewProjectRepository creates a new project repository
+func NewProjectRepository(db DBTX) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// Create creates a new project
+func (r *ProjectRepository) Create(ctx context.Context, project *Project) error {
+	query := `
+		INSERT INTO projects (` + projectColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		project.ID,
+		project.Key,
+		project.Name,
+		project.Description,
+		project.LastNumber,
+		project.CreatedAt,
+		project.OrganizationID,
+		definitionsJSON(project.CustomFields),
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+			return fmt.Errorf("project %s: %w", project.Key, ErrDuplicate)
+		}
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+	return nil
+}
+
+// SetCustomFields replaces the custom field definitions of a project
+func (r *ProjectRepository) SetCustomFields(ctx context.Context, id string, fields []CustomFieldDefinition) (*Project, error) {
+	query := `UPDATE projects SET custom_fields = $2::jsonb WHERE id = $1 RETURNING ` + projectColumns
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, id, definitionsJSON(fields)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project not found: %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update custom fields: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetByID retrieves a project by ID
+func (r *ProjectRepository) GetByID(ctx context.Context, id string) (*Project, error) {
+	return r.get(ctx, `id = $1`, id)
+}
+
+// GetByKey retrieves a project by key
+func (r *ProjectRepository) GetByKey(ctx context.Context, key string) (*Project, error) {
+	return r.get(ctx, `key = $1`, key)
+}
+
+func (r *ProjectRepository) get(ctx context.Context, condition, arg string) (*Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE ` + condition
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project not found: %s: %w", arg, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// List retrieves every project ordered by key
+func (r *ProjectRepository) List(ctx context.Context) ([]*Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects ORDER BY key`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate projects: %w", err)
+	}
+
+	return projects, nil
+}
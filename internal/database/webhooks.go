@@ -0,0 +1,348 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Webhook represents a webhook subscription in the database
+type Webhook struct {
+	ID             string
+	OrganizationID string
+	URL            string
+	Secret         string
+	EventTypes     []string
+	Filter         WebhookFilter
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookFilter is stored in the filter JSONB column of webhooks
+type WebhookFilter struct {
+	ProjectID  string   `json:"projectId,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Priorities []string `json:"priorities,omitempty"`
+}
+
+// JSON encodes the filter for the filter column
+func (f WebhookFilter) JSON() string {
+	data, _ := json.Marshal(f) // plain structs always encode
+	return string(data)
+}
+
+// WebhookDelivery represents one event on its way to one webhook
+type WebhookDelivery struct {
+	ID             string
+	OrganizationID string
+	WebhookID      string
+	EventID        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int32
+	NextAttemptAt  sql.NullTime
+	LastAttemptAt  sql.NullTime
+	LastStatusCode int32
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    sql.NullTime
+}
+
+// webhookColumns is the column list scanned by scanWebhook
+const webhookColumns = `id, organization_id, url, secret, event_types, filter, active, created_at, updated_at`
+
+// deliveryColumns is the column list scanned by scanDelivery
+const deliveryColumns = `id, organization_id, webhook_id, event_id, event_type, payload, status, attempts, ` +
+	`next_attempt_at, last_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+// scanWebhook scans a row selected with webhookColumns
+func scanWebhook(row rowScanner) (*Webhook, error) {
+	var webhook Webhook
+	var filter []byte
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.OrganizationID,
+		&webhook.URL,
+		&webhook.Secret,
+		pq.Array(&webhook.EventTypes),
+		&filter,
+		&webhook.Active,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filter, &webhook.Filter); err != nil {
+		return nil, fmt.Errorf("invalid webhook filter: %w", err)
+	}
+	return &webhook, nil
+}
+
+// scanDelivery scans a row selected with deliveryColumns
+func scanDelivery(row rowScanner) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.OrganizationID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// WebhookRepository handles webhook and delivery database operations. Run
+// it inside WithOrganization so row-level security scopes it to one
+// organization.
+type WebhookRepository struct {
+	db DBTX
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db DBTX) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create creates a new webhook
+func (r *WebhookRepository) Create(ctx context.Context, webhook *Webhook) error {
+	query := `
+		INSERT INTO webhooks (` + webhookColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		webhook.ID,
+		webhook.OrganizationID,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.EventTypes),
+		webhook.Filter.JSON(),
+		webhook.Active,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook by ID
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("webhook not found: %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// List retrieves every webhook, oldest first
+func (r *WebhookRepository) List(ctx context.Context) ([]*Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Update replaces the settings of a webhook
+func (r *WebhookRepository) Update(ctx context.Context, webhook *Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $2, secret = $3, event_types = $4, filter = $5::jsonb, active = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		pq.Array(webhook.EventTypes),
+		webhook.Filter.JSON(),
+		webhook.Active,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return expectRow(result, "webhook", webhook.ID)
+}
+
+// Delete deletes a webhook; its deliveries cascade
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return expectRow(result, "webhook", id)
+}
+
+// CreateDelivery creates a new delivery, unless the webhook already has a
+// delivery of the event
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (` + deliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.OrganizationID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.CreatedAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("webhook not found: %s: %w", delivery.WebhookID, ErrNotFound)
+		}
+		return fmt.Errorf("failed to create delivery: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery retrieves a delivery by ID
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	delivery, err := scanDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("delivery not found: %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries retrieves up to limit deliveries of a webhook, newest
+// first, only those with status unless it is empty
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]*WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id::text DESC
+		LIMIT $3`
+
+	return r.listDeliveries(ctx, query, webhookID, status, limit)
+}
+
+// ListDueDeliveries retrieves up to limit pending deliveries whose next
+// attempt is at or before now, earliest first. Run it inside
+// WithAllOrganizations to look across organizations.
+func (r *WebhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE status = 'PENDING' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at, id::text
+		LIMIT $2`
+
+	return r.listDeliveries(ctx, query, now, limit)
+}
+
+func (r *WebhookRepository) listDeliveries(ctx context.Context, query string, args ...interface{}) ([]*WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery records the state of a delivery
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+			last_status_code = $6, last_error = $7, delivered_at = $8
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+	return expectRow(result, "delivery", delivery.ID)
+}
+
+// expectRow returns ErrNotFound when a statement changed no row
+func expectRow(result sql.Result, kind, id string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s not found: %s: %w", kind, id, ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Package events carries ticket events from the ticket service to the
+// components that react to them
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Type names what happened to a ticket
+type Type string
+
+const (
+	// TicketCreated is published when a ticket is created
+	TicketCreated Type = "ticket.created"
+	// TicketUpdated is published when a ticket is updated
+	TicketUpdated Type = "ticket.updated"
+	// TicketTransitioned is published, after TicketUpdated, when an update
+	// changed a ticket's status
+	TicketTransitioned Type = "ticket.transitioned"
+	// TicketDeleted is published when a ticket is deleted
+	TicketDeleted Type = "ticket.deleted"
+	// SLAAtRisk is published when a ticket's pending SLA deadline is close
+	SLAAtRisk Type = "ticket.sla_at_risk"
+	// SLABreached is published when a ticket misses an SLA deadline
+	SLABreached Type = "ticket.sla_breached"
+)
+
+// Types lists every event type, in the order they are documented
+var Types = []Type{TicketCreated, TicketUpdated, TicketTransitioned, TicketDeleted, SLAAtRisk, SLABreached}
+
+// Valid reports whether t is a known event type
+func (t Type) Valid() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is something that happened to a ticket
+type Event struct {
+	ID             string
+	Type           Type
+	OrganizationID string
+	OccurredAt     time.Time
+	// The ticket as it is after the event; for TicketDeleted, as it was
+	Ticket *ticketpb.Ticket
+	// The ticket as it was before a TicketUpdated or TicketTransitioned event
+	Previous *ticketpb.Ticket
+}
+
+// New returns an event about a ticket with a fresh ID
+func New(eventType Type, ticket *ticketpb.Ticket, occurredAt time.Time) Event {
+	return Event{
+		ID:             uuid.New().String(),
+		Type:           eventType,
+		OrganizationID: ticket.OrganizationId,
+		OccurredAt:     occurredAt,
+		Ticket:         ticket,
+	}
+}
+
+// Changes returns the events of a write that turned previous into ticket, in
+// the order they are published: TicketCreated when previous is nil and
+// TicketDeleted when ticket is nil; otherwise TicketUpdated when the update
+// time moved and TicketTransitioned when the status changed. SLAAtRisk or
+// SLABreached follow when the SLA status became one of them. SLA evaluations
+// keep the update time, so they only publish the SLA events.
+func Changes(previous, ticket *ticketpb.Ticket, at time.Time) []Event {
+	if ticket == nil {
+		return []Event{New(TicketDeleted, previous, at)}
+	}
+	if previous == nil {
+		return append([]Event{New(TicketCreated, ticket, at)}, slaChanges(ticketpb.SLAStatus_SLA_STATUS_UNSPECIFIED, ticket, at)...)
+	}
+
+	var changes []Event
+	if !ticket.UpdatedAt.AsTime().Equal(previous.UpdatedAt.AsTime()) {
+		updated := New(TicketUpdated, ticket, at)
+		updated.Previous = previous
+		changes = append(changes, updated)
+	}
+	if ticket.Status != previous.Status {
+		transitioned := New(TicketTransitioned, ticket, at)
+		transitioned.Previous = previous
+		changes = append(changes, transitioned)
+	}
+	return append(changes, slaChanges(previous.Sla.GetStatus(), ticket, at)...)
+}
+
+// slaChanges returns the event of a ticket whose SLA status moved from
+// previous to at risk or breached
+func slaChanges(previous ticketpb.SLAStatus, ticket *ticketpb.Ticket, at time.Time) []Event {
+	current := ticket.Sla.GetStatus()
+	if current == previous {
+		return nil
+	}
+	switch current {
+	case ticketpb.SLAStatus_SLA_STATUS_AT_RISK:
+		return []Event{New(SLAAtRisk, ticket, at)}
+	case ticketpb.SLAStatus_SLA_STATUS_BREACHED:
+		return []Event{New(SLABreached, ticket, at)}
+	}
+	return nil
+}
+
+type contextKey struct{}
+
+// WithoutEvents returns a context whose ticket writes record no events, for
+// writes that restore history rather than change it, such as imports
+func WithoutEvents(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, true)
+}
+
+// Recorded reports whether ticket writes made with ctx record their events
+func Recorded(ctx context.Context) bool {
+	suppressed, _ := ctx.Value(contextKey{}).(bool)
+	return !suppressed
+}
+
+// encodedEvent is the JSON form of an Event
+type encodedEvent struct {
+	ID             string          `json:"id"`
+	Type           Type            `json:"type"`
+	OrganizationID string          `json:"organizationId"`
+	OccurredAt     time.Time       `json:"occurredAt"`
+	Ticket         json.RawMessage `json:"ticket"`
+	Previous       json.RawMessage `json:"previous,omitempty"`
+}
+
+// MarshalJSON encodes an event with its tickets in the protobuf JSON mapping
+func (e Event) MarshalJSON() ([]byte, error) {
+	encoded := encodedEvent{
+		ID:             e.ID,
+		Type:           e.Type,
+		OrganizationID: e.OrganizationID,
+		OccurredAt:     e.OccurredAt.UTC(),
+	}
+	var err error
+	if encoded.Ticket, err = protojson.Marshal(e.Ticket); err != nil {
+		return nil, fmt.Errorf("failed to encode ticket: %w", err)
+	}
+	if e.Previous != nil {
+		if encoded.Previous, err = protojson.Marshal(e.Previous); err != nil {
+			return nil, fmt.Errorf("failed to encode previous ticket: %w", err)
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes an event encoded by MarshalJSON
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var encoded encodedEvent
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	*e = Event{
+		ID:             encoded.ID,
+		Type:           encoded.Type,
+		OrganizationID: encoded.OrganizationID,
+		OccurredAt:     encoded.OccurredAt,
+		Ticket:         &ticketpb.Ticket{},
+	}
+	if err := protojson.Unmarshal(encoded.Ticket, e.Ticket); err != nil {
+		return fmt.Errorf("failed to decode ticket: %w", err)
+	}
+	if len(encoded.Previous) > 0 {
+		e.Previous = &ticketpb.Ticket{}
+		if err := protojson.Unmarshal(encoded.Previous, e.Previous); err != nil {
+			return fmt.Errorf("failed to decode previous ticket: %w", err)
+		}
+	}
+	return nil
+}
+
+// Handler reacts to an event. Handlers run in the publisher's goroutine, so
+// slow work belongs in a goroutine of its own.
+type Handler func(ctx context.Context, event Event)
+
+// Publisher publishes events
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Bus is an in-process Publisher that hands every event to its subscribers
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+var _ Publisher = (*Bus)(nil)
+
+// NewBus creates a bus without subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for every event published from now on
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish hands an event to every subscriber in the order they subscribed
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
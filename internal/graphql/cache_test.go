@@ -0,0 +1,105 @@
+package graphql_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/cache"
+	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/rest"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	"google.golang.org/grpc"
+)
+
+// cachedTicket is a ticket as read through the GraphQL API
+type cachedTicket struct {
+	ID    string
+	Title string
+	Tags  []string
+}
+
+// TestCacheInvalidatedByREST checks that GraphQL reads served from the ticket
+// cache see the writes made through the REST API of the same gateway
+func TestCacheInvalidatedByREST(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   *cachedTicket // nil when the ticket is gone
+	}{
+		{"update", http.MethodPatch, "/v1/tickets/%s", `{"title": "Renamed"}`,
+			&cachedTicket{Title: "Renamed", Tags: []string{"defect"}}},
+		{"merge tags", http.MethodPost, "/v1/tags:merge", `{"sources": ["defect"], "target": "bug"}`,
+			&cachedTicket{Title: "Cached", Tags: []string{"bug"}}},
+		{"delete", http.MethodDelete, "/v1/tickets/%s", ``, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := servicetest.Start(t)
+			cached := clients.NewCachedTicketClient(newTicketClient(t, service.Addr), cache.NewLRU(100), time.Hour,
+				cache.NewMetrics())
+			gateway := newGateway(t, cached)
+			restHandler, err := rest.NewHandler(service.Addr, grpc.WithChainUnaryInterceptor(cached.UnaryClientInterceptor()))
+			if err != nil {
+				t.Fatalf("failed to set up REST API: %v", err)
+			}
+			t.Cleanup(func() { restHandler.Close() })
+
+			var created struct{ CreateTicket cachedTicket }
+			post(t, gateway, nil, `mutation { createTicket(title: "Cached", reporterId: "u1", tags: ["defect"]) { id } }`, nil, &created)
+			id := created.CreateTicket.ID
+
+			// Read twice so the ticket and the list are served from the cache
+			read := func() (*cachedTicket, []cachedTicket) {
+				var list struct{ Tickets []cachedTicket }
+				post(t, gateway, nil, `{ tickets { id title tags } }`, nil, &list)
+				resp := post(t, gateway, nil, `query($id: ID!) { ticket(id: $id) { id title tags } }`,
+					map[string]interface{}{"id": id}, nil)
+				if len(resp.Errors) > 0 {
+					return nil, list.Tickets
+				}
+				var one struct{ Ticket *cachedTicket }
+				post(t, gateway, nil, `query($id: ID!) { ticket(id: $id) { id title tags } }`,
+					map[string]interface{}{"id": id}, &one)
+				return one.Ticket, list.Tickets
+			}
+			read()
+			read()
+
+			path := tt.path
+			if strings.Contains(path, "%s") {
+				path = fmt.Sprintf(path, id)
+			}
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			restHandler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s %s returned %d: %s", tt.method, path, rec.Code, rec.Body)
+			}
+
+			ticket, list := read()
+			if tt.want == nil {
+				if ticket != nil || len(list) != 0 {
+					t.Fatalf("deleted ticket still read as %+v, listed as %+v", ticket, list)
+				}
+				return
+			}
+			if ticket == nil {
+				t.Fatal("ticket is gone")
+			}
+			if ticket.Title != tt.want.Title || fmt.Sprint(ticket.Tags) != fmt.Sprint(tt.want.Tags) {
+				t.Errorf("ticket read as %+v, want %+v", *ticket, *tt.want)
+			}
+			if len(list) != 1 || list[0].Title != tt.want.Title || fmt.Sprint(list[0].Tags) != fmt.Sprint(tt.want.Tags) {
+				t.Errorf("tickets listed as %+v, want [%+v]", list, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,510 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ayush-pandya/Graphql/internal/sla"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Helper function to convert gRPC ticket to GraphQL ticket
+func convertGRPCTicketToGraphQL(grpcTicket *ticketpb.Ticket) *Ticket {
+	if grpcTicket == nil {
+		return nil
+	}
+	fmt.Println(grpcTicket)
+	// Convert gRPC enums to GraphQL enums
+	var status TicketStatus
+	switch grpcTicket.Status {
+	case ticketpb.TicketStatus_TICKET_STATUS_OPEN:
+		status = TicketStatusOpen
+	case ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS:
+		status = TicketStatusInProgress
+	case ticketpb.TicketStatus_TICKET_STATUS_RESOLVED:
+		status = TicketStatusResolved
+	case ticketpb.TicketStatus_TICKET_STATUS_CLOSED:
+		status = TicketStatusClosed
+	default:
+		status = TicketStatusOpen
+	}
+
+	var priority TicketPriority
+	switch grpcTicket.Priority {
+	case ticketpb.TicketPriority_TICKET_PRIORITY_LOW:
+		priority = TicketPriorityLow
+	case ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM:
+		priority = TicketPriorityMedium
+	case ticketpb.TicketPriority_TICKET_PRIORITY_HIGH:
+		priority = TicketPriorityHigh
+	case ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL:
+		priority = TicketPriorityCritical
+	default:
+		priority = TicketPriorityMedium
+	}
+
+	ticket := &Ticket{
+		ID:          grpcTicket.Id,
+		Title:       grpcTicket.Title,
+		Description: &grpcTicket.Description,
+		Status:      status,
+		Priority:    priority,
+		Tags:        convertStringSliceToPointerSlice(grpcTicket.Tags),
+		CreatedAt:   grpcTicket.CreatedAt.AsTime().Format(time.RFC3339),
+		UpdatedAt:   grpcTicket.UpdatedAt.AsTime().Format(time.RFC3339),
+	}
+	if grpcTicket.Key != "" {
+		ticket.Key = &grpcTicket.Key
+	}
+	ticket.CustomFields = make([]*CustomFieldValue, 0, len(grpcTicket.CustomFields))
+	for key, value := range grpcTicket.CustomFields {
+		ticket.CustomFields = append(ticket.CustomFields, &CustomFieldValue{Key: key, Value: value})
+	}
+	slices.SortFunc(ticket.CustomFields, func(a, b *CustomFieldValue) int { return strings.Compare(a.Key, b.Key) })
+	ticket.DueDate = formatTimestamp(grpcTicket.DueDate)
+	ticket.SLA = convertGRPCSLAToGraphQL(grpcTicket.Sla, time.Now())
+	return ticket
+}
+
+// convertGRPCSLAToGraphQL converts the SLA of a ticket, counting the time
+// remaining from now
+func convertGRPCSLAToGraphQL(grpcSLA *ticketpb.TicketSLA, now time.Time) *TicketSLA {
+	if grpcSLA == nil {
+		return nil
+	}
+	result := &TicketSLA{
+		Status:             SLAStatus(strings.TrimPrefix(grpcSLA.Status.String(), "SLA_STATUS_")),
+		Breached:           grpcSLA.Status == ticketpb.SLAStatus_SLA_STATUS_BREACHED,
+		FirstResponseDueAt: formatTimestamp(grpcSLA.FirstResponseDueAt),
+		ResolutionDueAt:    formatTimestamp(grpcSLA.ResolutionDueAt),
+		FirstRespondedAt:   formatTimestamp(grpcSLA.FirstRespondedAt),
+		ResolvedAt:         formatTimestamp(grpcSLA.ResolvedAt),
+	}
+	if due := sla.Pending(grpcSLA); due != nil {
+		remaining := int(due.AsTime().Sub(now) / time.Second)
+		result.DueAt = formatTimestamp(due)
+		result.Remaining = &remaining
+	}
+	return result
+}
+
+// convertGRPCSLAPolicyToGraphQL converts an SLA policy; untracked targets are null
+func convertGRPCSLAPolicyToGraphQL(policy *ticketpb.SLAPolicy) *SLAPolicy {
+	result := &SLAPolicy{
+		Priority: TicketPriority(strings.TrimPrefix(policy.Priority.String(), "TICKET_PRIORITY_")),
+	}
+	if seconds := int(policy.FirstResponse.AsDuration() / time.Second); seconds > 0 {
+		result.FirstResponseSeconds = &seconds
+	}
+	if seconds := int(policy.Resolution.AsDuration() / time.Second); seconds > 0 {
+		result.ResolutionSeconds = &seconds
+	}
+	return result
+}
+
+// formatTimestamp formats an optional timestamp as RFC 3339
+func formatTimestamp(ts *timestamppb.Timestamp) *string {
+	if ts == nil {
+		return nil
+	}
+	formatted := ts.AsTime().Format(time.RFC3339)
+	return &formatted
+}
+
+// parseDueDate parses a due date argument; an empty string clears the due date
+func parseDueDate(dueDate *string) (ts *timestamppb.Timestamp, clear bool, err error) {
+	if dueDate == nil {
+		return nil, false, nil
+	}
+	if *dueDate == "" {
+		return nil, true, nil
+	}
+	due, err := time.Parse(time.RFC3339, *dueDate)
+	if err != nil {
+		return nil, false, fmt.Errorf("dueDate must be an RFC 3339 date-time, got %q", *dueDate)
+	}
+	return timestamppb.New(due), false, nil
+}
+
+// Helper function to convert GraphQL enums to gRPC enums
+func convertGraphQLPriorityToGRPC(priority *TicketPriority) ticketpb.TicketPriority {
+	if priority == nil {
+		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
+	}
+
+	switch *priority {
+	case TicketPriorityLow:
+		return ticketpb.TicketPriority_TICKET_PRIORITY_LOW
+	case TicketPriorityMedium:
+		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
+	case TicketPriorityHigh:
+		return ticketpb.TicketPriority_TICKET_PRIORITY_HIGH
+	case TicketPriorityCritical:
+		return ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL
+	default:
+		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
+	}
+}
+
+// Helper function to convert string slice to pointer slice
+func convertStringSliceToPointerSlice(slice []string) []*string {
+	result := make([]*string, len(slice))
+	for i, s := range slice {
+		result[i] = &s
+	}
+	return result
+}
+
+// Helper function to convert pointer slice to string slice
+func convertPointerSliceToStringSlice(slice []*string) []string {
+	result := make([]string, 0, len(slice))
+	for _, s := range slice {
+		if s != nil {
+			result = append(result, *s)
+		}
+	}
+	return result
+}
+
+// convertGRPCAttachmentToGraphQL converts a gRPC attachment, signing its
+// download URL for the organization of ctx
+func (r *Resolver) convertGRPCAttachmentToGraphQL(ctx context.Context, grpcAttachment *ticketpb.Attachment) *Attachment {
+	attachment := &Attachment{
+		ID:          grpcAttachment.Id,
+		Filename:    grpcAttachment.Filename,
+		ContentType: grpcAttachment.ContentType,
+		Size:        int(grpcAttachment.Size),
+		Sha256:      grpcAttachment.Sha256,
+		CreatedAt:   grpcAttachment.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+	if r.attachmentSigner != nil {
+		attachment.URL = r.attachmentSigner.URL(tenant.ID(ctx), grpcAttachment.Id)
+	}
+	return attachment
+}
+
+// convertGRPCLinkToGraphQL converts a gRPC ticket link
+func convertGRPCLinkToGraphQL(grpcLink *ticketpb.TicketLink) *TicketLink {
+	var linkType TicketLinkType
+	switch grpcLink.Type {
+	case ticketpb.LinkType_LINK_TYPE_BLOCKS:
+		linkType = TicketLinkTypeBlocks
+	case ticketpb.LinkType_LINK_TYPE_DUPLICATES:
+		linkType = TicketLinkTypeDuplicates
+	case ticketpb.LinkType_LINK_TYPE_PARENT_OF:
+		linkType = TicketLinkTypeParentOf
+	default:
+		linkType = TicketLinkTypeRelatesTo
+	}
+
+	return &TicketLink{
+		Type:      linkType,
+		SourceID:  grpcLink.SourceId,
+		TargetID:  grpcLink.TargetId,
+		CreatedAt: grpcLink.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// convertGraphQLLinkTypeToGRPC converts a GraphQL link type to its gRPC enum
+func convertGraphQLLinkTypeToGRPC(linkType TicketLinkType) ticketpb.LinkType {
+	switch linkType {
+	case TicketLinkTypeBlocks:
+		return ticketpb.LinkType_LINK_TYPE_BLOCKS
+	case TicketLinkTypeDuplicates:
+		return ticketpb.LinkType_LINK_TYPE_DUPLICATES
+	case TicketLinkTypeRelatesTo:
+		return ticketpb.LinkType_LINK_TYPE_RELATES_TO
+	case TicketLinkTypeParentOf:
+		return ticketpb.LinkType_LINK_TYPE_PARENT_OF
+	default:
+		return ticketpb.LinkType_LINK_TYPE_UNSPECIFIED
+	}
+}
+
+// convertGRPCProjectToGraphQL converts a gRPC project
+func convertGRPCProjectToGraphQL(grpcProject *ticketpb.Project) *Project {
+	project := &Project{
+		ID:        grpcProject.Id,
+		Key:       grpcProject.Key,
+		Name:      grpcProject.Name,
+		CreatedAt: grpcProject.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+	if grpcProject.Description != "" {
+		project.Description = &grpcProject.Description
+	}
+	project.CustomFields = make([]*CustomFieldDefinition, len(grpcProject.CustomFields))
+	for i, field := range grpcProject.CustomFields {
+		project.CustomFields[i] = &CustomFieldDefinition{
+			Key:      field.Key,
+			Name:     field.Name,
+			Type:     CustomFieldType(strings.TrimPrefix(field.Type.String(), "CUSTOM_FIELD_TYPE_")),
+			Options:  append([]string{}, field.Options...),
+			Required: field.Required,
+		}
+	}
+	return project
+}
+
+// convertGraphQLCustomFieldDefinitionToGRPC converts a custom field definition input
+func convertGraphQLCustomFieldDefinitionToGRPC(field *CustomFieldDefinitionInput) *ticketpb.CustomFieldDefinition {
+	definition := &ticketpb.CustomFieldDefinition{
+		Key:     field.Key,
+		Type:    ticketpb.CustomFieldType(ticketpb.CustomFieldType_value["CUSTOM_FIELD_TYPE_"+string(field.Type)]),
+		Options: field.Options,
+	}
+	if field.Name != nil {
+		definition.Name = *field.Name
+	}
+	if field.Required != nil {
+		definition.Required = *field.Required
+	}
+	return definition
+}
+
+// convertCustomFieldInputs converts custom field inputs to the map sent over gRPC
+func convertCustomFieldInputs(fields []*CustomFieldInput) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		values[field.Key] = field.Value
+	}
+	return values
+}
+
+// convertGRPCWebhookToGraphQL converts a gRPC webhook; the secret is only set
+// when the ticket service returned it
+func convertGRPCWebhookToGraphQL(grpcWebhook *ticketpb.Webhook) *Webhook {
+	webhook := &Webhook{
+		ID:        grpcWebhook.Id,
+		URL:       grpcWebhook.Url,
+		Events:    make([]WebhookEvent, len(grpcWebhook.EventTypes)),
+		Active:    grpcWebhook.Active,
+		CreatedAt: grpcWebhook.CreatedAt.AsTime().Format(time.RFC3339),
+		UpdatedAt: grpcWebhook.UpdatedAt.AsTime().Format(time.RFC3339),
+	}
+	for i, eventType := range grpcWebhook.EventTypes {
+		webhook.Events[i] = convertEventTypeToGraphQL(eventType)
+	}
+	if grpcWebhook.Secret != "" {
+		webhook.Secret = &grpcWebhook.Secret
+	}
+	if filter := grpcWebhook.Filter; filter != nil {
+		webhook.Filter = &WebhookFilter{
+			Tags:       append([]string{}, filter.Tags...),
+			Priorities: make([]TicketPriority, len(filter.Priorities)),
+		}
+		if filter.ProjectId != "" {
+			webhook.Filter.ProjectID = &filter.ProjectId
+		}
+		for i, priority := range filter.Priorities {
+			webhook.Filter.Priorities[i] = TicketPriority(strings.TrimPrefix(priority.String(), "TICKET_PRIORITY_"))
+		}
+	}
+	return webhook
+}
+
+// convertGRPCWebhookDeliveryToGraphQL converts a gRPC webhook delivery
+func convertGRPCWebhookDeliveryToGraphQL(grpcDelivery *ticketpb.WebhookDelivery) *WebhookDelivery {
+	delivery := &WebhookDelivery{
+		ID:            grpcDelivery.Id,
+		WebhookID:     grpcDelivery.WebhookId,
+		EventID:       grpcDelivery.EventId,
+		Event:         convertEventTypeToGraphQL(grpcDelivery.EventType),
+		Payload:       grpcDelivery.Payload,
+		Status:        WebhookDeliveryStatus(strings.TrimPrefix(grpcDelivery.Status.String(), "WEBHOOK_DELIVERY_STATUS_")),
+		Attempts:      int(grpcDelivery.Attempts),
+		NextAttemptAt: formatTimestamp(grpcDelivery.NextAttemptAt),
+		LastAttemptAt: formatTimestamp(grpcDelivery.LastAttemptAt),
+		CreatedAt:     grpcDelivery.CreatedAt.AsTime().Format(time.RFC3339),
+		DeliveredAt:   formatTimestamp(grpcDelivery.DeliveredAt),
+	}
+	if grpcDelivery.LastStatusCode != 0 {
+		code := int(grpcDelivery.LastStatusCode)
+		delivery.LastStatusCode = &code
+	}
+	if grpcDelivery.LastError != "" {
+		delivery.LastError = &grpcDelivery.LastError
+	}
+	return delivery
+}
+
+// convertGraphQLWebhookFilterToGRPC converts a webhook filter input; a nil
+// input leaves the filter unset
+func convertGraphQLWebhookFilterToGRPC(filter *WebhookFilterInput) *ticketpb.WebhookFilter {
+	if filter == nil {
+		return nil
+	}
+	result := &ticketpb.WebhookFilter{Tags: filter.Tags}
+	if filter.ProjectKey != nil {
+		result.ProjectKey = *filter.ProjectKey
+	}
+	for _, priority := range filter.Priorities {
+		result.Priorities = append(result.Priorities, convertGraphQLPriorityToGRPC(&priority))
+	}
+	return result
+}
+
+// convertEventTypeToGraphQL converts an event type such as ticket.sla_breached
+// to its GraphQL enum value, TICKET_SLA_BREACHED
+func convertEventTypeToGraphQL(eventType string) WebhookEvent {
+	return WebhookEvent(strings.ToUpper(strings.ReplaceAll(eventType, ".", "_")))
+}
+
+// convertGraphQLEventsToGRPC converts GraphQL webhook events to event types
+func convertGraphQLEventsToGRPC(events []WebhookEvent) []string {
+	eventTypes := make([]string, len(events))
+	for i, event := range events {
+		eventTypes[i] = "ticket." + strings.ToLower(strings.TrimPrefix(string(event), "TICKET_"))
+	}
+	return eventTypes
+}
+
+// convertGRPCNotificationToGraphQL converts a gRPC notification
+func convertGRPCNotificationToGraphQL(grpcNotification *ticketpb.Notification) *Notification {
+	return &Notification{
+		ID:          grpcNotification.Id,
+		UserID:      grpcNotification.UserId,
+		Kind:        convertNotificationKindToGraphQL(grpcNotification.Kind),
+		TicketID:    grpcNotification.TicketId,
+		Message:     grpcNotification.Message,
+		CreatedAt:   grpcNotification.CreatedAt.AsTime().Format(time.RFC3339),
+		ReadAt:      formatTimestamp(grpcNotification.ReadAt),
+		Read:        grpcNotification.ReadAt != nil,
+		DeliverAt:   formatTimestamp(grpcNotification.DeliverAt),
+		DeliveredAt: formatTimestamp(grpcNotification.DeliveredAt),
+	}
+}
+
+// convertGRPCCommentToGraphQL converts a gRPC comment
+func convertGRPCCommentToGraphQL(grpcComment *ticketpb.Comment) *Comment {
+	return &Comment{
+		ID:        grpcComment.Id,
+		TicketID:  grpcComment.TicketId,
+		AuthorID:  grpcComment.AuthorId,
+		Body:      grpcComment.Body,
+		CreatedAt: grpcComment.CreatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// convertGraphQLStatusToGRPC converts a GraphQL status; nil yields
+// TICKET_STATUS_UNSPECIFIED, which leaves a ticket's status unchanged
+func convertGraphQLStatusToGRPC(status *TicketStatus) ticketpb.TicketStatus {
+	if status == nil {
+		return ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED
+	}
+
+	switch *status {
+	case TicketStatusOpen:
+		return ticketpb.TicketStatus_TICKET_STATUS_OPEN
+	case TicketStatusInProgress:
+		return ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
+	case TicketStatusResolved:
+		return ticketpb.TicketStatus_TICKET_STATUS_RESOLVED
+	case TicketStatusClosed:
+		return ticketpb.TicketStatus_TICKET_STATUS_CLOSED
+	default:
+		return ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED
+	}
+}
+
+// convertGraphQLPatchToGRPC converts the patch of a bulk update
+func convertGraphQLPatchToGRPC(patch TicketPatchInput) (*ticketpb.TicketPatch, error) {
+	due, clearDue, err := parseDueDate(patch.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	grpcPatch := &ticketpb.TicketPatch{
+		Status:       convertGraphQLStatusToGRPC(patch.Status),
+		Tags:         patch.Tags,
+		CustomFields: convertCustomFieldInputs(patch.CustomFields),
+		DueDate:      due,
+		ClearDueDate: clearDue,
+	}
+	if patch.Title != nil {
+		grpcPatch.Title = *patch.Title
+	}
+	if patch.Description != nil {
+		grpcPatch.Description = *patch.Description
+	}
+	if patch.Priority != nil {
+		grpcPatch.Priority = convertGraphQLPriorityToGRPC(patch.Priority)
+	}
+	if patch.AssigneeID != nil {
+		grpcPatch.AssigneeId = *patch.AssigneeID
+	}
+	return grpcPatch, nil
+}
+
+// convertGRPCBulkResultsToGraphQL converts the results of a bulk operation
+func convertGRPCBulkResultsToGraphQL(grpcResults []*ticketpb.BulkTicketResult) *BulkTicketsPayload {
+	payload := &BulkTicketsPayload{Results: make([]*BulkTicketResult, len(grpcResults))}
+	for i, grpcResult := range grpcResults {
+		result := &BulkTicketResult{ID: grpcResult.Id}
+		if code := codes.Code(grpcResult.ErrorCode); code != codes.OK {
+			result.Error = &BulkTicketError{Code: grpcCodeName(code), Message: grpcResult.ErrorMessage}
+			payload.Failed++
+		} else {
+			payload.Succeeded++
+		}
+		if grpcResult.Ticket != nil {
+			result.Ticket = convertGRPCTicketToGraphQL(grpcResult.Ticket)
+		}
+		payload.Results[i] = result
+	}
+	return payload
+}
+
+// grpcCodeName names a failure code the way google.rpc.Code does, e.g.
+// NotFound becomes NOT_FOUND
+func grpcCodeName(code codes.Code) string {
+	var name strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			name.WriteByte('_')
+		}
+		name.WriteRune(unicode.ToUpper(r))
+	}
+	return name.String()
+}
+
+// convertGRPCNotificationPreferencesToGraphQL converts gRPC notification
+// preferences
+func convertGRPCNotificationPreferencesToGraphQL(grpcPreferences *ticketpb.NotificationPreferences) *NotificationPreferences {
+	preferences := &NotificationPreferences{
+		UserID:     grpcPreferences.UserId,
+		Delivery:   NotificationDelivery(strings.TrimPrefix(grpcPreferences.Delivery.String(), "NOTIFICATION_DELIVERY_")),
+		DigestHour: int(grpcPreferences.DigestHour),
+		MutedKinds: make([]NotificationKind, len(grpcPreferences.MutedKinds)),
+		UpdatedAt:  formatTimestamp(grpcPreferences.UpdatedAt),
+	}
+	if grpcPreferences.Email != "" {
+		preferences.Email = &grpcPreferences.Email
+	}
+	for i, kind := range grpcPreferences.MutedKinds {
+		preferences.MutedKinds[i] = convertNotificationKindToGraphQL(kind)
+	}
+	return preferences
+}
+
+// convertNotificationKindToGraphQL converts a gRPC notification kind
+func convertNotificationKindToGraphQL(kind ticketpb.NotificationKind) NotificationKind {
+	return NotificationKind(strings.TrimPrefix(kind.String(), "NOTIFICATION_KIND_"))
+}
+
+// convertGraphQLNotificationKindsToGRPC converts GraphQL notification kinds
+func convertGraphQLNotificationKindsToGRPC(kinds []NotificationKind) []ticketpb.NotificationKind {
+	grpcKinds := make([]ticketpb.NotificationKind, len(kinds))
+	for i, kind := range kinds {
+		grpcKinds[i] = ticketpb.NotificationKind(ticketpb.NotificationKind_value["NOTIFICATION_KIND_"+string(kind)])
+	}
+	return grpcKinds
+}
@@ -0,0 +1,85 @@
+package graphql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/graphql"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// newGateway returns the GraphQL endpoint of a gateway in front of
+// ticketService, taking the organization from the X-Organization-ID header
+func newGateway(t *testing.T, ticketService clients.TicketService) http.Handler {
+	t.Helper()
+	srv := handler.New(graphql.NewExecutableSchema(graphql.Config{
+		Resolvers: graphql.NewResolverWithGRPC(nil, ticketService),
+	}))
+	srv.AddTransport(transport.POST{})
+	return tenant.Middleware(srv, tenant.Header, false, tenant.AllPeers)
+}
+
+// newTicketClient connects to the ticket service at addr
+func newTicketClient(t *testing.T, addr string) *clients.TicketClient {
+	t.Helper()
+	client, err := clients.NewTicketClient(addr)
+	if err != nil {
+		t.Fatalf("failed to connect to ticket service: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// response is a GraphQL response
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// post sends a GraphQL operation with the given request headers, which may
+// be nil, and decodes the response into data. It fails the test on errors
+// unless data is nil.
+func post(t *testing.T, gateway http.Handler, header http.Header, query string, variables map[string]interface{}, data interface{}) *response {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, values := range header {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, req)
+
+	var resp response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response %q (status %d): %v", rec.Body, rec.Code, err)
+	}
+	if data == nil {
+		return &resp
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("operation failed: %+v", resp.Errors)
+	}
+	if err := json.Unmarshal(resp.Data, data); err != nil {
+		t.Fatalf("failed to decode data %s: %v", resp.Data, err)
+	}
+	return &resp
+}
+
+// organization returns the request headers acting for an organization
+func organization(id string) http.Header {
+	header := make(http.Header)
+	header.Set(tenant.Header, id)
+	return header
+}
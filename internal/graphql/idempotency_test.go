@@ -0,0 +1,74 @@
+package graphql_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/ayush-pandya/Graphql/internal/graphql"
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	"google.golang.org/grpc"
+)
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour)))
+	srv := handler.New(graphql.NewExecutableSchema(graphql.Config{
+		Resolvers: graphql.NewResolverWithGRPC(nil, newTicketClient(t, service.Addr)),
+	}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(idempotency.Extension{})
+	gateway := tenant.Middleware(idempotency.Middleware(srv), tenant.Header, false, tenant.AllPeers)
+
+	header := make(http.Header)
+	header.Set(idempotency.Header, "import-1")
+	count := func() int {
+		var list struct{ Tickets []struct{ ID string } }
+		post(t, gateway, nil, `{ tickets { id } }`, nil, &list)
+		return len(list.Tickets)
+	}
+
+	// One key cannot stand for two calls
+	resp := post(t, gateway, header, `mutation {
+		first: createTicket(title: "First", reporterId: "u1") { id }
+		second: createTicket(title: "Second", reporterId: "u1") { id }
+	}`, nil, nil)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "single mutation") {
+		t.Fatalf("two mutations with the header: errors %+v, want the header refused", resp.Errors)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("the refused request created %d tickets", n)
+	}
+
+	// A single mutation is retried safely
+	var created [2]struct {
+		CreateTicket struct{ ID string }
+	}
+	for i := range created {
+		post(t, gateway, header, `mutation { createTicket(title: "Once", reporterId: "u1") { id } }`, nil, &created[i])
+	}
+	if created[0].CreateTicket.ID != created[1].CreateTicket.ID {
+		t.Errorf("retry created ticket %s, want a replay of %s", created[1].CreateTicket.ID, created[0].CreateTicket.ID)
+	}
+
+	// Keys given per field keep several mutations apart
+	var both struct {
+		First  struct{ ID string }
+		Second struct{ ID string }
+	}
+	post(t, gateway, nil, `mutation {
+		first: createTicket(title: "First", reporterId: "u1", idempotencyKey: "import-2") { id }
+		second: createTicket(title: "Second", reporterId: "u1", idempotencyKey: "import-3") { id }
+	}`, nil, &both)
+	if both.First.ID == both.Second.ID {
+		t.Errorf("both mutations returned ticket %s", both.First.ID)
+	}
+	if n := count(); n != 3 {
+		t.Errorf("%d tickets created, want 3", n)
+	}
+}
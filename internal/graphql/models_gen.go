@@ -9,14 +9,152 @@ import (
 	"strconv"
 )
 
+type Attachment struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
+	Sha256      string `json:"sha256"`
+	// Signed, time-limited download URL served by the gateway
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type BulkTicketError struct {
+	// The gRPC status code, e.g. NOT_FOUND or FAILED_PRECONDITION. ABORTED marks the tickets an
+	// atomic operation left unchanged because another ticket failed.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// The outcome of a bulk operation for one ticket
+type BulkTicketResult struct {
+	ID string `json:"id"`
+	// The updated ticket; null for deletions and failures
+	Ticket *Ticket `json:"ticket,omitempty"`
+	// Why the ticket was not changed; null when it was
+	Error *BulkTicketError `json:"error,omitempty"`
+}
+
+// The outcome of a bulk operation, one result per requested ticket in request order
+type BulkTicketsPayload struct {
+	Results   []*BulkTicketResult `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
+
+// A remark on a ticket
+type Comment struct {
+	ID       string `json:"id"`
+	TicketID string `json:"ticketId"`
+	// The user who wrote it; the sender's address for comments received by email
+	AuthorID  string `json:"authorId"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// A field a project defines for its tickets
+type CustomFieldDefinition struct {
+	// Lower-case letters, digits and underscores, starting with a letter
+	Key  string          `json:"key"`
+	Name string          `json:"name"`
+	Type CustomFieldType `json:"type"`
+	// Allowed values of an ENUM field
+	Options []string `json:"options"`
+	// Tickets must set the field when they are created
+	Required bool `json:"required"`
+}
+
+type CustomFieldDefinitionInput struct {
+	Key string `json:"key"`
+	// Defaults to the key
+	Name     *string         `json:"name,omitempty"`
+	Type     CustomFieldType `json:"type"`
+	Options  []string        `json:"options,omitempty"`
+	Required *bool           `json:"required,omitempty"`
+}
+
+// Sets a custom field; an empty value clears it when updating a ticket
+type CustomFieldInput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// The value of a custom field on a ticket
+type CustomFieldValue struct {
+	Key string `json:"key"`
+	// The value in the canonical form of the field's type
+	Value string `json:"value"`
+}
+
 type Mutation struct {
 }
 
+// An entry in a user's in-app notification inbox
+type Notification struct {
+	ID       string           `json:"id"`
+	UserID   string           `json:"userId"`
+	Kind     NotificationKind `json:"kind"`
+	TicketID string           `json:"ticketId"`
+	// Null once the ticket is deleted
+	Ticket    *Ticket `json:"ticket,omitempty"`
+	Message   string  `json:"message"`
+	CreatedAt string  `json:"createdAt"`
+	ReadAt    *string `json:"readAt,omitempty"`
+	Read      bool    `json:"read"`
+	// When the notification is due to be sent by email; null when it is not
+	DeliverAt   *string `json:"deliverAt,omitempty"`
+	DeliveredAt *string `json:"deliveredAt,omitempty"`
+}
+
+type NotificationPreferences struct {
+	UserID string `json:"userId"`
+	// Address notifications are emailed to; null when they are not emailed
+	Email    *string              `json:"email,omitempty"`
+	Delivery NotificationDelivery `json:"delivery"`
+	// Hour of the day (0-23, UTC) daily digests are sent
+	DigestHour int `json:"digestHour"`
+	// Kinds the user is not notified of, in the inbox or elsewhere
+	MutedKinds []NotificationKind `json:"mutedKinds"`
+	// Null until the user changes the defaults
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+}
+
+// A project groups tickets under a short key used to number them, e.g. WEB-42
+type Project struct {
+	ID          string  `json:"id"`
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+	// Fields the project defines for its tickets, in display order
+	CustomFields []*CustomFieldDefinition `json:"customFields"`
+	// Tickets of the project, newest first
+	Tickets []*Ticket `json:"tickets"`
+}
+
 type Query struct {
 }
 
+// Response and resolution targets for tickets of one priority
+type SLAPolicy struct {
+	Priority TicketPriority `json:"priority"`
+	// Seconds from creation to the first response; null when not tracked
+	FirstResponseSeconds *int `json:"firstResponseSeconds,omitempty"`
+	// Seconds from creation to resolution; null when not tracked
+	ResolutionSeconds *int `json:"resolutionSeconds,omitempty"`
+}
+
+// Number of tickets carrying a tag
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
 type Ticket struct {
-	ID          string         `json:"id"`
+	ID string `json:"id"`
+	// Human-readable key such as WEB-42, set for tickets created in a project
+	Key         *string        `json:"key,omitempty"`
 	Title       string         `json:"title"`
 	Description *string        `json:"description,omitempty"`
 	Status      TicketStatus   `json:"status"`
@@ -26,6 +164,63 @@ type Ticket struct {
 	Assignee    *User          `json:"assignee,omitempty"`
 	Reporter    *User          `json:"reporter,omitempty"`
 	Tags        []*string      `json:"tags,omitempty"`
+	Attachments []*Attachment  `json:"attachments"`
+	// Links from and to this ticket
+	Links []*TicketLink `json:"links"`
+	// The ticket this one is a sub-task of
+	Parent *Ticket `json:"parent,omitempty"`
+	// Sub-tasks of this ticket
+	Children []*Ticket `json:"children"`
+	Project  *Project  `json:"project,omitempty"`
+	// Values of the project's custom fields, ordered by key
+	CustomFields []*CustomFieldValue `json:"customFields"`
+	// When the ticket should be resolved (RFC 3339)
+	DueDate *string `json:"dueDate,omitempty"`
+	// Response and resolution deadlines; null when no SLA policy or due date applies
+	SLA *TicketSLA `json:"sla,omitempty"`
+	// IDs of the users watching the ticket, in the order they started watching
+	Watchers []string `json:"watchers"`
+	// Comments on the ticket, oldest first, including replies received by email
+	Comments []*Comment `json:"comments"`
+}
+
+// A typed link: the source ticket BLOCKS, DUPLICATES, RELATES_TO or is the PARENT_OF the target
+type TicketLink struct {
+	Type      TicketLinkType `json:"type"`
+	SourceID  string         `json:"sourceId"`
+	TargetID  string         `json:"targetId"`
+	Source    *Ticket        `json:"source"`
+	Target    *Ticket        `json:"target"`
+	CreatedAt string         `json:"createdAt"`
+}
+
+// The changes a bulk update makes to every ticket; omitted fields are left unchanged
+type TicketPatchInput struct {
+	Title        *string             `json:"title,omitempty"`
+	Description  *string             `json:"description,omitempty"`
+	Status       *TicketStatus       `json:"status,omitempty"`
+	Priority     *TicketPriority     `json:"priority,omitempty"`
+	AssigneeID   *string             `json:"assigneeId,omitempty"`
+	Tags         []string            `json:"tags,omitempty"`
+	CustomFields []*CustomFieldInput `json:"customFields,omitempty"`
+	// RFC 3339 date-time in the future; an empty string clears the due date
+	DueDate *string `json:"dueDate,omitempty"`
+}
+
+// Where a ticket stands against the SLA policy of its priority and its due date
+type TicketSLA struct {
+	Status SLAStatus `json:"status"`
+	// The deadline the ticket is waiting on: the first response until the ticket leaves
+	// OPEN, then its resolution. Null once both are met.
+	DueAt    *string `json:"dueAt,omitempty"`
+	Breached bool    `json:"breached"`
+	// Seconds left until dueAt, negative once it has passed
+	Remaining          *int    `json:"remaining,omitempty"`
+	FirstResponseDueAt *string `json:"firstResponseDueAt,omitempty"`
+	// The earlier of the policy's resolution deadline and the due date
+	ResolutionDueAt  *string `json:"resolutionDueAt,omitempty"`
+	FirstRespondedAt *string `json:"firstRespondedAt,omitempty"`
+	ResolvedAt       *string `json:"resolvedAt,omitempty"`
 }
 
 type User struct {
@@ -34,6 +229,435 @@ type User struct {
 	Email string `json:"email"`
 }
 
+// A subscription POSTing signed JSON payloads of ticket events to a URL. Each request
+// carries an X-Webhook-Signature header: sha256= followed by the hex HMAC-SHA256 of
+// "<X-Webhook-Timestamp>.<body>" keyed with the webhook's secret.
+type Webhook struct {
+	ID     string         `json:"id"`
+	URL    string         `json:"url"`
+	Events []WebhookEvent `json:"events"`
+	// Null when the webhook hears about every ticket
+	Filter *WebhookFilter `json:"filter,omitempty"`
+	// Inactive webhooks are not sent new events
+	Active bool `json:"active"`
+	// The signing secret; only returned by createWebhook
+	Secret    *string `json:"secret,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+	// Deliveries of the webhook, newest first; status DEAD lists the dead letters
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
+
+// One event on its way to one webhook
+type WebhookDelivery struct {
+	ID        string       `json:"id"`
+	WebhookID string       `json:"webhookId"`
+	EventID   string       `json:"eventId"`
+	Event     WebhookEvent `json:"event"`
+	// The JSON body POSTed to the webhook
+	Payload       string                `json:"payload"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	NextAttemptAt *string               `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt *string               `json:"lastAttemptAt,omitempty"`
+	// HTTP status of the last attempt; null when it got no response
+	LastStatusCode *int    `json:"lastStatusCode,omitempty"`
+	LastError      *string `json:"lastError,omitempty"`
+	CreatedAt      string  `json:"createdAt"`
+	DeliveredAt    *string `json:"deliveredAt,omitempty"`
+}
+
+// Narrows the tickets a webhook hears about; a ticket must match every condition set
+type WebhookFilter struct {
+	ProjectID *string  `json:"projectId,omitempty"`
+	Project   *Project `json:"project,omitempty"`
+	// The ticket carries all of these tags
+	Tags []string `json:"tags"`
+	// The ticket has one of these priorities
+	Priorities []TicketPriority `json:"priorities"`
+}
+
+type WebhookFilterInput struct {
+	ProjectKey *string          `json:"projectKey,omitempty"`
+	Tags       []string         `json:"tags,omitempty"`
+	Priorities []TicketPriority `json:"priorities,omitempty"`
+}
+
+type CacheControlScope string
+
+const (
+	CacheControlScopePublic  CacheControlScope = "PUBLIC"
+	CacheControlScopePrivate CacheControlScope = "PRIVATE"
+)
+
+var AllCacheControlScope = []CacheControlScope{
+	CacheControlScopePublic,
+	CacheControlScopePrivate,
+}
+
+func (e CacheControlScope) IsValid() bool {
+	switch e {
+	case CacheControlScopePublic, CacheControlScopePrivate:
+		return true
+	}
+	return false
+}
+
+func (e CacheControlScope) String() string {
+	return string(e)
+}
+
+func (e *CacheControlScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CacheControlScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CacheControlScope", str)
+	}
+	return nil
+}
+
+func (e CacheControlScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CacheControlScope) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CacheControlScope) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText CustomFieldType = "TEXT"
+	// Decimal number, stored without trailing zeros (e.g. 2.5)
+	CustomFieldTypeNumber CustomFieldType = "NUMBER"
+	// One of the field's options
+	CustomFieldTypeEnum CustomFieldType = "ENUM"
+	// Calendar date as YYYY-MM-DD
+	CustomFieldTypeDate CustomFieldType = "DATE"
+	// User ID
+	CustomFieldTypeUser CustomFieldType = "USER"
+)
+
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeText,
+	CustomFieldTypeNumber,
+	CustomFieldTypeEnum,
+	CustomFieldTypeDate,
+	CustomFieldTypeUser,
+}
+
+func (e CustomFieldType) IsValid() bool {
+	switch e {
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeEnum, CustomFieldTypeDate, CustomFieldTypeUser:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CustomFieldType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CustomFieldType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// How notifications are sent outside the inbox
+type NotificationDelivery string
+
+const (
+	// Each notification is sent as it happens
+	NotificationDeliveryImmediate NotificationDelivery = "IMMEDIATE"
+	// Notifications are batched into one message a day, at digestHour
+	NotificationDeliveryDailyDigest NotificationDelivery = "DAILY_DIGEST"
+	// Notifications only reach the inbox
+	NotificationDeliveryOff NotificationDelivery = "OFF"
+)
+
+var AllNotificationDelivery = []NotificationDelivery{
+	NotificationDeliveryImmediate,
+	NotificationDeliveryDailyDigest,
+	NotificationDeliveryOff,
+}
+
+func (e NotificationDelivery) IsValid() bool {
+	switch e {
+	case NotificationDeliveryImmediate, NotificationDeliveryDailyDigest, NotificationDeliveryOff:
+		return true
+	}
+	return false
+}
+
+func (e NotificationDelivery) String() string {
+	return string(e)
+}
+
+func (e *NotificationDelivery) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationDelivery(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationDelivery", str)
+	}
+	return nil
+}
+
+func (e NotificationDelivery) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationDelivery) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationDelivery) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// What a notification tells its user about a ticket
+type NotificationKind string
+
+const (
+	// The ticket was assigned to the user
+	NotificationKindAssigned NotificationKind = "ASSIGNED"
+	// The ticket the user reported or watches moved to another status
+	NotificationKindStatusChanged NotificationKind = "STATUS_CHANGED"
+	// The ticket the user reported or watches was resolved or closed
+	NotificationKindResolved NotificationKind = "RESOLVED"
+	// The ticket the user watches was changed without a status change
+	NotificationKindUpdated NotificationKind = "UPDATED"
+	// The ticket the user watches was deleted
+	NotificationKindDeleted NotificationKind = "DELETED"
+	// The ticket assigned to or watched by the user is at risk of missing its SLA
+	NotificationKindSLAAtRisk NotificationKind = "SLA_AT_RISK"
+	// The ticket assigned to or watched by the user missed its SLA
+	NotificationKindSLABreached NotificationKind = "SLA_BREACHED"
+)
+
+var AllNotificationKind = []NotificationKind{
+	NotificationKindAssigned,
+	NotificationKindStatusChanged,
+	NotificationKindResolved,
+	NotificationKindUpdated,
+	NotificationKindDeleted,
+	NotificationKindSLAAtRisk,
+	NotificationKindSLABreached,
+}
+
+func (e NotificationKind) IsValid() bool {
+	switch e {
+	case NotificationKindAssigned, NotificationKindStatusChanged, NotificationKindResolved, NotificationKindUpdated, NotificationKindDeleted, NotificationKindSLAAtRisk, NotificationKindSLABreached:
+		return true
+	}
+	return false
+}
+
+func (e NotificationKind) String() string {
+	return string(e)
+}
+
+func (e *NotificationKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationKind", str)
+	}
+	return nil
+}
+
+func (e NotificationKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SLAStatus string
+
+const (
+	SLAStatusOnTrack SLAStatus = "ON_TRACK"
+	// Less than the at-risk share of the pending deadline's window is left
+	SLAStatusAtRisk SLAStatus = "AT_RISK"
+	// A deadline was missed; a breached ticket stays breached
+	SLAStatusBreached SLAStatus = "BREACHED"
+	// Every deadline was met
+	SLAStatusMet SLAStatus = "MET"
+)
+
+var AllSLAStatus = []SLAStatus{
+	SLAStatusOnTrack,
+	SLAStatusAtRisk,
+	SLAStatusBreached,
+	SLAStatusMet,
+}
+
+func (e SLAStatus) IsValid() bool {
+	switch e {
+	case SLAStatusOnTrack, SLAStatusAtRisk, SLAStatusBreached, SLAStatusMet:
+		return true
+	}
+	return false
+}
+
+func (e SLAStatus) String() string {
+	return string(e)
+}
+
+func (e *SLAStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SLAStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SLAStatus", str)
+	}
+	return nil
+}
+
+func (e SLAStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SLAStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SLAStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TicketLinkType string
+
+const (
+	TicketLinkTypeBlocks     TicketLinkType = "BLOCKS"
+	TicketLinkTypeDuplicates TicketLinkType = "DUPLICATES"
+	TicketLinkTypeRelatesTo  TicketLinkType = "RELATES_TO"
+	TicketLinkTypeParentOf   TicketLinkType = "PARENT_OF"
+)
+
+var AllTicketLinkType = []TicketLinkType{
+	TicketLinkTypeBlocks,
+	TicketLinkTypeDuplicates,
+	TicketLinkTypeRelatesTo,
+	TicketLinkTypeParentOf,
+}
+
+func (e TicketLinkType) IsValid() bool {
+	switch e {
+	case TicketLinkTypeBlocks, TicketLinkTypeDuplicates, TicketLinkTypeRelatesTo, TicketLinkTypeParentOf:
+		return true
+	}
+	return false
+}
+
+func (e TicketLinkType) String() string {
+	return string(e)
+}
+
+func (e *TicketLinkType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TicketLinkType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TicketLinkType", str)
+	}
+	return nil
+}
+
+func (e TicketLinkType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TicketLinkType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TicketLinkType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type TicketPriority string
 
 const (
@@ -151,3 +775,127 @@ func (e TicketStatus) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+type WebhookDeliveryStatus string
+
+const (
+	// Waiting for its next attempt
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// Gave up after the last attempt failed; redeliverWebhook queues it again
+	WebhookDeliveryStatusDead WebhookDeliveryStatus = "DEAD"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusDead,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Ticket events a webhook can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookEventTicketCreated WebhookEvent = "TICKET_CREATED"
+	WebhookEventTicketUpdated WebhookEvent = "TICKET_UPDATED"
+	// Sent after TICKET_UPDATED when an update changes the ticket's status
+	WebhookEventTicketTransitioned WebhookEvent = "TICKET_TRANSITIONED"
+	WebhookEventTicketDeleted      WebhookEvent = "TICKET_DELETED"
+	WebhookEventTicketSLAAtRisk    WebhookEvent = "TICKET_SLA_AT_RISK"
+	WebhookEventTicketSLABreached  WebhookEvent = "TICKET_SLA_BREACHED"
+)
+
+var AllWebhookEvent = []WebhookEvent{
+	WebhookEventTicketCreated,
+	WebhookEventTicketUpdated,
+	WebhookEventTicketTransitioned,
+	WebhookEventTicketDeleted,
+	WebhookEventTicketSLAAtRisk,
+	WebhookEventTicketSLABreached,
+}
+
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookEventTicketCreated, WebhookEventTicketUpdated, WebhookEventTicketTransitioned, WebhookEventTicketDeleted, WebhookEventTicketSLAAtRisk, WebhookEventTicketSLABreached:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEvent) String() string {
+	return string(e)
+}
+
+func (e *WebhookEvent) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEvent", str)
+	}
+	return nil
+}
+
+func (e WebhookEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookEvent) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookEvent) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -7,21 +7,22 @@ package graphql
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"log"
 
+	"github.com/ayush-pandya/Graphql/internal/attachments"
 	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Database interface defines methods our resolvers need
-type Database interface {
-	CreateTicket(ctx context.Context, ticket *Ticket) error
-	GetTicket(ctx context.Context, id string) (*Ticket, error)
-	// Add other database methods as needed
-}
-
 // Resolver holds dependencies for GraphQL resolvers
 type Resolver struct {
-	db           *sql.DB
-	ticketClient *clients.TicketClient
+	db               *sql.DB
+	ticketClient     clients.TicketService
+	attachmentSigner *attachments.Signer
 }
 
 // NewResolver creates a new GraphQL resolver
@@ -32,9 +33,125 @@ func NewResolver(db *sql.DB) *Resolver {
 }
 
 // NewResolverWithGRPC creates a new GraphQL resolver with gRPC clients
-func NewResolverWithGRPC(db *sql.DB, ticketClient *clients.TicketClient) *Resolver {
+func NewResolverWithGRPC(db *sql.DB, ticketClient clients.TicketService) *Resolver {
 	return &Resolver{
 		db:           db,
 		ticketClient: ticketClient,
 	}
 }
+
+// WithAttachmentSigner sets the signer used to build attachment download URLs
+func (r *Resolver) WithAttachmentSigner(signer *attachments.Signer) *Resolver {
+	r.attachmentSigner = signer
+	return r
+}
+
+// withIdempotencyKey makes the ticket service calls of a mutation carry its
+// idempotencyKey argument, which takes precedence over the Idempotency-Key header
+func withIdempotencyKey(ctx context.Context, key *string) context.Context {
+	if key == nil || *key == "" {
+		return ctx
+	}
+	return idempotency.WithKey(ctx, *key)
+}
+
+// mergeTags replaces the source tags with target through the ticket service
+func (r *Resolver) mergeTags(ctx context.Context, sources []string, target string) (int, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return 0, fmt.Errorf("ticket service is not available")
+	}
+
+	changed, err := r.ticketClient.MergeTags(ctx, sources, target)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC MergeTags: %v", err)
+		return 0, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	log.Printf("GraphQL Gateway: Successfully merged tags into %q on %d tickets", target, changed)
+	return int(changed), nil
+}
+
+// listLinks lists the links of a ticket through the ticket service
+func (r *Resolver) listLinks(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	links, err := r.ticketClient.ListLinks(ctx, ticketID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListLinks: %v", err)
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	return links, nil
+}
+
+// linkedTicket loads the ticket at the other end of a link
+func (r *Resolver) linkedTicket(ctx context.Context, id string) (*Ticket, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcTicket, err := r.ticketClient.GetTicket(ctx, id)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC GetTicket: %v", err)
+		return nil, fmt.Errorf("failed to get linked ticket: %w", err)
+	}
+	return convertGRPCTicketToGraphQL(grpcTicket), nil
+}
+
+// listTickets lists the newest tickets, optionally of a single project and
+// with the given custom field values
+func (r *Resolver) listTickets(ctx context.Context, first *int, projectKey string, customFields map[string]string) ([]*Ticket, error) {
+	log.Println("GraphQL Gateway: Listing tickets via gRPC")
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	pageSize := 100
+	if first != nil {
+		if *first < 1 || *first > 100 {
+			return nil, fmt.Errorf("first must be between 1 and 100")
+		}
+		pageSize = *first
+	}
+
+	// Call gRPC service
+	grpcTickets, _, err := r.ticketClient.ListTickets(ctx, int32(pageSize), "", projectKey, customFields)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListTickets: %v", err)
+		return nil, fmt.Errorf("failed to list tickets: %w", err)
+	}
+
+	// Convert gRPC response to GraphQL
+	tickets := make([]*Ticket, len(grpcTickets))
+	for i, grpcTicket := range grpcTickets {
+		tickets[i] = convertGRPCTicketToGraphQL(grpcTicket)
+	}
+
+	log.Printf("GraphQL Gateway: Successfully listed %d tickets via gRPC", len(tickets))
+	return tickets, nil
+}
+
+// project loads a project by ID, or by key when id is empty, returning nil
+// when it does not exist
+func (r *Resolver) project(ctx context.Context, id, key string) (*Project, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcProject, err := r.ticketClient.GetProject(ctx, id, key)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		log.Printf("GraphQL Gateway: Error calling gRPC GetProject: %v", err)
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return convertGRPCProjectToGraphQL(grpcProject), nil
+}
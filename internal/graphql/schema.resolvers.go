@@ -8,100 +8,17 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
+	"strings"
 
+	"github.com/99designs/gqlgen/graphql"
 	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Helper function to convert gRPC ticket to GraphQL ticket
-func convertGRPCTicketToGraphQL(grpcTicket *ticketpb.Ticket) *Ticket {
-	if grpcTicket == nil {
-		return nil
-	}
-	fmt.Println(grpcTicket)
-	// Convert gRPC enums to GraphQL enums
-	var status TicketStatus
-	switch grpcTicket.Status {
-	case ticketpb.TicketStatus_TICKET_STATUS_OPEN:
-		status = TicketStatusOpen
-	case ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS:
-		status = TicketStatusInProgress
-	case ticketpb.TicketStatus_TICKET_STATUS_RESOLVED:
-		status = TicketStatusResolved
-	case ticketpb.TicketStatus_TICKET_STATUS_CLOSED:
-		status = TicketStatusClosed
-	default:
-		status = TicketStatusOpen
-	}
-
-	var priority TicketPriority
-	switch grpcTicket.Priority {
-	case ticketpb.TicketPriority_TICKET_PRIORITY_LOW:
-		priority = TicketPriorityLow
-	case ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM:
-		priority = TicketPriorityMedium
-	case ticketpb.TicketPriority_TICKET_PRIORITY_HIGH:
-		priority = TicketPriorityHigh
-	case ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL:
-		priority = TicketPriorityCritical
-	default:
-		priority = TicketPriorityMedium
-	}
-
-	return &Ticket{
-		ID:          grpcTicket.Id,
-		Title:       grpcTicket.Title,
-		Description: &grpcTicket.Description,
-		Status:      status,
-		Priority:    priority,
-		Tags:        convertStringSliceToPointerSlice(grpcTicket.Tags),
-		CreatedAt:   grpcTicket.CreatedAt.AsTime().Format(time.RFC3339),
-		UpdatedAt:   grpcTicket.UpdatedAt.AsTime().Format(time.RFC3339),
-	}
-}
-
-// Helper function to convert GraphQL enums to gRPC enums
-func convertGraphQLPriorityToGRPC(priority *TicketPriority) ticketpb.TicketPriority {
-	if priority == nil {
-		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
-	}
-
-	switch *priority {
-	case TicketPriorityLow:
-		return ticketpb.TicketPriority_TICKET_PRIORITY_LOW
-	case TicketPriorityMedium:
-		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
-	case TicketPriorityHigh:
-		return ticketpb.TicketPriority_TICKET_PRIORITY_HIGH
-	case TicketPriorityCritical:
-		return ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL
-	default:
-		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
-	}
-}
-
-// Helper function to convert string slice to pointer slice
-func convertStringSliceToPointerSlice(slice []string) []*string {
-	result := make([]*string, len(slice))
-	for i, s := range slice {
-		result[i] = &s
-	}
-	return result
-}
-
-// Helper function to convert pointer slice to string slice
-func convertPointerSliceToStringSlice(slice []*string) []string {
-	result := make([]string, 0, len(slice))
-	for _, s := range slice {
-		if s != nil {
-			result = append(result, *s)
-		}
-	}
-	return result
-}
-
 // CreateTicket is the resolver for the createTicket field.
-func (r *mutationResolver) CreateTicket(ctx context.Context, title string, description *string, priority *TicketPriority, status *string, assigneeID string, tags []*string) (*Ticket, error) {
+func (r *mutationResolver) CreateTicket(ctx context.Context, title string, description *string, priority *TicketPriority, assigneeID *string, reporterID string, tags []*string, projectKey *string, customFields []*CustomFieldInput, dueDate *string, idempotencyKey *string) (*Ticket, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
 	log.Printf("GraphQL Gateway: Creating ticket via gRPC - Title: %s", title)
 
 	// Check if gRPC client is available
@@ -115,11 +32,29 @@ func (r *mutationResolver) CreateTicket(ctx context.Context, title string, descr
 		desc = *description
 	}
 
+	assignee := ""
+	if assigneeID != nil {
+		assignee = *assigneeID
+	}
+
 	grpcPriority := convertGraphQLPriorityToGRPC(priority)
 	grpcTags := convertPointerSliceToStringSlice(tags)
 
+	project := ""
+	if projectKey != nil {
+		project = *projectKey
+	}
+
+	due, clearDue, err := parseDueDate(dueDate)
+	if err != nil {
+		return nil, err
+	}
+	if clearDue {
+		return nil, fmt.Errorf("dueDate must not be empty")
+	}
+
 	// Call gRPC service
-	grpcTicket, err := r.ticketClient.CreateTicket(ctx, title, desc, grpcPriority, assigneeID, grpcTags)
+	grpcTicket, err := r.ticketClient.CreateTicket(ctx, title, desc, grpcPriority, assignee, reporterID, grpcTags, project, convertCustomFieldInputs(customFields), due)
 	if err != nil {
 		log.Printf("GraphQL Gateway: Error calling gRPC CreateTicket: %v", err)
 		return nil, fmt.Errorf("failed to create ticket: %w", err)
@@ -133,7 +68,8 @@ func (r *mutationResolver) CreateTicket(ctx context.Context, title string, descr
 }
 
 // UpdateTicket is the resolver for the updateTicket field.
-func (r *mutationResolver) UpdateTicket(ctx context.Context, id string, title *string, description *string, status *TicketStatus, priority *TicketPriority, assigneeID *string, tags []*string) (*Ticket, error) {
+func (r *mutationResolver) UpdateTicket(ctx context.Context, id string, title *string, description *string, status *TicketStatus, priority *TicketPriority, assigneeID *string, tags []*string, customFields []*CustomFieldInput, dueDate *string, idempotencyKey *string) (*Ticket, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
 	log.Printf("GraphQL Gateway: Updating ticket via gRPC - ID: %s", id)
 
 	// Check if gRPC client is available
@@ -157,26 +93,22 @@ func (r *mutationResolver) UpdateTicket(ctx context.Context, id string, title *s
 		assigneeStr = *assigneeID
 	}
 
-	grpcPriority := convertGraphQLPriorityToGRPC(priority)
+	// Omitted status and priority are left unchanged
+	grpcPriority := ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+	if priority != nil {
+		grpcPriority = convertGraphQLPriorityToGRPC(priority)
+	}
 	grpcTags := convertPointerSliceToStringSlice(tags)
 
-	// For status, we need to convert it (simplified for now)
-	grpcStatus := ticketpb.TicketStatus_TICKET_STATUS_OPEN
-	if status != nil {
-		switch *status {
-		case TicketStatusOpen:
-			grpcStatus = ticketpb.TicketStatus_TICKET_STATUS_OPEN
-		case TicketStatusInProgress:
-			grpcStatus = ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
-		case TicketStatusResolved:
-			grpcStatus = ticketpb.TicketStatus_TICKET_STATUS_RESOLVED
-		case TicketStatusClosed:
-			grpcStatus = ticketpb.TicketStatus_TICKET_STATUS_CLOSED
-		}
+	grpcStatus := convertGraphQLStatusToGRPC(status)
+
+	due, clearDue, err := parseDueDate(dueDate)
+	if err != nil {
+		return nil, err
 	}
 
 	// Call gRPC service
-	grpcTicket, err := r.ticketClient.UpdateTicket(ctx, id, titleStr, descStr, grpcStatus, grpcPriority, assigneeStr, grpcTags)
+	grpcTicket, err := r.ticketClient.UpdateTicket(ctx, id, titleStr, descStr, grpcStatus, grpcPriority, assigneeStr, grpcTags, convertCustomFieldInputs(customFields), due, clearDue)
 	if err != nil {
 		log.Printf("GraphQL Gateway: Error calling gRPC UpdateTicket: %v", err)
 		return nil, fmt.Errorf("failed to update ticket: %w", err)
@@ -190,7 +122,8 @@ func (r *mutationResolver) UpdateTicket(ctx context.Context, id string, title *s
 }
 
 // DeleteTicket is the resolver for the deleteTicket field.
-func (r *mutationResolver) DeleteTicket(ctx context.Context, id string) (*bool, error) {
+func (r *mutationResolver) DeleteTicket(ctx context.Context, id string, idempotencyKey *string) (*bool, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
 	log.Printf("GraphQL Gateway: Deleting ticket via gRPC - ID: %s", id)
 
 	// Check if gRPC client is available
@@ -209,35 +142,107 @@ func (r *mutationResolver) DeleteTicket(ctx context.Context, id string) (*bool,
 	return &success, nil
 }
 
-// Tickets is the resolver for the tickets field.
-func (r *queryResolver) Tickets(ctx context.Context) ([]*Ticket, error) {
-	log.Println("GraphQL Gateway: Listing tickets via gRPC")
+// BulkUpdateTickets is the resolver for the bulkUpdateTickets field.
+func (r *mutationResolver) BulkUpdateTickets(ctx context.Context, ids []string, patch TicketPatchInput, atomic *bool, idempotencyKey *string) (*BulkTicketsPayload, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Bulk updating %d tickets via gRPC", len(ids))
+
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcPatch, err := convertGraphQLPatchToGRPC(patch)
+	if err != nil {
+		return nil, err
+	}
+	results, err := r.ticketClient.BulkUpdateTickets(ctx, ids, grpcPatch, atomic != nil && *atomic)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC BulkUpdateTickets: %v", err)
+		return nil, fmt.Errorf("failed to bulk update tickets: %w", err)
+	}
+
+	payload := convertGRPCBulkResultsToGraphQL(results)
+	log.Printf("GraphQL Gateway: Bulk updated %d of %d tickets via gRPC", payload.Succeeded, len(ids))
+	return payload, nil
+}
+
+// BulkTransitionTickets is the resolver for the bulkTransitionTickets field.
+func (r *mutationResolver) BulkTransitionTickets(ctx context.Context, ids []string, status TicketStatus, atomic *bool, idempotencyKey *string) (*BulkTicketsPayload, error) {
+	return r.BulkUpdateTickets(ctx, ids, TicketPatchInput{Status: &status}, atomic, idempotencyKey)
+}
+
+// BulkDeleteTickets is the resolver for the bulkDeleteTickets field.
+func (r *mutationResolver) BulkDeleteTickets(ctx context.Context, ids []string, atomic *bool, idempotencyKey *string) (*BulkTicketsPayload, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Bulk deleting %d tickets via gRPC", len(ids))
+
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	results, err := r.ticketClient.BulkDeleteTickets(ctx, ids, atomic != nil && *atomic)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC BulkDeleteTickets: %v", err)
+		return nil, fmt.Errorf("failed to bulk delete tickets: %w", err)
+	}
+
+	payload := convertGRPCBulkResultsToGraphQL(results)
+	log.Printf("GraphQL Gateway: Bulk deleted %d of %d tickets via gRPC", payload.Succeeded, len(ids))
+	return payload, nil
+}
+
+// CreateProject is the resolver for the createProject field.
+func (r *mutationResolver) CreateProject(ctx context.Context, key string, name string, description *string, idempotencyKey *string) (*Project, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Creating project via gRPC - Key: %s", key)
 
 	// Check if gRPC client is available
 	if r.ticketClient == nil {
 		return nil, fmt.Errorf("ticket service is not available")
 	}
 
+	desc := ""
+	if description != nil {
+		desc = *description
+	}
+
 	// Call gRPC service
-	grpcTickets, _, err := r.ticketClient.ListTickets(ctx, 100, "") // Get first 100
+	grpcProject, err := r.ticketClient.CreateProject(ctx, key, name, desc)
 	if err != nil {
-		log.Printf("GraphQL Gateway: Error calling gRPC ListTickets: %v", err)
-		return nil, fmt.Errorf("failed to list tickets: %w", err)
+		log.Printf("GraphQL Gateway: Error calling gRPC CreateProject: %v", err)
+		return nil, fmt.Errorf("failed to create project: %w", err)
 	}
 
-	// Convert gRPC response to GraphQL
-	tickets := make([]*Ticket, len(grpcTickets))
-	for i, grpcTicket := range grpcTickets {
-		tickets[i] = convertGRPCTicketToGraphQL(grpcTicket)
+	return convertGRPCProjectToGraphQL(grpcProject), nil
+}
+
+// SetCustomFields is the resolver for the setCustomFields field.
+func (r *mutationResolver) SetCustomFields(ctx context.Context, projectKey string, fields []*CustomFieldDefinitionInput) (*Project, error) {
+	log.Printf("GraphQL Gateway: Setting custom fields via gRPC - Project: %s", projectKey)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
 	}
 
-	log.Printf("GraphQL Gateway: Successfully listed %d tickets via gRPC", len(tickets))
-	return tickets, nil
+	definitions := make([]*ticketpb.CustomFieldDefinition, len(fields))
+	for i, field := range fields {
+		definitions[i] = convertGraphQLCustomFieldDefinitionToGRPC(field)
+	}
+
+	// Call gRPC service
+	grpcProject, err := r.ticketClient.SetCustomFields(ctx, projectKey, definitions)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC SetCustomFields: %v", err)
+		return nil, fmt.Errorf("failed to set custom fields: %w", err)
+	}
+
+	return convertGRPCProjectToGraphQL(grpcProject), nil
 }
 
-// Ticket is the resolver for the ticket field.
-func (r *queryResolver) Ticket(ctx context.Context, id string) (*Ticket, error) {
-	log.Printf("GraphQL Gateway: Getting ticket via gRPC - ID: %s", id)
+// AddAttachment is the resolver for the addAttachment field.
+func (r *mutationResolver) AddAttachment(ctx context.Context, ticketID string, file graphql.Upload) (*Attachment, error) {
+	log.Printf("GraphQL Gateway: Uploading attachment via gRPC - Ticket: %s, Filename: %s", ticketID, file.Filename)
 
 	// Check if gRPC client is available
 	if r.ticketClient == nil {
@@ -245,7 +250,323 @@ func (r *queryResolver) Ticket(ctx context.Context, id string) (*Ticket, error)
 	}
 
 	// Call gRPC service
-	grpcTicket, err := r.ticketClient.GetTicket(ctx, id)
+	grpcAttachment, err := r.ticketClient.UploadAttachment(ctx, ticketID, file.Filename, file.ContentType, file.File)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC UploadAttachment: %v", err)
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	log.Printf("GraphQL Gateway: Successfully uploaded attachment via gRPC - ID: %s", grpcAttachment.Id)
+	return r.convertGRPCAttachmentToGraphQL(ctx, grpcAttachment), nil
+}
+
+// LinkTickets is the resolver for the linkTickets field.
+func (r *mutationResolver) LinkTickets(ctx context.Context, sourceID string, targetID string, typeArg TicketLinkType, idempotencyKey *string) (*TicketLink, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Linking tickets via gRPC - %s %s %s", sourceID, typeArg, targetID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcLink, err := r.ticketClient.LinkTickets(ctx, sourceID, targetID, convertGraphQLLinkTypeToGRPC(typeArg))
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC LinkTickets: %v", err)
+		return nil, fmt.Errorf("failed to link tickets: %w", err)
+	}
+
+	return convertGRPCLinkToGraphQL(grpcLink), nil
+}
+
+// UnlinkTickets is the resolver for the unlinkTickets field.
+func (r *mutationResolver) UnlinkTickets(ctx context.Context, sourceID string, targetID string, typeArg TicketLinkType, idempotencyKey *string) (bool, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Unlinking tickets via gRPC - %s %s %s", sourceID, typeArg, targetID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return false, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	success, err := r.ticketClient.UnlinkTickets(ctx, sourceID, targetID, convertGraphQLLinkTypeToGRPC(typeArg))
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC UnlinkTickets: %v", err)
+		return false, fmt.Errorf("failed to unlink tickets: %w", err)
+	}
+
+	return success, nil
+}
+
+// RenameTag is the resolver for the renameTag field.
+func (r *mutationResolver) RenameTag(ctx context.Context, from string, to string, idempotencyKey *string) (int, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Renaming tag via gRPC - %q to %q", from, to)
+	return r.mergeTags(ctx, []string{from}, to)
+}
+
+// MergeTags is the resolver for the mergeTags field.
+func (r *mutationResolver) MergeTags(ctx context.Context, sources []string, target string, idempotencyKey *string) (int, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Merging tags via gRPC - %v into %q", sources, target)
+	return r.mergeTags(ctx, sources, target)
+}
+
+// CreateWebhook is the resolver for the createWebhook field.
+func (r *mutationResolver) CreateWebhook(ctx context.Context, url string, events []WebhookEvent, secret *string, filter *WebhookFilterInput, idempotencyKey *string) (*Webhook, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Creating webhook via gRPC - URL: %s", url)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	webhookSecret := ""
+	if secret != nil {
+		webhookSecret = *secret
+	}
+
+	// Call gRPC service
+	grpcWebhook, err := r.ticketClient.CreateWebhook(ctx, url, webhookSecret, convertGraphQLEventsToGRPC(events), convertGraphQLWebhookFilterToGRPC(filter))
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC CreateWebhook: %v", err)
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return convertGRPCWebhookToGraphQL(grpcWebhook), nil
+}
+
+// UpdateWebhook is the resolver for the updateWebhook field.
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, id string, url *string, events []WebhookEvent, secret *string, filter *WebhookFilterInput, active *bool, idempotencyKey *string) (*Webhook, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Updating webhook via gRPC - ID: %s", id)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	webhookURL := ""
+	if url != nil {
+		webhookURL = *url
+	}
+	webhookSecret := ""
+	if secret != nil {
+		webhookSecret = *secret
+	}
+
+	// Call gRPC service
+	grpcWebhook, err := r.ticketClient.UpdateWebhook(ctx, id, webhookURL, webhookSecret, convertGraphQLEventsToGRPC(events), convertGraphQLWebhookFilterToGRPC(filter), active)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC UpdateWebhook: %v", err)
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return convertGRPCWebhookToGraphQL(grpcWebhook), nil
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id string, idempotencyKey *string) (bool, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Deleting webhook via gRPC - ID: %s", id)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return false, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	success, err := r.ticketClient.DeleteWebhook(ctx, id)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC DeleteWebhook: %v", err)
+		return false, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return success, nil
+}
+
+// RedeliverWebhook is the resolver for the redeliverWebhook field.
+func (r *mutationResolver) RedeliverWebhook(ctx context.Context, deliveryID string, idempotencyKey *string) (*WebhookDelivery, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Redelivering webhook delivery via gRPC - ID: %s", deliveryID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcDelivery, err := r.ticketClient.RedeliverWebhook(ctx, deliveryID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC RedeliverWebhook: %v", err)
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+
+	return convertGRPCWebhookDeliveryToGraphQL(grpcDelivery), nil
+}
+
+// WatchTicket is the resolver for the watchTicket field.
+func (r *mutationResolver) WatchTicket(ctx context.Context, ticketID string, userID string) (*Ticket, error) {
+	log.Printf("GraphQL Gateway: Watching ticket via gRPC - ID: %s, User: %s", ticketID, userID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	if _, err := r.ticketClient.WatchTicket(ctx, ticketID, userID); err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC WatchTicket: %v", err)
+		return nil, fmt.Errorf("failed to watch ticket: %w", err)
+	}
+
+	return r.linkedTicket(ctx, ticketID)
+}
+
+// UnwatchTicket is the resolver for the unwatchTicket field.
+func (r *mutationResolver) UnwatchTicket(ctx context.Context, ticketID string, userID string) (bool, error) {
+	log.Printf("GraphQL Gateway: Unwatching ticket via gRPC - ID: %s, User: %s", ticketID, userID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return false, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	success, err := r.ticketClient.UnwatchTicket(ctx, ticketID, userID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC UnwatchTicket: %v", err)
+		return false, fmt.Errorf("failed to unwatch ticket: %w", err)
+	}
+
+	return success, nil
+}
+
+// MarkNotificationRead is the resolver for the markNotificationRead field.
+func (r *mutationResolver) MarkNotificationRead(ctx context.Context, id string) (*Notification, error) {
+	log.Printf("GraphQL Gateway: Marking notification read via gRPC - ID: %s", id)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcNotification, err := r.ticketClient.MarkNotificationRead(ctx, id)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC MarkNotificationRead: %v", err)
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	return convertGRPCNotificationToGraphQL(grpcNotification), nil
+}
+
+// AddComment is the resolver for the addComment field.
+func (r *mutationResolver) AddComment(ctx context.Context, ticketID string, authorID string, body string, idempotencyKey *string) (*Comment, error) {
+	ctx = withIdempotencyKey(ctx, idempotencyKey)
+	log.Printf("GraphQL Gateway: Adding comment via gRPC - Ticket: %s, Author: %s", ticketID, authorID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcComment, err := r.ticketClient.AddComment(ctx, ticketID, authorID, body)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC AddComment: %v", err)
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return convertGRPCCommentToGraphQL(grpcComment), nil
+}
+
+// UpdateNotificationPreferences is the resolver for the updateNotificationPreferences field.
+func (r *mutationResolver) UpdateNotificationPreferences(ctx context.Context, userID string, email *string, delivery *NotificationDelivery, digestHour *int, mutedKinds []NotificationKind) (*NotificationPreferences, error) {
+	log.Printf("GraphQL Gateway: Updating notification preferences via gRPC - User: %s", userID)
+
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcDelivery := ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_UNSPECIFIED
+	if delivery != nil {
+		grpcDelivery = ticketpb.NotificationDelivery(ticketpb.NotificationDelivery_value["NOTIFICATION_DELIVERY_"+string(*delivery)])
+	}
+	var grpcDigestHour *int32
+	if digestHour != nil {
+		hour := int32(*digestHour)
+		grpcDigestHour = &hour
+	}
+
+	// Call gRPC service; an explicit empty list unmutes every kind
+	grpcPreferences, err := r.ticketClient.UpdateNotificationPreferences(ctx, userID, email, grpcDelivery, grpcDigestHour,
+		convertGraphQLNotificationKindsToGRPC(mutedKinds), mutedKinds != nil)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC UpdateNotificationPreferences: %v", err)
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return convertGRPCNotificationPreferencesToGraphQL(grpcPreferences), nil
+}
+
+// Ticket is the resolver for the ticket field.
+func (r *notificationResolver) Ticket(ctx context.Context, obj *Notification) (*Ticket, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcTicket, err := r.ticketClient.GetTicket(ctx, obj.TicketID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		log.Printf("GraphQL Gateway: Error calling gRPC GetTicket: %v", err)
+		return nil, fmt.Errorf("failed to get notification ticket: %w", err)
+	}
+	return convertGRPCTicketToGraphQL(grpcTicket), nil
+}
+
+// Tickets is the resolver for the tickets field.
+func (r *projectResolver) Tickets(ctx context.Context, obj *Project, first *int) ([]*Ticket, error) {
+	return r.listTickets(ctx, first, obj.Key, nil)
+}
+
+// Tickets is the resolver for the tickets field.
+func (r *queryResolver) Tickets(ctx context.Context, first *int, project *string, customFields []*CustomFieldInput) ([]*Ticket, error) {
+	projectKey := ""
+	if project != nil {
+		projectKey = *project
+	}
+	return r.listTickets(ctx, first, projectKey, convertCustomFieldInputs(customFields))
+}
+
+// Ticket is the resolver for the ticket field.
+func (r *queryResolver) Ticket(ctx context.Context, id *string, key *string) (*Ticket, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	var (
+		grpcTicket *ticketpb.Ticket
+		err        error
+	)
+	switch {
+	case id != nil && key == nil:
+		log.Printf("GraphQL Gateway: Getting ticket via gRPC - ID: %s", *id)
+		grpcTicket, err = r.ticketClient.GetTicket(ctx, *id)
+	case key != nil && id == nil:
+		log.Printf("GraphQL Gateway: Getting ticket via gRPC - Key: %s", *key)
+		grpcTicket, err = r.ticketClient.GetTicketByKey(ctx, *key)
+	default:
+		return nil, fmt.Errorf("exactly one of id and key is required")
+	}
 	if err != nil {
 		log.Printf("GraphQL Gateway: Error calling gRPC GetTicket: %v", err)
 		return nil, fmt.Errorf("failed to get ticket: %w", err)
@@ -258,11 +579,350 @@ func (r *queryResolver) Ticket(ctx context.Context, id string) (*Ticket, error)
 	return ticket, nil
 }
 
+// Project is the resolver for the project field.
+func (r *queryResolver) Project(ctx context.Context, key string) (*Project, error) {
+	return r.project(ctx, "", key)
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context) ([]*Project, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcProjects, err := r.ticketClient.ListProjects(ctx)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListProjects: %v", err)
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	projects := make([]*Project, len(grpcProjects))
+	for i, grpcProject := range grpcProjects {
+		projects[i] = convertGRPCProjectToGraphQL(grpcProject)
+	}
+	return projects, nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context, prefix *string, first *int) ([]*TagCount, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	prefixStr := ""
+	if prefix != nil {
+		prefixStr = *prefix
+	}
+
+	limit := 20
+	if first != nil {
+		if *first < 1 || *first > 100 {
+			return nil, fmt.Errorf("first must be between 1 and 100")
+		}
+		limit = *first
+	}
+
+	// Call gRPC service
+	grpcTags, err := r.ticketClient.ListTags(ctx, prefixStr, int32(limit))
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListTags: %v", err)
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make([]*TagCount, len(grpcTags))
+	for i, grpcTag := range grpcTags {
+		tags[i] = &TagCount{Name: grpcTag.Name, Count: int(grpcTag.Count)}
+	}
+	return tags, nil
+}
+
+// SLAPolicies is the resolver for the slaPolicies field.
+func (r *queryResolver) SLAPolicies(ctx context.Context) ([]*SLAPolicy, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcPolicies, err := r.ticketClient.ListSLAPolicies(ctx)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListSLAPolicies: %v", err)
+		return nil, fmt.Errorf("failed to list SLA policies: %w", err)
+	}
+
+	policies := make([]*SLAPolicy, len(grpcPolicies))
+	for i, policy := range grpcPolicies {
+		policies[i] = convertGRPCSLAPolicyToGraphQL(policy)
+	}
+	return policies, nil
+}
+
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) Webhooks(ctx context.Context) ([]*Webhook, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcWebhooks, err := r.ticketClient.ListWebhooks(ctx)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListWebhooks: %v", err)
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]*Webhook, len(grpcWebhooks))
+	for i, grpcWebhook := range grpcWebhooks {
+		webhooks[i] = convertGRPCWebhookToGraphQL(grpcWebhook)
+	}
+	return webhooks, nil
+}
+
+// Notifications is the resolver for the notifications field.
+func (r *queryResolver) Notifications(ctx context.Context, userID string, unreadOnly *bool, first *int) ([]*Notification, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	limit := int32(50)
+	if first != nil {
+		limit = int32(*first)
+	}
+
+	// Call gRPC service
+	grpcNotifications, err := r.ticketClient.ListNotifications(ctx, userID, unreadOnly != nil && *unreadOnly, limit)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListNotifications: %v", err)
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notifications := make([]*Notification, len(grpcNotifications))
+	for i, grpcNotification := range grpcNotifications {
+		notifications[i] = convertGRPCNotificationToGraphQL(grpcNotification)
+	}
+	return notifications, nil
+}
+
+// NotificationPreferences is the resolver for the notificationPreferences field.
+func (r *queryResolver) NotificationPreferences(ctx context.Context, userID string) (*NotificationPreferences, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcPreferences, err := r.ticketClient.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC GetNotificationPreferences: %v", err)
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return convertGRPCNotificationPreferencesToGraphQL(grpcPreferences), nil
+}
+
+// Attachments is the resolver for the attachments field.
+func (r *ticketResolver) Attachments(ctx context.Context, obj *Ticket) ([]*Attachment, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcAttachments, err := r.ticketClient.ListAttachments(ctx, obj.ID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListAttachments: %v", err)
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	attachments := make([]*Attachment, len(grpcAttachments))
+	for i, grpcAttachment := range grpcAttachments {
+		attachments[i] = r.convertGRPCAttachmentToGraphQL(ctx, grpcAttachment)
+	}
+	return attachments, nil
+}
+
+// Links is the resolver for the links field.
+func (r *ticketResolver) Links(ctx context.Context, obj *Ticket) ([]*TicketLink, error) {
+	grpcLinks, err := r.listLinks(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*TicketLink, len(grpcLinks))
+	for i, grpcLink := range grpcLinks {
+		links[i] = convertGRPCLinkToGraphQL(grpcLink)
+	}
+	return links, nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *ticketResolver) Parent(ctx context.Context, obj *Ticket) (*Ticket, error) {
+	grpcLinks, err := r.listLinks(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, grpcLink := range grpcLinks {
+		if grpcLink.Type == ticketpb.LinkType_LINK_TYPE_PARENT_OF && grpcLink.TargetId == obj.ID {
+			return r.linkedTicket(ctx, grpcLink.SourceId)
+		}
+	}
+	return nil, nil
+}
+
+// Children is the resolver for the children field.
+func (r *ticketResolver) Children(ctx context.Context, obj *Ticket) ([]*Ticket, error) {
+	grpcLinks, err := r.listLinks(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*Ticket
+	for _, grpcLink := range grpcLinks {
+		if grpcLink.Type == ticketpb.LinkType_LINK_TYPE_PARENT_OF && grpcLink.SourceId == obj.ID {
+			child, err := r.linkedTicket(ctx, grpcLink.TargetId)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+	}
+	return children, nil
+}
+
+// Project is the resolver for the project field.
+func (r *ticketResolver) Project(ctx context.Context, obj *Ticket) (*Project, error) {
+	// Ticket keys are the project key followed by the ticket number
+	if obj.Key == nil {
+		return nil, nil
+	}
+	projectKey, _, found := strings.Cut(*obj.Key, "-")
+	if !found {
+		return nil, nil
+	}
+	return r.project(ctx, "", projectKey)
+}
+
+// Watchers is the resolver for the watchers field.
+func (r *ticketResolver) Watchers(ctx context.Context, obj *Ticket) ([]string, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	watchers, err := r.ticketClient.ListWatchers(ctx, obj.ID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListWatchers: %v", err)
+		return nil, fmt.Errorf("failed to list watchers: %w", err)
+	}
+	if watchers == nil {
+		watchers = []string{}
+	}
+	return watchers, nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *ticketResolver) Comments(ctx context.Context, obj *Ticket) ([]*Comment, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	// Call gRPC service
+	grpcComments, err := r.ticketClient.ListComments(ctx, obj.ID)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListComments: %v", err)
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	comments := make([]*Comment, len(grpcComments))
+	for i, grpcComment := range grpcComments {
+		comments[i] = convertGRPCCommentToGraphQL(grpcComment)
+	}
+	return comments, nil
+}
+
+// Source is the resolver for the source field.
+func (r *ticketLinkResolver) Source(ctx context.Context, obj *TicketLink) (*Ticket, error) {
+	return r.linkedTicket(ctx, obj.SourceID)
+}
+
+// Target is the resolver for the target field.
+func (r *ticketLinkResolver) Target(ctx context.Context, obj *TicketLink) (*Ticket, error) {
+	return r.linkedTicket(ctx, obj.TargetID)
+}
+
+// Deliveries is the resolver for the deliveries field.
+func (r *webhookResolver) Deliveries(ctx context.Context, obj *Webhook, status *WebhookDeliveryStatus, first *int) ([]*WebhookDelivery, error) {
+	// Check if gRPC client is available
+	if r.ticketClient == nil {
+		return nil, fmt.Errorf("ticket service is not available")
+	}
+
+	grpcStatus := ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+	if status != nil {
+		grpcStatus = ticketpb.WebhookDeliveryStatus(ticketpb.WebhookDeliveryStatus_value["WEBHOOK_DELIVERY_STATUS_"+string(*status)])
+	}
+	limit := int32(50)
+	if first != nil {
+		limit = int32(*first)
+	}
+
+	// Call gRPC service
+	grpcDeliveries, err := r.ticketClient.ListWebhookDeliveries(ctx, obj.ID, grpcStatus, limit)
+	if err != nil {
+		log.Printf("GraphQL Gateway: Error calling gRPC ListWebhookDeliveries: %v", err)
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*WebhookDelivery, len(grpcDeliveries))
+	for i, grpcDelivery := range grpcDeliveries {
+		deliveries[i] = convertGRPCWebhookDeliveryToGraphQL(grpcDelivery)
+	}
+	return deliveries, nil
+}
+
+// Project is the resolver for the project field.
+func (r *webhookFilterResolver) Project(ctx context.Context, obj *WebhookFilter) (*Project, error) {
+	if obj.ProjectID == nil {
+		return nil, nil
+	}
+	return r.project(ctx, *obj.ProjectID, "")
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Notification returns NotificationResolver implementation.
+func (r *Resolver) Notification() NotificationResolver { return &notificationResolver{r} }
+
+// Project returns ProjectResolver implementation.
+func (r *Resolver) Project() ProjectResolver { return &projectResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Ticket returns TicketResolver implementation.
+func (r *Resolver) Ticket() TicketResolver { return &ticketResolver{r} }
+
+// TicketLink returns TicketLinkResolver implementation.
+func (r *Resolver) TicketLink() TicketLinkResolver { return &ticketLinkResolver{r} }
+
+// Webhook returns WebhookResolver implementation.
+func (r *Resolver) Webhook() WebhookResolver { return &webhookResolver{r} }
+
+// WebhookFilter returns WebhookFilterResolver implementation.
+func (r *Resolver) WebhookFilter() WebhookFilterResolver { return &webhookFilterResolver{r} }
+
 type mutationResolver struct{ *Resolver }
+type notificationResolver struct{ *Resolver }
+type projectResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type ticketResolver struct{ *Resolver }
+type ticketLinkResolver struct{ *Resolver }
+type webhookResolver struct{ *Resolver }
+type webhookFilterResolver struct{ *Resolver }
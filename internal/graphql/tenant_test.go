@@ -0,0 +1,70 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	"google.golang.org/grpc"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(false, tenant.AllPeers)),
+		grpc.ChainStreamInterceptor(tenant.StreamServerInterceptor(false, tenant.AllPeers)))
+	gateway := newGateway(t, newTicketClient(t, service.Addr))
+
+	var created struct {
+		CreateTicket struct{ ID string }
+	}
+	post(t, gateway, organization("acme"),
+		`mutation { createTicket(title: "Acme only", reporterId: "u1", tags: ["secret"]) { id } }`, nil, &created)
+	id := created.CreateTicket.ID
+
+	var own struct {
+		Ticket *struct{ Title string }
+	}
+	post(t, gateway, organization("acme"), `query($id: ID!) { ticket(id: $id) { title } }`,
+		map[string]interface{}{"id": id}, &own)
+	if own.Ticket == nil || own.Ticket.Title != "Acme only" {
+		t.Fatalf("acme reads its ticket as %+v", own.Ticket)
+	}
+
+	for _, org := range []string{"globex", ""} {
+		t.Run("organization "+org, func(t *testing.T) {
+			header := organization(org)
+			var other struct {
+				Tickets []struct{ ID string }
+				Tags    []struct{ Name string }
+			}
+			post(t, gateway, header, `{ tickets { id } tags { name } }`, nil, &other)
+			if len(other.Tickets) != 0 || len(other.Tags) != 0 {
+				t.Errorf("another organization lists %+v", other)
+			}
+			if resp := post(t, gateway, header, `query($id: ID!) { ticket(id: $id) { title } }`,
+				map[string]interface{}{"id": id}, nil); len(resp.Errors) == 0 {
+				t.Errorf("another organization reads the ticket: %s", resp.Data)
+			}
+
+			if resp := post(t, gateway, header, `mutation($id: ID!) { updateTicket(id: $id, title: "Taken over") { id } }`,
+				map[string]interface{}{"id": id}, nil); len(resp.Errors) == 0 {
+				t.Error("another organization updated the ticket")
+			}
+			var deleted struct{ DeleteTicket *bool }
+			resp := post(t, gateway, header, `mutation($id: ID!) { deleteTicket(id: $id) }`,
+				map[string]interface{}{"id": id}, nil)
+			if len(resp.Errors) == 0 {
+				if err := json.Unmarshal(resp.Data, &deleted); err != nil || (deleted.DeleteTicket != nil && *deleted.DeleteTicket) {
+					t.Errorf("another organization deleted the ticket: %s", resp.Data)
+				}
+			}
+		})
+	}
+
+	post(t, gateway, organization("acme"), `query($id: ID!) { ticket(id: $id) { title } }`,
+		map[string]interface{}{"id": id}, &own)
+	if own.Ticket == nil || own.Ticket.Title != "Acme only" {
+		t.Fatalf("acme ticket after the other organizations' operations = %+v", own.Ticket)
+	}
+}
@@ -0,0 +1,38 @@
+package grpcweb
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jsonCodec lets the gRPC server read and write messages as JSON, for the
+// JSON variants of Connect and gRPC-Web. It is registered as the "json"
+// content subtype.
+type jsonCodec struct{}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal %T: not a protobuf message", v)
+	}
+	return protojson.Marshal(message)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal %T: not a protobuf message", v)
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, message)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
@@ -0,0 +1,177 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// connectCodes are the Connect names of the gRPC status codes and the HTTP
+// statuses unary errors are sent with
+var connectCodes = map[codes.Code]struct {
+	name       string
+	httpStatus int
+}{
+	codes.Canceled:           {"canceled", 499},
+	codes.Unknown:            {"unknown", http.StatusInternalServerError},
+	codes.InvalidArgument:    {"invalid_argument", http.StatusBadRequest},
+	codes.DeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout},
+	codes.NotFound:           {"not_found", http.StatusNotFound},
+	codes.AlreadyExists:      {"already_exists", http.StatusConflict},
+	codes.PermissionDenied:   {"permission_denied", http.StatusForbidden},
+	codes.ResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests},
+	codes.FailedPrecondition: {"failed_precondition", http.StatusBadRequest},
+	codes.Aborted:            {"aborted", http.StatusConflict},
+	codes.OutOfRange:         {"out_of_range", http.StatusBadRequest},
+	codes.Unimplemented:      {"unimplemented", http.StatusNotImplemented},
+	codes.Internal:           {"internal", http.StatusInternalServerError},
+	codes.Unavailable:        {"unavailable", http.StatusServiceUnavailable},
+	codes.DataLoss:           {"data_loss", http.StatusInternalServerError},
+	codes.Unauthenticated:    {"unauthenticated", http.StatusUnauthorized},
+}
+
+// connectError is the JSON form of an error in the Connect protocol
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func newConnectError(st *status.Status) *connectError {
+	name := connectCodes[st.Code()].name
+	if name == "" {
+		name = "unknown"
+	}
+	return &connectError{Code: name, Message: st.Message()}
+}
+
+// setTimeout gives req the gRPC form of a Connect-Timeout-Ms value
+func setTimeout(req *http.Request, value string) error {
+	if value == "" {
+		return nil
+	}
+	ms, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || len(value) > 10 {
+		return fmt.Errorf("invalid Connect-Timeout-Ms %q", value)
+	}
+	// gRPC timeouts have at most eight digits
+	if ms <= 99999999 {
+		req.Header.Set("Grpc-Timeout", strconv.FormatUint(ms, 10)+"m")
+	} else {
+		req.Header.Set("Grpc-Timeout", strconv.FormatUint((ms+999)/1000, 10)+"S")
+	}
+	return nil
+}
+
+// serveConnectUnary serves a unary Connect call: the body is the bare
+// request message and the response the bare reply or a JSON error, with
+// the trailers sent as Trailer- prefixed headers
+func (h *Handler) serveConnectUnary(w http.ResponseWriter, r *http.Request, mediaType string) {
+	subtype := strings.TrimPrefix(mediaType, "application/")
+
+	fail := func(st *status.Status) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(connectCodes[st.Code()].httpStatus)
+		json.NewEncoder(w).Encode(newConnectError(st))
+	}
+	if encoding := r.Header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		fail(status.New(codes.Unimplemented, fmt.Sprintf("compression %q is not supported", encoding)))
+		return
+	}
+	message, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			fail(status.New(codes.ResourceExhausted, fmt.Sprintf("request message larger than %d bytes", maxMessageSize)))
+		} else {
+			fail(status.New(codes.InvalidArgument, fmt.Sprintf("failed to read request: %v", err)))
+		}
+		return
+	}
+
+	req := grpcRequest(r, bytes.NewReader(envelope(0, message)), subtype)
+	if err := setTimeout(req, r.Header.Get("Connect-Timeout-Ms")); err != nil {
+		fail(status.New(codes.InvalidArgument, err.Error()))
+		return
+	}
+	var header http.Header
+	var reply bytes.Buffer
+	rec := newRecorder(func(sent http.Header) { header = sent }, func(p []byte) { reply.Write(p) })
+	h.server.ServeHTTP(rec, req)
+	trailer := rec.finish()
+
+	copyHeader(w.Header(), header)
+	for name, values := range metadata(trailer) {
+		for _, value := range values {
+			w.Header().Add("Trailer-"+name, value)
+		}
+	}
+	if st := statusOf(trailer); st.Code() != codes.OK {
+		fail(st)
+		return
+	}
+
+	frame := reply.Bytes()
+	if len(frame) < 5 || int(binary.BigEndian.Uint32(frame[1:5])) != len(frame)-5 {
+		fail(status.New(codes.Internal, "server sent a malformed reply"))
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(frame)-5))
+	w.WriteHeader(http.StatusOK)
+	w.Write(frame[5:])
+}
+
+// endOfStream is the last message of a Connect stream
+type endOfStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+// serveConnectStream serves a streaming Connect call. Its messages are
+// framed like gRPC messages; the status and trailers follow as a JSON
+// message flagged 0x02.
+func (h *Handler) serveConnectStream(w http.ResponseWriter, r *http.Request, mediaType string) {
+	subtype := strings.TrimPrefix(mediaType, "application/connect+")
+	flusher, _ := w.(http.Flusher)
+	// Keep reading client streams after the first reply
+	http.NewResponseController(w).EnableFullDuplex()
+
+	rec := newRecorder(func(header http.Header) {
+		copyHeader(w.Header(), header)
+		w.Header().Set("Content-Type", mediaType)
+		w.WriteHeader(http.StatusOK)
+	}, func(p []byte) {
+		w.Write(p)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	})
+	req := grpcRequest(r, r.Body, subtype)
+	if encoding := r.Header.Get("Connect-Content-Encoding"); encoding != "" && encoding != "identity" {
+		rec.fail(status.New(codes.Unimplemented, fmt.Sprintf("compression %q is not supported", encoding)))
+	} else if err := setTimeout(req, r.Header.Get("Connect-Timeout-Ms")); err != nil {
+		rec.fail(status.New(codes.InvalidArgument, err.Error()))
+	} else {
+		h.server.ServeHTTP(rec, req)
+	}
+	trailer := rec.finish()
+
+	end := endOfStream{Metadata: metadata(trailer)}
+	if st := statusOf(trailer); st.Code() != codes.OK {
+		end.Error = newConnectError(st)
+	}
+	if len(end.Metadata) == 0 {
+		end.Metadata = nil
+	}
+	data, _ := json.Marshal(end)
+	w.Write(envelope(0x02, data))
+}
@@ -0,0 +1,324 @@
+// Package grpcweb serves a gRPC server to HTTP/1.1 clients such as browsers
+// over the gRPC-Web and Connect protocols. Each request is rewritten into a
+// gRPC request and handed to the server's ServeHTTP, so it runs through the
+// same interceptors as native gRPC calls.
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxMessageSize bounds buffered Connect request bodies, matching the gRPC
+// server's default receive limit
+const maxMessageSize = 4 << 20
+
+// exposedHeaders are the response headers browsers may read
+var exposedHeaders = strings.Join([]string{
+	"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+	"Ratelimit-Limit", "Ratelimit-Remaining", "Ratelimit-Reset", "Retry-After",
+	"Idempotent-Replayed",
+}, ", ")
+
+// droppedHeaders are protocol headers that must not reach the gRPC server
+// as metadata or content negotiation
+var droppedHeaders = []string{
+	"Accept-Encoding", "Content-Encoding", "Content-Length",
+	"Connect-Accept-Encoding", "Connect-Content-Encoding", "Connect-Protocol-Version", "Connect-Timeout-Ms",
+	"Grpc-Accept-Encoding", "Grpc-Encoding",
+}
+
+// Handler serves the services of a gRPC server over gRPC-Web and Connect
+type Handler struct {
+	server    *grpc.Server
+	origins   map[string]bool
+	anyOrigin bool
+}
+
+// NewHandler returns a handler for server. Browsers on the allowed origins
+// ("*" allows any) may call it cross-origin.
+func NewHandler(server *grpc.Server, allowedOrigins []string) *Handler {
+	h := &Handler{server: server, origins: make(map[string]bool)}
+	for _, origin := range allowedOrigins {
+		if origin = strings.TrimSpace(origin); origin == "*" {
+			h.anyOrigin = true
+		} else if origin != "" {
+			h.origins[strings.TrimSuffix(origin, "/")] = true
+		}
+	}
+	return h
+}
+
+// ServeHTTP dispatches a request by its protocol, told apart by content type
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.cors(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(mediaType, "application/grpc-web"):
+		h.serveGRPCWeb(w, r, mediaType)
+	case mediaType == "application/connect+proto" || mediaType == "application/connect+json":
+		h.serveConnectStream(w, r, mediaType)
+	case mediaType == "application/proto" || mediaType == "application/json":
+		h.serveConnectUnary(w, r, mediaType)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type %q: use gRPC-Web or Connect", mediaType), http.StatusUnsupportedMediaType)
+	}
+}
+
+// cors adds the CORS headers for allowed origins and answers preflight
+// requests, reporting whether the request was one
+func (h *Handler) cors(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !(h.anyOrigin || h.origins[origin]) {
+		return false
+	}
+	header := w.Header()
+	header.Set("Access-Control-Allow-Origin", origin)
+	header.Add("Vary", "Origin")
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		header.Set("Access-Control-Allow-Methods", http.MethodPost)
+		header.Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+		header.Set("Access-Control-Max-Age", "7200")
+		w.WriteHeader(http.StatusNoContent)
+		return true
+	}
+	header.Set("Access-Control-Expose-Headers", exposedHeaders)
+	return false
+}
+
+// grpcRequest rewrites r into a gRPC request carrying body, with messages
+// encoded as subtype (proto or json)
+func grpcRequest(r *http.Request, body io.Reader, subtype string) *http.Request {
+	req := r.Clone(r.Context())
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2", 2, 0
+	req.Body = io.NopCloser(body)
+	req.ContentLength = -1
+	for _, name := range droppedHeaders {
+		req.Header.Del(name)
+	}
+	req.Header.Set("Content-Type", "application/grpc+"+subtype)
+	return req
+}
+
+// serveGRPCWeb serves a gRPC-Web request. The messages already are gRPC
+// length-prefixed messages (base64 encoded in the -text variant); the
+// trailers follow them as a message flagged 0x80.
+func (h *Handler) serveGRPCWeb(w http.ResponseWriter, r *http.Request, mediaType string) {
+	text := strings.HasPrefix(mediaType, "application/grpc-web-text")
+	subtype := "proto"
+	if strings.HasSuffix(mediaType, "+json") {
+		subtype = "json"
+	}
+	responseType := "application/grpc-web+" + subtype
+	if text {
+		responseType = "application/grpc-web-text+" + subtype
+	}
+
+	var body io.Reader = r.Body
+	var out io.Writer = w
+	var encoder io.WriteCloser
+	if text {
+		body = base64.NewDecoder(base64.StdEncoding, r.Body)
+		encoder = base64.NewEncoder(base64.StdEncoding, w)
+		out = encoder
+	}
+	flusher, _ := w.(http.Flusher)
+	http.NewResponseController(w).EnableFullDuplex()
+
+	rec := newRecorder(func(header http.Header) {
+		copyHeader(w.Header(), header)
+		w.Header().Set("Content-Type", responseType)
+		w.WriteHeader(http.StatusOK)
+	}, func(p []byte) {
+		out.Write(p)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	})
+	if encoding := r.Header.Get("Grpc-Encoding"); encoding != "" && encoding != "identity" {
+		rec.fail(status.New(codes.Unimplemented, fmt.Sprintf("compression %q is not supported", encoding)))
+	} else {
+		h.server.ServeHTTP(rec, grpcRequest(r, body, subtype))
+	}
+
+	trailer := rec.finish()
+	var block bytes.Buffer
+	names := make([]string, 0, len(trailer))
+	for name := range trailer {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range trailer[name] {
+			fmt.Fprintf(&block, "%s: %s\r\n", strings.ToLower(name), value)
+		}
+	}
+	out.Write(envelope(0x80, block.Bytes()))
+	if encoder != nil {
+		encoder.Close()
+	}
+}
+
+// recorder is the http.ResponseWriter handed to the gRPC server. It passes
+// the headers and messages the server writes on to start and data, and
+// keeps what the server sets after them as trailers.
+type recorder struct {
+	header  http.Header
+	sent    map[string]bool
+	code    int
+	started bool
+	// rejected is the body of a request the server refused outright
+	rejected bytes.Buffer
+	start    func(header http.Header)
+	data     func(p []byte)
+}
+
+func newRecorder(start func(header http.Header), data func(p []byte)) *recorder {
+	return &recorder{header: make(http.Header), sent: make(map[string]bool), start: start, data: data}
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(code int) {
+	if rec.code == 0 {
+		rec.code = code
+	}
+}
+
+func (rec *recorder) Write(p []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	if rec.code != http.StatusOK {
+		return rec.rejected.Write(p)
+	}
+	rec.begin()
+	rec.data(p)
+	return len(p), nil
+}
+
+func (rec *recorder) Flush() {
+	rec.WriteHeader(http.StatusOK)
+	if rec.code == http.StatusOK {
+		rec.begin()
+	}
+}
+
+// begin passes the headers written so far on to start, once
+func (rec *recorder) begin() {
+	if rec.started {
+		return
+	}
+	rec.started = true
+	header := make(http.Header)
+	for name, values := range rec.header {
+		rec.sent[name] = true
+		switch name {
+		case "Content-Type", "Date", "Trailer":
+		default:
+			header[name] = values
+		}
+	}
+	rec.start(header)
+}
+
+// fail answers the request with a status without calling the server
+func (rec *recorder) fail(st *status.Status) {
+	rec.header.Set("Grpc-Status", strconv.Itoa(int(st.Code())))
+	rec.header.Set("Grpc-Message", url.PathEscape(st.Message()))
+}
+
+// finish starts the response if the server wrote nothing and returns the
+// trailers, which always include Grpc-Status
+func (rec *recorder) finish() http.Header {
+	if rec.code != 0 && rec.code != http.StatusOK {
+		// The server refused the request before it became a call
+		code := codes.Internal
+		if rec.code == http.StatusBadRequest {
+			code = codes.InvalidArgument
+		}
+		rec.header = make(http.Header)
+		rec.fail(status.New(code, strings.TrimSpace(rec.rejected.String())))
+	}
+	if !rec.started {
+		// Trailers-only response: nothing counts as sent
+		rec.started = true
+		rec.start(make(http.Header))
+	}
+
+	trailer := make(http.Header)
+	for name, values := range rec.header {
+		if key, ok := strings.CutPrefix(name, http.TrailerPrefix); ok {
+			trailer[http.CanonicalHeaderKey(key)] = values
+		} else if !rec.sent[name] {
+			trailer[name] = values
+		}
+	}
+	if trailer.Get("Grpc-Status") == "" {
+		trailer.Set("Grpc-Status", strconv.Itoa(int(codes.Internal)))
+		trailer.Set("Grpc-Message", url.PathEscape("server sent no status"))
+	}
+	return trailer
+}
+
+// statusOf returns the status carried by gRPC trailers
+func statusOf(trailer http.Header) *status.Status {
+	code, err := strconv.Atoi(trailer.Get("Grpc-Status"))
+	if err != nil {
+		return status.New(codes.Internal, "malformed grpc-status")
+	}
+	message := trailer.Get("Grpc-Message")
+	if unescaped, err := url.PathUnescape(message); err == nil {
+		message = unescaped
+	}
+	return status.New(codes.Code(code), message)
+}
+
+// metadata returns the trailers without the gRPC status
+func metadata(trailer http.Header) http.Header {
+	md := trailer.Clone()
+	for _, name := range []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"} {
+		md.Del(name)
+	}
+	return md
+}
+
+// copyHeader adds the values of src to dst
+func copyHeader(dst, src http.Header) {
+	for name, values := range src {
+		for _, value := range values {
+			dst.Add(name, value)
+		}
+	}
+}
+
+// envelope frames a message with its flags and length, as gRPC, gRPC-Web and
+// Connect streams do
+func envelope(flags byte, message []byte) []byte {
+	frame := make([]byte, 5+len(message))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(message)))
+	copy(frame[5:], message)
+	return frame
+}
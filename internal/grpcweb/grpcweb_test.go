@@ -0,0 +1,277 @@
+package grpcweb_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/grpcweb"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const origin = "https://app.example.com"
+
+// start serves a ticket service over gRPC-Web and Connect to origin
+func start(t *testing.T) string {
+	t.Helper()
+	service := servicetest.Start(t)
+	server := httptest.NewServer(grpcweb.NewHandler(service.Server, []string{origin}))
+	t.Cleanup(server.Close)
+	return server.URL + "/ticket.TicketService/"
+}
+
+// post sends body to a method and returns the response with its body read
+func post(t *testing.T, url, contentType string, body []byte, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	return resp, data
+}
+
+// frame is a length-prefixed message of a gRPC-Web or Connect stream
+type frame struct {
+	flags byte
+	data  []byte
+}
+
+func envelope(message proto.Message) []byte {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+	return append(binary.BigEndian.AppendUint32([]byte{0}, uint32(len(data))), data...)
+}
+
+func frames(t *testing.T, body []byte) []frame {
+	t.Helper()
+	var frames []frame
+	for len(body) > 0 {
+		if len(body) < 5 || len(body)-5 < int(binary.BigEndian.Uint32(body[1:5])) {
+			t.Fatalf("truncated frame in %q", body)
+		}
+		n := 5 + int(binary.BigEndian.Uint32(body[1:5]))
+		frames = append(frames, frame{flags: body[0], data: body[5:n]})
+		body = body[n:]
+	}
+	return frames
+}
+
+// grpcWeb makes a gRPC-Web call with binary messages and returns the reply
+// messages and the trailer block
+func grpcWeb(t *testing.T, url string, text bool, req proto.Message) ([][]byte, string) {
+	t.Helper()
+	contentType, body := "application/grpc-web+proto", envelope(req)
+	if text {
+		contentType, body = "application/grpc-web-text+proto", []byte(base64.StdEncoding.EncodeToString(body))
+	}
+	resp, data := post(t, url, contentType, body, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != contentType {
+		t.Fatalf("got status %d with %q, want 200 with %q", resp.StatusCode, resp.Header.Get("Content-Type"), contentType)
+	}
+	if text {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			t.Fatalf("response is not base64: %v", err)
+		}
+		data = decoded
+	}
+
+	var messages [][]byte
+	all := frames(t, data)
+	for i, f := range all {
+		switch f.flags {
+		case 0:
+			messages = append(messages, f.data)
+		case 0x80:
+			if i != len(all)-1 {
+				t.Fatal("trailers are not the last frame")
+			}
+			return messages, string(f.data)
+		default:
+			t.Fatalf("unexpected frame flags %#x", f.flags)
+		}
+	}
+	t.Fatal("response has no trailers")
+	return nil, ""
+}
+
+func create(t *testing.T, url, title string) *ticketpb.Ticket {
+	t.Helper()
+	messages, trailer := grpcWeb(t, url+"CreateTicket", false, &ticketpb.CreateTicketRequest{Title: title})
+	if !strings.Contains(trailer, "grpc-status: 0\r\n") || len(messages) != 1 {
+		t.Fatalf("CreateTicket: %d messages, trailers %q", len(messages), trailer)
+	}
+	var reply ticketpb.CreateTicketResponse
+	if err := proto.Unmarshal(messages[0], &reply); err != nil {
+		t.Fatalf("failed to decode reply: %v", err)
+	}
+	return reply.Ticket
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	url := start(t)
+	created := create(t, url, "Printer on fire")
+
+	for _, text := range []bool{false, true} {
+		messages, trailer := grpcWeb(t, url+"GetTicket", text, &ticketpb.GetTicketRequest{Id: created.Id})
+		if !strings.Contains(trailer, "grpc-status: 0\r\n") || len(messages) != 1 {
+			t.Fatalf("GetTicket (text %t): %d messages, trailers %q", text, len(messages), trailer)
+		}
+		var reply ticketpb.GetTicketResponse
+		if err := proto.Unmarshal(messages[0], &reply); err != nil || reply.Ticket.Title != "Printer on fire" {
+			t.Errorf("GetTicket (text %t) = %v, %v; want the ticket", text, reply.Ticket, err)
+		}
+	}
+}
+
+func TestGRPCWebServerStream(t *testing.T) {
+	url := start(t)
+	// One more than a page, so the export takes two messages
+	for i := 0; i <= store.MaxPageSize; i++ {
+		create(t, url, "Ticket")
+	}
+
+	messages, trailer := grpcWeb(t, url+"ExportTickets", true, &ticketpb.ExportTicketsRequest{})
+	if !strings.Contains(trailer, "grpc-status: 0\r\n") {
+		t.Fatalf("ExportTickets trailers %q", trailer)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("ExportTickets sent %d messages, want 2", len(messages))
+	}
+	exported := 0
+	for _, message := range messages {
+		var reply ticketpb.ExportTicketsResponse
+		if err := proto.Unmarshal(message, &reply); err != nil {
+			t.Fatalf("failed to decode reply: %v", err)
+		}
+		exported += len(reply.Tickets)
+	}
+	if exported != store.MaxPageSize+1 {
+		t.Errorf("exported %d tickets, want %d", exported, store.MaxPageSize+1)
+	}
+}
+
+func TestGRPCWebError(t *testing.T) {
+	url := start(t)
+
+	messages, trailer := grpcWeb(t, url+"GetTicket", false, &ticketpb.GetTicketRequest{Id: "missing"})
+	if len(messages) != 0 {
+		t.Errorf("failed call sent %d messages", len(messages))
+	}
+	if !strings.Contains(trailer, "grpc-status: 5\r\n") || !strings.Contains(trailer, "grpc-message: ") {
+		t.Errorf("trailers %q, want NotFound with a message", trailer)
+	}
+
+	// The server refuses what it cannot decode before the call starts
+	resp, data := post(t, url+"GetTicket", "application/grpc-web+proto", []byte{0, 0, 0, 0, 9, 1}, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("truncated request: status %d", resp.StatusCode)
+	}
+	f := frames(t, data)
+	if last := f[len(f)-1]; last.flags != 0x80 || strings.Contains(string(last.data), "grpc-status: 0\r\n") {
+		t.Errorf("truncated request: trailers %q, want an error status", last.data)
+	}
+}
+
+func TestConnectUnary(t *testing.T) {
+	url := start(t)
+	created := create(t, url, "Printer on fire")
+
+	// JSON
+	resp, data := post(t, url+"GetTicket", "application/json", []byte(`{"id": "`+created.Id+`"}`), nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("JSON call: status %d with %q: %s", resp.StatusCode, resp.Header.Get("Content-Type"), data)
+	}
+	var reply ticketpb.GetTicketResponse
+	if err := protojson.Unmarshal(data, &reply); err != nil || reply.Ticket.Title != "Printer on fire" {
+		t.Errorf("JSON call = %v, %v; want the ticket", reply.Ticket, err)
+	}
+
+	// Binary protobuf
+	body, _ := proto.Marshal(&ticketpb.GetTicketRequest{Id: created.Id})
+	resp, data = post(t, url+"GetTicket", "application/proto", body, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/proto" {
+		t.Fatalf("proto call: status %d with %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	reply.Reset()
+	if err := proto.Unmarshal(data, &reply); err != nil || reply.Ticket.Title != "Printer on fire" {
+		t.Errorf("proto call = %v, %v; want the ticket", reply.Ticket, err)
+	}
+
+	// Errors are JSON with the HTTP status of their code
+	resp, data = post(t, url+"GetTicket", "application/json", []byte(`{"id": "missing"}`), nil)
+	var connectErr struct{ Code, Message string }
+	if err := json.Unmarshal(data, &connectErr); err != nil {
+		t.Fatalf("error is not JSON: %s", data)
+	}
+	if resp.StatusCode != http.StatusNotFound || connectErr.Code != "not_found" || connectErr.Message == "" {
+		t.Errorf("missing ticket: status %d, error %+v; want 404 not_found", resp.StatusCode, connectErr)
+	}
+}
+
+func TestOrigins(t *testing.T) {
+	url := start(t)
+
+	preflight := func(from string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, url+"GetTicket", nil)
+		req.Header.Set("Origin", from)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("OPTIONS: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := preflight(origin)
+	if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != origin {
+		t.Errorf("preflight from %s: status %d, allowed origin %q", origin, resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Headers"); got != "content-type,x-grpc-web" {
+		t.Errorf("preflight allowed headers %q", got)
+	}
+
+	const other = "https://evil.example.com"
+	resp = preflight(other)
+	if resp.StatusCode == http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("preflight from %s: status %d, allowed origin %q; want it refused", other, resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+
+	// Calls from a rejected origin get no CORS headers, so browsers do not
+	// hand the response to the page
+	resp, _ = post(t, url+"GetTicket", "application/json", []byte(`{"id": "missing"}`), http.Header{"Origin": {other}})
+	if resp.Header.Get("Access-Control-Allow-Origin") != "" || resp.Header.Get("Access-Control-Expose-Headers") != "" {
+		t.Errorf("call from %s got CORS headers %v", other, resp.Header)
+	}
+	resp, _ = post(t, url+"GetTicket", "application/json", []byte(`{"id": "missing"}`), http.Header{"Origin": {origin}})
+	if resp.Header.Get("Access-Control-Allow-Origin") != origin || !strings.Contains(resp.Header.Get("Access-Control-Expose-Headers"), "Grpc-Status") {
+		t.Errorf("call from %s: CORS headers %v", origin, resp.Header)
+	}
+}
@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorCodeAmbiguousKey is the GraphQL error extension code returned when the
+// Idempotency-Key header is sent with a mutation of several fields
+const ErrorCodeAmbiguousKey = "AMBIGUOUS_IDEMPOTENCY_KEY"
+
+// Extension limits the Idempotency-Key header to GraphQL mutations that
+// select a single field. Middleware gives the key to every call of the
+// request, so with a second field the first call would replay or fail the
+// second; such mutations pass the idempotencyKey argument to each field.
+type Extension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = Extension{}
+
+// ExtensionName returns the extension name
+func (Extension) ExtensionName() string {
+	return "Idempotency"
+}
+
+// Validate is a no-op, the extension works with any schema
+func (Extension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationContext rejects a mutation of several fields made with the
+// Idempotency-Key header
+func (Extension) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if _, ok := FromContext(ctx); !ok || opCtx.Operation == nil || opCtx.Operation.Operation != ast.Mutation {
+		return nil
+	}
+
+	mutations := 0
+	for _, field := range graphql.CollectFields(opCtx, opCtx.Operation.SelectionSet, []string{"Mutation"}) {
+		if field.Name != "__typename" {
+			mutations++
+		}
+	}
+	if mutations <= 1 {
+		return nil
+	}
+	return &gqlerror.Error{
+		Message: fmt.Sprintf("the %s header applies to a single mutation; pass idempotencyKey to each of the %d mutations instead",
+			Header, mutations),
+		Extensions: map[string]interface{}{"code": ErrorCodeAmbiguousKey},
+	}
+}
@@ -0,0 +1,298 @@
+// Package idempotency lets clients retry ticket service calls safely. A call
+// made with an idempotency key runs once; retries with the same key and
+// request get the first response back instead of repeating the change.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// Header is the HTTP header carrying the idempotency key
+	Header = "Idempotency-Key"
+	// MetadataKey is the gRPC metadata key carrying the idempotency key
+	MetadataKey = "idempotency-key"
+	// ReplayedMetadataKey is set to "true" in the header metadata of a
+	// response replayed from an earlier call
+	ReplayedMetadataKey = "idempotent-replayed"
+	// RequestIDField names the request field holding the idempotency key.
+	// Only methods whose request has it accept idempotency keys.
+	RequestIDField = "request_id"
+	// MaxKeyLength bounds the length of a key
+	MaxKeyLength = 255
+	// LockTimeout is how long a call may hold its key before a retry may
+	// take the key over, assuming the call died with its server
+	LockTimeout = time.Minute
+	// SecretField names response fields that are never saved, such as the
+	// signing secret CreateWebhook returns. Replays return them empty.
+	SecretField = "secret"
+)
+
+// Record is what a store keeps for a key: the request it was first used
+// with and, once that call succeeded, its response
+type Record struct {
+	Key string
+	// Method is the full gRPC method of the call
+	Method string
+	// RequestHash identifies the request (see Hash)
+	RequestHash []byte
+	// Response is the marshalled response of the call
+	Response  []byte
+	CreatedAt time.Time
+	// CompletedAt is when the call succeeded, zero while it runs
+	CompletedAt time.Time
+	ExpiresAt   time.Time
+}
+
+// Pending reports whether the call holding the key has not finished
+func (r *Record) Pending() bool {
+	return r.CompletedAt.IsZero()
+}
+
+// Store persists records. Keys are unique within an organization and every
+// method acts for the organization of ctx (see tenant.ID).
+type Store interface {
+	// Reserve stores record as pending unless its key holds a live record,
+	// which it returns instead (with a nil error). Records expired at
+	// record.CreatedAt and pending records created before staleBefore are
+	// replaced; expired records may be purged along the way.
+	Reserve(ctx context.Context, record *Record, staleBefore time.Time) (*Record, error)
+	// Complete saves the response of a reserved key
+	Complete(ctx context.Context, key string, response []byte, completedAt time.Time) error
+	// Release removes a key that is still pending, so its call can be retried
+	Release(ctx context.Context, key string) error
+}
+
+type contextKey struct{}
+
+// WithKey returns a context whose ticket service calls carry key
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// FromContext returns the idempotency key set on ctx
+func FromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(contextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// Validate checks that a key is at most MaxKeyLength printable ASCII
+// characters
+func Validate(key string) error {
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("idempotency key is longer than %d characters", MaxKeyLength)
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return fmt.Errorf("idempotency key %q must be printable ASCII", key)
+		}
+	}
+	return nil
+}
+
+// Middleware puts the key in the Idempotency-Key header on the request
+// context, to be forwarded by UnaryClientInterceptor
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimSpace(r.Header.Get(Header))
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := Validate(key); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithKey(r.Context(), key)))
+	})
+}
+
+// UnaryClientInterceptor forwards the idempotency key of the context as gRPC
+// metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if key, ok := FromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, key)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor makes calls with an idempotency key run once. The
+// key is the request's request_id field or, when that is empty, the
+// idempotency-key metadata; methods without the field ignore keys.
+//
+// The first call reserves the key. A retry within retention gets the first
+// response back, or Aborted while the first call still runs; reusing the key
+// for another request is InvalidArgument. Failed calls release their key, so
+// errors are not replayed. Saved responses leave out SecretField, so only the
+// first response carries a secret.
+func UnaryServerInterceptor(store Store, retention time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		key, ok := requestKey(ctx, message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		if err := Validate(key); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		now := time.Now().UTC()
+		record := &Record{
+			Key:         key,
+			Method:      info.FullMethod,
+			RequestHash: Hash(info.FullMethod, message),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(retention),
+		}
+		existing, err := store.Reserve(ctx, record, now.Add(-LockTimeout))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reserve idempotency key: %v", err)
+		}
+		if existing != nil {
+			return replay(ctx, record, existing)
+		}
+
+		reply, err := handler(ctx, req)
+		// Settle the key even when the caller has gone away
+		ctx = context.WithoutCancel(ctx)
+		if err != nil {
+			if releaseErr := store.Release(ctx, key); releaseErr != nil {
+				log.Printf("⚠️  Failed to release idempotency key %q: %v", key, releaseErr)
+			}
+			return nil, err
+		}
+		saved := proto.Clone(reply.(proto.Message))
+		redact(saved.ProtoReflect())
+		response, err := proto.Marshal(saved)
+		if err == nil {
+			err = store.Complete(ctx, key, response, time.Now().UTC())
+		}
+		if err != nil {
+			// The change is made: answer it, at the risk of a retry repeating it
+			log.Printf("⚠️  Failed to save the response of idempotency key %q: %v", key, err)
+		}
+		return reply, nil
+	}
+}
+
+// requestKey returns the idempotency key of a call to a method that accepts one
+func requestKey(ctx context.Context, message proto.Message) (string, bool) {
+	reflected := message.ProtoReflect()
+	field := reflected.Descriptor().Fields().ByName(RequestIDField)
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return "", false
+	}
+	if key := reflected.Get(field).String(); key != "" {
+		return key, true
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0], true
+		}
+	}
+	return "", false
+}
+
+// redact clears the SecretField string fields of message and of the messages
+// it holds
+func redact(message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.Name() == SecretField && field.Kind() == protoreflect.StringKind:
+			message.Clear(field)
+		case field.IsMap():
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					redact(value.Message())
+					return true
+				})
+			}
+		case field.Message() == nil:
+		case field.IsList():
+			for i := 0; i < value.List().Len(); i++ {
+				redact(value.List().Get(i).Message())
+			}
+		default:
+			redact(value.Message())
+		}
+		return true
+	})
+}
+
+// replay answers a call whose key is taken with the response of the call
+// that took it
+func replay(ctx context.Context, record, existing *Record) (interface{}, error) {
+	if existing.Method != record.Method || !bytes.Equal(existing.RequestHash, record.RequestHash) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"idempotency key %q was already used with a different request", record.Key)
+	}
+	if existing.Pending() {
+		return nil, status.Errorf(codes.Aborted,
+			"a request with idempotency key %q is still in progress", record.Key)
+	}
+
+	reply, err := newReply(record.Method)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := proto.Unmarshal(existing.Response, reply); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read saved response: %v", err)
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ReplayedMetadataKey, "true"))
+	return reply, nil
+}
+
+// newReply returns an empty response message of a gRPC method
+func newReply(fullMethod string) (proto.Message, error) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", "."))
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find method %s: %w", fullMethod, err)
+	}
+	method, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method", fullMethod)
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find response type of %s: %w", fullMethod, err)
+	}
+	return messageType.New().Interface(), nil
+}
+
+// Hash identifies a request to a method regardless of its request_id, so the
+// same request sent with the key in the field or in metadata matches
+func Hash(fullMethod string, message proto.Message) []byte {
+	message = proto.Clone(message)
+	reflected := message.ProtoReflect()
+	if field := reflected.Descriptor().Fields().ByName(RequestIDField); field != nil {
+		reflected.Clear(field)
+	}
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+
+	hash := sha256.New()
+	hash.Write([]byte(fullMethod))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hash.Sum(nil)
+}
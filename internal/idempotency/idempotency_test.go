@@ -0,0 +1,260 @@
+package idempotency_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var createTicket = &grpc.UnaryServerInfo{FullMethod: ticketpb.TicketService_CreateTicket_FullMethodName}
+
+// stream records the header metadata a handler sets
+type stream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *stream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// service counts the tickets its CreateTicket handler creates
+type service struct {
+	created atomic.Int32
+	err     error
+}
+
+func (s *service) handle(ctx context.Context, req interface{}) (interface{}, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	n := s.created.Add(1)
+	return &ticketpb.CreateTicketResponse{Ticket: &ticketpb.Ticket{
+		Id:    fmt.Sprintf("ticket-%d", n),
+		Title: req.(*ticketpb.CreateTicketRequest).Title,
+	}}, nil
+}
+
+// call makes a CreateTicket call through the interceptor and returns the
+// reply and whether it was replayed
+func call(interceptor grpc.UnaryServerInterceptor, ctx context.Context, s *service, req *ticketpb.CreateTicketRequest) (*ticketpb.CreateTicketResponse, bool, error) {
+	st := &stream{}
+	reply, err := interceptor(grpc.NewContextWithServerTransportStream(ctx, st), req, createTicket, s.handle)
+	if err != nil {
+		return nil, false, err
+	}
+	return reply.(*ticketpb.CreateTicketResponse), st.header.Get(idempotency.ReplayedMetadataKey) != nil, nil
+}
+
+func TestReplay(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour)
+	ctx := context.Background()
+	s := &service{}
+
+	first, replayed, err := call(interceptor, ctx, s, &ticketpb.CreateTicketRequest{Title: "Printer on fire", RequestId: "k1"})
+	if err != nil || replayed {
+		t.Fatalf("first call = %v, replayed %t", err, replayed)
+	}
+
+	// The same request with the key in metadata gets the saved response
+	withKey := metadata.NewIncomingContext(ctx, metadata.Pairs(idempotency.MetadataKey, "k1"))
+	retry, replayed, err := call(interceptor, withKey, s, &ticketpb.CreateTicketRequest{Title: "Printer on fire"})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !replayed || !proto.Equal(retry, first) {
+		t.Errorf("retry = %v (replayed %t), want the first response replayed", retry, replayed)
+	}
+	if n := s.created.Load(); n != 1 {
+		t.Errorf("handler ran %d times, want once", n)
+	}
+
+	// Keys are scoped to an organization
+	acme := tenant.WithOrganization(ctx, "acme")
+	if _, replayed, err := call(interceptor, acme, s, &ticketpb.CreateTicketRequest{Title: "Printer on fire", RequestId: "k1"}); err != nil || replayed {
+		t.Errorf("call in another organization = %v, replayed %t; want it run", err, replayed)
+	}
+
+	// Calls without a key always run
+	for range 2 {
+		if _, replayed, err := call(interceptor, ctx, s, &ticketpb.CreateTicketRequest{Title: "Printer on fire"}); err != nil || replayed {
+			t.Fatalf("call without a key = %v, replayed %t", err, replayed)
+		}
+	}
+	if n := s.created.Load(); n != 4 {
+		t.Errorf("handler ran %d times, want 4", n)
+	}
+}
+
+func TestKeyReusedForAnotherRequest(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour)
+	s := &service{}
+	if _, _, err := call(interceptor, context.Background(), s, &ticketpb.CreateTicketRequest{Title: "Printer on fire", RequestId: "k1"}); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+
+	_, _, err := call(interceptor, context.Background(), s, &ticketpb.CreateTicketRequest{Title: "Coffee machine broken", RequestId: "k1"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("call with another request = %v, want InvalidArgument", err)
+	}
+	if n := s.created.Load(); n != 1 {
+		t.Errorf("handler ran %d times, want once", n)
+	}
+}
+
+func TestPendingKey(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour)
+	req := &ticketpb.CreateTicketRequest{Title: "Printer on fire", RequestId: "k1"}
+
+	started, finish := make(chan struct{}), make(chan struct{})
+	slow := &service{}
+	done := make(chan error)
+	go func() {
+		_, err := interceptor(context.Background(), req, createTicket, func(ctx context.Context, req interface{}) (interface{}, error) {
+			close(started)
+			<-finish
+			return slow.handle(ctx, req)
+		})
+		done <- err
+	}()
+	<-started
+
+	// A retry while the first call runs is told to try again later
+	s := &service{}
+	if _, _, err := call(interceptor, context.Background(), s, req); status.Code(err) != codes.Aborted {
+		t.Errorf("retry during the call = %v, want Aborted", err)
+	}
+	close(finish)
+	if err := <-done; err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if _, replayed, err := call(interceptor, context.Background(), s, req); err != nil || !replayed {
+		t.Errorf("retry after the call = %v, replayed %t; want the response replayed", err, replayed)
+	}
+	if n := s.created.Load(); n != 0 {
+		t.Errorf("retries ran the handler %d times", n)
+	}
+}
+
+func TestReleaseOnError(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour)
+	req := &ticketpb.CreateTicketRequest{Title: "Printer on fire", RequestId: "k1"}
+
+	failing := &service{err: status.Error(codes.Unavailable, "database down")}
+	if _, _, err := call(interceptor, context.Background(), failing, req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("failing call = %v, want its error", err)
+	}
+
+	// Errors are not replayed: the retry runs
+	s := &service{}
+	if _, replayed, err := call(interceptor, context.Background(), s, req); err != nil || replayed {
+		t.Errorf("retry = %v, replayed %t; want it run", err, replayed)
+	}
+	if n := s.created.Load(); n != 1 {
+		t.Errorf("handler ran %d times, want once", n)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	// Records are kept for no time at all, so every call runs
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), 0)
+	s := &service{}
+	req := &ticketpb.CreateTicketRequest{Title: "Printer on fire", RequestId: "k1"}
+	for range 2 {
+		if _, replayed, err := call(interceptor, context.Background(), s, req); err != nil || replayed {
+			t.Fatalf("call = %v, replayed %t", err, replayed)
+		}
+	}
+	if n := s.created.Load(); n != 2 {
+		t.Errorf("handler ran %d times, want twice", n)
+	}
+}
+
+func TestSecretsNotSaved(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour)
+	createWebhook := &grpc.UnaryServerInfo{FullMethod: ticketpb.TicketService_CreateWebhook_FullMethodName}
+	handle := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &ticketpb.CreateWebhookResponse{Webhook: &ticketpb.Webhook{Id: "webhook-1", Secret: "s3cr3t"}}, nil
+	}
+	req := &ticketpb.CreateWebhookRequest{Url: "https://example.com/hook", RequestId: "k1"}
+
+	first, err := interceptor(context.Background(), req, createWebhook, handle)
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if secret := first.(*ticketpb.CreateWebhookResponse).Webhook.Secret; secret != "s3cr3t" {
+		t.Errorf("first response has secret %q, want it returned", secret)
+	}
+
+	// The replay has the webhook without its secret
+	st := &stream{}
+	retry, err := interceptor(grpc.NewContextWithServerTransportStream(context.Background(), st), req, createWebhook, handle)
+	if err != nil || st.header.Get(idempotency.ReplayedMetadataKey) == nil {
+		t.Fatalf("retry = %v, want the response replayed", err)
+	}
+	webhook := retry.(*ticketpb.CreateWebhookResponse).Webhook
+	if webhook.Id != "webhook-1" || webhook.Secret != "" {
+		t.Errorf("replayed webhook %v, want it without its secret", webhook)
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	ctx := context.Background()
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := func(createdAt time.Time) *idempotency.Record {
+		return &idempotency.Record{Key: "k1", Method: createTicket.FullMethod, CreatedAt: createdAt, ExpiresAt: createdAt.Add(time.Hour)}
+	}
+
+	if existing, err := store.Reserve(ctx, record(at), at.Add(-idempotency.LockTimeout)); err != nil || existing != nil {
+		t.Fatalf("Reserve = %v, %v; want the key reserved", existing, err)
+	}
+
+	// A pending key is held until its lock times out
+	if existing, _ := store.Reserve(ctx, record(at.Add(time.Second)), at.Add(time.Second-idempotency.LockTimeout)); existing == nil || !existing.Pending() {
+		t.Fatalf("Reserve of a held key = %v, want the pending record", existing)
+	}
+	takeover := at.Add(2 * idempotency.LockTimeout)
+	if existing, _ := store.Reserve(ctx, record(takeover), takeover.Add(-idempotency.LockTimeout)); existing != nil {
+		t.Fatalf("Reserve of a stale key = %v, want it taken over", existing)
+	}
+
+	// A completed key is held until it expires
+	if err := store.Complete(ctx, "k1", []byte("response"), takeover); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	later := takeover.Add(59 * time.Minute)
+	existing, _ := store.Reserve(ctx, record(later), later.Add(-idempotency.LockTimeout))
+	if existing == nil || string(existing.Response) != "response" {
+		t.Fatalf("Reserve before expiry = %v, want the completed record", existing)
+	}
+	expired := takeover.Add(time.Hour)
+	if existing, _ := store.Reserve(ctx, record(expired), expired.Add(-idempotency.LockTimeout)); existing != nil {
+		t.Errorf("Reserve after expiry = %v, want the key reserved again", existing)
+	}
+
+	// Release only drops pending keys
+	if err := store.Release(ctx, "k1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if existing, _ := store.Reserve(ctx, record(expired), expired.Add(-idempotency.LockTimeout)); existing != nil {
+		t.Errorf("Reserve after Release = %v, want the key free", existing)
+	}
+	store.Complete(ctx, "k1", []byte("again"), expired)
+	store.Release(ctx, "k1")
+	if existing, _ := store.Reserve(ctx, record(expired), expired.Add(-idempotency.LockTimeout)); existing == nil || string(existing.Response) != "again" {
+		t.Errorf("Reserve after releasing a completed key = %v, want the completed record", existing)
+	}
+}
@@ -0,0 +1,83 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// purgeInterval is how often the memory store drops expired records
+const purgeInterval = time.Minute
+
+// MemoryStore keeps records in process. It copies them on the way in and
+// out.
+type MemoryStore struct {
+	mu       sync.Mutex
+	records  map[memoryKey]*Record
+	purgedAt time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// memoryKey scopes a key to its organization
+type memoryKey struct {
+	organizationID string
+	key            string
+}
+
+// NewMemoryStore creates an empty in-memory record store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[memoryKey]*Record)}
+}
+
+// Reserve stores record unless its key holds a live record
+func (m *MemoryStore) Reserve(ctx context.Context, record *Record, staleBefore time.Time) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := record.CreatedAt
+	if now.Sub(m.purgedAt) >= purgeInterval {
+		m.purgedAt = now
+		for key, existing := range m.records {
+			if !existing.ExpiresAt.After(now) {
+				delete(m.records, key)
+			}
+		}
+	}
+
+	key := memoryKey{organizationID: tenant.ID(ctx), key: record.Key}
+	if existing, ok := m.records[key]; ok && existing.ExpiresAt.After(now) &&
+		!(existing.Pending() && existing.CreatedAt.Before(staleBefore)) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	m.records[key] = &copied
+	return nil, nil
+}
+
+// Complete saves the response of a reserved key
+func (m *MemoryStore) Complete(ctx context.Context, key string, response []byte, completedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.records[memoryKey{organizationID: tenant.ID(ctx), key: key}]; ok {
+		record.Response = append([]byte(nil), response...)
+		record.CompletedAt = completedAt
+	}
+	return nil
+}
+
+// Release removes a key that is still pending
+func (m *MemoryStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scoped := memoryKey{organizationID: tenant.ID(ctx), key: key}
+	if record, ok := m.records[scoped]; ok && record.Pending() {
+		delete(m.records, scoped)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package inbound
+
+import (
+	"context"
+	"crypto/subtle"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenMetadataKey is the gRPC metadata key carrying the token the mail
+// gateway proves itself with
+const TokenMetadataKey = "x-inbound-token"
+
+// threadMethods are the ticket service methods that read and extend email
+// threads. They take Message-IDs from the mail gateway as given, so anyone
+// else calling them could attach mail to any ticket or learn which messages
+// were filed where.
+var threadMethods = map[string]bool{
+	ticketpb.TicketService_FindEmailThread_FullMethodName:    true,
+	ticketpb.TicketService_RecordEmailMessage_FullMethodName: true,
+}
+
+// UnaryServerInterceptor only lets callers presenting token use the email
+// thread methods, on every transport the server is reached over. With an
+// empty token nobody may.
+func UnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !threadMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if token == "" {
+			return nil, status.Error(codes.PermissionDenied, "email threading is disabled: set inbound.token on the ticket service and the mail gateway")
+		}
+		var given string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(TokenMetadataKey); len(values) > 0 {
+				given = values[0]
+			}
+		}
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is only available to the mail gateway", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UnaryClientInterceptor sends token with calls to the email thread methods
+func UnaryClientInterceptor(token string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if threadMethods[method] && token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, TokenMetadataKey, token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
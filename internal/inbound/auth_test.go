@@ -0,0 +1,53 @@
+package inbound_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/clients"
+	"github.com/ayush-pandya/Graphql/internal/inbound"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestThreadToken(t *testing.T) {
+	service := servicetest.Start(t, grpc.ChainUnaryInterceptor(inbound.UnaryServerInterceptor("mail-secret")))
+	connect := func(opts ...grpc.DialOption) *clients.TicketClient {
+		client, err := clients.NewTicketClient(service.Addr, opts...)
+		if err != nil {
+			t.Fatalf("NewTicketClient: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	ctx := context.Background()
+
+	mail := connect(grpc.WithChainUnaryInterceptor(inbound.UnaryClientInterceptor("mail-secret")))
+	ticket, err := mail.CreateTicket(ctx, "Printer on fire", "", 0, "", "alice@example.com", nil, "", nil, nil)
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	if _, err := mail.RecordEmailMessage(ctx, "m1@example.com", ticket.Id); err != nil {
+		t.Fatalf("RecordEmailMessage with the token: %v", err)
+	}
+	if id, err := mail.FindEmailThread(ctx, []string{"m1@example.com"}); err != nil || id != ticket.Id {
+		t.Fatalf("FindEmailThread with the token = %q, %v; want %s", id, err, ticket.Id)
+	}
+
+	for name, client := range map[string]*clients.TicketClient{
+		"no token":    connect(),
+		"wrong token": connect(grpc.WithChainUnaryInterceptor(inbound.UnaryClientInterceptor("guess"))),
+	} {
+		if _, err := client.FindEmailThread(ctx, []string{"m1@example.com"}); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("FindEmailThread with %s = %v, want PermissionDenied", name, err)
+		}
+		if _, err := client.RecordEmailMessage(ctx, "m2@example.com", ticket.Id); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("RecordEmailMessage with %s = %v, want PermissionDenied", name, err)
+		}
+		if _, err := client.GetTicket(ctx, ticket.Id); err != nil {
+			t.Errorf("GetTicket with %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Package inbound files incoming email as tickets: new messages become
+// tickets reported by their sender, replies become comments on the ticket
+// they answer and attachments are stored on that ticket
+package inbound
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxPartDepth caps the nesting of multipart bodies
+const maxPartDepth = 10
+
+// ErrMalformed is returned for messages that cannot be parsed
+var ErrMalformed = errors.New("malformed message")
+
+// Attachment is a file sent with a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Message is the part of an email the processor files
+type Message struct {
+	// MessageID is the Message-ID header without its angle brackets
+	MessageID string
+	// References lists the Message-IDs of the messages this one replies to,
+	// most recent first: In-Reply-To, then References from last to first
+	References []string
+	From       *mail.Address
+	Subject    string
+	// Text is the plain-text body, converted from HTML when the message has
+	// no plain-text part
+	Text        string
+	Attachments []Attachment
+	// AutoSubmitted is set for messages sent by a machine rather than a
+	// person, such as out-of-office replies and notifications
+	AutoSubmitted bool
+}
+
+// ParseMessage parses a MIME email
+func ParseMessage(r io.Reader) (*Message, error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	from, err := m.Header.AddressList("From")
+	if err != nil || len(from) == 0 {
+		return nil, fmt.Errorf("%w: no valid From address", ErrMalformed)
+	}
+	msg := &Message{
+		MessageID:     firstMessageID(m.Header.Get("Message-ID")),
+		From:          from[0],
+		Subject:       decodeHeader(m.Header.Get("Subject")),
+		AutoSubmitted: autoSubmitted(m.Header),
+	}
+	msg.From.Address = strings.ToLower(msg.From.Address)
+
+	references := messageIDs(m.Header.Get("In-Reply-To"))
+	all := messageIDs(m.Header.Get("References"))
+	for i := len(all) - 1; i >= 0; i-- {
+		references = append(references, all[i])
+	}
+	for _, id := range references {
+		if !slices.Contains(msg.References, id) {
+			msg.References = append(msg.References, id)
+		}
+	}
+
+	var body parts
+	if err := body.walk(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"),
+		m.Header.Get("Content-Disposition"), m.Body, 0); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	msg.Text = body.text
+	if msg.Text == "" && body.html != "" {
+		msg.Text = htmlToText(body.html)
+	}
+	msg.Text = strings.TrimSpace(normalizeNewlines(msg.Text))
+	msg.Attachments = body.attachments
+	return msg, nil
+}
+
+// parts collects the bodies and attachments of a message
+type parts struct {
+	text        string
+	html        string
+	attachments []Attachment
+}
+
+// walk visits a MIME part, descending into multipart bodies
+func (p *parts) walk(contentType, encoding, disposition string, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return errors.New("multipart nesting is too deep")
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// RFC 2045 makes untyped (and unreadable) parts plain text
+		mediaType, params = "text/plain", map[string]string{"charset": "us-ascii"}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read multipart body: %w", err)
+			}
+			err = p.walk(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"),
+				part.Header.Get("Content-Disposition"), part, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(body, encoding))
+	if err != nil {
+		return fmt.Errorf("failed to decode %s part: %w", mediaType, err)
+	}
+
+	dispositionType, dispositionParams, _ := mime.ParseMediaType(disposition)
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = decodeHeader(filename)
+	switch {
+	case dispositionType == "attachment" || filename != "":
+		p.attachments = append(p.attachments, Attachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Content:     content,
+		})
+	case mediaType == "text/plain" && p.text == "":
+		p.text = decodeCharset(content, params["charset"])
+	case mediaType == "text/html" && p.html == "":
+		p.html = decodeCharset(content, params["charset"])
+	}
+	return nil
+}
+
+// decodeTransfer undoes the Content-Transfer-Encoding of a part
+func decodeTransfer(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// base64Cleaner drops the line breaks and spaces base64 bodies are
+// wrapped with
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	for {
+		n, err := c.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+				p[kept] = b
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// decodeCharset converts text in charset to UTF-8. Latin-1 is converted;
+// other charsets are kept when the text is valid UTF-8 and have their
+// invalid bytes replaced otherwise.
+func decodeCharset(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	return strings.ToValidUTF8(string(content), string(utf8.RuneError))
+}
+
+// decodeHeader decodes the RFC 2047 encoded words of a header
+func decodeHeader(value string) string {
+	decoder := mime.WordDecoder{CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		content, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(decodeCharset(content, charset)), nil
+	}}
+	decoded, err := decoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// autoSubmitted reports whether headers mark a message as sent by a machine
+// (RFC 3834), which the processor ignores to stay out of mail loops
+func autoSubmitted(header mail.Header) bool {
+	if value := strings.ToLower(strings.TrimSpace(header.Get("Auto-Submitted"))); value != "" && value != "no" {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(header.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return header.Get("X-Autoreply") != "" || header.Get("X-Autorespond") != ""
+}
+
+var messageIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// messageIDs extracts the Message-IDs of a header such as References,
+// without their angle brackets
+func messageIDs(value string) []string {
+	var ids []string
+	for _, match := range messageIDPattern.FindAllStringSubmatch(value, -1) {
+		ids = append(ids, match[1])
+	}
+	if len(ids) == 0 {
+		// Some clients leave the angle brackets out
+		if id := strings.TrimSpace(value); id != "" && !strings.ContainsAny(id, " \t") {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// firstMessageID returns the first Message-ID of a header, empty when it
+// has none
+func firstMessageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+var (
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)\s*>`)
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6]|blockquote)\s*>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinePattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText keeps the text of an HTML body and its line breaks
+func htmlToText(body string) string {
+	body = htmlDropPattern.ReplaceAllString(body, "")
+	body = htmlBreakPattern.ReplaceAllString(body, "\n")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	body = html.UnescapeString(body)
+
+	lines := strings.Split(normalizeNewlines(body), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return blankLinePattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
+
+var replyHeaderPattern = regexp.MustCompile(`^(On .+ wrote:|-+ ?Original Message ?-+|From: .+@.+)$`)
+
+// ReplyText returns the new text of a reply: what precedes the quoted
+// message and the sender's signature
+func ReplyText(text string) string {
+	var kept []string
+	for _, line := range strings.Split(normalizeNewlines(text), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if replyHeaderPattern.MatchString(trimmed) || line == "-- " {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,205 @@
+package inbound_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/inbound"
+)
+
+// crlf turns a message written with \n line breaks into one on the wire
+func crlf(message string) string {
+	return strings.ReplaceAll(message, "\n", "\r\n")
+}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		subject     string
+		text        string
+		attachments map[string]string // filename to content
+		references  []string
+		auto        bool
+	}{
+		{
+			name: "plain text",
+			message: `From: Alice <Alice@Example.com>
+Subject: Printer on fire
+Message-ID: <m1@example.com>
+
+It is on fire.
+`,
+			subject: "Printer on fire",
+			text:    "It is on fire.",
+		},
+		{
+			name: "alternative prefers the plain text",
+			message: `From: alice@example.com
+Subject: Both
+MIME-Version: 1.0
+Content-Type: multipart/alternative; boundary="b1"
+
+--b1
+Content-Type: text/plain; charset=utf-8
+
+Plain body
+--b1
+Content-Type: text/html; charset=utf-8
+
+<p>HTML body</p>
+--b1--
+`,
+			subject: "Both",
+			text:    "Plain body",
+		},
+		{
+			name: "html only, with a base64 attachment",
+			message: `From: alice@example.com
+Subject: Screenshot
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/html; charset=utf-8
+
+<html><head><style>p { color: red }</style></head><body>
+<p>Hello&nbsp;there</p><div>Second   line &lt;b&gt;</div><br><script>track()</script>
+</body></html>
+--inner--
+--outer
+Content-Type: image/png; name="shot.png"
+Content-Disposition: attachment; filename="shot.png"
+Content-Transfer-Encoding: base64
+
+aGVsbG8g
+d29ybGQ=
+--outer--
+`,
+			subject:     "Screenshot",
+			text:        "Hello there\nSecond line <b>",
+			attachments: map[string]string{"shot.png": "hello world"},
+		},
+		{
+			name: "quoted-printable",
+			message: `From: alice@example.com
+Subject: =?UTF-8?Q?Caf=C3=A9?=
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Caf=C3=A9 au lait is a soft=
+ly broken line, a =3D sign.
+`,
+			subject: "Café",
+			text:    "Café au lait is a softly broken line, a = sign.",
+		},
+		{
+			name: "ISO-8859-1",
+			message: "From: alice@example.com\n" +
+				"Subject: =?ISO-8859-1?Q?R=E9sum=E9?=\n" +
+				"Content-Type: text/plain; charset=ISO-8859-1\n" +
+				"Content-Transfer-Encoding: 8bit\n\n" +
+				"Na\xefve caf\xe9\n",
+			subject: "Résumé",
+			text:    "Naïve café",
+		},
+		{
+			name: "ISO-8859-1 quoted-printable html",
+			message: `From: alice@example.com
+Subject: Latin
+Content-Type: text/html; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+<p>Gr=FC=DFe</p>
+`,
+			subject: "Latin",
+			text:    "Grüße",
+		},
+		{
+			name: "reply threading",
+			message: `From: alice@example.com
+Subject: Re: Printer on fire
+In-Reply-To: <m2@example.com>
+References: <m0@example.com> <m1@example.com> <m2@example.com>
+
+Still burning.
+`,
+			subject:    "Re: Printer on fire",
+			text:       "Still burning.",
+			references: []string{"m2@example.com", "m1@example.com", "m0@example.com"},
+		},
+		{
+			name: "auto-reply",
+			message: `From: alice@example.com
+Subject: Out of office
+Auto-Submitted: auto-replied
+
+Back next week.
+`,
+			subject: "Out of office",
+			text:    "Back next week.",
+			auto:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := inbound.ParseMessage(strings.NewReader(crlf(tt.message)))
+			if err != nil {
+				t.Fatalf("ParseMessage: %v", err)
+			}
+			if msg.Subject != tt.subject {
+				t.Errorf("subject %q, want %q", msg.Subject, tt.subject)
+			}
+			if msg.Text != tt.text {
+				t.Errorf("text %q, want %q", msg.Text, tt.text)
+			}
+			if msg.From.Address != "alice@example.com" {
+				t.Errorf("from %q, want the lowercased address", msg.From.Address)
+			}
+			if strings.Join(msg.References, " ") != strings.Join(tt.references, " ") {
+				t.Errorf("references %q, want %q", msg.References, tt.references)
+			}
+			if msg.AutoSubmitted != tt.auto {
+				t.Errorf("auto-submitted %t, want %t", msg.AutoSubmitted, tt.auto)
+			}
+			if len(msg.Attachments) != len(tt.attachments) {
+				t.Fatalf("%d attachments, want %d", len(msg.Attachments), len(tt.attachments))
+			}
+			for _, attachment := range msg.Attachments {
+				if content := string(attachment.Content); content != tt.attachments[attachment.Filename] {
+					t.Errorf("attachment %q holds %q, want %q", attachment.Filename, content, tt.attachments[attachment.Filename])
+				}
+			}
+		})
+	}
+
+	if _, err := inbound.ParseMessage(strings.NewReader(crlf("Subject: Anonymous\n\nHello\n"))); !errors.Is(err, inbound.ErrMalformed) {
+		t.Errorf("ParseMessage without From = %v, want ErrMalformed", err)
+	}
+}
+
+func TestReplyText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"no quote", "Thanks, fixed.", "Thanks, fixed."},
+		{"attribution line", "Thanks, fixed.\r\n\r\nOn Mon, 1 Jan 2024, Bob <bob@example.com> wrote:\r\n> Is it fixed?", "Thanks, fixed."},
+		{"quoted lines", "> Is it fixed?\nYes.\n> And the other one?\nAlso.", "Yes.\nAlso."},
+		{"signature", "Yes.\n-- \nAlice\nSupport", "Yes."},
+		{"forwarded original", "See below.\n-----Original Message-----\nFrom: bob@example.com\nOld text", "See below."},
+		{"outlook header", "Done.\n\nFrom: Bob <bob@example.com>\nSent: Monday", "Done."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inbound.ReplyText(tt.text); got != tt.want {
+				t.Errorf("ReplyText = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Tickets is the part of the ticket service the processor files email
+// with. clients.TicketClient implements it over gRPC.
+type Tickets interface {
+	CreateTicket(ctx context.Context, title, description string, priority ticketpb.TicketPriority, assigneeID, reporterID string, tags []string, projectKey string, customFields map[string]string, dueDate *timestamppb.Timestamp) (*ticketpb.Ticket, error)
+	GetTicket(ctx context.Context, id string) (*ticketpb.Ticket, error)
+	GetTicketByKey(ctx context.Context, key string) (*ticketpb.Ticket, error)
+	AddComment(ctx context.Context, ticketID, authorID, body string) (*ticketpb.Comment, error)
+	UploadAttachment(ctx context.Context, ticketID, filename, contentType string, content io.Reader) (*ticketpb.Attachment, error)
+	FindEmailThread(ctx context.Context, messageIDs []string) (string, error)
+	RecordEmailMessage(ctx context.Context, messageID, ticketID string) (bool, error)
+}
+
+// ProcessorOptions configure how email is filed
+type ProcessorOptions struct {
+	// ProjectKey is the project new tickets are created in; tickets are
+	// created outside projects when it is empty
+	ProjectKey string
+	// Organization is the organization email is filed for
+	Organization string
+	// Tags are put on new tickets, e.g. "email"
+	Tags []string
+}
+
+// Result says what the processor did with a message
+type Result struct {
+	// Ticket is the ticket the message was filed on; nil when it was
+	// skipped for being sent by a machine
+	Ticket *ticketpb.Ticket
+	// Created is set when the message created the ticket rather than
+	// commenting on it
+	Created bool
+	// Duplicate is set when the message was already filed
+	Duplicate bool
+	// Attachments are the attachments stored on the ticket
+	Attachments []*ticketpb.Attachment
+	// Rejected lists the attachments the ticket service refused, such as
+	// ones too large or of a type it does not allow
+	Rejected []string
+}
+
+// Processor files parsed email as tickets and comments
+type Processor struct {
+	tickets Tickets
+	opts    ProcessorOptions
+}
+
+// NewProcessor creates a processor filing email through tickets
+func NewProcessor(tickets Tickets, opts ProcessorOptions) *Processor {
+	return &Processor{tickets: tickets, opts: opts}
+}
+
+// ticketKeyPattern matches ticket keys in subjects, e.g. [WEB-42]
+var ticketKeyPattern = regexp.MustCompile(`\[([A-Za-z][A-Za-z0-9]{1,9}-[0-9]+)\]`)
+
+// replyPrefixPattern matches the reply and forward prefixes of subjects
+var replyPrefixPattern = regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|sv|wg)\s*(\[\d+\])?\s*:\s*)+`)
+
+// Process files a message. A message whose subject names a ticket key, such
+// as [WEB-42], or that replies to a message of a ticket's email thread is
+// added to that ticket as a comment by its sender; any other message creates
+// a ticket reported by its sender. Either way its attachments are stored on
+// the ticket and its Message-ID joins the ticket's thread, so a message
+// delivered twice is filed once. Auto-replies and other messages sent by
+// machines are skipped.
+func (p *Processor) Process(ctx context.Context, msg *Message) (*Result, error) {
+	if msg.AutoSubmitted {
+		log.Printf("Inbound: Skipping automatic message from %s", msg.From.Address)
+		return &Result{}, nil
+	}
+	if p.opts.Organization != "" {
+		ctx = tenant.WithOrganization(ctx, p.opts.Organization)
+	}
+
+	if msg.MessageID != "" {
+		ticketID, err := p.findThread(ctx, []string{msg.MessageID})
+		if err != nil {
+			return nil, err
+		}
+		if ticketID != "" {
+			log.Printf("Inbound: Skipping message %s, already filed on ticket %s", msg.MessageID, ticketID)
+			return &Result{Ticket: &ticketpb.Ticket{Id: ticketID}, Duplicate: true}, nil
+		}
+	}
+
+	ticket, err := p.thread(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Ticket: ticket}
+	if ticket == nil {
+		if result.Ticket, err = p.createTicket(ctx, msg); err != nil {
+			return nil, err
+		}
+		result.Created = true
+	} else if err := p.addComment(ctx, ticket, msg); err != nil {
+		return nil, err
+	}
+
+	if msg.MessageID != "" {
+		if _, err := p.tickets.RecordEmailMessage(ctx, msg.MessageID, result.Ticket.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	// The message is filed: an attachment the ticket service refuses is
+	// reported rather than failing it, which would have it sent again
+	for _, file := range msg.Attachments {
+		filename := file.Filename
+		if filename == "" {
+			filename = "attachment"
+		}
+		attachment, err := p.tickets.UploadAttachment(ctx, result.Ticket.Id, filename, file.ContentType,
+			bytes.NewReader(file.Content))
+		if err != nil {
+			log.Printf("Inbound: Failed to store attachment %q on ticket %s: %v", filename, result.Ticket.Id, err)
+			result.Rejected = append(result.Rejected, filename)
+			continue
+		}
+		result.Attachments = append(result.Attachments, attachment)
+	}
+	return result, nil
+}
+
+// thread returns the ticket a message belongs to: the first ticket its
+// subject names, or else the ticket of the messages it replies to. It
+// returns nil for a message that starts a new thread.
+func (p *Processor) thread(ctx context.Context, msg *Message) (*ticketpb.Ticket, error) {
+	for _, match := range ticketKeyPattern.FindAllStringSubmatch(msg.Subject, -1) {
+		ticket, err := p.tickets.GetTicketByKey(ctx, strings.ToUpper(match[1]))
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up ticket %s: %w", match[1], err)
+		}
+		return ticket, nil
+	}
+
+	ticketID, err := p.findThread(ctx, msg.References)
+	if err != nil || ticketID == "" {
+		return nil, err
+	}
+	ticket, err := p.tickets.GetTicket(ctx, ticketID)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket %s: %w", ticketID, err)
+	}
+	return ticket, nil
+}
+
+// findThread returns the ticket of the first known message, empty when no
+// message is known
+func (p *Processor) findThread(ctx context.Context, messageIDs []string) (string, error) {
+	if len(messageIDs) == 0 {
+		return "", nil
+	}
+	ticketID, err := p.tickets.FindEmailThread(ctx, messageIDs)
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	return ticketID, err
+}
+
+// createTicket creates the ticket a message starts
+func (p *Processor) createTicket(ctx context.Context, msg *Message) (*ticketpb.Ticket, error) {
+	title := strings.TrimSpace(replyPrefixPattern.ReplaceAllString(msg.Subject, ""))
+	if title == "" {
+		title = "(no subject)"
+	}
+	ticket, err := p.tickets.CreateTicket(ctx, title, msg.Text, ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED,
+		"", msg.From.Address, p.opts.Tags, p.opts.ProjectKey, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Inbound: Created ticket %s from %s", ticketName(ticket), msg.From.Address)
+	return ticket, nil
+}
+
+// addComment adds a reply to its ticket. A reply without new text, e.g. one
+// only sending attachments, adds no comment.
+func (p *Processor) addComment(ctx context.Context, ticket *ticketpb.Ticket, msg *Message) error {
+	body := ReplyText(msg.Text)
+	if body == "" {
+		return nil
+	}
+	if _, err := p.tickets.AddComment(ctx, ticket.Id, msg.From.Address, body); err != nil {
+		return fmt.Errorf("failed to comment on ticket %s: %w", ticketName(ticket), err)
+	}
+	log.Printf("Inbound: Added reply from %s to ticket %s", msg.From.Address, ticketName(ticket))
+	return nil
+}
+
+// ticketName names a ticket in logs by its key, or its ID when it has none
+func ticketName(ticket *ticketpb.Ticket) string {
+	if ticket.Key != "" {
+		return ticket.Key
+	}
+	return ticket.Id
+}
+
+// IsPermanent reports whether err means a message can never be filed, so
+// the sender should not try again
+func IsPermanent(err error) bool {
+	return errors.Is(err, ErrMalformed) || status.Code(err) == codes.InvalidArgument
+}
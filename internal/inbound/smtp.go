@@ -0,0 +1,382 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SMTPOptions configure the SMTP listener
+type SMTPOptions struct {
+	// Domain is the host name the listener greets clients with
+	Domain string
+	// Recipients are the addresses mail is accepted for; an entry starting
+	// with @ accepts a whole domain. Mail for any address is accepted when
+	// it is empty.
+	Recipients []string
+	// MaxMessageSize is the size in bytes of the largest message accepted
+	MaxMessageSize int64
+	// Timeout bounds each command and the transfer of each message
+	Timeout time.Duration
+}
+
+// maxRecipients caps the recipients of one message
+const maxRecipients = 100
+
+// SMTPServer accepts email over SMTP (RFC 5321) and files it with a
+// Processor. It speaks the subset of SMTP mail servers and clients deliver
+// with and offers neither TLS nor authentication: run it on a private
+// network, behind the MTA that receives the organization's mail, or
+// locally to try it out.
+type SMTPServer struct {
+	processor *Processor
+	opts      SMTPOptions
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// ErrServerClosed is returned by Serve once the server is shut down
+var ErrServerClosed = errors.New("inbound: server closed")
+
+// NewSMTPServer creates an SMTP listener filing mail with processor
+func NewSMTPServer(processor *Processor, opts SMTPOptions) *SMTPServer {
+	return &SMTPServer{
+		processor: processor,
+		opts:      opts,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// ListenAndServe accepts SMTP connections on addr
+func (s *SMTPServer) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return s.Serve(ln)
+}
+
+// Serve accepts SMTP connections on ln until the server is shut down
+func (s *SMTPServer) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		ln.Close()
+		return ErrServerClosed
+	}
+	s.listeners[ln] = struct{}{}
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			delete(s.listeners, ln)
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			continue
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(conn)
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Shutdown stops accepting connections and waits for the open ones to
+// finish, closing them when ctx is done first
+func (s *SMTPServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	for ln := range s.listeners {
+		ln.Close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.mu.Unlock()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// session is the state of one SMTP connection
+type session struct {
+	server *SMTPServer
+	conn   net.Conn
+	text   *textproto.Conn
+	helo   string
+	from   string
+	to     []string
+	// hasFrom is set once MAIL was accepted; the null sender <> is valid
+	hasFrom bool
+}
+
+func (s *SMTPServer) serveConn(conn net.Conn) {
+	sess := &session{server: s, conn: conn, text: textproto.NewConn(conn)}
+	defer sess.text.Close()
+
+	sess.reply(220, "%s ESMTP ticket mail gateway ready", s.opts.Domain)
+	for {
+		sess.deadline()
+		line, err := sess.text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			sess.hello(arg, false)
+		case "EHLO":
+			sess.hello(arg, true)
+		case "MAIL":
+			sess.mail(arg)
+		case "RCPT":
+			sess.rcpt(arg)
+		case "DATA":
+			if !sess.data() {
+				return
+			}
+		case "RSET":
+			sess.reset()
+			sess.reply(250, "2.0.0 OK")
+		case "NOOP":
+			sess.reply(250, "2.0.0 OK")
+		case "VRFY":
+			sess.reply(252, "2.5.2 Cannot verify user, but will accept message")
+		case "QUIT":
+			sess.reply(221, "2.0.0 %s closing connection", s.opts.Domain)
+			return
+		default:
+			sess.reply(502, "5.5.2 Command not recognized")
+		}
+	}
+}
+
+// deadline bounds the next read or write of the session
+func (sess *session) deadline() {
+	if timeout := sess.server.opts.Timeout; timeout > 0 {
+		sess.conn.SetDeadline(time.Now().Add(timeout))
+	}
+}
+
+// reply writes a one-line reply
+func (sess *session) reply(code int, format string, args ...any) {
+	sess.text.PrintfLine("%d %s", code, fmt.Sprintf(format, args...))
+}
+
+func (sess *session) reset() {
+	sess.from, sess.to, sess.hasFrom = "", nil, false
+}
+
+func (sess *session) hello(domain string, extended bool) {
+	if domain == "" {
+		sess.reply(501, "5.5.4 Domain required")
+		return
+	}
+	sess.helo = domain
+	sess.reset()
+	if !extended {
+		sess.reply(250, "%s", sess.server.opts.Domain)
+		return
+	}
+	sess.text.PrintfLine("250-%s greets %s", sess.server.opts.Domain, domain)
+	sess.text.PrintfLine("250-SIZE %d", sess.server.opts.MaxMessageSize)
+	sess.text.PrintfLine("250-8BITMIME")
+	sess.text.PrintfLine("250 ENHANCEDSTATUSCODES")
+}
+
+func (sess *session) mail(arg string) {
+	switch {
+	case sess.helo == "":
+		sess.reply(503, "5.5.1 Send HELO or EHLO first")
+		return
+	case sess.hasFrom:
+		sess.reply(503, "5.5.1 Sender already given")
+		return
+	}
+	address, params, ok := pathArgument(arg, "FROM:")
+	if !ok {
+		sess.reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+		return
+	}
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "SIZE") {
+			if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > sess.server.opts.MaxMessageSize {
+				sess.reply(552, "5.3.4 Message size exceeds the limit of %d bytes", sess.server.opts.MaxMessageSize)
+				return
+			}
+		}
+	}
+	sess.from, sess.hasFrom = address, true
+	sess.reply(250, "2.1.0 OK")
+}
+
+func (sess *session) rcpt(arg string) {
+	if !sess.hasFrom {
+		sess.reply(503, "5.5.1 Send MAIL first")
+		return
+	}
+	address, _, ok := pathArgument(arg, "TO:")
+	if !ok || address == "" {
+		sess.reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+		return
+	}
+	if !sess.server.accepts(address) {
+		sess.reply(550, "5.1.1 Mailbox unavailable: %s", address)
+		return
+	}
+	if len(sess.to) >= maxRecipients {
+		sess.reply(452, "4.5.3 Too many recipients")
+		return
+	}
+	sess.to = append(sess.to, address)
+	sess.reply(250, "2.1.5 OK")
+}
+
+// data receives and files a message. It returns false when the connection
+// can no longer be used.
+func (sess *session) data() bool {
+	if len(sess.to) == 0 {
+		sess.reply(503, "5.5.1 Send RCPT first")
+		return true
+	}
+	sess.reply(354, "Start mail input; end with <CRLF>.<CRLF>")
+
+	limit := sess.server.opts.MaxMessageSize
+	sess.deadline()
+	body := sess.text.DotReader()
+	content, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return false
+	}
+	defer sess.reset()
+	if int64(len(content)) > limit {
+		// Drain the rest of the message so the session stays in sync
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			return false
+		}
+		sess.reply(552, "5.3.4 Message size exceeds the limit of %d bytes", limit)
+		return true
+	}
+
+	code, message := sess.server.deliver(sess.from, content)
+	sess.deadline()
+	sess.reply(code, "%s", message)
+	return true
+}
+
+// deliver parses and files a message and returns the SMTP reply
+func (s *SMTPServer) deliver(from string, content []byte) (int, string) {
+	msg, err := ParseMessage(bytes.NewReader(content))
+	if err != nil {
+		log.Printf("Inbound: Rejecting message from %s: %v", from, err)
+		return 554, "5.6.0 Message could not be parsed"
+	}
+
+	ctx := context.Background()
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	result, err := s.processor.Process(ctx, msg)
+	if err != nil {
+		log.Printf("Inbound: Failed to file message %s from %s: %v", msg.MessageID, msg.From.Address, err)
+		if IsPermanent(err) {
+			return 554, "5.7.0 Message rejected by the ticket service"
+		}
+		return 451, "4.3.0 Ticket service unavailable, try again later"
+	}
+	if result.Ticket == nil {
+		return 250, "2.0.0 OK, automatic message ignored"
+	}
+	return 250, fmt.Sprintf("2.0.0 OK, filed on ticket %s", ticketName(result.Ticket))
+}
+
+// accepts reports whether mail for address is accepted
+func (s *SMTPServer) accepts(address string) bool {
+	if len(s.opts.Recipients) == 0 {
+		return true
+	}
+	address = strings.ToLower(address)
+	_, domain, _ := strings.Cut(address, "@")
+	for _, recipient := range s.opts.Recipients {
+		recipient = strings.ToLower(recipient)
+		if recipient == address || (strings.HasPrefix(recipient, "@") && recipient[1:] == domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathArgument parses the <address> and parameters of a MAIL FROM: or
+// RCPT TO: command. The null path <> yields an empty address.
+func pathArgument(arg, prefix string) (string, []string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	fields := strings.Fields(strings.TrimSpace(arg[len(prefix):]))
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+	path := fields[0]
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", nil, false
+	}
+	address := path[1 : len(path)-1]
+	if address != "" {
+		parsed, err := mail.ParseAddress("<" + address + ">")
+		if err != nil {
+			return "", nil, false
+		}
+		address = parsed.Address
+	}
+	return address, fields[1:], true
+}
@@ -0,0 +1,186 @@
+package inbound_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/inbound"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// tickets is an in-memory ticket service for the processor
+type tickets struct {
+	mu       sync.Mutex
+	tickets  []*ticketpb.Ticket
+	comments map[string][]string // ticket ID to comment bodies
+	threads  map[string]string   // Message-ID to ticket ID
+}
+
+func newTickets() *tickets {
+	return &tickets{comments: make(map[string][]string), threads: make(map[string]string)}
+}
+
+func (s *tickets) CreateTicket(ctx context.Context, title, description string, priority ticketpb.TicketPriority, assigneeID, reporterID string, tags []string, projectKey string, customFields map[string]string, dueDate *timestamppb.Timestamp) (*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ticket := &ticketpb.Ticket{
+		Id:          fmt.Sprintf("ticket-%d", len(s.tickets)+1),
+		Key:         fmt.Sprintf("%s-%d", projectKey, len(s.tickets)+1),
+		Title:       title,
+		Description: description,
+		ReporterId:  reporterID,
+		Tags:        tags,
+	}
+	s.tickets = append(s.tickets, ticket)
+	return ticket, nil
+}
+
+func (s *tickets) GetTicket(ctx context.Context, id string) (*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ticket := range s.tickets {
+		if ticket.Id == id {
+			return ticket, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "ticket %s not found", id)
+}
+
+func (s *tickets) GetTicketByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ticket := range s.tickets {
+		if ticket.Key == key {
+			return ticket, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "ticket %s not found", key)
+}
+
+func (s *tickets) AddComment(ctx context.Context, ticketID, authorID, body string) (*ticketpb.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.comments[ticketID] = append(s.comments[ticketID], body)
+	return &ticketpb.Comment{TicketId: ticketID, AuthorId: authorID, Body: body}, nil
+}
+
+func (s *tickets) UploadAttachment(ctx context.Context, ticketID, filename, contentType string, content io.Reader) (*ticketpb.Attachment, error) {
+	return &ticketpb.Attachment{TicketId: ticketID, Filename: filename, ContentType: contentType}, nil
+}
+
+func (s *tickets) FindEmailThread(ctx context.Context, messageIDs []string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range messageIDs {
+		if ticketID, ok := s.threads[id]; ok {
+			return ticketID, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *tickets) RecordEmailMessage(ctx context.Context, messageID, ticketID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.threads[messageID]; ok {
+		return false, nil
+	}
+	s.threads[messageID] = ticketID
+	return true, nil
+}
+
+// listen serves SMTP for support@example.com on a loopback port
+func listen(t *testing.T, store *tickets) string {
+	t.Helper()
+	server := inbound.NewSMTPServer(inbound.NewProcessor(store, inbound.ProcessorOptions{ProjectKey: "HELP"}), inbound.SMTPOptions{
+		Domain:         "mail.example.com",
+		Recipients:     []string{"support@example.com"},
+		MaxMessageSize: 1024,
+		Timeout:        5 * time.Second,
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(ln)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	})
+	return ln.Addr().String()
+}
+
+func TestSMTPServer(t *testing.T) {
+	store := newTickets()
+	addr := listen(t, store)
+	send := func(to, message string) error {
+		return smtp.SendMail(addr, nil, "alice@example.com", []string{to}, []byte(crlf(message)))
+	}
+
+	err := send("support@example.com", `From: Alice <alice@example.com>
+To: support@example.com
+Subject: Printer on fire
+Message-ID: <m1@example.com>
+
+It is on fire.
+`)
+	if err != nil {
+		t.Fatalf("SendMail: %v", err)
+	}
+	if len(store.tickets) != 1 || store.tickets[0].Title != "Printer on fire" || store.tickets[0].ReporterId != "alice@example.com" {
+		t.Fatalf("tickets %v, want one reported by alice", store.tickets)
+	}
+
+	// The reply is filed on the ticket of the message it answers, without
+	// the quote
+	err = send("Support@Example.com", `From: alice@example.com
+To: support@example.com
+Subject: Re: Printer on fire
+Message-ID: <m2@example.com>
+In-Reply-To: <m1@example.com>
+
+Still burning.
+
+On Mon, 1 Jan 2024, Support <support@example.com> wrote:
+> We are on it.
+`)
+	if err != nil {
+		t.Fatalf("SendMail reply: %v", err)
+	}
+	if comments := store.comments["ticket-1"]; len(comments) != 1 || !strings.Contains(comments[0], "Still burning.") || strings.Contains(comments[0], "We are on it") {
+		t.Fatalf("comments %q, want the reply without the quote", comments)
+	}
+
+	// A second delivery of a message is accepted and filed once
+	if err := send("support@example.com", "From: alice@example.com\nSubject: Re: Printer on fire\nMessage-ID: <m2@example.com>\n\nStill burning.\n"); err != nil {
+		t.Fatalf("SendMail duplicate: %v", err)
+	}
+	if len(store.tickets) != 1 || len(store.comments["ticket-1"]) != 1 {
+		t.Errorf("duplicate delivery filed again: %d tickets, %d comments", len(store.tickets), len(store.comments["ticket-1"]))
+	}
+
+	// Mail for other addresses, too large or unreadable is refused
+	if err := send("sales@example.com", "From: alice@example.com\nSubject: Hi\n\nHi\n"); err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("SendMail to another address = %v, want 550", err)
+	}
+	if err := send("support@example.com", "From: alice@example.com\nSubject: Big\n\n"+strings.Repeat("x", 2048)+"\n"); err == nil || !strings.Contains(err.Error(), "552") {
+		t.Errorf("SendMail of a large message = %v, want 552", err)
+	}
+	if err := send("support@example.com", "Subject: Anonymous\n\nHi\n"); err == nil || !strings.Contains(err.Error(), "554") {
+		t.Errorf("SendMail without From = %v, want 554", err)
+	}
+	if len(store.tickets) != 1 {
+		t.Errorf("refused mail created tickets: %d", len(store.tickets))
+	}
+}
@@ -0,0 +1,126 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+)
+
+// EmailOptions configures the SMTP server the email channel sends through
+type EmailOptions struct {
+	Host string
+	Port string
+	// Username and Password authenticate with PLAIN auth when Username is set,
+	// which net/smtp only allows over TLS or to localhost
+	Username string
+	Password string
+	// From is the sender address of every message
+	From string
+	// Timeout of one message, connection included
+	Timeout time.Duration
+}
+
+// EmailChannel sends notifications as plain text email to the address in
+// each user's preferences, upgrading to TLS when the server offers STARTTLS
+type EmailChannel struct {
+	opts EmailOptions
+}
+
+var _ Channel = (*EmailChannel)(nil)
+
+// NewEmailChannel creates an email channel sending through an SMTP server
+func NewEmailChannel(opts EmailOptions) *EmailChannel {
+	return &EmailChannel{opts: opts}
+}
+
+// Send emails one notification, or a digest of several, to the user
+func (c *EmailChannel) Send(ctx context.Context, preferences *ticketpb.NotificationPreferences, notifications []*ticketpb.Notification) error {
+	if preferences.Email == "" || len(notifications) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(c.opts.Host, c.opts.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, c.opts.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.opts.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if c.opts.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.opts.Username, c.opts.Password, c.opts.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate with SMTP server: %w", err)
+		}
+	}
+	if err := client.Mail(c.opts.From); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	if err := client.Rcpt(preferences.Email); err != nil {
+		return fmt.Errorf("SMTP server rejected recipient: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(c.message(preferences.Email, notifications, time.Now())); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return client.Quit()
+}
+
+// message renders the email about notifications
+func (c *EmailChannel) message(to string, notifications []*ticketpb.Notification, at time.Time) []byte {
+	subject := notifications[0].Message
+	if len(notifications) > 1 {
+		subject = fmt.Sprintf("Your daily digest: %d ticket notifications", len(notifications))
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", c.opts.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerText(subject)))
+	fmt.Fprintf(&b, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", uuid.New().String(), c.opts.Host)
+	// Marks the message as automatic (RFC 3834) so mail gateways and
+	// auto-responders do not answer it
+	b.WriteString("Auto-Submitted: auto-generated\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	for _, notification := range notifications {
+		fmt.Fprintf(&b, "%s (%s)\r\n", headerText(notification.Message),
+			notification.CreatedAt.AsTime().Format("2006-01-02 15:04 MST"))
+	}
+	return b.Bytes()
+}
+
+// headerText keeps user-provided text, such as ticket titles, on one line
+func headerText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
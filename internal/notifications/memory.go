@@ -0,0 +1,238 @@
+package notifications
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// watcher is a user watching a ticket
+type watcher struct {
+	organizationID string
+	ticketID       string
+	userID         string
+}
+
+// preferencesKey identifies the preferences of a user of an organization
+type preferencesKey struct {
+	organizationID string
+	userID         string
+}
+
+// MemoryStore keeps watchers, notifications and preferences in process.
+// Like store.MemoryStore it copies them on the way in and out.
+type MemoryStore struct {
+	mu            sync.RWMutex
+	watchers      []watcher // in the order they started watching
+	notifications map[string]*ticketpb.Notification
+	preferences   map[preferencesKey]*ticketpb.NotificationPreferences
+}
+
+var _ Store = (*MemoryStore)(nil)
+var _ Store = (*store.SQLiteNotifications)(nil)
+var _ Store = (*store.PostgresNotifications)(nil)
+
+// NewMemoryStore creates an empty in-memory notification store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		notifications: make(map[string]*ticketpb.Notification),
+		preferences:   make(map[preferencesKey]*ticketpb.NotificationPreferences),
+	}
+}
+
+// Watch adds a watcher to a ticket
+func (m *MemoryStore) Watch(ctx context.Context, ticketID, userID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w := watcher{organizationID: tenant.ID(ctx), ticketID: ticketID, userID: userID}
+	if !slices.Contains(m.watchers, w) {
+		m.watchers = append(m.watchers, w)
+	}
+	return nil
+}
+
+// Unwatch removes a watcher
+func (m *MemoryStore) Unwatch(ctx context.Context, ticketID, userID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w := watcher{organizationID: tenant.ID(ctx), ticketID: ticketID, userID: userID}
+	watching := slices.Contains(m.watchers, w)
+	m.watchers = slices.DeleteFunc(m.watchers, func(other watcher) bool { return other == w })
+	return watching, nil
+}
+
+// Watchers returns the watchers of a ticket
+func (m *MemoryStore) Watchers(ctx context.Context, ticketID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	organizationID := tenant.ID(ctx)
+	var users []string
+	for _, w := range m.watchers {
+		if w.organizationID == organizationID && w.ticketID == ticketID {
+			users = append(users, w.userID)
+		}
+	}
+	return users, nil
+}
+
+// DeleteWatchers removes every watcher of a ticket
+func (m *MemoryStore) DeleteWatchers(ctx context.Context, ticketID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	organizationID := tenant.ID(ctx)
+	m.watchers = slices.DeleteFunc(m.watchers, func(w watcher) bool {
+		return w.organizationID == organizationID && w.ticketID == ticketID
+	})
+	return nil
+}
+
+// CreateNotifications stores new notifications
+func (m *MemoryStore) CreateNotifications(ctx context.Context, notifications []*ticketpb.Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, notification := range notifications {
+		notification.OrganizationId = tenant.ID(ctx)
+		if !m.notifiedLocked(notification) {
+			m.notifications[notification.Id] = proto.Clone(notification).(*ticketpb.Notification)
+		}
+	}
+	return nil
+}
+
+// notifiedLocked reports whether the user of a notification already has one
+// of its event. Callers hold m.mu.
+func (m *MemoryStore) notifiedLocked(notification *ticketpb.Notification) bool {
+	for _, other := range m.notifications {
+		if other.OrganizationId == notification.OrganizationId && other.UserId == notification.UserId &&
+			other.EventId == notification.EventId {
+			return true
+		}
+	}
+	return false
+}
+
+// ListNotifications returns the notifications of a user, newest first
+func (m *MemoryStore) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]*ticketpb.Notification, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	organizationID := tenant.ID(ctx)
+	var notifications []*ticketpb.Notification
+	for _, notification := range m.notifications {
+		if notification.OrganizationId == organizationID && notification.UserId == userID &&
+			(!unreadOnly || notification.ReadAt == nil) {
+			notifications = append(notifications, notification)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		a, b := notifications[i], notifications[j]
+		if !a.CreatedAt.AsTime().Equal(b.CreatedAt.AsTime()) {
+			return a.CreatedAt.AsTime().After(b.CreatedAt.AsTime())
+		}
+		return a.Id > b.Id
+	})
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return cloneNotifications(notifications), nil
+}
+
+// MarkRead sets the read time of a notification
+func (m *MemoryStore) MarkRead(ctx context.Context, id string, at time.Time) (*ticketpb.Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	notification, exists := m.notifications[id]
+	if !exists || notification.OrganizationId != tenant.ID(ctx) {
+		return nil, store.ErrNotFound
+	}
+	if notification.ReadAt == nil {
+		notification.ReadAt = timestamppb.New(at)
+	}
+	return proto.Clone(notification).(*ticketpb.Notification), nil
+}
+
+// DueNotifications returns the notifications of every organization whose
+// delivery is due
+func (m *MemoryStore) DueNotifications(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Notification, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var due []*ticketpb.Notification
+	for _, notification := range m.notifications {
+		if notification.DeliverAt != nil && !notification.DeliverAt.AsTime().After(now) {
+			due = append(due, notification)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		a, b := due[i], due[j]
+		if !a.DeliverAt.AsTime().Equal(b.DeliverAt.AsTime()) {
+			return a.DeliverAt.AsTime().Before(b.DeliverAt.AsTime())
+		}
+		return a.Id < b.Id
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return cloneNotifications(due), nil
+}
+
+// MarkDelivered records the delivery of notifications
+func (m *MemoryStore) MarkDelivered(ctx context.Context, ids []string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	organizationID := tenant.ID(ctx)
+	for _, id := range ids {
+		if notification, exists := m.notifications[id]; exists && notification.OrganizationId == organizationID {
+			notification.DeliverAt = nil
+			notification.DeliveredAt = timestamppb.New(at)
+		}
+	}
+	return nil
+}
+
+// GetPreferences retrieves the preferences of a user
+func (m *MemoryStore) GetPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	preferences, exists := m.preferences[preferencesKey{organizationID: tenant.ID(ctx), userID: userID}]
+	if !exists {
+		return nil, store.ErrNotFound
+	}
+	return proto.Clone(preferences).(*ticketpb.NotificationPreferences), nil
+}
+
+// SetPreferences stores the preferences of a user
+func (m *MemoryStore) SetPreferences(ctx context.Context, preferences *ticketpb.NotificationPreferences) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	preferences.OrganizationId = tenant.ID(ctx)
+	key := preferencesKey{organizationID: preferences.OrganizationId, userID: preferences.UserId}
+	m.preferences[key] = proto.Clone(preferences).(*ticketpb.NotificationPreferences)
+	return nil
+}
+
+// cloneNotifications copies notifications handed out by the store
+func cloneNotifications(notifications []*ticketpb.Notification) []*ticketpb.Notification {
+	cloned := make([]*ticketpb.Notification, len(notifications))
+	for i, notification := range notifications {
+		cloned[i] = proto.Clone(notification).(*ticketpb.Notification)
+	}
+	return cloned
+}
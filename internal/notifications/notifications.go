@@ -0,0 +1,187 @@
+// Package notifications tells users about the tickets they are involved
+// in: assignees, reporters and watchers get an in-app inbox entry for the
+// ticket events that concern them, also sent on delivery channels such as
+// email, at once or batched into a daily digest
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+// DefaultDigestHour is the hour of the day (UTC) daily digests are sent
+// unless a user picks another
+const DefaultDigestHour = 8
+
+// Store persists watchers, notifications and notification preferences.
+// Every method acts for the organization of ctx (see tenant.ID) except
+// DueNotifications, and returns store.ErrNotFound for unknown IDs.
+type Store interface {
+	// Watch adds a watcher to a ticket; watching it again is not an error
+	Watch(ctx context.Context, ticketID, userID string, at time.Time) error
+	// Unwatch removes a watcher and reports whether the user was watching
+	Unwatch(ctx context.Context, ticketID, userID string) (bool, error)
+	// Watchers returns the watchers of a ticket in the order they started
+	// watching
+	Watchers(ctx context.Context, ticketID string) ([]string, error)
+	// DeleteWatchers removes every watcher of a ticket
+	DeleteWatchers(ctx context.Context, ticketID string) error
+
+	// CreateNotifications stores new notifications, setting OrganizationId
+	// from ctx. Notifications of an event their user already has are
+	// skipped, so an event handled twice notifies once.
+	CreateNotifications(ctx context.Context, notifications []*ticketpb.Notification) error
+	// ListNotifications returns up to limit notifications of a user, newest
+	// first, only the unread ones when unreadOnly is set
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]*ticketpb.Notification, error)
+	// MarkRead sets the read time of a notification unless it is already
+	// read and returns the notification
+	MarkRead(ctx context.Context, id string, at time.Time) (*ticketpb.Notification, error)
+	// DueNotifications returns up to limit notifications whose delivery is
+	// at or before now, earliest first. Like store.SLAStore it spans all
+	// organizations: the notifier then acts for each notification's own.
+	DueNotifications(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Notification, error)
+	// MarkDelivered sets the delivery time of notifications and clears
+	// their pending delivery
+	MarkDelivered(ctx context.Context, ids []string, at time.Time) error
+
+	// GetPreferences retrieves the preferences a user stored
+	GetPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error)
+	// SetPreferences stores a user's preferences, setting OrganizationId
+	// from ctx
+	SetPreferences(ctx context.Context, preferences *ticketpb.NotificationPreferences) error
+}
+
+// Preferences returns a user's preferences, the defaults when the user
+// stored none
+func Preferences(ctx context.Context, s Store, userID string) (*ticketpb.NotificationPreferences, error) {
+	preferences, err := s.GetPreferences(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		return &ticketpb.NotificationPreferences{
+			UserId:     userID,
+			Delivery:   ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_IMMEDIATE,
+			DigestHour: DefaultDigestHour,
+		}, nil
+	}
+	return preferences, err
+}
+
+// ValidatePreferences checks the digest hour and the muted kinds of
+// preferences
+func ValidatePreferences(preferences *ticketpb.NotificationPreferences) error {
+	if preferences.DigestHour < 0 || preferences.DigestHour > 23 {
+		return fmt.Errorf("digest hour %d is not between 0 and 23", preferences.DigestHour)
+	}
+	for _, kind := range preferences.MutedKinds {
+		if _, known := ticketpb.NotificationKind_name[int32(kind)]; !known || kind == ticketpb.NotificationKind_NOTIFICATION_KIND_UNSPECIFIED {
+			return fmt.Errorf("unknown notification kind %d", kind)
+		}
+	}
+	return nil
+}
+
+// Recipient is a user to notify of an event
+type Recipient struct {
+	UserID string
+	Kind   ticketpb.NotificationKind
+}
+
+// Recipients returns the users to notify of an event about a ticket with
+// watchers, each one once:
+//   - the new assignee of a created or reassigned ticket (ASSIGNED);
+//   - the watchers of a ticket updated without a status change (UPDATED);
+//   - the reporter and watchers of a transition (RESOLVED when the ticket was
+//     resolved or closed, STATUS_CHANGED otherwise);
+//   - the watchers of a deleted ticket (DELETED);
+//   - the assignee and watchers of a ticket whose SLA is at risk or breached.
+func Recipients(event events.Event, watchers []string) []Recipient {
+	ticket, previous := event.Ticket, event.Previous
+	var recipients []Recipient
+	add := func(kind ticketpb.NotificationKind, users ...string) {
+		for _, user := range users {
+			if user != "" && !slices.ContainsFunc(recipients, func(r Recipient) bool { return r.UserID == user }) {
+				recipients = append(recipients, Recipient{UserID: user, Kind: kind})
+			}
+		}
+	}
+
+	switch event.Type {
+	case events.TicketCreated:
+		add(ticketpb.NotificationKind_NOTIFICATION_KIND_ASSIGNED, ticket.AssigneeId)
+	case events.TicketUpdated:
+		if previous != nil && ticket.AssigneeId != previous.AssigneeId {
+			add(ticketpb.NotificationKind_NOTIFICATION_KIND_ASSIGNED, ticket.AssigneeId)
+		}
+		// A status change notifies with the TicketTransitioned event that follows
+		if previous == nil || ticket.Status == previous.Status {
+			add(ticketpb.NotificationKind_NOTIFICATION_KIND_UPDATED, watchers...)
+		}
+	case events.TicketTransitioned:
+		kind := ticketpb.NotificationKind_NOTIFICATION_KIND_STATUS_CHANGED
+		if resolved(ticket.Status) {
+			kind = ticketpb.NotificationKind_NOTIFICATION_KIND_RESOLVED
+		}
+		add(kind, ticket.ReporterId)
+		add(kind, watchers...)
+	case events.TicketDeleted:
+		add(ticketpb.NotificationKind_NOTIFICATION_KIND_DELETED, watchers...)
+	case events.SLAAtRisk:
+		add(ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_AT_RISK, ticket.AssigneeId)
+		add(ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_AT_RISK, watchers...)
+	case events.SLABreached:
+		add(ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_BREACHED, ticket.AssigneeId)
+		add(ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_BREACHED, watchers...)
+	}
+	return recipients
+}
+
+// resolved reports whether a status ends the work on a ticket
+func resolved(status ticketpb.TicketStatus) bool {
+	return status == ticketpb.TicketStatus_TICKET_STATUS_RESOLVED || status == ticketpb.TicketStatus_TICKET_STATUS_CLOSED
+}
+
+// Message returns the one-line summary of a notification about a ticket,
+// e.g. WEB-42 "Checkout is down" was assigned to you
+func Message(kind ticketpb.NotificationKind, ticket *ticketpb.Ticket) string {
+	subject := fmt.Sprintf("%q", ticket.Title)
+	if ticket.Key != "" {
+		subject = ticket.Key + " " + subject
+	}
+	switch kind {
+	case ticketpb.NotificationKind_NOTIFICATION_KIND_ASSIGNED:
+		return subject + " was assigned to you"
+	case ticketpb.NotificationKind_NOTIFICATION_KIND_STATUS_CHANGED:
+		return subject + " moved to " + strings.TrimPrefix(ticket.Status.String(), "TICKET_STATUS_")
+	case ticketpb.NotificationKind_NOTIFICATION_KIND_RESOLVED:
+		if ticket.Status == ticketpb.TicketStatus_TICKET_STATUS_CLOSED {
+			return subject + " was closed"
+		}
+		return subject + " was resolved"
+	case ticketpb.NotificationKind_NOTIFICATION_KIND_DELETED:
+		return subject + " was deleted"
+	case ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_AT_RISK:
+		return subject + " is at risk of missing its SLA"
+	case ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_BREACHED:
+		return subject + " breached its SLA"
+	}
+	return subject + " was updated"
+}
+
+// NextDigest returns the first time after after that falls on the digest
+// hour
+func NextDigest(after time.Time, hour int) time.Time {
+	after = after.UTC()
+	next := time.Date(after.Year(), after.Month(), after.Day(), hour, 0, 0, 0, time.UTC)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
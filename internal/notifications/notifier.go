@@ -0,0 +1,219 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// batchSize is the number of due notifications loaded at a time
+const batchSize = 100
+
+// Channel sends notifications outside the in-app inbox
+type Channel interface {
+	// Send sends a user one message about notifications: a single one, or
+	// the batch of a daily digest. Users the channel cannot reach, such as
+	// users without an email address, are skipped without an error.
+	Send(ctx context.Context, preferences *ticketpb.NotificationPreferences, notifications []*ticketpb.Notification) error
+}
+
+// Notifier turns ticket events into notifications and sends them on its
+// channels as the preferences of their users allow
+type Notifier struct {
+	store    Store
+	channels []Channel
+}
+
+// NewNotifier creates a notifier that keeps notifications in store
+func NewNotifier(store Store, channels ...Channel) *Notifier {
+	return &Notifier{store: store, channels: channels}
+}
+
+// now returns the current time at the microsecond precision kept by SQL stores
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// Notify notifies the users concerned by an event (see Recipients), except
+// those who muted its kind, acting for the event's organization. Notifying
+// of an event again is harmless: users get one notification per event, so
+// the outbox relay may hand it over more than once.
+func (n *Notifier) Notify(ctx context.Context, event events.Event) error {
+	ctx = tenant.WithOrganization(ctx, event.OrganizationID)
+	watchers, err := n.store.Watchers(ctx, event.Ticket.Id)
+	if err != nil {
+		return fmt.Errorf("failed to list watchers of %s: %w", event.Ticket.Id, err)
+	}
+
+	var notifications []*ticketpb.Notification
+	created := now()
+	for _, recipient := range Recipients(event, watchers) {
+		preferences, err := Preferences(ctx, n.store, recipient.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get notification preferences of %s: %w", recipient.UserID, err)
+		}
+		if slices.Contains(preferences.MutedKinds, recipient.Kind) {
+			continue
+		}
+		notifications = append(notifications, &ticketpb.Notification{
+			Id:        uuid.New().String(),
+			UserId:    recipient.UserID,
+			Kind:      recipient.Kind,
+			TicketId:  event.Ticket.Id,
+			EventId:   event.ID,
+			Message:   Message(recipient.Kind, event.Ticket),
+			CreatedAt: timestamppb.New(created),
+			DeliverAt: n.deliverAt(preferences, created),
+		})
+	}
+	if len(notifications) > 0 {
+		if err := n.store.CreateNotifications(ctx, notifications); err != nil {
+			return fmt.Errorf("failed to create %d notifications of event %s: %w", len(notifications), event.ID, err)
+		}
+	}
+
+	// Watchers of a deleted ticket were told above and are done watching
+	if event.Type == events.TicketDeleted {
+		if err := n.store.DeleteWatchers(ctx, event.Ticket.Id); err != nil {
+			return fmt.Errorf("failed to delete watchers of %s: %w", event.Ticket.Id, err)
+		}
+	}
+	return nil
+}
+
+// deliverAt returns when the channels send a notification created at
+// created, or nil when they do not
+func (n *Notifier) deliverAt(preferences *ticketpb.NotificationPreferences, created time.Time) *timestamppb.Timestamp {
+	if len(n.channels) == 0 {
+		return nil
+	}
+	switch preferences.Delivery {
+	case ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_OFF:
+		return nil
+	case ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_DAILY_DIGEST:
+		return timestamppb.New(NextDigest(created, int(preferences.DigestHour)))
+	}
+	return timestamppb.New(created)
+}
+
+// Run sends due notifications every interval until ctx is done. Run it in
+// one ticket service only, or notifications may be sent twice.
+func (n *Notifier) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := n.DeliverDue(ctx); err != nil {
+				log.Printf("Notifications: Error sending notifications: %v", err)
+			}
+		}
+	}
+}
+
+// DeliverDue sends every notification whose delivery is due on the channels
+// and returns the number sent. A user's due notifications go out one by one,
+// or as a single digest for users who chose the daily digest. Notifications
+// a channel failed to send stay due and are sent again on the next pass.
+func (n *Notifier) DeliverDue(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		due, err := n.store.DueNotifications(ctx, now(), batchSize)
+		if err != nil {
+			return delivered, err
+		}
+
+		var errs []error
+		for _, batch := range byUser(due) {
+			sent, err := n.deliver(ctx, batch)
+			delivered += sent
+			errs = append(errs, err)
+		}
+		if err := errors.Join(errs...); err != nil {
+			return delivered, err
+		}
+		if len(due) < batchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// byUser splits notifications into the batches of each user, keeping their
+// order
+func byUser(notifications []*ticketpb.Notification) [][]*ticketpb.Notification {
+	var batches [][]*ticketpb.Notification
+	index := make(map[preferencesKey]int)
+	for _, notification := range notifications {
+		key := preferencesKey{organizationID: notification.OrganizationId, userID: notification.UserId}
+		i, exists := index[key]
+		if !exists {
+			i = len(batches)
+			index[key] = i
+			batches = append(batches, nil)
+		}
+		batches[i] = append(batches[i], notification)
+	}
+	return batches
+}
+
+// deliver sends the due notifications of one user, acting for the user's
+// organization, and returns the number sent
+func (n *Notifier) deliver(ctx context.Context, notifications []*ticketpb.Notification) (int, error) {
+	ctx = tenant.WithOrganization(ctx, notifications[0].OrganizationId)
+	userID := notifications[0].UserId
+	preferences, err := Preferences(ctx, n.store, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get notification preferences of %s: %w", userID, err)
+	}
+
+	// Users who turned delivery off since are not sent what is still due
+	var messages [][]*ticketpb.Notification
+	switch preferences.Delivery {
+	case ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_OFF:
+		return 0, n.markDelivered(ctx, notifications)
+	case ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_DAILY_DIGEST:
+		messages = [][]*ticketpb.Notification{notifications}
+	default:
+		for _, notification := range notifications {
+			messages = append(messages, []*ticketpb.Notification{notification})
+		}
+	}
+
+	sent := 0
+	for _, message := range messages {
+		for _, channel := range n.channels {
+			if err := channel.Send(ctx, preferences, message); err != nil {
+				return sent, fmt.Errorf("failed to notify %s: %w", userID, err)
+			}
+		}
+		if err := n.markDelivered(ctx, message); err != nil {
+			return sent, err
+		}
+		sent += len(message)
+	}
+	return sent, nil
+}
+
+// markDelivered records that notifications were delivered
+func (n *Notifier) markDelivered(ctx context.Context, notifications []*ticketpb.Notification) error {
+	ids := make([]string, len(notifications))
+	for i, notification := range notifications {
+		ids[i] = notification.Id
+	}
+	if err := n.store.MarkDelivered(ctx, ids, now()); err != nil {
+		return fmt.Errorf("failed to record the delivery of notifications: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/nats-io/nats.go"
+)
+
+// natsFlushTimeout bounds the wait for the server to acknowledge a publish
+const natsFlushTimeout = 5 * time.Second
+
+// NATSSink publishes events as JSON to the subject "<prefix>.<event type>",
+// e.g. tickets.ticket.created. The event ID travels in the Nats-Msg-Id
+// header, so a JetStream stream capturing the subjects drops the duplicates
+// of at-least-once delivery.
+type NATSSink struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+// NewNATSSink connects to the NATS server at url, retrying in the background
+// while it is unreachable
+func NewNATSSink(url, prefix string) (*NATSSink, error) {
+	conn, err := nats.Connect(url, nats.Name("ticket-service"), nats.MaxReconnects(-1), nats.RetryOnFailedConnect(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return &NATSSink{conn: conn, prefix: prefix}, nil
+}
+
+// Publish sends an event and waits until the server has it
+func (s *NATSSink) Publish(ctx context.Context, event events.Event) error {
+	if !s.conn.IsConnected() {
+		return fmt.Errorf("not connected to NATS (%s)", s.conn.Status())
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	msg := nats.NewMsg(s.prefix + "." + string(event.Type))
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+	msg.Data = data
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, natsFlushTimeout)
+	defer cancel()
+	if err := s.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush NATS connection: %w", err)
+	}
+	return nil
+}
+
+// Close drains pending messages and closes the connection
+func (s *NATSSink) Close() error {
+	return s.conn.Drain()
+}
@@ -0,0 +1,100 @@
+// Package outbox relays the ticket events recorded in a store's outbox to
+// the sinks that react to them. Stores record events in the transaction of
+// the write that caused them, so an event is never lost between a commit
+// and its publication; the relay hands each one to every sink, in the order
+// they were recorded, and removes it from the outbox once all of them took
+// it. Delivery is at least once: consumers drop duplicates by event ID.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// batchSize is the number of pending events loaded at a time
+const batchSize = 100
+
+// Sink takes events from the relay. An error leaves the event in the outbox,
+// to be handed to every sink again on the next pass.
+type Sink interface {
+	Publish(ctx context.Context, event events.Event) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(ctx context.Context, event events.Event) error
+
+// Publish calls f
+func (f SinkFunc) Publish(ctx context.Context, event events.Event) error {
+	return f(ctx, event)
+}
+
+// Bus returns a sink publishing events to the subscribers of an in-process
+// bus, acting for the event's organization
+func Bus(bus *events.Bus) Sink {
+	return SinkFunc(func(ctx context.Context, event events.Event) error {
+		bus.Publish(tenant.WithOrganization(ctx, event.OrganizationID), event)
+		return nil
+	})
+}
+
+// Relay moves events from an outbox to its sinks
+type Relay struct {
+	store store.OutboxStore
+	sinks []Sink
+}
+
+// NewRelay creates a relay of the events pending in store
+func NewRelay(store store.OutboxStore, sinks ...Sink) *Relay {
+	return &Relay{store: store, sinks: sinks}
+}
+
+// Run relays pending events every interval until ctx is done. Run it in one
+// ticket service only, or events may be relayed twice and out of order.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.RelayPending(ctx); err != nil {
+				log.Printf("Outbox: Error relaying events: %v", err)
+			}
+		}
+	}
+}
+
+// RelayPending hands every pending event to the sinks, oldest first, and
+// returns the number of events relayed. It stops at the first event a sink
+// fails to take, so later events never overtake it.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	relayed := 0
+	for {
+		pending, err := r.store.PendingEvents(ctx, batchSize)
+		if err != nil {
+			return relayed, fmt.Errorf("failed to load pending events: %w", err)
+		}
+		for _, event := range pending {
+			for _, sink := range r.sinks {
+				if err := sink.Publish(ctx, event); err != nil {
+					return relayed, fmt.Errorf("failed to publish event %s (%s): %w", event.ID, event.Type, err)
+				}
+			}
+			if err := r.store.MarkPublished(ctx, event); err != nil {
+				return relayed, fmt.Errorf("failed to mark event %s published: %w", event.ID, err)
+			}
+			relayed++
+		}
+		if len(pending) < batchSize {
+			return relayed, nil
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package persisted
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// ErrorCodeNotFound matches the APQ protocol code for unknown hashes
+	ErrorCodeNotFound = "PERSISTED_QUERY_NOT_FOUND"
+	// ErrorCodeNotAllowed is returned for ad-hoc queries in allowlist mode
+	ErrorCodeNotAllowed = "OPERATION_NOT_ALLOWED"
+)
+
+// Allowlist only executes operations listed in the manifest. Clients may send
+// either the APQ persistedQuery extension or the full query text; anything not
+// in the manifest is rejected and nothing is ever added at runtime.
+type Allowlist struct {
+	Manifest *Manifest
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = Allowlist{}
+
+// ExtensionName returns the extension name
+func (a Allowlist) ExtensionName() string {
+	return "PersistedQueryAllowlist"
+}
+
+// Validate is a no-op, the extension works with any schema
+func (a Allowlist) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationParameters resolves the query from the manifest or rejects it
+func (a Allowlist) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if persisted, ok := rawParams.Extensions["persistedQuery"].(map[string]interface{}); ok {
+		hash, _ := persisted["sha256Hash"].(string)
+		query, found := a.Manifest.Lookup(hash)
+		if !found {
+			return codedError(ErrorCodeNotFound, "PersistedQueryNotFound")
+		}
+		rawParams.Query = query
+		return nil
+	}
+
+	if !a.Manifest.Contains(rawParams.Query) {
+		return codedError(ErrorCodeNotAllowed, "operation is not in the persisted query allowlist")
+	}
+	return nil
+}
+
+// codedError builds a GraphQL error with an extension code
+func codedError(code, message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
@@ -0,0 +1,162 @@
+package persisted
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// ManifestVersion is the current manifest file format version
+const ManifestVersion = 1
+
+// Manifest is the list of operations approved for the allowlist mode,
+// keyed by the SHA-256 hash of the normalized operation text
+type Manifest struct {
+	Version    int               `json:"version"`
+	Operations map[string]string `json:"operations"`
+	// Aliases maps the hash of each operation's text as extracted, which is
+	// what APQ clients send, to its entry in Operations
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// normalized maps the hash of each operation's normalized text to its
+	// entry in Operations. The two hashes only differ for entries written
+	// before operations were normalized; Contains matches query text by it.
+	normalized map[string]string
+}
+
+// NewManifest creates an empty manifest
+func NewManifest() *Manifest {
+	return &Manifest{
+		Version:    ManifestVersion,
+		Operations: make(map[string]string),
+		Aliases:    make(map[string]string),
+		normalized: make(map[string]string),
+	}
+}
+
+// Normalize formats a GraphQL document canonically, so the same operation
+// hashes the same whatever its whitespace, commas or comments
+func Normalize(query string) (string, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	formatter.NewFormatter(&b).FormatQueryDocument(doc)
+	return b.String(), nil
+}
+
+// Hash returns the SHA-256 hash used to identify a query, matching the APQ protocol
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Add registers the normalized form of a query and returns its hash. The
+// hash of the query as given finds it too.
+func (m *Manifest) Add(query string) (string, error) {
+	normalized, err := Normalize(query)
+	if err != nil {
+		return "", err
+	}
+	hash := Hash(normalized)
+	m.Operations[hash] = normalized
+	m.normalized[hash] = hash
+	if raw := Hash(query); raw != hash {
+		m.Aliases[raw] = hash
+	}
+	return hash, nil
+}
+
+// Lookup returns the query stored for a hash: the hash of an entry, of the
+// operation as extracted or of its normalized text
+func (m *Manifest) Lookup(hash string) (string, bool) {
+	if query, ok := m.Operations[hash]; ok {
+		return query, true
+	}
+	entry, ok := m.Aliases[hash]
+	if !ok {
+		entry, ok = m.normalized[hash]
+	}
+	if !ok {
+		return "", false
+	}
+	return m.Operations[entry], true
+}
+
+// Contains reports whether the manifest lists a query, however it is formatted
+func (m *Manifest) Contains(query string) bool {
+	normalized, err := Normalize(query)
+	if err != nil {
+		return false
+	}
+	_, ok := m.normalized[Hash(normalized)]
+	return ok
+}
+
+// Hashes returns the manifest hashes in sorted order
+func (m *Manifest) Hashes() []string {
+	hashes := make([]string, 0, len(m.Operations))
+	for hash := range m.Operations {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// LoadManifest reads and verifies a manifest file
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	manifest := NewManifest()
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+
+	for hash, query := range manifest.Operations {
+		if Hash(query) != hash {
+			return nil, fmt.Errorf("manifest entry %s does not match the hash of its query", hash)
+		}
+		normalized, err := Normalize(query)
+		if err != nil {
+			return nil, fmt.Errorf("manifest entry %s: %w", hash, err)
+		}
+		manifest.normalized[Hash(normalized)] = hash
+	}
+	if manifest.Aliases == nil {
+		manifest.Aliases = make(map[string]string)
+	}
+	for alias, hash := range manifest.Aliases {
+		if _, ok := manifest.Operations[hash]; !ok {
+			return nil, fmt.Errorf("manifest alias %s names the missing entry %s", alias, hash)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Save writes the manifest as indented JSON
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
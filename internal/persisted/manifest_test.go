@@ -0,0 +1,133 @@
+package persisted_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/ayush-pandya/Graphql/internal/persisted"
+)
+
+const extracted = `query Ticket($id: ID!) {
+  ticket(id: $id) { id title }
+}`
+
+// reformatted is extracted as a client might send it
+const reformatted = `
+	# fetched by the ticket page
+	query Ticket($id: ID!) { ticket(id: $id) { id, title } }
+`
+
+func TestManifestNormalizes(t *testing.T) {
+	manifest := persisted.NewManifest()
+	hash, err := manifest.Add(extracted)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if again, _ := manifest.Add(reformatted); again != hash {
+		t.Errorf("the same operation formatted differently hashes to %s and %s", hash, again)
+	}
+	if len(manifest.Operations) != 1 {
+		t.Errorf("manifest holds %d operations, want 1", len(manifest.Operations))
+	}
+	if !manifest.Contains(reformatted) {
+		t.Error("the manifest does not contain the reformatted operation")
+	}
+	if manifest.Contains(`query Ticket($id: ID!) { ticket(id: $id) { id title description } }`) {
+		t.Error("the manifest contains an operation selecting more fields")
+	}
+	if _, err := manifest.Add("query {"); err == nil {
+		t.Error("Add accepted an invalid document")
+	}
+}
+
+func TestManifestLookupByClientHash(t *testing.T) {
+	manifest := persisted.NewManifest()
+	hash, err := manifest.Add(extracted)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if persisted.Hash(extracted) == hash {
+		t.Fatal("the extracted operation is already normalized")
+	}
+
+	// APQ clients hash the operation as written, not its normalized text
+	path := filepath.Join(t.TempDir(), "persisted-queries.json")
+	if err := manifest.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := persisted.LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	for _, m := range []*persisted.Manifest{manifest, loaded} {
+		for _, h := range []string{hash, persisted.Hash(extracted)} {
+			if query, ok := m.Lookup(h); !ok || query != m.Operations[hash] {
+				t.Errorf("Lookup(%s) = %q, %t; want the operation", h[:12], query, ok)
+			}
+		}
+		if _, ok := m.Lookup(persisted.Hash(reformatted)); ok {
+			t.Error("Lookup found the hash of text that was never extracted")
+		}
+	}
+
+	allowlist := persisted.Allowlist{Manifest: loaded}
+	params := graphql.RawParams{Extensions: map[string]interface{}{
+		"persistedQuery": map[string]interface{}{"sha256Hash": persisted.Hash(extracted)},
+	}}
+	if err := allowlist.MutateOperationParameters(context.Background(), &params); err != nil || params.Query != loaded.Operations[hash] {
+		t.Errorf("allowlist by the client's hash: query %q, error %v", params.Query, err)
+	}
+}
+
+func TestLoadManifestBeforeNormalization(t *testing.T) {
+	// Manifests used to hold operations as extracted
+	path := filepath.Join(t.TempDir(), "persisted-queries.json")
+	data, _ := json.Marshal(map[string]interface{}{
+		"version":    persisted.ManifestVersion,
+		"operations": map[string]string{persisted.Hash(extracted): extracted},
+	})
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	manifest, err := persisted.LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if !manifest.Contains(reformatted) {
+		t.Error("the manifest does not contain the reformatted operation")
+	}
+
+	allowlist := persisted.Allowlist{Manifest: manifest}
+	tests := []struct {
+		name   string
+		params graphql.RawParams
+		code   string
+	}{
+		{"text", graphql.RawParams{Query: reformatted}, ""},
+		{"hash", graphql.RawParams{Extensions: map[string]interface{}{
+			"persistedQuery": map[string]interface{}{"sha256Hash": persisted.Hash(extracted)},
+		}}, ""},
+		{"unknown text", graphql.RawParams{Query: `{ tickets { id } }`}, persisted.ErrorCodeNotAllowed},
+		{"invalid text", graphql.RawParams{Query: `{`}, persisted.ErrorCodeNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			err := allowlist.MutateOperationParameters(context.Background(), &params)
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("operation rejected: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Extensions["code"] != tt.code {
+				t.Fatalf("got %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
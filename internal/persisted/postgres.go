@@ -0,0 +1,105 @@
+package persisted
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// touchInterval limits how often a hit refreshes the last use of a query, so
+// popular queries do not write on every request
+const touchInterval = time.Minute
+
+// PostgresCache stores automatic persisted queries in PostgreSQL so they are
+// shared by every gateway instance and survive restarts. Queries unused for
+// the TTL expire, and registering a query evicts the expired ones and the
+// least recently used beyond the maximum number of entries.
+type PostgresCache struct {
+	db         *sql.DB
+	ttl        time.Duration // 0 keeps queries until evicted by size
+	maxEntries int           // 0 does not limit the number of queries
+
+	mu        sync.Mutex
+	lastEvict time.Time
+	now       func() time.Time
+}
+
+var _ graphql.Cache[string] = (*PostgresCache)(nil)
+
+// NewPostgresCache creates a persisted query store backed by the persisted_queries table
+func NewPostgresCache(db *sql.DB, ttl time.Duration, maxEntries int) *PostgresCache {
+	return &PostgresCache{db: db, ttl: ttl, maxEntries: maxEntries, now: time.Now}
+}
+
+// Get looks up a query by its SHA-256 hash
+func (c *PostgresCache) Get(ctx context.Context, hash string) (string, bool) {
+	var query string
+	var lastUsed time.Time
+	err := c.db.QueryRowContext(ctx, `SELECT query, last_used_at FROM persisted_queries WHERE hash = $1`, hash).
+		Scan(&query, &lastUsed)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Persisted queries: failed to load %s: %v", hash, err)
+		}
+		return "", false
+	}
+
+	now := c.now()
+	if c.ttl > 0 && now.Sub(lastUsed) > c.ttl {
+		// The client registers it again
+		return "", false
+	}
+	if now.Sub(lastUsed) > touchInterval {
+		if _, err := c.db.ExecContext(ctx, `UPDATE persisted_queries SET last_used_at = $2 WHERE hash = $1`, hash, now); err != nil {
+			log.Printf("Persisted queries: failed to refresh %s: %v", hash, err)
+		}
+	}
+	return query, true
+}
+
+// Add stores a query under its SHA-256 hash
+func (c *PostgresCache) Add(ctx context.Context, hash string, query string) {
+	_, err := c.db.ExecContext(ctx, `
+		INSERT INTO persisted_queries (hash, query, last_used_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (hash) DO UPDATE SET last_used_at = EXCLUDED.last_used_at`, hash, query, c.now())
+	if err != nil {
+		log.Printf("Persisted queries: failed to store %s: %v", hash, err)
+		return
+	}
+	c.evict(ctx)
+}
+
+// evict deletes expired queries and the least recently used beyond the
+// maximum, at most once per touchInterval
+func (c *PostgresCache) evict(ctx context.Context) {
+	c.mu.Lock()
+	now := c.now()
+	if now.Sub(c.lastEvict) < touchInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.lastEvict = now
+	c.mu.Unlock()
+
+	if c.ttl > 0 {
+		if _, err := c.db.ExecContext(ctx, `DELETE FROM persisted_queries WHERE last_used_at < $1`, now.Add(-c.ttl)); err != nil {
+			log.Printf("Persisted queries: failed to delete expired queries: %v", err)
+		}
+	}
+	if c.maxEntries > 0 {
+		_, err := c.db.ExecContext(ctx, `
+			DELETE FROM persisted_queries WHERE hash IN (
+				SELECT hash FROM persisted_queries
+				ORDER BY last_used_at DESC, hash
+				OFFSET $1
+			)`, c.maxEntries)
+		if err != nil {
+			log.Printf("Persisted queries: failed to evict queries: %v", err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package persisted
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// openPostgres connects to the database named by TEST_POSTGRES_DSN, with
+// migrations/ applied, and empties its persisted queries
+func openPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`TRUNCATE persisted_queries`); err != nil {
+		t.Fatalf("failed to empty database: %v", err)
+	}
+	return db
+}
+
+func TestPostgresCache(t *testing.T) {
+	db := openPostgres(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+	cache := NewPostgresCache(db, time.Hour, 3)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		query := fmt.Sprintf("{ ticket(id: %d) { id } }", i)
+		cache.Add(ctx, Hash(query), query)
+		now = now.Add(2 * touchInterval)
+	}
+	if _, ok := cache.Get(ctx, Hash("{ ticket(id: 0) { id } }")); !ok {
+		t.Fatal("Get missed a registered query")
+	}
+
+	// A fourth query evicts the least recently used one, which is 1 since 0 was just read
+	now = now.Add(2 * touchInterval)
+	cache.Add(ctx, Hash("{ tickets { id } }"), "{ tickets { id } }")
+	if _, ok := cache.Get(ctx, Hash("{ ticket(id: 1) { id } }")); ok {
+		t.Error("the least recently used query was not evicted")
+	}
+	if _, ok := cache.Get(ctx, Hash("{ ticket(id: 0) { id } }")); !ok {
+		t.Error("a recently used query was evicted")
+	}
+
+	// Queries unused for the TTL expire
+	now = now.Add(time.Hour + time.Minute)
+	if _, ok := cache.Get(ctx, Hash("{ tickets { id } }")); ok {
+		t.Error("Get returned an expired query")
+	}
+	cache.Add(ctx, Hash("{ projects { id } }"), "{ projects { id } }")
+	var rows int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM persisted_queries`).Scan(&rows); err != nil {
+		t.Fatalf("failed to count queries: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("%d queries left after eviction, want 1", rows)
+	}
+}
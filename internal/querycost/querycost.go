@@ -0,0 +1,240 @@
+package querycost
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// extensionKey is the key the computed cost is returned under in response extensions
+	extensionKey = "cost"
+
+	// ErrorCodeTooDeep is returned when an operation exceeds the depth limit
+	ErrorCodeTooDeep = "QUERY_TOO_DEEP"
+	// ErrorCodeTooComplex is returned when an operation exceeds the complexity limit
+	ErrorCodeTooComplex = "QUERY_TOO_COMPLEX"
+
+	// MaxMultiplier caps a multiplier argument at the largest page the
+	// resolvers return, so asking for more does not inflate the cost
+	MaxMultiplier = 100
+)
+
+// Stats describes the measured cost of an operation
+type Stats struct {
+	Depth         int `json:"depth"`
+	MaxDepth      int `json:"maxDepth"`
+	Complexity    int `json:"complexity"`
+	MaxComplexity int `json:"maxComplexity"`
+}
+
+// Extension rejects operations that are too deep or too expensive before they
+// are executed. Field costs come from the @cost directive in the schema; a
+// limit of zero disables that check.
+type Extension struct {
+	MaxDepth      int
+	MaxComplexity int
+
+	schema *ast.Schema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+	graphql.ResponseInterceptor
+} = &Extension{}
+
+// New creates a query cost extension with the given limits
+func New(maxDepth, maxComplexity int) *Extension {
+	return &Extension{MaxDepth: maxDepth, MaxComplexity: maxComplexity}
+}
+
+// ExtensionName returns the extension name
+func (e *Extension) ExtensionName() string {
+	return "QueryCost"
+}
+
+// Validate keeps a reference to the schema so @cost directives can be read
+func (e *Extension) Validate(schema graphql.ExecutableSchema) error {
+	e.schema = schema.Schema()
+	return nil
+}
+
+// MutateOperationContext measures the operation and rejects it when over the limits
+func (e *Extension) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil {
+		return nil
+	}
+
+	depth, complexity := e.measure(opCtx.Operation.SelectionSet, opCtx.Variables)
+	stats := &Stats{
+		Depth:         depth,
+		MaxDepth:      e.MaxDepth,
+		Complexity:    complexity,
+		MaxComplexity: e.MaxComplexity,
+	}
+	opCtx.Stats.SetExtension(extensionKey, stats)
+
+	if e.MaxDepth > 0 && depth > e.MaxDepth {
+		return limitError(ErrorCodeTooDeep,
+			fmt.Sprintf("operation has depth %d, which exceeds the limit of %d", depth, e.MaxDepth), stats)
+	}
+	if e.MaxComplexity > 0 && complexity > e.MaxComplexity {
+		return limitError(ErrorCodeTooComplex,
+			fmt.Sprintf("operation has complexity %d, which exceeds the limit of %d", complexity, e.MaxComplexity), stats)
+	}
+	return nil
+}
+
+// InterceptResponse adds the computed cost to the response extensions
+func (e *Extension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp == nil || !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	if stats, ok := graphql.GetOperationContext(ctx).Stats.GetExtension(extensionKey).(*Stats); ok {
+		if resp.Extensions == nil {
+			resp.Extensions = make(map[string]interface{})
+		}
+		resp.Extensions[extensionKey] = stats
+	}
+	return resp
+}
+
+// measure returns the depth and complexity of a selection set. Costs
+// saturate at math.MaxInt, and measuring stops once the complexity is over
+// the limit since the operation is rejected anyway.
+func (e *Extension) measure(selections ast.SelectionSet, variables map[string]interface{}) (int, int) {
+	maxDepth, total := 0, 0
+
+	for _, selection := range selections {
+		var depth, cost int
+
+		switch sel := selection.(type) {
+		case *ast.Field:
+			// Introspection is not charged and does not count towards depth
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			childDepth, childCost := e.measure(sel.SelectionSet, variables)
+			weight, multiplier := fieldCost(sel, variables)
+			depth = childDepth + 1
+			cost = addCost(weight, mulCost(multiplier, childCost))
+		case *ast.InlineFragment:
+			depth, cost = e.measure(sel.SelectionSet, variables)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				depth, cost = e.measure(sel.Definition.SelectionSet, variables)
+			}
+		}
+
+		total = addCost(total, cost)
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		if e.MaxComplexity > 0 && total > e.MaxComplexity {
+			break
+		}
+	}
+
+	return maxDepth, total
+}
+
+// fieldCost reads the weight and multiplier of a field from its @cost directive.
+// Without the directive, object fields cost 1 and scalar fields are free. Each
+// integer multiplier argument counts for at most MaxMultiplier; a list argument
+// counts its items and multiplies the weight as well, since the field does its
+// work once per item.
+func fieldCost(field *ast.Field, variables map[string]interface{}) (int, int) {
+	weight, multiplier := 0, 1
+	if len(field.SelectionSet) > 0 {
+		weight = 1
+	}
+	if field.Definition == nil {
+		return weight, multiplier
+	}
+
+	directive := field.Definition.Directives.ForName("cost")
+	if directive == nil {
+		return weight, multiplier
+	}
+
+	if arg := directive.Arguments.ForName("weight"); arg != nil {
+		if raw, err := arg.Value.Value(nil); err == nil {
+			if value, ok := toInt(raw); ok && value >= 0 {
+				weight = value
+			}
+		}
+	}
+
+	if arg := directive.Arguments.ForName("multipliers"); arg != nil {
+		raw, _ := arg.Value.Value(nil)
+		names, _ := raw.([]interface{})
+		args := field.ArgumentMap(variables)
+		for _, name := range names {
+			argName, _ := name.(string)
+			if items, ok := args[argName].([]interface{}); ok {
+				if len(items) > 0 {
+					weight = mulCost(weight, len(items))
+					multiplier = mulCost(multiplier, len(items))
+				}
+				continue
+			}
+			if value, ok := toInt(args[argName]); ok && value > 0 {
+				multiplier = mulCost(multiplier, min(value, MaxMultiplier))
+			}
+		}
+	}
+
+	return weight, multiplier
+}
+
+// toInt converts a GraphQL integer value to int
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v >= math.MaxInt {
+			return math.MaxInt, true
+		}
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// addCost adds two non-negative costs, saturating at math.MaxInt
+func addCost(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// mulCost multiplies two non-negative costs, saturating at math.MaxInt
+func mulCost(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+// limitError builds the error returned for a rejected operation
+func limitError(code, message string, stats *Stats) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code": code,
+			"cost": stats,
+		},
+	}
+}
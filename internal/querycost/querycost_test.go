@@ -0,0 +1,151 @@
+package querycost
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `
+directive @cost(weight: Int = 1, multipliers: [String!]) on FIELD_DEFINITION
+
+type Ticket {
+  id: ID!
+  project: Project @cost(weight: 5)
+}
+
+type Project {
+  key: String!
+  tickets(first: Int = 100): [Ticket!]! @cost(weight: 10, multipliers: ["first"])
+}
+
+type BulkPayload {
+  results: [Ticket!]!
+  succeeded: Int!
+}
+
+type Query {
+  tickets(first: Int = 100): [Ticket!]! @cost(weight: 10, multipliers: ["first"])
+}
+
+type Mutation {
+  bulkDeleteTickets(ids: [ID!]!): BulkPayload! @cost(weight: 2, multipliers: ["ids"])
+}
+`
+
+// operation parses query against the test schema
+func operation(t *testing.T, query string, variables map[string]interface{}) *graphql.OperationContext {
+	t.Helper()
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: testSchema})
+	doc, errs := gqlparser.LoadQuery(schema, query)
+	if errs != nil {
+		t.Fatalf("failed to parse query: %v", errs)
+	}
+	return &graphql.OperationContext{Operation: doc.Operations[0], Variables: variables}
+}
+
+func TestMutateOperationContext(t *testing.T) {
+	nested := `query($n: Int) {
+		tickets(first: $n) { project { tickets(first: $n) { project { tickets(first: $n) { id } } } } }
+	}`
+
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]interface{}
+		complexity int
+		rejected   bool
+	}{
+		{
+			name:       "single page",
+			query:      `{ tickets(first: 10) { id } }`,
+			complexity: 10,
+		},
+		{
+			name:       "default page size",
+			query:      `{ tickets { id } }`,
+			complexity: 10,
+		},
+		{
+			name:  "huge first is clamped",
+			query: `{ tickets(first: 2147483647) { project { key } } }`,
+			// 10 + 100 * 5
+			complexity: 510,
+		},
+		{
+			name:  "bulk mutation",
+			query: `mutation { bulkDeleteTickets(ids: ["1", "2", "3"]) { succeeded } }`,
+			// 2 per ID
+			complexity: 6,
+		},
+		{
+			name:      "bulk mutation with selections",
+			query:     `mutation($ids: [ID!]!) { bulkDeleteTickets(ids: $ids) { results { project { key } } } }`,
+			variables: map[string]interface{}{"ids": []interface{}{"1", "2", "3"}},
+			// 3 * 2 + 3 * (1 + 5)
+			complexity: 24,
+		},
+		{
+			name:      "nested huge first",
+			query:     nested,
+			variables: map[string]interface{}{"n": 2147483647},
+			rejected:  true,
+		},
+		{
+			name: "nested huge literal first",
+			query: `{
+				tickets(first: 2147483647) { project { tickets(first: 2147483647) { project { tickets(first: 2147483647) { id } } } } }
+			}`,
+			rejected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opCtx := operation(t, tt.query, tt.variables)
+			ext := New(0, 10000)
+
+			err := ext.MutateOperationContext(context.Background(), opCtx)
+			stats := opCtx.Stats.GetExtension(extensionKey).(*Stats)
+			if stats.Complexity < 0 {
+				t.Fatalf("complexity overflowed to %d", stats.Complexity)
+			}
+			if tt.rejected {
+				if err == nil {
+					t.Fatalf("operation with complexity %d was accepted", stats.Complexity)
+				}
+				if code := err.Extensions["code"]; code != ErrorCodeTooComplex {
+					t.Fatalf("got error code %v, want %s", code, ErrorCodeTooComplex)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("operation was rejected: %v", err)
+			}
+			if stats.Complexity != tt.complexity {
+				t.Fatalf("got complexity %d, want %d", stats.Complexity, tt.complexity)
+			}
+		})
+	}
+}
+
+func TestMeasureSaturates(t *testing.T) {
+	// 100^12 overflows int without saturation
+	query := "id"
+	for i := 0; i < 12; i++ {
+		query = "tickets(first: 100) { project { " + query + " } }"
+	}
+	query = "{ " + strings.Replace(query, "project { id }", "id", 1) + " }"
+	opCtx := operation(t, query, nil)
+
+	// Without a complexity limit the whole operation is measured
+	_, complexity := New(0, 0).measure(opCtx.Operation.SelectionSet, nil)
+	if complexity != math.MaxInt {
+		t.Fatalf("got complexity %d, want it to saturate at %d", complexity, math.MaxInt)
+	}
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strconv"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// queryMethods lists the gRPC methods that only read. Every other method,
+// including ones added to the service later, is charged to the mutation
+// budget.
+var queryMethods = map[string]bool{
+	"/ticket.TicketService/GetTicket":                                true,
+	"/ticket.TicketService/ListTickets":                              true,
+	"/ticket.TicketService/ExportTickets":                            true,
+	"/ticket.TicketService/GetProject":                               true,
+	"/ticket.TicketService/ListProjects":                             true,
+	"/ticket.TicketService/ListTags":                                 true,
+	"/ticket.TicketService/ListSLAPolicies":                          true,
+	"/ticket.TicketService/ListWebhooks":                             true,
+	"/ticket.TicketService/ListWebhookDeliveries":                    true,
+	"/ticket.TicketService/ListWatchers":                             true,
+	"/ticket.TicketService/ListNotifications":                        true,
+	"/ticket.TicketService/GetNotificationPreferences":               true,
+	"/ticket.TicketService/ListComments":                             true,
+	"/ticket.TicketService/FindEmailThread":                          true,
+	"/ticket.TicketService/ListLinks":                                true,
+	"/ticket.TicketService/ListAttachments":                          true,
+	"/ticket.TicketService/DownloadAttachment":                       true,
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      true,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+}
+
+// isMutation reports whether the gRPC method may change state
+func isMutation(fullMethod string) bool {
+	return !queryMethods[fullMethod]
+}
+
+// itemRequest is implemented by bulk requests, which are charged one token
+// per ticket they name
+type itemRequest interface {
+	GetIds() []string
+}
+
+// cost returns the number of tokens a unary request takes
+func cost(req interface{}) int {
+	if r, ok := req.(itemRequest); ok && len(r.GetIds()) > 1 {
+		return len(r.GetIds())
+	}
+	return 1
+}
+
+// UnaryServerInterceptor applies the same query/mutation budgets to gRPC
+// calls. Bulk calls take one token per ticket.
+func UnaryServerInterceptor(queries, mutations *Limiter, trusted []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		limiter := queries
+		if isMutation(info.FullMethod) {
+			limiter = mutations
+		}
+		if limiter == nil {
+			return handler(ctx, req)
+		}
+
+		if err := allow(ctx, limiter, grpcClientKey(ctx, trusted), cost(req)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor applies the budgets to streaming calls. Opening a
+// stream takes one token, and every ticket sent on an ImportTickets stream
+// takes another, so an import cannot create more tickets than separate calls
+// could.
+func StreamServerInterceptor(queries, mutations *Limiter, trusted []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		limiter := queries
+		if isMutation(info.FullMethod) {
+			limiter = mutations
+		}
+		if limiter == nil {
+			return handler(srv, ss)
+		}
+
+		key := grpcClientKey(ss.Context(), trusted)
+		if err := allow(ss.Context(), limiter, key, 1); err != nil {
+			return err
+		}
+		if itemStreams[info.FullMethod] {
+			ss = &limitedStream{ServerStream: ss, limiter: limiter, key: key}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// itemStreams lists client streams whose messages after the first are one
+// ticket each
+var itemStreams = map[string]bool{
+	"/ticket.TicketService/ImportTickets": true,
+}
+
+// limitedStream charges every client message after the first
+type limitedStream struct {
+	grpc.ServerStream
+	limiter  *Limiter
+	key      string
+	received int
+}
+
+func (s *limitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.received++
+	if s.received == 1 {
+		return nil
+	}
+	result := s.limiter.Allow(s.key)
+	if !result.Allowed {
+		return exhausted(result)
+	}
+	return nil
+}
+
+// allow takes n tokens from the bucket of key and sets the rate limit headers
+func allow(ctx context.Context, limiter *Limiter, key string, n int) error {
+	result := limiter.AllowN(key, n)
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(result.Limit),
+		"ratelimit-remaining", strconv.Itoa(result.Remaining),
+		"ratelimit-reset", strconv.Itoa(seconds(result.Reset)),
+	)
+	if !result.Allowed {
+		md.Append("retry-after", strconv.Itoa(seconds(result.RetryAfter)))
+		_ = grpc.SetHeader(ctx, md)
+		return exhausted(result)
+	}
+	_ = grpc.SetHeader(ctx, md)
+	return nil
+}
+
+// exhausted returns the error for a call over its budget
+func exhausted(result Result) error {
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %d seconds", seconds(result.RetryAfter))
+}
+
+// grpcClientKey identifies the caller by the x-api-key, then x-user-id
+// metadata when a trusted proxy sends the call, and by the peer address
+// otherwise
+func grpcClientKey(ctx context.Context, trusted []netip.Prefix) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	addr := p.Addr.String()
+	if md, ok := metadata.FromIncomingContext(ctx); ok && tenant.Trusted(addr, trusted) {
+		if values := md.Get(APIKeyMetadataKey); len(values) > 0 && values[0] != "" {
+			return "key:" + values[0]
+		}
+		if values := md.Get(UserIDMetadataKey); len(values) > 0 && values[0] != "" {
+			return "user:" + values[0]
+		}
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return "ip:" + host
+}
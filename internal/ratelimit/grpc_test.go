@@ -0,0 +1,196 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsMutation(t *testing.T) {
+	desc := ticketpb.TicketService_ServiceDesc
+	var methods []string
+	for _, m := range desc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+
+	// Every read-only RPC is named after what it reads
+	for _, method := range methods {
+		query := false
+		for _, prefix := range []string{"Get", "List", "Find", "Export", "Download"} {
+			query = query || strings.HasPrefix(method, prefix)
+		}
+		fullMethod := "/" + desc.ServiceName + "/" + method
+		if got := isMutation(fullMethod); got == query {
+			t.Errorf("isMutation(%q) = %t, want %t", fullMethod, got, !query)
+		}
+	}
+	if !isMutation("/ticket.TicketService/NotYetKnown") {
+		t.Error("an unknown method is charged as a query")
+	}
+}
+
+func TestAllowN(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewLimiter(1, 5)
+	limiter.now = func() time.Time { return now }
+
+	if r := limiter.AllowN("a", 3); !r.Allowed || r.Remaining != 2 {
+		t.Fatalf("AllowN(3) = %+v, want allowed with 2 remaining", r)
+	}
+	if r := limiter.AllowN("a", 3); r.Allowed || r.RetryAfter != time.Second {
+		t.Fatalf("AllowN(3) = %+v, want denied for 1s", r)
+	}
+
+	// A cost above the burst runs on a full bucket and leaves it in debt
+	now = now.Add(3 * time.Second)
+	if r := limiter.AllowN("a", 10); !r.Allowed || r.Remaining != 0 {
+		t.Fatalf("AllowN(10) = %+v, want allowed with 0 remaining", r)
+	}
+	if r := limiter.Allow("a"); r.Allowed || r.RetryAfter != 6*time.Second {
+		t.Fatalf("Allow = %+v, want denied for 6s", r)
+	}
+
+}
+
+// start serves the ticket service with a mutation budget of burst tokens
+// that does not refill during the test, believing the identity metadata of
+// trusted peers. The client forwards the identity of its call contexts.
+func start(t *testing.T, burst int, trusted ...netip.Prefix) ticketpb.TicketServiceClient {
+	t.Helper()
+	mutations := NewLimiter(0.001, burst)
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(nil, mutations, trusted)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(nil, mutations, trusted)))
+
+	conn, err := grpc.NewClient(service.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return ticketpb.NewTicketServiceClient(conn)
+}
+
+func TestBulkCost(t *testing.T) {
+	client := start(t, 4)
+	ctx := context.Background()
+
+	created, err := client.CreateTicket(ctx, &ticketpb.CreateTicketRequest{Title: "First"})
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	ids := []string{created.Ticket.Id, "missing-1", "missing-2"}
+	if _, err := client.BulkDeleteTickets(ctx, &ticketpb.BulkDeleteTicketsRequest{Ids: ids}); err != nil {
+		t.Fatalf("BulkDeleteTickets: %v", err)
+	}
+
+	// The bulk call took three of the four tokens
+	_, err = client.AddComment(ctx, &ticketpb.AddCommentRequest{TicketId: created.Ticket.Id, Body: "Too late"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("AddComment = %v, want ResourceExhausted", err)
+	}
+	if _, err := client.ListTickets(ctx, &ticketpb.ListTicketsRequest{}); err != nil {
+		t.Fatalf("ListTickets is charged to the mutation budget: %v", err)
+	}
+}
+
+func TestImportCost(t *testing.T) {
+	client := start(t, 3)
+
+	stream, err := client.ImportTickets(context.Background())
+	if err != nil {
+		t.Fatalf("ImportTickets: %v", err)
+	}
+	send := []*ticketpb.ImportTicketsRequest{{
+		Data: &ticketpb.ImportTicketsRequest_Options{Options: &ticketpb.ImportOptions{}},
+	}}
+	for _, title := range []string{"One", "Two", "Three", "Four"} {
+		send = append(send, &ticketpb.ImportTicketsRequest{
+			Data: &ticketpb.ImportTicketsRequest_Ticket{Ticket: &ticketpb.TicketRecord{Title: title}},
+		})
+	}
+	for _, req := range send {
+		if stream.Send(req) != nil {
+			break
+		}
+	}
+
+	// Opening the stream and the first two tickets use the budget of three
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("ImportTickets = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestIdentityMetadata(t *testing.T) {
+	create := func(client ticketpb.TicketServiceClient, user string) error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-user-id", user)
+		_, err := client.CreateTicket(ctx, &ticketpb.CreateTicketRequest{Title: "From " + user})
+		return err
+	}
+
+	// Any client can name a user, so a fresh one does not buy a fresh budget
+	client := start(t, 1)
+	if err := create(client, "alice"); err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	if err := create(client, "bob"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("CreateTicket as another user = %v, want ResourceExhausted", err)
+	}
+
+	// Behind a trusted proxy every user has a budget of their own
+	client = start(t, 1, netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128"))
+	for _, user := range []string{"alice", "bob"} {
+		if err := create(client, user); err != nil {
+			t.Fatalf("CreateTicket as %s: %v", user, err)
+		}
+	}
+}
+
+func TestIdentityForwarding(t *testing.T) {
+	client := start(t, 1, netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128"))
+	gateway := IdentityMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := client.CreateTicket(r.Context(), &ticketpb.CreateTicketRequest{Title: "Forwarded"}); err != nil {
+			w.WriteHeader(runtime.HTTPStatusFromCode(status.Code(err)))
+		}
+	}), []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+	create := func(remoteAddr, user string) int {
+		r := httptest.NewRequest(http.MethodPost, "/query", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-User-ID", user)
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// Users named by the trusted proxy have a budget of their own
+	for _, user := range []string{"alice", "bob"} {
+		if code := create("10.1.2.3:4000", user); code != http.StatusOK {
+			t.Fatalf("CreateTicket as %s from the proxy: status %d", user, code)
+		}
+	}
+
+	// Users named by other clients are not forwarded, so they share the
+	// gateway's budget
+	if code := create("203.0.113.7:4000", "carol"); code != http.StatusOK {
+		t.Fatalf("CreateTicket from a client: status %d", code)
+	}
+	if code := create("203.0.113.7:4000", "dave"); code != http.StatusTooManyRequests {
+		t.Fatalf("CreateTicket as another user from a client: status %d, want 429", code)
+	}
+}
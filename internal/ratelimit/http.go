@@ -0,0 +1,213 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrorCode is the GraphQL error extension code returned when a client is throttled
+const ErrorCode = "RATE_LIMITED"
+
+type contextKey struct{}
+
+// requestState carries the client key and response headers from the HTTP
+// middleware to the GraphQL extension
+type requestState struct {
+	key     string
+	header  http.Header
+	limited bool
+}
+
+// statusWriter turns the response into a 429 once the request was throttled
+type statusWriter struct {
+	http.ResponseWriter
+	state       *requestState
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.state.limited {
+		code = http.StatusTooManyRequests
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Middleware identifies the client of each request so the GraphQL extension
+// can charge the right bucket. It must wrap the GraphQL handler.
+func Middleware(next http.Handler, trustProxy bool, trusted []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := &requestState{
+			key:    ClientKey(r, trustProxy, trusted),
+			header: w.Header(),
+		}
+		ctx := context.WithValue(r.Context(), contextKey{}, state)
+		next.ServeHTTP(&statusWriter{ResponseWriter: w, state: state}, r.WithContext(ctx))
+	})
+}
+
+// RequestMiddleware charges each request to the client's bucket, reads (GET
+// and HEAD) to queries and everything else to mutations. Throttled requests
+// get a 429 with a JSON error shaped like the REST API's gRPC status errors.
+func RequestMiddleware(next http.Handler, queries, mutations *Limiter, trustProxy bool, trusted []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limiter := mutations
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			limiter = queries
+		}
+		if limiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		result := limiter.Allow(ClientKey(r, trustProxy, trusted))
+		setHeaders(w.Header(), result)
+		if result.Allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		retryAfter := seconds(result.RetryAfter)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, `{"code":%d,"message":"rate limit exceeded, retry in %d seconds","details":[]}`+"\n",
+			codes.ResourceExhausted, retryAfter)
+	})
+}
+
+// ClientKey identifies the caller by API key, then user ID, then IP address.
+// Anyone can set the X-API-Key and X-User-ID headers, so they only count when
+// the request comes from a trusted proxy, which authenticates callers;
+// otherwise a client could take a fresh budget with every request. The same
+// goes for X-Forwarded-For, where only the hops appended by trusted proxies
+// can be believed.
+func ClientKey(r *http.Request, trustProxy bool, trusted []netip.Prefix) string {
+	if tenant.Trusted(r.RemoteAddr, trusted) {
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			return "key:" + key
+		}
+		if user := r.Header.Get("X-User-ID"); user != "" {
+			return "user:" + user
+		}
+	}
+	if trustProxy && tenant.Trusted(r.RemoteAddr, trusted) {
+		if ip := forwardedFor(r.Header, trusted); ip != "" {
+			return "ip:" + ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// forwardedFor returns the client address in the X-Forwarded-For headers:
+// the right-most hop that is not itself a trusted proxy. Hops further left
+// were sent by the client and may be forged.
+func forwardedFor(header http.Header, trusted []netip.Prefix) string {
+	hops := strings.Split(strings.Join(header.Values("X-Forwarded-For"), ","), ",")
+	client := ""
+	for i := len(hops) - 1; i >= 0; i-- {
+		client = strings.TrimSpace(hops[i])
+		if client != "" && !tenant.Trusted(client, trusted) {
+			break
+		}
+	}
+	return client
+}
+
+// Extension applies separate query and mutation budgets to GraphQL operations
+type Extension struct {
+	Queries   *Limiter
+	Mutations *Limiter
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = Extension{}
+
+// ExtensionName returns the extension name
+func (e Extension) ExtensionName() string {
+	return "RateLimit"
+}
+
+// Validate is a no-op, the extension works with any schema
+func (e Extension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationContext charges the client's bucket before execution
+func (e Extension) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	state, ok := ctx.Value(contextKey{}).(*requestState)
+	if !ok || opCtx.Operation == nil {
+		return nil
+	}
+
+	limiter := e.Queries
+	if opCtx.Operation.Operation == ast.Mutation {
+		limiter = e.Mutations
+	}
+	if limiter == nil {
+		return nil
+	}
+
+	result := limiter.Allow(state.key)
+	setHeaders(state.header, result)
+	if result.Allowed {
+		return nil
+	}
+
+	state.limited = true
+	retryAfter := seconds(result.RetryAfter)
+	state.header.Set("Retry-After", strconv.Itoa(retryAfter))
+
+	return &gqlerror.Error{
+		Message: fmt.Sprintf("rate limit exceeded, retry in %d seconds", retryAfter),
+		Extensions: map[string]interface{}{
+			"code":       ErrorCode,
+			"retryAfter": retryAfter,
+		},
+	}
+}
+
+// setHeaders writes the RateLimit-* headers for a result
+func setHeaders(header http.Header, result Result) {
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+}
+
+// seconds rounds a duration up to whole seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,44 @@
+package ratelimit_test
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/ratelimit"
+)
+
+func TestClientKey(t *testing.T) {
+	proxy := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		trustProxy bool
+		trusted    []netip.Prefix
+		want       string
+	}{
+		{"address", "203.0.113.7:4000", nil, false, nil, "ip:203.0.113.7"},
+		{"api key from a client", "203.0.113.7:4000", map[string]string{"X-API-Key": "k1"}, false, nil, "ip:203.0.113.7"},
+		{"user from an untrusted peer", "203.0.113.7:4000", map[string]string{"X-User-ID": "u1"}, false, proxy, "ip:203.0.113.7"},
+		{"api key from a trusted proxy", "10.1.2.3:4000", map[string]string{"X-API-Key": "k1", "X-User-ID": "u1"}, false, proxy, "key:k1"},
+		{"user from a trusted proxy", "10.1.2.3:4000", map[string]string{"X-User-ID": "u1"}, false, proxy, "user:u1"},
+		{"forwarded address", "10.1.2.3:4000", map[string]string{"X-Forwarded-For": "198.51.100.1, 10.1.2.3"}, true, proxy, "ip:198.51.100.1"},
+		{"forged forwarded address", "10.1.2.3:4000", map[string]string{"X-Forwarded-For": "192.0.2.1, 198.51.100.1, 10.9.9.9"}, true, proxy, "ip:198.51.100.1"},
+		{"forwarded through trusted proxies only", "10.1.2.3:4000", map[string]string{"X-Forwarded-For": "10.4.5.6, 10.9.9.9"}, true, proxy, "ip:10.4.5.6"},
+		{"forwarded from an untrusted peer", "203.0.113.7:4000", map[string]string{"X-Forwarded-For": "198.51.100.1"}, true, proxy, "ip:203.0.113.7"},
+		{"forwarded without trusted proxies", "10.1.2.3:4000", map[string]string{"X-Forwarded-For": "198.51.100.1"}, true, nil, "ip:10.1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/query", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			if got := ratelimit.ClientKey(r, tt.trustProxy, tt.trusted); got != tt.want {
+				t.Errorf("ClientKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// APIKeyMetadataKey is the gRPC metadata key carrying the caller's API key
+	APIKeyMetadataKey = "x-api-key"
+	// UserIDMetadataKey is the gRPC metadata key carrying the caller's user ID
+	UserIDMetadataKey = "x-user-id"
+)
+
+type identityKey struct{}
+
+// Identity is the caller a trusted proxy vouched for with the X-API-Key and
+// X-User-ID headers
+type Identity struct {
+	APIKey string
+	UserID string
+}
+
+// WithIdentity returns a context carrying the caller's identity
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller's identity set on ctx
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok && identity != Identity{}
+}
+
+// IdentityMiddleware puts the X-API-Key and X-User-ID headers of requests
+// from a trusted proxy on the request context, so the client interceptors
+// pass them on and the ticket service charges the caller rather than the
+// gateway. The headers of other clients are ignored, as in ClientKey.
+func IdentityMiddleware(next http.Handler, trusted []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := Identity{APIKey: r.Header.Get("X-API-Key"), UserID: r.Header.Get("X-User-ID")}
+		if identity != (Identity{}) && tenant.Trusted(r.RemoteAddr, trusted) {
+			r = r.WithContext(WithIdentity(r.Context(), identity))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UnaryClientInterceptor forwards the caller's identity of the context as gRPC metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the caller's identity of the context as gRPC metadata
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoing(ctx context.Context) context.Context {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if identity.APIKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, APIKeyMetadataKey, identity.APIKey)
+	}
+	if identity.UserID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, UserIDMetadataKey, identity.UserID)
+	}
+	return ctx
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval controls how often idle buckets are dropped
+const sweepInterval = time.Minute
+
+// Limiter is a token-bucket rate limiter keyed by client
+type Limiter struct {
+	rate  float64 // tokens added per second
+	burst int     // bucket capacity
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Result describes the outcome of a single Allow or AllowN call
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // time until the bucket is full again
+	RetryAfter time.Duration // time until the next request would be allowed
+}
+
+// NewLimiter creates a limiter refilling rate tokens per second up to burst
+func NewLimiter(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes one token from the bucket for key
+func (l *Limiter) Allow(key string) Result {
+	return l.AllowN(key, 1)
+}
+
+// AllowN takes n tokens from the bucket for key. A cost above the burst is
+// allowed once the bucket is full and leaves it in debt, so large calls still
+// run but pay for every item.
+func (l *Limiter) AllowN(key string, n int) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(float64(l.burst), b.tokens+elapsed*l.rate)
+	b.last = now
+
+	result := Result{Limit: l.burst}
+	if needed := math.Min(float64(n), float64(l.burst)); b.tokens >= needed {
+		b.tokens -= float64(n)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.durationFor(needed - b.tokens)
+	}
+
+	result.Remaining = int(math.Max(0, math.Floor(b.tokens)))
+	result.Reset = l.durationFor(float64(l.burst) - b.tokens)
+	return result
+}
+
+// durationFor returns how long it takes to refill the given number of tokens
+func (l *Limiter) durationFor(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep drops buckets that have been idle long enough to be full again
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= l.durationFor(float64(l.burst)-b.tokens) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package rest serves the ticket service as a REST/JSON API, transcoding
+// requests to gRPC calls by the google.api.http options of ticket.proto
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/textproto"
+
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/ratelimit"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Prefix is the path the API is served under
+	Prefix = "/v1/"
+	// OpenAPIPath serves the OpenAPI document of the API
+	OpenAPIPath = "/openapi.json"
+)
+
+// forwardedHeaders are the request headers passed on to the ticket service
+// as call metadata, so retries keep their idempotency key. Anything else,
+// notably Grpc-Metadata-* headers naming another organization, is dropped;
+// the caller's identity is passed on from the request context (see
+// ratelimit.IdentityMiddleware) so only trusted proxies can set it.
+var forwardedHeaders = map[string]string{
+	"Idempotency-Key": idempotency.MetadataKey,
+}
+
+// ReplayedHeader is set to "true" on responses replayed for a retried
+// idempotency key
+const ReplayedHeader = "Idempotent-Replayed"
+
+// Handler serves the REST API over its own connection to the ticket service
+type Handler struct {
+	conn *grpc.ClientConn
+	mux  *runtime.ServeMux
+}
+
+// NewHandler connects to the ticket service at target. Calls act for the
+// organization and caller on the request context (see tenant.Middleware and
+// ratelimit.IdentityMiddleware); opts add to the dial options, e.g.
+// interceptors that watch the calls.
+func NewHandler(target string, opts ...grpc.DialOption) (*Handler, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tenant.UnaryClientInterceptor(), ratelimit.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tenant.StreamClientInterceptor(), ratelimit.StreamClientInterceptor()),
+	}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ticket service: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(func(header string) (string, bool) {
+			key, ok := forwardedHeaders[textproto.CanonicalMIMEHeaderKey(header)]
+			return key, ok
+		}),
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			if key == idempotency.ReplayedMetadataKey {
+				return ReplayedHeader, true
+			}
+			return runtime.MetadataHeaderPrefix + key, true
+		}),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{EmitUnpopulated: true},
+			// Misspelled fields are rejected rather than silently left unchanged
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: false},
+		}),
+	)
+	if err := ticketpb.RegisterTicketServiceHandlerClient(context.Background(), mux, ticketpb.NewTicketServiceClient(conn)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register REST handlers: %w", err)
+	}
+
+	return &Handler{conn: conn, mux: mux}, nil
+}
+
+// ServeHTTP transcodes a request to a ticket service call
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Close closes the connection to the ticket service
+func (h *Handler) Close() error {
+	return h.conn.Close()
+}
+
+// OpenAPIHandler serves the OpenAPI document of the API
+func OpenAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.Write(ticketpb.OpenAPI)
+	})
+}
@@ -5,39 +5,23 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/ayush-pandya/Graphql/internal/database"
 	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
 var DB *sql.DB
 
-// getDBConfig returns database configuration parameters
-func getDBConfig() (string, string, string, string, string, string) {
-	host := "localhost"
-	port := "5432"
-	user := "ayushpandya"
-	password := "postgres"
-	dbname := "ticketdb"
-	sslmode := "disable"
-
-	return host, port, user, password, dbname, sslmode
-}
-
-func Connect() (*sql.DB, error) {
-
-	// Get database configuration
-	host, port, user, password, dbname, sslmode := getDBConfig()
+func Connect(config database.Config) (*sql.DB, error) {
 
 	log.Printf("Database settings: host=%s, port=%s, user=%s, dbname=%s",
-		host, port, user, dbname)
+		config.Host, config.Port, config.User, config.DBName)
 
 	// Create connection string
 	connStr := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		user, password, host, port, dbname, sslmode,
+		config.User, config.Password, config.Host, config.Port, config.DBName, config.SSLMode,
 	)
 
-	log.Println("Connection string", connStr)
-
 	var err error
 	DB, err = sql.Open("postgres", connStr)
 	if err != nil {
@@ -0,0 +1,191 @@
+// Package sla computes the response and resolution deadlines of tickets from
+// per-priority policies and tracks where tickets stand against them
+package sla
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Policy holds the targets of one priority. A zero duration is not tracked.
+type Policy struct {
+	FirstResponse time.Duration
+	Resolution    time.Duration
+}
+
+// Policies maps ticket priorities to their policy
+type Policies map[ticketpb.TicketPriority]Policy
+
+// DefaultPolicies are the policies used when none are configured
+var DefaultPolicies = []string{"CRITICAL=1h/4h", "HIGH=4h/24h", "MEDIUM=8h/72h", "LOW=24h/168h"}
+
+// ParsePolicies parses policies written as PRIORITY=response/resolution,
+// e.g. CRITICAL=1h/4h. Priorities without a policy have no SLA.
+func ParsePolicies(specs []string) (Policies, error) {
+	policies := make(Policies, len(specs))
+	for _, spec := range specs {
+		name, targets, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid SLA policy %q: want PRIORITY=response/resolution", spec)
+		}
+		priority, ok := ticketpb.TicketPriority_value["TICKET_PRIORITY_"+strings.ToUpper(strings.TrimSpace(name))]
+		if !ok || priority == 0 {
+			return nil, fmt.Errorf("invalid SLA policy %q: unknown priority %q", spec, name)
+		}
+		response, resolution, ok := strings.Cut(targets, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid SLA policy %q: want PRIORITY=response/resolution", spec)
+		}
+
+		var policy Policy
+		var err error
+		if policy.FirstResponse, err = time.ParseDuration(strings.TrimSpace(response)); err != nil || policy.FirstResponse < 0 {
+			return nil, fmt.Errorf("invalid SLA policy %q: bad response target %q", spec, response)
+		}
+		if policy.Resolution, err = time.ParseDuration(strings.TrimSpace(resolution)); err != nil || policy.Resolution < 0 {
+			return nil, fmt.Errorf("invalid SLA policy %q: bad resolution target %q", spec, resolution)
+		}
+		policies[ticketpb.TicketPriority(priority)] = policy
+	}
+	return policies, nil
+}
+
+// Tracker applies policies to tickets. A ticket becomes at risk once less
+// than the atRisk share of the window of its pending deadline is left.
+type Tracker struct {
+	policies Policies
+	atRisk   float64
+}
+
+// NewTracker creates a tracker for the given policies
+func NewTracker(policies Policies, atRisk float64) *Tracker {
+	return &Tracker{policies: policies, atRisk: atRisk}
+}
+
+// List returns the policies, most urgent priority first
+func (t *Tracker) List() []*ticketpb.SLAPolicy {
+	list := make([]*ticketpb.SLAPolicy, 0, len(t.policies))
+	for priority, policy := range t.policies {
+		list = append(list, &ticketpb.SLAPolicy{
+			Priority:      priority,
+			FirstResponse: durationpb.New(policy.FirstResponse),
+			Resolution:    durationpb.New(policy.Resolution),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Priority > list[j].Priority })
+	return list
+}
+
+// Plan sets the deadlines of a ticket from its creation time, priority and
+// due date, then evaluates it. Call it when a ticket is created and whenever
+// its priority or due date changes. A ticket that loses every deadline keeps
+// its SLA, which then has nothing pending.
+func (t *Tracker) Plan(ticket *ticketpb.Ticket, now time.Time) {
+	policy := t.policies[ticket.Priority]
+	createdAt := ticket.CreatedAt.AsTime()
+
+	var firstResponse, resolution *timestamppb.Timestamp
+	if policy.FirstResponse > 0 {
+		firstResponse = timestamppb.New(createdAt.Add(policy.FirstResponse))
+	}
+	if policy.Resolution > 0 {
+		resolution = timestamppb.New(createdAt.Add(policy.Resolution))
+	}
+	if due := ticket.DueDate; due != nil && (resolution == nil || due.AsTime().Before(resolution.AsTime())) {
+		resolution = timestamppb.New(due.AsTime())
+	}
+	if firstResponse == nil && resolution == nil && ticket.Sla == nil {
+		return
+	}
+
+	if ticket.Sla == nil {
+		ticket.Sla = &ticketpb.TicketSLA{}
+	}
+	ticket.Sla.FirstResponseDueAt = firstResponse
+	ticket.Sla.ResolutionDueAt = resolution
+	t.Evaluate(ticket, now)
+}
+
+// Transition records a status change of a ticket that already carries its
+// new status. Leaving OPEN is the first response; resolving or closing stops
+// the resolution clock and reopening restarts it.
+func (t *Tracker) Transition(ticket *ticketpb.Ticket, previous ticketpb.TicketStatus, now time.Time) {
+	sla := ticket.Sla
+	if sla == nil || ticket.Status == previous {
+		return
+	}
+
+	if sla.FirstRespondedAt == nil && ticket.Status != ticketpb.TicketStatus_TICKET_STATUS_OPEN {
+		sla.FirstRespondedAt = timestamppb.New(now)
+	}
+	switch ticket.Status {
+	case ticketpb.TicketStatus_TICKET_STATUS_RESOLVED, ticketpb.TicketStatus_TICKET_STATUS_CLOSED:
+		if sla.ResolvedAt == nil {
+			sla.ResolvedAt = timestamppb.New(now)
+		}
+	default:
+		sla.ResolvedAt = nil
+	}
+	t.Evaluate(ticket, now)
+}
+
+// Evaluate updates the status and next check time of a ticket's SLA and
+// reports whether the status changed
+func (t *Tracker) Evaluate(ticket *ticketpb.Ticket, now time.Time) bool {
+	sla := ticket.Sla
+	if sla == nil {
+		return false
+	}
+	previous := sla.Status
+	sla.Status, sla.NextCheckAt = t.status(sla, ticket.CreatedAt.AsTime(), now)
+	return sla.Status != previous
+}
+
+// status returns where an SLA stands at now and when that next changes
+func (t *Tracker) status(sla *ticketpb.TicketSLA, createdAt, now time.Time) (ticketpb.SLAStatus, *timestamppb.Timestamp) {
+	if sla.Status == ticketpb.SLAStatus_SLA_STATUS_BREACHED ||
+		missed(sla.FirstResponseDueAt, sla.FirstRespondedAt) || missed(sla.ResolutionDueAt, sla.ResolvedAt) {
+		return ticketpb.SLAStatus_SLA_STATUS_BREACHED, nil
+	}
+
+	deadline := Pending(sla)
+	if deadline == nil {
+		return ticketpb.SLAStatus_SLA_STATUS_MET, nil
+	}
+	due := deadline.AsTime()
+	if !now.Before(due) {
+		return ticketpb.SLAStatus_SLA_STATUS_BREACHED, nil
+	}
+	atRisk := due.Add(-time.Duration(float64(due.Sub(createdAt)) * t.atRisk))
+	if !now.Before(atRisk) {
+		return ticketpb.SLAStatus_SLA_STATUS_AT_RISK, timestamppb.New(due)
+	}
+	return ticketpb.SLAStatus_SLA_STATUS_ON_TRACK, timestamppb.New(atRisk)
+}
+
+// Pending returns the deadline an SLA is waiting on: the first response
+// until the ticket is answered, then its resolution. It is nil when both are
+// done or not tracked.
+func Pending(sla *ticketpb.TicketSLA) *timestamppb.Timestamp {
+	switch {
+	case sla == nil:
+		return nil
+	case sla.FirstResponseDueAt != nil && sla.FirstRespondedAt == nil:
+		return sla.FirstResponseDueAt
+	case sla.ResolutionDueAt != nil && sla.ResolvedAt == nil:
+		return sla.ResolutionDueAt
+	}
+	return nil
+}
+
+// missed reports whether something done at done (nil while pending) missed
+// its deadline
+func missed(deadline, done *timestamppb.Timestamp) bool {
+	return deadline != nil && done != nil && done.AsTime().After(deadline.AsTime())
+}
@@ -0,0 +1,896 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	walFile      = "tickets.wal"
+	snapshotFile = "tickets.snapshot"
+)
+
+// SyncPolicy decides when write-ahead log writes are flushed to stable storage
+type SyncPolicy string
+
+const (
+	// SyncAlways fsyncs after every mutation
+	SyncAlways SyncPolicy = "always"
+	// SyncInterval fsyncs pending writes every DurableOptions.SyncInterval
+	SyncInterval SyncPolicy = "interval"
+	// SyncNever leaves flushing to the operating system
+	SyncNever SyncPolicy = "never"
+)
+
+// DurableOptions configures a DurableStore
+type DurableOptions struct {
+	Sync              SyncPolicy
+	SyncInterval      time.Duration
+	SnapshotInterval  time.Duration // 0 disables periodic snapshots
+	SnapshotThreshold int           // WAL records that trigger a snapshot, 0 disables
+}
+
+// DurableStore is a MemoryStore whose mutations, and the outbox events they
+// record, are appended to a write-ahead log in dir. The log is periodically compacted into a snapshot, and both are
+// replayed when the store is opened.
+type DurableStore struct {
+	mem  *MemoryStore
+	dir  string
+	opts DurableOptions
+
+	mu      sync.Mutex // serializes mutations, log writes and snapshots
+	wal     *os.File
+	size    int64 // bytes in the log
+	records int   // records in the log since the last snapshot
+	dirty   bool  // log writes not yet fsynced
+	closed  bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ TicketStore = (*DurableStore)(nil)
+
+// OpenDurableStore restores the store kept in dir. A new directory starts with
+// the seed tickets.
+func OpenDurableStore(dir string, opts DurableOptions, seed ...*ticketpb.Ticket) (*DurableStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	s := &DurableStore{mem: NewMemoryStore(), dir: dir, opts: opts, done: make(chan struct{})}
+
+	fresh := true
+	snapshot, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	switch {
+	case err == nil:
+		fresh = false
+		if err := s.loadSnapshot(snapshot); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	walPath := filepath.Join(dir, walFile)
+	replayed := 0
+	logData, err := os.ReadFile(walPath)
+	switch {
+	case err == nil:
+		fresh = false
+		valid, err := decodeRecords(logData, func(op byte, payload []byte) error {
+			replayed++
+			return s.replay(op, payload)
+		})
+		if errors.Is(err, errTornRecord) {
+			// A crash mid-write leaves a partial record that was never acknowledged
+			log.Printf("⚠️  Discarding %d bytes of incomplete write-ahead log record", len(logData)-valid)
+			if err := os.Truncate(walPath, int64(valid)); err != nil {
+				return nil, fmt.Errorf("failed to truncate write-ahead log: %w", err)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to replay write-ahead log %s: %w", walPath, err)
+		}
+		s.size = int64(valid)
+		s.records = replayed
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read write-ahead log: %w", err)
+	}
+
+	s.wal, err = os.OpenFile(walPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	if fresh {
+		for _, ticket := range seed {
+			if err := s.Create(context.Background(), ticket); err != nil {
+				s.wal.Close()
+				return nil, err
+			}
+		}
+	}
+
+	log.Printf("💾 Restored %d tickets from %s (%d write-ahead log records)", len(s.mem.tickets), dir, replayed)
+
+	s.wg.Add(1)
+	go s.background()
+	return s, nil
+}
+
+// loadSnapshot restores the tickets of a snapshot
+func (s *DurableStore) loadSnapshot(data []byte) error {
+	records, ended := 0, false
+	_, err := decodeRecords(data, func(op byte, payload []byte) error {
+		if ended {
+			return errors.New("data after end of snapshot")
+		}
+		if op == recordEnd {
+			count, n := binary.Uvarint(payload)
+			if n <= 0 || int(count) != records {
+				return fmt.Errorf("snapshot holds %d records, trailer says %d", records, count)
+			}
+			ended = true
+			return nil
+		}
+		records++
+		return s.replay(op, payload)
+	})
+	if err == nil && !ended {
+		err = errTornRecord
+	}
+	if err != nil {
+		// Snapshots are renamed into place once complete, so any damage is corruption
+		if errors.Is(err, errTornRecord) {
+			err = fmt.Errorf("%w: truncated snapshot", ErrCorrupt)
+		}
+		return fmt.Errorf("failed to load snapshot %s: %w", filepath.Join(s.dir, snapshotFile), err)
+	}
+	return nil
+}
+
+// replay applies a record to the in-memory state. Records carry full ticket
+// and project state, so replaying a record twice is harmless.
+func (s *DurableStore) replay(op byte, payload []byte) error {
+	switch op {
+	case recordPut:
+		ticket := &ticketpb.Ticket{}
+		if err := proto.Unmarshal(payload, ticket); err != nil {
+			return err
+		}
+		s.mem.putLocked(ticket)
+	case recordProject:
+		project := &ticketpb.Project{}
+		if err := proto.Unmarshal(payload, project); err != nil {
+			return err
+		}
+		s.mem.putProjectLocked(project)
+	case recordDelete:
+		s.mem.deleteLocked(string(payload))
+	case recordLink, recordUnlink:
+		link := &ticketpb.TicketLink{}
+		if err := proto.Unmarshal(payload, link); err != nil {
+			return err
+		}
+		// A log replayed over a newer snapshot may link tickets the log deletes
+		// later, or links the snapshot already holds; both end up as recorded
+		if op == recordUnlink {
+			s.mem.unlinkLocked(link.SourceId, link.TargetId, link.Type)
+		} else {
+			s.mem.linkLocked(link)
+		}
+	case recordEvent:
+		var event events.Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		// A log replayed over a newer snapshot holds events the snapshot
+		// may already have
+		if !slices.ContainsFunc(s.mem.outbox, func(pending events.Event) bool { return pending.ID == event.ID }) {
+			s.mem.outbox = append(s.mem.outbox, event)
+		}
+	case recordPublished:
+		s.mem.outbox = slices.DeleteFunc(s.mem.outbox, func(pending events.Event) bool {
+			return pending.ID == string(payload)
+		})
+	default:
+		return fmt.Errorf("unknown record type %d", op)
+	}
+	return nil
+}
+
+// Create stores a new ticket
+func (s *DurableStore) Create(ctx context.Context, ticket *ticketpb.Ticket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mem.mu.RLock()
+	_, exists := s.mem.tickets[ticket.Id]
+	s.mem.mu.RUnlock()
+	if exists {
+		return ErrTicketExists
+	}
+	ticket.OrganizationId = tenant.ID(ctx)
+
+	// Numbering a ticket advances its project's counter, so both are logged together
+	var records []byte
+	var project *ticketpb.Project
+	if ticket.ProjectId != "" {
+		var err error
+		if project, err = s.mem.GetProject(ctx, ticket.ProjectId); err != nil {
+			return err
+		}
+		project.LastNumber++
+		ticket.Key = TicketKey(project.Key, project.LastNumber)
+		payload, err := proto.Marshal(project)
+		if err != nil {
+			return fmt.Errorf("failed to encode project: %w", err)
+		}
+		records = encodeRecord(recordProject, payload)
+	}
+	payload, err := proto.Marshal(ticket)
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket: %w", err)
+	}
+	records = append(records, encodeRecord(recordPut, payload)...)
+	changes, eventLog, err := eventRecords(ctx, nil, ticket)
+	if err != nil {
+		return err
+	}
+	records = append(records, eventLog...)
+	count := 1 + len(changes)
+	if project != nil {
+		count++
+	}
+	if err := s.write(records, count); err != nil {
+		return err
+	}
+
+	s.mem.mu.Lock()
+	if project != nil {
+		s.mem.putProjectLocked(project)
+	}
+	s.mem.putLocked(proto.Clone(ticket).(*ticketpb.Ticket))
+	s.mem.outbox = append(s.mem.outbox, changes...)
+	s.mem.mu.Unlock()
+
+	s.maybeSnapshot()
+	return nil
+}
+
+// GetByKey retrieves a ticket by its project key
+func (s *DurableStore) GetByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	return s.mem.GetByKey(ctx, key)
+}
+
+// Get retrieves a ticket by ID
+func (s *DurableStore) Get(ctx context.Context, id string) (*ticketpb.Ticket, error) {
+	return s.mem.Get(ctx, id)
+}
+
+// List returns a page of tickets, newest first
+func (s *DurableStore) List(ctx context.Context, opts ListOptions) ([]*ticketpb.Ticket, string, error) {
+	return s.mem.List(ctx, opts)
+}
+
+// SLADue returns the tickets of every organization whose SLA is due for evaluation
+func (s *DurableStore) SLADue(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Ticket, error) {
+	return s.mem.SLADue(ctx, now, limit)
+}
+
+// Update applies a partial update to a ticket
+func (s *DurableStore) Update(ctx context.Context, id string, update TicketUpdate) (*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, err := s.mem.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ticket := proto.Clone(previous).(*ticketpb.Ticket)
+	update.Apply(ticket)
+	ticket.UpdatedAt = timestamppb.New(update.UpdatedAt)
+	payload, err := proto.Marshal(ticket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ticket: %w", err)
+	}
+	changes, eventLog, err := eventRecords(ctx, previous, ticket)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.write(append(encodeRecord(recordPut, payload), eventLog...), 1+len(changes)); err != nil {
+		return nil, err
+	}
+	updated, err := s.mem.Update(events.WithoutEvents(ctx), id, update)
+	if err != nil {
+		return nil, err
+	}
+	s.enqueue(changes)
+	s.maybeSnapshot()
+	return updated, nil
+}
+
+// Delete removes a ticket
+func (s *DurableStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, err := s.mem.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	changes, eventLog, err := eventRecords(ctx, ticket, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.write(append(encodeRecord(recordDelete, []byte(id)), eventLog...), 1+len(changes)); err != nil {
+		return err
+	}
+	if err := s.mem.Delete(events.WithoutEvents(ctx), id); err != nil {
+		return err
+	}
+	s.enqueue(changes)
+	s.maybeSnapshot()
+	return nil
+}
+
+// BulkUpdate applies updates to several tickets, logging them in a single write
+func (s *DurableStore) BulkUpdate(ctx context.Context, changes []TicketChange) ([]*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []byte
+	var recorded []events.Event
+	// A ticket changed twice is changed from its first new state
+	current := make(map[string]*ticketpb.Ticket)
+	for i, change := range changes {
+		previous, ok := current[change.ID]
+		if !ok {
+			var err error
+			if previous, err = s.mem.Get(ctx, change.ID); err != nil {
+				return nil, &BulkError{Index: i, ID: change.ID, Err: err}
+			}
+		}
+		ticket := proto.Clone(previous).(*ticketpb.Ticket)
+		change.Update.Apply(ticket)
+		ticket.UpdatedAt = timestamppb.New(change.Update.UpdatedAt)
+		current[change.ID] = ticket
+		payload, err := proto.Marshal(ticket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode ticket: %w", err)
+		}
+		changeEvents, eventLog, err := eventRecords(ctx, previous, ticket)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, encodeRecord(recordPut, payload)...)
+		records = append(records, eventLog...)
+		recorded = append(recorded, changeEvents...)
+	}
+	if err := s.write(records, len(changes)+len(recorded)); err != nil {
+		return nil, err
+	}
+	updated, err := s.mem.BulkUpdate(events.WithoutEvents(ctx), changes)
+	if err != nil {
+		return nil, err
+	}
+	s.enqueue(recorded)
+	s.maybeSnapshot()
+	return updated, nil
+}
+
+// BulkDelete removes several tickets, logging them in a single write
+func (s *DurableStore) BulkDelete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []byte
+	var recorded []events.Event
+	for i, id := range ids {
+		ticket, err := s.mem.Get(ctx, id)
+		if err != nil {
+			return &BulkError{Index: i, ID: id, Err: err}
+		}
+		changes, eventLog, err := eventRecords(ctx, ticket, nil)
+		if err != nil {
+			return err
+		}
+		records = append(records, encodeRecord(recordDelete, []byte(id))...)
+		records = append(records, eventLog...)
+		recorded = append(recorded, changes...)
+	}
+	if err := s.write(records, len(ids)+len(recorded)); err != nil {
+		return err
+	}
+	if err := s.mem.BulkDelete(events.WithoutEvents(ctx), ids); err != nil {
+		return err
+	}
+	s.enqueue(recorded)
+	s.maybeSnapshot()
+	return nil
+}
+
+// PendingEvents returns the oldest unpublished events. The outbox is logged
+// with the writes its events come from, so events still pending when the
+// process stops are published after a restart.
+func (s *DurableStore) PendingEvents(ctx context.Context, limit int) ([]events.Event, error) {
+	return s.mem.PendingEvents(ctx, limit)
+}
+
+// MarkPublished removes a published event from the outbox
+func (s *DurableStore) MarkPublished(ctx context.Context, event events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.append(recordPublished, []byte(event.ID)); err != nil {
+		return err
+	}
+	if err := s.mem.MarkPublished(ctx, event); err != nil {
+		return err
+	}
+	s.maybeSnapshot()
+	return nil
+}
+
+// eventRecords returns the outbox events of a change, unless ctx records
+// none, along with their log records
+func eventRecords(ctx context.Context, previous, ticket *ticketpb.Ticket) ([]events.Event, []byte, error) {
+	if !events.Recorded(ctx) {
+		return nil, nil, nil
+	}
+	if previous != nil {
+		previous = proto.Clone(previous).(*ticketpb.Ticket)
+	}
+	if ticket != nil {
+		ticket = proto.Clone(ticket).(*ticketpb.Ticket)
+	}
+	changes := events.Changes(previous, ticket, time.Now())
+	var records []byte
+	for _, event := range changes {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode event: %w", err)
+		}
+		records = append(records, encodeRecord(recordEvent, payload)...)
+	}
+	return changes, records, nil
+}
+
+// enqueue adds logged events to the outbox. Callers hold s.mu.
+func (s *DurableStore) enqueue(changes []events.Event) {
+	if len(changes) == 0 {
+		return
+	}
+	s.mem.mu.Lock()
+	s.mem.outbox = append(s.mem.outbox, changes...)
+	s.mem.mu.Unlock()
+}
+
+// Tags returns the tag catalogue
+func (s *DurableStore) Tags(ctx context.Context, query TagQuery) ([]*ticketpb.TagCount, error) {
+	return s.mem.Tags(ctx, query)
+}
+
+// MergeTags replaces the source tags with target on every ticket
+func (s *DurableStore) MergeTags(ctx context.Context, sources []string, target string, updatedAt time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Log the new state of every changed ticket before touching memory
+	organizationID := tenant.ID(ctx)
+	s.mem.mu.RLock()
+	var changed []*ticketpb.Ticket
+	var records []byte
+	var recorded []events.Event
+	for _, ticket := range s.mem.tickets {
+		if ticket.OrganizationId != organizationID {
+			continue
+		}
+		if tags, ok := MergeTagList(ticket.Tags, sources, target); ok {
+			merged := proto.Clone(ticket).(*ticketpb.Ticket)
+			merged.Tags = tags
+			merged.UpdatedAt = timestamppb.New(updatedAt)
+			payload, err := proto.Marshal(merged)
+			if err != nil {
+				s.mem.mu.RUnlock()
+				return 0, fmt.Errorf("failed to encode ticket: %w", err)
+			}
+			changes, eventLog, err := eventRecords(ctx, ticket, merged)
+			if err != nil {
+				s.mem.mu.RUnlock()
+				return 0, err
+			}
+			records = append(records, encodeRecord(recordPut, payload)...)
+			records = append(records, eventLog...)
+			recorded = append(recorded, changes...)
+			changed = append(changed, merged)
+		}
+	}
+	s.mem.mu.RUnlock()
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	if err := s.write(records, len(changed)+len(recorded)); err != nil {
+		return 0, err
+	}
+	s.mem.mu.Lock()
+	for _, ticket := range changed {
+		s.mem.tickets[ticket.Id] = ticket
+	}
+	s.mem.outbox = append(s.mem.outbox, recorded...)
+	s.mem.mu.Unlock()
+
+	s.maybeSnapshot()
+	return len(changed), nil
+}
+
+// Link stores a link between two tickets
+func (s *DurableStore) Link(ctx context.Context, link *ticketpb.TicketLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mem.mu.RLock()
+	err := s.checkLink(tenant.ID(ctx), link)
+	s.mem.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	payload, err := proto.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to encode link: %w", err)
+	}
+	if err := s.append(recordLink, payload); err != nil {
+		return err
+	}
+	if err := s.mem.Link(ctx, link); err != nil {
+		return err
+	}
+	s.maybeSnapshot()
+	return nil
+}
+
+// checkLink reports why a link cannot be stored in the organization. Callers
+// hold s.mem.mu.
+func (s *DurableStore) checkLink(organizationID string, link *ticketpb.TicketLink) error {
+	if s.mem.visibleLocked(organizationID, link.SourceId) == nil || s.mem.visibleLocked(organizationID, link.TargetId) == nil {
+		return ErrNotFound
+	}
+	for _, existing := range s.mem.links {
+		if SameLink(existing, link.SourceId, link.TargetId, link.Type) {
+			return ErrLinkExists
+		}
+	}
+	return nil
+}
+
+// Unlink removes a link
+func (s *DurableStore) Unlink(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mem.mu.RLock()
+	found := s.mem.visibleLocked(tenant.ID(ctx), sourceID) != nil &&
+		slices.ContainsFunc(s.mem.links, func(link *ticketpb.TicketLink) bool {
+			return SameLink(link, sourceID, targetID, linkType)
+		})
+	s.mem.mu.RUnlock()
+	if !found {
+		return ErrNotFound
+	}
+	payload, err := proto.Marshal(&ticketpb.TicketLink{SourceId: sourceID, TargetId: targetID, Type: linkType})
+	if err != nil {
+		return fmt.Errorf("failed to encode link: %w", err)
+	}
+	if err := s.append(recordUnlink, payload); err != nil {
+		return err
+	}
+	if err := s.mem.Unlink(ctx, sourceID, targetID, linkType); err != nil {
+		return err
+	}
+	s.maybeSnapshot()
+	return nil
+}
+
+// Links returns the links from or to a ticket
+func (s *DurableStore) Links(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	return s.mem.Links(ctx, ticketID)
+}
+
+// CreateProject stores a new project
+func (s *DurableStore) CreateProject(ctx context.Context, project *ticketpb.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project.OrganizationId = tenant.ID(ctx)
+	s.mem.mu.RLock()
+	taken := s.mem.projectByKeyLocked(project.OrganizationId, project.Key) != nil
+	_, exists := s.mem.projects[project.Id]
+	s.mem.mu.RUnlock()
+	if taken {
+		return ErrProjectExists
+	}
+	if exists {
+		return fmt.Errorf("project already exists: %s", project.Id)
+	}
+	payload, err := proto.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("failed to encode project: %w", err)
+	}
+	if err := s.append(recordProject, payload); err != nil {
+		return err
+	}
+	if err := s.mem.CreateProject(ctx, project); err != nil {
+		return err
+	}
+	s.maybeSnapshot()
+	return nil
+}
+
+// GetProject retrieves a project by ID
+func (s *DurableStore) GetProject(ctx context.Context, id string) (*ticketpb.Project, error) {
+	return s.mem.GetProject(ctx, id)
+}
+
+// GetProjectByKey retrieves a project by key
+func (s *DurableStore) GetProjectByKey(ctx context.Context, key string) (*ticketpb.Project, error) {
+	return s.mem.GetProjectByKey(ctx, key)
+}
+
+// ListProjects returns every project ordered by key
+func (s *DurableStore) ListProjects(ctx context.Context) ([]*ticketpb.Project, error) {
+	return s.mem.ListProjects(ctx)
+}
+
+// SetCustomFields replaces the custom field definitions of a project
+func (s *DurableStore) SetCustomFields(ctx context.Context, projectID string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, err := s.mem.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	project.CustomFields = cloneDefinitions(fields)
+	payload, err := proto.Marshal(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode project: %w", err)
+	}
+	if err := s.append(recordProject, payload); err != nil {
+		return nil, err
+	}
+	updated, err := s.mem.SetCustomFields(ctx, projectID, fields)
+	if err != nil {
+		return nil, err
+	}
+	s.maybeSnapshot()
+	return updated, nil
+}
+
+// Snapshot compacts the write-ahead log into a new snapshot
+func (s *DurableStore) Snapshot() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("store is closed")
+	}
+	return s.snapshotLocked()
+}
+
+// Close takes a final snapshot and closes the write-ahead log
+func (s *DurableStore) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	s.mu.Unlock()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.snapshotLocked()
+	if closeErr := s.wal.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// append writes a record to the log. Callers hold s.mu.
+func (s *DurableStore) append(op byte, payload []byte) error {
+	return s.write(encodeRecord(op, payload), 1)
+}
+
+// write appends count encoded records to the log in a single write, honouring
+// the sync policy. Callers hold s.mu.
+func (s *DurableStore) write(records []byte, count int) error {
+	if s.closed {
+		return errors.New("store is closed")
+	}
+
+	if _, err := s.wal.Write(records); err != nil {
+		// Drop any partial record so later records are not appended after garbage
+		s.wal.Truncate(s.size)
+		return fmt.Errorf("failed to write to write-ahead log: %w", err)
+	}
+	if s.opts.Sync == SyncAlways {
+		if err := s.wal.Sync(); err != nil {
+			s.wal.Truncate(s.size)
+			return fmt.Errorf("failed to sync write-ahead log: %w", err)
+		}
+	} else {
+		s.dirty = true
+	}
+	s.size += int64(len(records))
+	s.records += count
+	return nil
+}
+
+// maybeSnapshot compacts the log once it reaches the snapshot threshold. Callers hold s.mu.
+func (s *DurableStore) maybeSnapshot() {
+	if s.opts.SnapshotThreshold > 0 && s.records >= s.opts.SnapshotThreshold {
+		if err := s.snapshotLocked(); err != nil {
+			log.Printf("⚠️  Failed to snapshot tickets: %v", err)
+		}
+	}
+}
+
+// snapshotLocked writes every ticket to a new snapshot and empties the log.
+// Callers hold s.mu.
+func (s *DurableStore) snapshotLocked() error {
+	if s.records == 0 {
+		return nil
+	}
+
+	s.mem.mu.RLock()
+	var data []byte
+	// Projects precede the tickets numbered from them
+	for _, project := range s.mem.projects {
+		payload, err := proto.Marshal(project)
+		if err != nil {
+			s.mem.mu.RUnlock()
+			return fmt.Errorf("failed to encode project: %w", err)
+		}
+		data = append(data, encodeRecord(recordProject, payload)...)
+	}
+	for _, ticket := range s.mem.tickets {
+		payload, err := proto.Marshal(ticket)
+		if err != nil {
+			s.mem.mu.RUnlock()
+			return fmt.Errorf("failed to encode ticket: %w", err)
+		}
+		data = append(data, encodeRecord(recordPut, payload)...)
+	}
+	// Links follow the tickets they refer to
+	for _, link := range s.mem.links {
+		payload, err := proto.Marshal(link)
+		if err != nil {
+			s.mem.mu.RUnlock()
+			return fmt.Errorf("failed to encode link: %w", err)
+		}
+		data = append(data, encodeRecord(recordLink, payload)...)
+	}
+	// Pending events keep their order
+	for _, event := range s.mem.outbox {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			s.mem.mu.RUnlock()
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		data = append(data, encodeRecord(recordEvent, payload)...)
+	}
+	records := len(s.mem.projects) + len(s.mem.tickets) + len(s.mem.links) + len(s.mem.outbox)
+	data = append(data, encodeRecord(recordEnd, binary.AppendUvarint(nil, uint64(records)))...)
+	s.mem.mu.RUnlock()
+
+	// Write the snapshot beside the old one and rename it into place, so a crash
+	// leaves either the old snapshot with its log or the new one
+	tmp := filepath.Join(s.dir, snapshotFile+".tmp")
+	if err := writeFileSync(tmp, data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, snapshotFile)); err != nil {
+		return fmt.Errorf("failed to install snapshot: %w", err)
+	}
+	if err := syncDir(s.dir); err != nil {
+		return fmt.Errorf("failed to sync data directory: %w", err)
+	}
+
+	// Replaying the old log over the new snapshot is harmless, so a crash
+	// before the truncation below loses nothing
+	if err := s.wal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+	if err := s.wal.Sync(); err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	s.size, s.records, s.dirty = 0, 0, false
+	return nil
+}
+
+// background fsyncs the log and takes snapshots on the configured intervals
+func (s *DurableStore) background() {
+	defer s.wg.Done()
+
+	var syncTick, snapshotTick <-chan time.Time
+	if s.opts.Sync == SyncInterval && s.opts.SyncInterval > 0 {
+		ticker := time.NewTicker(s.opts.SyncInterval)
+		defer ticker.Stop()
+		syncTick = ticker.C
+	}
+	if s.opts.SnapshotInterval > 0 {
+		ticker := time.NewTicker(s.opts.SnapshotInterval)
+		defer ticker.Stop()
+		snapshotTick = ticker.C
+	}
+
+	for {
+		select {
+		case <-syncTick:
+			s.mu.Lock()
+			if s.dirty && !s.closed {
+				if err := s.wal.Sync(); err != nil {
+					log.Printf("⚠️  Failed to sync write-ahead log: %v", err)
+				} else {
+					s.dirty = false
+				}
+			}
+			s.mu.Unlock()
+		case <-snapshotTick:
+			s.mu.Lock()
+			if !s.closed {
+				if err := s.snapshotLocked(); err != nil {
+					log.Printf("⚠️  Failed to snapshot tickets: %v", err)
+				}
+			}
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// writeFileSync writes data to path and fsyncs it
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir fsyncs a directory so renames within it are durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,188 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/store/storetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+)
+
+func TestDurableStore(t *testing.T) {
+	for _, sync := range []store.SyncPolicy{store.SyncAlways, store.SyncInterval} {
+		t.Run(string(sync), func(t *testing.T) {
+			storetest.Run(t, func(t *testing.T) store.TicketStore {
+				s, err := store.OpenDurableStore(t.TempDir(), store.DurableOptions{
+					Sync:              sync,
+					SyncInterval:      10 * time.Millisecond,
+					SnapshotThreshold: 5,
+				})
+				if err != nil {
+					t.Fatalf("failed to open durable store: %v", err)
+				}
+				return s
+			})
+		})
+	}
+}
+
+func TestDurableDamagedLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		damage  func(log []byte) []byte
+		corrupt bool
+	}{
+		{"torn header", func(log []byte) []byte { return append(log, 0x40, 0, 0) }, false},
+		{"torn record", func(log []byte) []byte {
+			return append(log, 0x40, 0, 0, 0, 1, 2, 3, 4, 1, 0x0a)
+		}, false},
+		{"zero filled tail", func(log []byte) []byte { return append(log, make([]byte, 64)...) }, false},
+		{"large tail of plausible lengths", func(log []byte) []byte {
+			// Most offsets read as the start of a long record, which a scan
+			// checksumming every candidate takes quadratic time over
+			tail := make([]byte, 1<<20)
+			for i := 4; i < len(tail); i += 4 {
+				tail[i] = 1
+			}
+			copy(tail, []byte{0xff, 0xff, 0xff, 0x7f})
+			return append(log, tail...)
+		}, false},
+		{"invalid length mid-file", func(log []byte) []byte {
+			copy(log[0:4], []byte{0xff, 0xff, 0xff, 0x7f})
+			return log
+		}, true},
+		{"zero length mid-file", func(log []byte) []byte {
+			copy(log[0:4], []byte{0, 0, 0, 0})
+			return log
+		}, true},
+		{"checksum mismatch mid-file", func(log []byte) []byte {
+			log[4] ^= 0xff
+			return log
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Closing the store compacts its log into a snapshot, so copy
+			// the log while it is open
+			written := t.TempDir()
+			s, err := store.OpenDurableStore(written, store.DurableOptions{Sync: store.SyncAlways})
+			if err != nil {
+				t.Fatalf("failed to open durable store: %v", err)
+			}
+			var ids []string
+			for _, title := range []string{"One", "Two", "Three"} {
+				ticket := &ticketpb.Ticket{Id: uuid.NewString(), Title: title}
+				if err := s.Create(context.Background(), ticket); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+				ids = append(ids, ticket.Id)
+			}
+			intact, err := os.ReadFile(filepath.Join(written, "tickets.wal"))
+			if err != nil {
+				t.Fatalf("failed to read write-ahead log: %v", err)
+			}
+			s.Close()
+
+			dir := t.TempDir()
+			walPath := filepath.Join(dir, "tickets.wal")
+			damaged := tt.damage(bytes.Clone(intact))
+			if err := os.WriteFile(walPath, damaged, 0o644); err != nil {
+				t.Fatalf("failed to write write-ahead log: %v", err)
+			}
+
+			s, err = store.OpenDurableStore(dir, store.DurableOptions{Sync: store.SyncAlways})
+			if tt.corrupt {
+				if !errors.Is(err, store.ErrCorrupt) {
+					t.Fatalf("OpenDurableStore = %v, want ErrCorrupt", err)
+				}
+				// The log is left for inspection rather than truncated
+				if log, _ := os.ReadFile(walPath); !bytes.Equal(log, damaged) {
+					t.Fatal("the corrupt write-ahead log was modified")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenDurableStore: %v", err)
+			}
+			defer s.Close()
+			for _, id := range ids {
+				if _, err := s.Get(context.Background(), id); err != nil {
+					t.Errorf("Get(%s) after recovery: %v", id, err)
+				}
+			}
+			if log, _ := os.ReadFile(walPath); !bytes.Equal(log, intact) {
+				t.Errorf("the torn record was not truncated: log holds %d bytes, want %d", len(log), len(intact))
+			}
+		})
+	}
+}
+
+func TestDurableOutbox(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := store.OpenDurableStore(dir, store.DurableOptions{Sync: store.SyncAlways})
+	if err != nil {
+		t.Fatalf("failed to open durable store: %v", err)
+	}
+	for _, title := range []string{"One", "Two"} {
+		if err := s.Create(ctx, &ticketpb.Ticket{Id: uuid.NewString(), Title: title}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	created, err := s.PendingEvents(ctx, 10)
+	if err != nil || len(created) != 2 {
+		t.Fatalf("PendingEvents = %d events, %v; want 2", len(created), err)
+	}
+	status := ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
+	if _, err := s.Update(ctx, created[1].Ticket.Id, store.TicketUpdate{Status: &status, UpdatedAt: time.Now()}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := s.MarkPublished(ctx, created[0]); err != nil {
+		t.Fatalf("MarkPublished: %v", err)
+	}
+	want, err := s.PendingEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+
+	// Restored from the log after a crash, and from the snapshot Close takes
+	crashed := t.TempDir()
+	walData, err := os.ReadFile(filepath.Join(dir, "tickets.wal"))
+	if err != nil {
+		t.Fatalf("failed to read write-ahead log: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(crashed, "tickets.wal"), walData, 0o644); err != nil {
+		t.Fatalf("failed to write write-ahead log: %v", err)
+	}
+	s.Close()
+
+	for name, dir := range map[string]string{"log": crashed, "snapshot": dir} {
+		t.Run(name, func(t *testing.T) {
+			s, err := store.OpenDurableStore(dir, store.DurableOptions{Sync: store.SyncAlways})
+			if err != nil {
+				t.Fatalf("OpenDurableStore: %v", err)
+			}
+			defer s.Close()
+			got, err := s.PendingEvents(ctx, 10)
+			if err != nil {
+				t.Fatalf("PendingEvents: %v", err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("restored %d pending events, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i].ID != want[i].ID || got[i].Type != want[i].Type || got[i].Ticket.GetTitle() != want[i].Ticket.GetTitle() {
+					t.Errorf("event %d = %s %s, want %s %s", i, got[i].Type, got[i].ID, want[i].Type, want[i].ID)
+				}
+			}
+		})
+	}
+}
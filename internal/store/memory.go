@@ -0,0 +1,550 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MemoryStore keeps tickets in process. Tickets are copied on the way in and
+// out so callers never share state with the store.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	tickets  map[string]*ticketpb.Ticket
+	keys     map[string]string // organization and ticket key to ID
+	projects map[string]*ticketpb.Project
+	links    []*ticketpb.TicketLink // oldest first
+	outbox   []events.Event         // unpublished events, oldest first
+}
+
+var _ TicketStore = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an in-memory store holding the given tickets
+func NewMemoryStore(seed ...*ticketpb.Ticket) *MemoryStore {
+	s := &MemoryStore{
+		tickets:  make(map[string]*ticketpb.Ticket),
+		keys:     make(map[string]string),
+		projects: make(map[string]*ticketpb.Project),
+	}
+	for _, ticket := range seed {
+		s.putLocked(proto.Clone(ticket).(*ticketpb.Ticket))
+	}
+	return s
+}
+
+// SampleTickets returns the sample tickets also inserted by the PostgreSQL migration
+func SampleTickets() []*ticketpb.Ticket {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return []*ticketpb.Ticket{
+		{
+			Id:          "550e8400-e29b-41d4-a716-446655440001",
+			Title:       "Fix login bug",
+			Description: "Users cannot log in with their email",
+			Status:      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+			Priority:    ticketpb.TicketPriority_TICKET_PRIORITY_HIGH,
+			AssigneeId:  "user-123",
+			Tags:        []string{"bug", "urgent"},
+			CreatedAt:   timestamppb.New(now),
+			UpdatedAt:   timestamppb.New(now),
+		},
+		{
+			Id:          "550e8400-e29b-41d4-a716-446655440002",
+			Title:       "Add dark mode",
+			Description: "Implement dark mode for better user experience",
+			Status:      ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS,
+			Priority:    ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM,
+			AssigneeId:  "user-456",
+			Tags:        []string{"feature", "ui"},
+			CreatedAt:   timestamppb.New(now),
+			UpdatedAt:   timestamppb.New(now),
+		},
+		{
+			Id:          "550e8400-e29b-41d4-a716-446655440003",
+			Title:       "Performance optimization",
+			Description: "Optimize database queries for faster response",
+			Status:      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+			Priority:    ticketpb.TicketPriority_TICKET_PRIORITY_LOW,
+			AssigneeId:  "user-789",
+			Tags:        []string{"performance", "database"},
+			CreatedAt:   timestamppb.New(now),
+			UpdatedAt:   timestamppb.New(now),
+		},
+	}
+}
+
+// Create stores a new ticket
+func (s *MemoryStore) Create(ctx context.Context, ticket *ticketpb.Ticket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tickets[ticket.Id]; exists {
+		return ErrTicketExists
+	}
+	ticket.OrganizationId = tenant.ID(ctx)
+	if ticket.ProjectId != "" {
+		project := s.projectLocked(ticket.OrganizationId, ticket.ProjectId)
+		if project == nil {
+			return ErrProjectNotFound
+		}
+		project.LastNumber++
+		ticket.Key = TicketKey(project.Key, project.LastNumber)
+	}
+	s.putLocked(proto.Clone(ticket).(*ticketpb.Ticket))
+	s.recordLocked(ctx, nil, ticket)
+	return nil
+}
+
+// recordLocked adds the events of a change to the outbox, unless ctx records
+// none. Callers hold s.mu.
+func (s *MemoryStore) recordLocked(ctx context.Context, previous, ticket *ticketpb.Ticket) {
+	if !events.Recorded(ctx) {
+		return
+	}
+	if previous != nil {
+		previous = proto.Clone(previous).(*ticketpb.Ticket)
+	}
+	if ticket != nil {
+		ticket = proto.Clone(ticket).(*ticketpb.Ticket)
+	}
+	s.outbox = append(s.outbox, events.Changes(previous, ticket, time.Now())...)
+}
+
+// putLocked stores a ticket and indexes its key. Tickets stored before
+// organizations existed belong to tenant.Default. Callers hold s.mu.
+func (s *MemoryStore) putLocked(ticket *ticketpb.Ticket) {
+	if ticket.OrganizationId == "" {
+		ticket.OrganizationId = tenant.Default
+	}
+	s.tickets[ticket.Id] = ticket
+	if ticket.Key != "" {
+		s.keys[scopedKey(ticket.OrganizationId, ticket.Key)] = ticket.Id
+	}
+}
+
+// putProjectLocked stores a project. Projects stored before organizations
+// existed belong to tenant.Default. Callers hold s.mu.
+func (s *MemoryStore) putProjectLocked(project *ticketpb.Project) {
+	if project.OrganizationId == "" {
+		project.OrganizationId = tenant.Default
+	}
+	s.projects[project.Id] = project
+}
+
+// scopedKey indexes a ticket key within an organization
+func scopedKey(organizationID, key string) string {
+	return organizationID + "/" + key
+}
+
+// visibleLocked returns the ticket with the given ID when it belongs to the
+// organization. Callers hold s.mu.
+func (s *MemoryStore) visibleLocked(organizationID, id string) *ticketpb.Ticket {
+	ticket := s.tickets[id]
+	if ticket == nil || ticket.OrganizationId != organizationID {
+		return nil
+	}
+	return ticket
+}
+
+// Get retrieves a ticket by ID
+func (s *MemoryStore) Get(ctx context.Context, id string) (*ticketpb.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ticket := s.visibleLocked(tenant.ID(ctx), id)
+	if ticket == nil {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(ticket).(*ticketpb.Ticket), nil
+}
+
+// GetByKey retrieves a ticket by its project key
+func (s *MemoryStore) GetByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, exists := s.keys[scopedKey(tenant.ID(ctx), key)]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(s.tickets[id]).(*ticketpb.Ticket), nil
+}
+
+// List returns a page of tickets, newest first
+func (s *MemoryStore) List(ctx context.Context, opts ListOptions) ([]*ticketpb.Ticket, string, error) {
+	cursor, err := DecodeCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	organizationID := tenant.ID(ctx)
+	s.mu.RLock()
+	all := make([]*ticketpb.Ticket, 0, len(s.tickets))
+	for _, ticket := range s.tickets {
+		if ticket.OrganizationId == organizationID && (cursor == nil || cursor.Before(ticket)) && opts.Matches(ticket) {
+			all = append(all, ticket)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return Less(all[i], all[j]) })
+
+	limit := opts.Limit()
+	var next string
+	if len(all) > limit {
+		all = all[:limit]
+		next = CursorAfter(all[limit-1]).Encode()
+	}
+	tickets := make([]*ticketpb.Ticket, len(all))
+	for i, ticket := range all {
+		tickets[i] = proto.Clone(ticket).(*ticketpb.Ticket)
+	}
+	s.mu.RUnlock()
+
+	return tickets, next, nil
+}
+
+// Update applies a partial update to a ticket
+func (s *MemoryStore) Update(ctx context.Context, id string, update TicketUpdate) (*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket := s.visibleLocked(tenant.ID(ctx), id)
+	if ticket == nil {
+		return nil, ErrNotFound
+	}
+	previous := proto.Clone(ticket).(*ticketpb.Ticket)
+	update.Apply(ticket)
+	ticket.UpdatedAt = timestamppb.New(update.UpdatedAt)
+	s.recordLocked(ctx, previous, ticket)
+	return proto.Clone(ticket).(*ticketpb.Ticket), nil
+}
+
+// BulkUpdate applies updates to several tickets, or to none when one is missing
+func (s *MemoryStore) BulkUpdate(ctx context.Context, changes []TicketChange) ([]*ticketpb.Ticket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organizationID := tenant.ID(ctx)
+	for i, change := range changes {
+		if s.visibleLocked(organizationID, change.ID) == nil {
+			return nil, &BulkError{Index: i, ID: change.ID, Err: ErrNotFound}
+		}
+	}
+	updated := make([]*ticketpb.Ticket, len(changes))
+	for i, change := range changes {
+		ticket := s.tickets[change.ID]
+		previous := proto.Clone(ticket).(*ticketpb.Ticket)
+		change.Update.Apply(ticket)
+		ticket.UpdatedAt = timestamppb.New(change.Update.UpdatedAt)
+		s.recordLocked(ctx, previous, ticket)
+		updated[i] = proto.Clone(ticket).(*ticketpb.Ticket)
+	}
+	return updated, nil
+}
+
+// SLADue returns the tickets of every organization whose SLA is due for evaluation
+func (s *MemoryStore) SLADue(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Ticket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var due []*ticketpb.Ticket
+	for _, ticket := range s.tickets {
+		if SLADue(ticket, now) {
+			due = append(due, ticket)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return LessSLADue(due[i], due[j]) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	tickets := make([]*ticketpb.Ticket, len(due))
+	for i, ticket := range due {
+		tickets[i] = proto.Clone(ticket).(*ticketpb.Ticket)
+	}
+	return tickets, nil
+}
+
+// Delete removes a ticket
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket := s.visibleLocked(tenant.ID(ctx), id)
+	if ticket == nil {
+		return ErrNotFound
+	}
+	s.deleteLocked(id)
+	s.recordLocked(ctx, ticket, nil)
+	return nil
+}
+
+// BulkDelete removes several tickets, or none when one is missing
+func (s *MemoryStore) BulkDelete(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organizationID := tenant.ID(ctx)
+	for i, id := range ids {
+		if s.visibleLocked(organizationID, id) == nil {
+			return &BulkError{Index: i, ID: id, Err: ErrNotFound}
+		}
+	}
+	for _, id := range ids {
+		ticket := s.tickets[id]
+		s.deleteLocked(id)
+		s.recordLocked(ctx, ticket, nil)
+	}
+	return nil
+}
+
+// deleteLocked removes a ticket and its links. Callers hold s.mu.
+func (s *MemoryStore) deleteLocked(id string) {
+	if ticket, exists := s.tickets[id]; exists && ticket.Key != "" {
+		delete(s.keys, scopedKey(ticket.OrganizationId, ticket.Key))
+	}
+	delete(s.tickets, id)
+	s.links = slices.DeleteFunc(s.links, func(link *ticketpb.TicketLink) bool {
+		return link.SourceId == id || link.TargetId == id
+	})
+}
+
+// Link stores a link between two tickets
+func (s *MemoryStore) Link(ctx context.Context, link *ticketpb.TicketLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organizationID := tenant.ID(ctx)
+	if s.visibleLocked(organizationID, link.SourceId) == nil || s.visibleLocked(organizationID, link.TargetId) == nil {
+		return ErrNotFound
+	}
+	return s.linkLocked(link)
+}
+
+// linkLocked stores a link. Callers hold s.mu.
+func (s *MemoryStore) linkLocked(link *ticketpb.TicketLink) error {
+	if s.tickets[link.SourceId] == nil || s.tickets[link.TargetId] == nil {
+		return ErrNotFound
+	}
+	for _, existing := range s.links {
+		if SameLink(existing, link.SourceId, link.TargetId, link.Type) {
+			return ErrLinkExists
+		}
+	}
+	s.links = append(s.links, proto.Clone(link).(*ticketpb.TicketLink))
+	return nil
+}
+
+// Unlink removes a link
+func (s *MemoryStore) Unlink(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.visibleLocked(tenant.ID(ctx), sourceID) == nil {
+		return ErrNotFound
+	}
+	return s.unlinkLocked(sourceID, targetID, linkType)
+}
+
+// unlinkLocked removes a link. Callers hold s.mu.
+func (s *MemoryStore) unlinkLocked(sourceID, targetID string, linkType ticketpb.LinkType) error {
+	for i, link := range s.links {
+		if SameLink(link, sourceID, targetID, linkType) {
+			s.links = slices.Delete(s.links, i, i+1)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Links returns the links from or to a ticket
+func (s *MemoryStore) Links(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Links only join tickets of the same organization
+	if s.visibleLocked(tenant.ID(ctx), ticketID) == nil {
+		return nil, nil
+	}
+	var links []*ticketpb.TicketLink
+	for _, link := range s.links {
+		if link.SourceId == ticketID || link.TargetId == ticketID {
+			links = append(links, proto.Clone(link).(*ticketpb.TicketLink))
+		}
+	}
+	return links, nil
+}
+
+// CreateProject stores a new project
+func (s *MemoryStore) CreateProject(ctx context.Context, project *ticketpb.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project.OrganizationId = tenant.ID(ctx)
+	if s.projectByKeyLocked(project.OrganizationId, project.Key) != nil {
+		return ErrProjectExists
+	}
+	if _, exists := s.projects[project.Id]; exists {
+		return fmt.Errorf("project already exists: %s", project.Id)
+	}
+	s.putProjectLocked(proto.Clone(project).(*ticketpb.Project))
+	return nil
+}
+
+// GetProject retrieves a project by ID
+func (s *MemoryStore) GetProject(ctx context.Context, id string) (*ticketpb.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	project := s.projectLocked(tenant.ID(ctx), id)
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	return proto.Clone(project).(*ticketpb.Project), nil
+}
+
+// GetProjectByKey retrieves a project by key
+func (s *MemoryStore) GetProjectByKey(ctx context.Context, key string) (*ticketpb.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	project := s.projectByKeyLocked(tenant.ID(ctx), key)
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	return proto.Clone(project).(*ticketpb.Project), nil
+}
+
+// projectLocked returns the project with the given ID when it belongs to the
+// organization. Callers hold s.mu.
+func (s *MemoryStore) projectLocked(organizationID, id string) *ticketpb.Project {
+	project := s.projects[id]
+	if project == nil || project.OrganizationId != organizationID {
+		return nil
+	}
+	return project
+}
+
+// projectByKeyLocked finds a project of the organization by key. Callers hold s.mu.
+func (s *MemoryStore) projectByKeyLocked(organizationID, key string) *ticketpb.Project {
+	for _, project := range s.projects {
+		if project.OrganizationId == organizationID && project.Key == key {
+			return project
+		}
+	}
+	return nil
+}
+
+// ListProjects returns every project ordered by key
+func (s *MemoryStore) ListProjects(ctx context.Context) ([]*ticketpb.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	organizationID := tenant.ID(ctx)
+	projects := make([]*ticketpb.Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		if project.OrganizationId == organizationID {
+			projects = append(projects, proto.Clone(project).(*ticketpb.Project))
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Key < projects[j].Key })
+	return projects, nil
+}
+
+// SetCustomFields replaces the custom field definitions of a project
+func (s *MemoryStore) SetCustomFields(ctx context.Context, projectID string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := s.projectLocked(tenant.ID(ctx), projectID)
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	project.CustomFields = cloneDefinitions(fields)
+	return proto.Clone(project).(*ticketpb.Project), nil
+}
+
+// cloneDefinitions deep-copies custom field definitions
+func cloneDefinitions(fields []*ticketpb.CustomFieldDefinition) []*ticketpb.CustomFieldDefinition {
+	cloned := make([]*ticketpb.CustomFieldDefinition, len(fields))
+	for i, field := range fields {
+		cloned[i] = proto.Clone(field).(*ticketpb.CustomFieldDefinition)
+	}
+	return cloned
+}
+
+// Tags returns the tag catalogue
+func (s *MemoryStore) Tags(ctx context.Context, query TagQuery) ([]*ticketpb.TagCount, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	organizationID := tenant.ID(ctx)
+	tickets := make([]*ticketpb.Ticket, 0, len(s.tickets))
+	for _, ticket := range s.tickets {
+		if ticket.OrganizationId == organizationID {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return CountTags(tickets, query), nil
+}
+
+// MergeTags replaces the source tags with target on every ticket
+func (s *MemoryStore) MergeTags(ctx context.Context, sources []string, target string, updatedAt time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organizationID := tenant.ID(ctx)
+	changed := 0
+	for _, ticket := range s.tickets {
+		if ticket.OrganizationId != organizationID {
+			continue
+		}
+		if tags, ok := MergeTagList(ticket.Tags, sources, target); ok {
+			previous := proto.Clone(ticket).(*ticketpb.Ticket)
+			ticket.Tags = tags
+			ticket.UpdatedAt = timestamppb.New(updatedAt)
+			s.recordLocked(ctx, previous, ticket)
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// PendingEvents returns the oldest unpublished events
+func (s *MemoryStore) PendingEvents(ctx context.Context, limit int) ([]events.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pending := s.outbox[:min(limit, len(s.outbox))]
+	copied := make([]events.Event, len(pending))
+	for i, event := range pending {
+		copied[i] = event
+		copied[i].Ticket = proto.Clone(event.Ticket).(*ticketpb.Ticket)
+		if event.Previous != nil {
+			copied[i].Previous = proto.Clone(event.Previous).(*ticketpb.Ticket)
+		}
+	}
+	return copied, nil
+}
+
+// MarkPublished removes a published event from the outbox
+func (s *MemoryStore) MarkPublished(ctx context.Context, event events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outbox = slices.DeleteFunc(s.outbox, func(pending events.Event) bool {
+		return pending.ID == event.ID
+	})
+	return nil
+}
+
+// Close is a no-op for the memory store
+func (s *MemoryStore) Close() error {
+	return nil
+}
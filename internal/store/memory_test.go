@@ -0,0 +1,35 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/store/storetest"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+)
+
+func TestMemoryStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.TicketStore {
+		return store.NewMemoryStore()
+	})
+}
+
+func TestMemoryWebhooks(t *testing.T) {
+	storetest.RunWebhooks(t, func(t *testing.T) webhooks.Store {
+		return webhooks.NewMemoryStore()
+	})
+}
+
+func TestMemoryNotifications(t *testing.T) {
+	storetest.RunNotifications(t, func(t *testing.T) notifications.Store {
+		return notifications.NewMemoryStore()
+	})
+}
+
+func TestMemoryComments(t *testing.T) {
+	storetest.RunComments(t, func(t *testing.T) comments.Store {
+		return comments.NewMemoryStore()
+	})
+}
@@ -0,0 +1,816 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PostgresStore keeps tickets in PostgreSQL through database.TicketRepository.
+// Every call runs in a transaction scoped to the caller's organization, so
+// the row-level security policies of the tables decide what it can see.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+var _ TicketStore = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a store on top of an open connection
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// repositories are the repositories of one organization-scoped transaction
+type repositories struct {
+	tickets  *database.TicketRepository
+	links    *database.LinkRepository
+	projects *database.ProjectRepository
+	outbox   *database.OutboxRepository
+}
+
+// scoped runs fn with repositories limited to the organization of ctx
+func (s *PostgresStore) scoped(ctx context.Context, fn func(repos repositories) error) error {
+	return database.WithOrganization(ctx, s.db, tenant.ID(ctx), func(tx database.DBTX) error {
+		return fn(repositories{
+			tickets:  database.NewTicketRepository(tx),
+			links:    database.NewLinkRepository(tx),
+			projects: database.NewProjectRepository(tx),
+			outbox:   database.NewOutboxRepository(tx),
+		})
+	})
+}
+
+// Create stores a new ticket
+func (s *PostgresStore) Create(ctx context.Context, ticket *ticketpb.Ticket) error {
+	if ticket.ProjectId != "" && !validID(ticket.ProjectId) {
+		return ErrProjectNotFound
+	}
+	ticket.OrganizationId = tenant.ID(ctx)
+	return s.scoped(ctx, func(repos repositories) error {
+		created, err := repos.tickets.Create(ctx, ticketToDB(ticket))
+		if errors.Is(err, database.ErrDuplicate) {
+			return ErrTicketExists
+		}
+		if err != nil {
+			return projectNotFound(err)
+		}
+		ticket.Key = created.Key.String
+		return repos.record(ctx, nil, ticketFromDB(created))
+	})
+}
+
+// record adds the events of a change to the outbox, unless ctx records none
+func (repos repositories) record(ctx context.Context, previous, ticket *ticketpb.Ticket) error {
+	if !events.Recorded(ctx) {
+		return nil
+	}
+	for _, event := range events.Changes(previous, ticket, time.Now()) {
+		row, err := outboxToDB(event)
+		if err != nil {
+			return err
+		}
+		if err := repos.outbox.Insert(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get retrieves a ticket by ID
+func (s *PostgresStore) Get(ctx context.Context, id string) (*ticketpb.Ticket, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	var ticket *database.Ticket
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		ticket, err = repos.tickets.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return ticketFromDB(ticket), nil
+}
+
+// GetByKey retrieves a ticket by its project key
+func (s *PostgresStore) GetByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	var ticket *database.Ticket
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		ticket, err = repos.tickets.GetByKey(ctx, key)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return ticketFromDB(ticket), nil
+}
+
+// List returns a page of tickets, newest first
+func (s *PostgresStore) List(ctx context.Context, opts ListOptions) ([]*ticketpb.Ticket, string, error) {
+	cursor, err := DecodeCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if cursor == nil {
+		cursor = &Cursor{}
+	} else if !validID(cursor.ID) {
+		return nil, "", ErrInvalidPageToken
+	}
+	if opts.ProjectID != "" && !validID(opts.ProjectID) {
+		return nil, "", nil
+	}
+
+	// Fetch one extra row to know whether another page follows
+	limit := opts.Limit()
+	var rows []*database.Ticket
+	err = s.scoped(ctx, func(repos repositories) (err error) {
+		rows, err = repos.tickets.List(ctx, database.TicketFilter{
+			Limit:          limit + 1,
+			AfterCreatedAt: cursor.CreatedAt,
+			AfterID:        cursor.ID,
+			Tags:           opts.Tags,
+			Query:          opts.Query,
+			ProjectID:      opts.ProjectID,
+			CustomFields:   opts.CustomFields,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(rows) > limit {
+		rows = rows[:limit]
+		next = Cursor{CreatedAt: rows[limit-1].CreatedAt, ID: rows[limit-1].ID}.Encode()
+	}
+	tickets := make([]*ticketpb.Ticket, len(rows))
+	for i, row := range rows {
+		tickets[i] = ticketFromDB(row)
+	}
+	return tickets, next, nil
+}
+
+// Update applies a partial update to a ticket
+func (s *PostgresStore) Update(ctx context.Context, id string, update TicketUpdate) (*ticketpb.Ticket, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+
+	var ticket *database.Ticket
+	err := s.scoped(ctx, func(repos repositories) error {
+		previous, err := repos.tickets.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ticket, err = repos.tickets.Update(ctx, id, updateColumns(update)); err != nil {
+			return err
+		}
+		return repos.record(ctx, ticketFromDB(previous), ticketFromDB(ticket))
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return ticketFromDB(ticket), nil
+}
+
+// BulkUpdate applies updates to several tickets in one transaction, locking
+// them all before changing any
+func (s *PostgresStore) BulkUpdate(ctx context.Context, changes []TicketChange) ([]*ticketpb.Ticket, error) {
+	ids := make([]string, len(changes))
+	for i, change := range changes {
+		ids[i] = change.ID
+	}
+	if err := validIDs(ids); err != nil {
+		return nil, err
+	}
+
+	updated := make([]*ticketpb.Ticket, len(changes))
+	err := s.scoped(ctx, func(repos repositories) error {
+		previous, err := lockTickets(ctx, repos, ids)
+		if err != nil {
+			return err
+		}
+		for i, change := range changes {
+			ticket, err := repos.tickets.Update(ctx, change.ID, updateColumns(change.Update))
+			if err != nil {
+				return err
+			}
+			updated[i] = ticketFromDB(ticket)
+			if err := repos.record(ctx, previous[i], updated[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// updateColumns returns the columns an update changes, as accepted by
+// database.TicketRepository.Update
+func updateColumns(update TicketUpdate) map[string]interface{} {
+	updates := map[string]interface{}{"updated_at": update.UpdatedAt}
+	if update.Title != nil {
+		updates["title"] = *update.Title
+	}
+	if update.Description != nil {
+		updates["description"] = nullString(*update.Description)
+	}
+	if update.Status != nil {
+		updates["status"] = StatusToDB(*update.Status)
+	}
+	if update.Priority != nil {
+		updates["priority"] = PriorityToDB(*update.Priority)
+	}
+	if update.AssigneeID != nil {
+		updates["assignee_id"] = nullString(*update.AssigneeID)
+	}
+	if update.Tags != nil {
+		updates["tags"] = update.Tags
+	}
+	if update.CustomFields != nil {
+		updates["custom_fields"] = update.CustomFields
+	}
+	if update.DueDate != nil {
+		updates["due_date"] = sql.NullTime{Time: *update.DueDate, Valid: !update.DueDate.IsZero()}
+	}
+	if update.SLA != nil {
+		updates["sla"] = slaToDB(update.SLA)
+	}
+	return updates
+}
+
+// lockTickets locks the tickets of a bulk write and returns them in the
+// order of ids, or a *BulkError naming the first one that does not exist
+func lockTickets(ctx context.Context, repos repositories, ids []string) ([]*ticketpb.Ticket, error) {
+	rows, err := repos.tickets.GetByIDsForUpdate(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]*ticketpb.Ticket, len(ids))
+	for i, id := range ids {
+		row, ok := rows[uuid.MustParse(id).String()]
+		if !ok {
+			return nil, &BulkError{Index: i, ID: id, Err: ErrNotFound}
+		}
+		tickets[i] = ticketFromDB(row)
+	}
+	return tickets, nil
+}
+
+// SLADue returns the tickets of every organization whose SLA is due for evaluation
+func (s *PostgresStore) SLADue(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Ticket, error) {
+	var rows []*database.Ticket
+	err := database.WithAllOrganizations(ctx, s.db, func(tx database.DBTX) (err error) {
+		rows, err = database.NewTicketRepository(tx).ListSLADue(ctx, now, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]*ticketpb.Ticket, len(rows))
+	for i, row := range rows {
+		tickets[i] = ticketFromDB(row)
+	}
+	return tickets, nil
+}
+
+// Delete removes a ticket
+func (s *PostgresStore) Delete(ctx context.Context, id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+	return notFound(s.scoped(ctx, func(repos repositories) error {
+		previous, err := repos.tickets.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := repos.tickets.Delete(ctx, id); err != nil {
+			return err
+		}
+		return repos.record(ctx, ticketFromDB(previous), nil)
+	}))
+}
+
+// BulkDelete removes several tickets in one transaction, locking them all
+// before deleting any
+func (s *PostgresStore) BulkDelete(ctx context.Context, ids []string) error {
+	if err := validIDs(ids); err != nil {
+		return err
+	}
+	return s.scoped(ctx, func(repos repositories) error {
+		previous, err := lockTickets(ctx, repos, ids)
+		if err != nil {
+			return err
+		}
+		for i, id := range ids {
+			if err := repos.tickets.Delete(ctx, id); err != nil {
+				return err
+			}
+			if err := repos.record(ctx, previous[i], nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PendingEvents returns the oldest unpublished events of every organization
+func (s *PostgresStore) PendingEvents(ctx context.Context, limit int) ([]events.Event, error) {
+	var rows []*database.OutboxEvent
+	err := database.WithAllOrganizations(ctx, s.db, func(tx database.DBTX) (err error) {
+		rows, err = database.NewOutboxRepository(tx).ListPending(ctx, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outboxFromDB(rows)
+}
+
+// MarkPublished removes a published event from the outbox, acting for the
+// event's organization
+func (s *PostgresStore) MarkPublished(ctx context.Context, event events.Event) error {
+	return database.WithOrganization(ctx, s.db, event.OrganizationID, func(tx database.DBTX) error {
+		return database.NewOutboxRepository(tx).Delete(ctx, event.ID)
+	})
+}
+
+// Tags returns the tag catalogue
+func (s *PostgresStore) Tags(ctx context.Context, query TagQuery) ([]*ticketpb.TagCount, error) {
+	var counts []database.TagCount
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		counts, err = repos.tickets.TagCounts(ctx, query.Prefix, query.Limit())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]*ticketpb.TagCount, len(counts))
+	for i, count := range counts {
+		tags[i] = &ticketpb.TagCount{Name: count.Tag, Count: int32(count.Count)}
+	}
+	return tags, nil
+}
+
+// MergeTags replaces the source tags with target on every ticket
+func (s *PostgresStore) MergeTags(ctx context.Context, sources []string, target string, updatedAt time.Time) (int, error) {
+	var changed int
+	err := s.scoped(ctx, func(repos repositories) error {
+		// Lock the tickets first so each event has the state the merge replaced
+		previous, err := repos.tickets.GetByTagsForUpdate(ctx, sources)
+		if err != nil {
+			return err
+		}
+		merged, err := repos.tickets.MergeTags(ctx, sources, target, updatedAt)
+		if err != nil {
+			return err
+		}
+		for _, ticket := range merged {
+			if err := repos.record(ctx, ticketFromDB(previous[ticket.ID]), ticketFromDB(ticket)); err != nil {
+				return err
+			}
+		}
+		changed = len(merged)
+		return nil
+	})
+	return changed, err
+}
+
+// Link stores a link between two tickets
+func (s *PostgresStore) Link(ctx context.Context, link *ticketpb.TicketLink) error {
+	if !validID(link.SourceId) || !validID(link.TargetId) {
+		return ErrNotFound
+	}
+	err := s.scoped(ctx, func(repos repositories) error {
+		return repos.links.Create(ctx, &database.TicketLink{
+			SourceID:  link.SourceId,
+			TargetID:  link.TargetId,
+			LinkType:  LinkTypeToDB(link.Type),
+			CreatedAt: link.CreatedAt.AsTime(),
+		})
+	})
+	if errors.Is(err, database.ErrDuplicate) {
+		return ErrLinkExists
+	}
+	return notFound(err)
+}
+
+// Unlink removes a link
+func (s *PostgresStore) Unlink(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) error {
+	if !validID(sourceID) || !validID(targetID) {
+		return ErrNotFound
+	}
+	return notFound(s.scoped(ctx, func(repos repositories) error {
+		return repos.links.Delete(ctx, sourceID, targetID, LinkTypeToDB(linkType))
+	}))
+}
+
+// Links returns the links from or to a ticket
+func (s *PostgresStore) Links(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	if !validID(ticketID) {
+		return nil, nil
+	}
+	var rows []*database.TicketLink
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		rows, err = repos.links.ListByTicket(ctx, ticketID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*ticketpb.TicketLink, len(rows))
+	for i, row := range rows {
+		links[i] = &ticketpb.TicketLink{
+			SourceId:  row.SourceID,
+			TargetId:  row.TargetID,
+			Type:      LinkTypeFromDB(row.LinkType),
+			CreatedAt: timestamppb.New(row.CreatedAt),
+		}
+	}
+	return links, nil
+}
+
+// CreateProject stores a new project
+func (s *PostgresStore) CreateProject(ctx context.Context, project *ticketpb.Project) error {
+	project.OrganizationId = tenant.ID(ctx)
+	err := s.scoped(ctx, func(repos repositories) error {
+		return repos.projects.Create(ctx, &database.Project{
+			ID:             project.Id,
+			Key:            project.Key,
+			Name:           project.Name,
+			Description:    nullString(project.Description),
+			LastNumber:     project.LastNumber,
+			CreatedAt:      project.CreatedAt.AsTime(),
+			OrganizationID: project.OrganizationId,
+			CustomFields:   definitionsToDB(project.CustomFields),
+		})
+	})
+	if errors.Is(err, database.ErrDuplicate) {
+		return ErrProjectExists
+	}
+	return err
+}
+
+// GetProject retrieves a project by ID
+func (s *PostgresStore) GetProject(ctx context.Context, id string) (*ticketpb.Project, error) {
+	if !validID(id) {
+		return nil, ErrProjectNotFound
+	}
+	var project *database.Project
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		project, err = repos.projects.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, projectNotFound(err)
+	}
+	return projectFromDB(project), nil
+}
+
+// GetProjectByKey retrieves a project by key
+func (s *PostgresStore) GetProjectByKey(ctx context.Context, key string) (*ticketpb.Project, error) {
+	var project *database.Project
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		project, err = repos.projects.GetByKey(ctx, key)
+		return err
+	})
+	if err != nil {
+		return nil, projectNotFound(err)
+	}
+	return projectFromDB(project), nil
+}
+
+// ListProjects returns every project ordered by key
+func (s *PostgresStore) ListProjects(ctx context.Context) ([]*ticketpb.Project, error) {
+	var rows []*database.Project
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		rows, err = repos.projects.List(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]*ticketpb.Project, len(rows))
+	for i, row := range rows {
+		projects[i] = projectFromDB(row)
+	}
+	return projects, nil
+}
+
+// SetCustomFields replaces the custom field definitions of a project
+func (s *PostgresStore) SetCustomFields(ctx context.Context, projectID string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error) {
+	if !validID(projectID) {
+		return nil, ErrProjectNotFound
+	}
+	var project *database.Project
+	err := s.scoped(ctx, func(repos repositories) (err error) {
+		project, err = repos.projects.SetCustomFields(ctx, projectID, definitionsToDB(fields))
+		return err
+	})
+	if err != nil {
+		return nil, projectNotFound(err)
+	}
+	return projectFromDB(project), nil
+}
+
+// Close closes the database connection
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+// validID reports whether id can be stored in the UUID primary key
+func validID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// validIDs returns a *BulkError for the first ID of a bulk write that cannot
+// be stored in the UUID primary key
+func validIDs(ids []string) error {
+	for i, id := range ids {
+		if !validID(id) {
+			return &BulkError{Index: i, ID: id, Err: ErrNotFound}
+		}
+	}
+	return nil
+}
+
+// notFound translates repository not-found errors into ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// projectNotFound translates repository not-found errors into ErrProjectNotFound
+func projectNotFound(err error) error {
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrProjectNotFound
+	}
+	return err
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// outboxToDB converts an event to its outbox row
+func outboxToDB(event events.Event) (*database.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return &database.OutboxEvent{
+		EventID:        event.ID,
+		OrganizationID: event.OrganizationID,
+		EventType:      string(event.Type),
+		OccurredAt:     event.OccurredAt,
+		Payload:        string(payload),
+	}, nil
+}
+
+// outboxFromDB converts outbox rows to events
+func outboxFromDB(rows []*database.OutboxEvent) ([]events.Event, error) {
+	pending := make([]events.Event, len(rows))
+	for i, row := range rows {
+		if err := json.Unmarshal([]byte(row.Payload), &pending[i]); err != nil {
+			return nil, fmt.Errorf("invalid outbox event %s: %w", row.EventID, err)
+		}
+	}
+	return pending, nil
+}
+
+// ticketToDB converts a protobuf ticket to its database row
+func ticketToDB(ticket *ticketpb.Ticket) *database.Ticket {
+	return &database.Ticket{
+		ID:             ticket.Id,
+		Title:          ticket.Title,
+		Description:    nullString(ticket.Description),
+		Status:         StatusToDB(ticket.Status),
+		Priority:       PriorityToDB(ticket.Priority),
+		AssigneeID:     nullString(ticket.AssigneeId),
+		Tags:           ticket.Tags,
+		CreatedAt:      ticket.CreatedAt.AsTime(),
+		UpdatedAt:      ticket.UpdatedAt.AsTime(),
+		ReporterID:     nullString(ticket.ReporterId),
+		ProjectID:      nullString(ticket.ProjectId),
+		OrganizationID: ticket.OrganizationId,
+		CustomFields:   ticket.CustomFields,
+		DueDate:        nullTime(ticket.DueDate),
+		SLA:            slaToDB(ticket.Sla),
+	}
+}
+
+// ticketFromDB converts a database row to a protobuf ticket
+func ticketFromDB(row *database.Ticket) *ticketpb.Ticket {
+	return &ticketpb.Ticket{
+		Id:             row.ID,
+		Title:          row.Title,
+		Description:    row.Description.String,
+		Status:         StatusFromDB(row.Status),
+		Priority:       PriorityFromDB(row.Priority),
+		AssigneeId:     row.AssigneeID.String,
+		Tags:           row.Tags,
+		CreatedAt:      timestamppb.New(row.CreatedAt),
+		UpdatedAt:      timestamppb.New(row.UpdatedAt),
+		ReporterId:     row.ReporterID.String,
+		ProjectId:      row.ProjectID.String,
+		Key:            row.Key.String,
+		OrganizationId: row.OrganizationID,
+		CustomFields:   row.CustomFields,
+		DueDate:        timestampFromDB(row.DueDate),
+		Sla:            slaFromDB(row.SLA),
+	}
+}
+
+// slaToDB converts the SLA of a ticket to its columns
+func slaToDB(sla *ticketpb.TicketSLA) database.TicketSLA {
+	if sla == nil {
+		return database.TicketSLA{}
+	}
+	return database.TicketSLA{
+		FirstResponseDueAt: nullTime(sla.FirstResponseDueAt),
+		ResolutionDueAt:    nullTime(sla.ResolutionDueAt),
+		FirstRespondedAt:   nullTime(sla.FirstRespondedAt),
+		ResolvedAt:         nullTime(sla.ResolvedAt),
+		Status:             nullString(SLAStatusToDB(sla.Status)),
+		NextCheckAt:        nullTime(sla.NextCheckAt),
+	}
+}
+
+// slaFromDB converts SLA columns to the SLA of a ticket, nil when it has none
+func slaFromDB(row database.TicketSLA) *ticketpb.TicketSLA {
+	if !row.Status.Valid {
+		return nil
+	}
+	return &ticketpb.TicketSLA{
+		FirstResponseDueAt: timestampFromDB(row.FirstResponseDueAt),
+		ResolutionDueAt:    timestampFromDB(row.ResolutionDueAt),
+		FirstRespondedAt:   timestampFromDB(row.FirstRespondedAt),
+		ResolvedAt:         timestampFromDB(row.ResolvedAt),
+		Status:             SLAStatusFromDB(row.Status.String),
+		NextCheckAt:        timestampFromDB(row.NextCheckAt),
+	}
+}
+
+// nullTime converts an optional timestamp to a nullable column value
+func nullTime(ts *timestamppb.Timestamp) sql.NullTime {
+	if ts == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: ts.AsTime(), Valid: true}
+}
+
+// timestampFromDB converts a nullable column value to an optional timestamp
+func timestampFromDB(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+// projectFromDB converts a database row to a protobuf project
+func projectFromDB(row *database.Project) *ticketpb.Project {
+	return &ticketpb.Project{
+		Id:             row.ID,
+		Key:            row.Key,
+		Name:           row.Name,
+		Description:    row.Description.String,
+		LastNumber:     row.LastNumber,
+		CreatedAt:      timestamppb.New(row.CreatedAt),
+		OrganizationId: row.OrganizationID,
+		CustomFields:   definitionsFromDB(row.CustomFields),
+	}
+}
+
+// definitionsToDB converts protobuf custom field definitions to their stored form
+func definitionsToDB(fields []*ticketpb.CustomFieldDefinition) []database.CustomFieldDefinition {
+	rows := make([]database.CustomFieldDefinition, len(fields))
+	for i, field := range fields {
+		rows[i] = database.CustomFieldDefinition{
+			Key:      field.Key,
+			Name:     field.Name,
+			Type:     CustomFieldTypeToDB(field.Type),
+			Options:  field.Options,
+			Required: field.Required,
+		}
+	}
+	return rows
+}
+
+// definitionsFromDB converts stored custom field definitions to protobuf
+func definitionsFromDB(rows []database.CustomFieldDefinition) []*ticketpb.CustomFieldDefinition {
+	fields := make([]*ticketpb.CustomFieldDefinition, len(rows))
+	for i, row := range rows {
+		fields[i] = &ticketpb.CustomFieldDefinition{
+			Key:      row.Key,
+			Name:     row.Name,
+			Type:     CustomFieldTypeFromDB(row.Type),
+			Options:  row.Options,
+			Required: row.Required,
+		}
+	}
+	return fields
+}
+
+// StatusToDB converts a protobuf status to its database value
+func StatusToDB(status ticketpb.TicketStatus) string {
+	switch status {
+	case ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS:
+		return "IN_PROGRESS"
+	case ticketpb.TicketStatus_TICKET_STATUS_RESOLVED:
+		return "RESOLVED"
+	case ticketpb.TicketStatus_TICKET_STATUS_CLOSED:
+		return "CLOSED"
+	default:
+		return "OPEN"
+	}
+}
+
+// StatusFromDB converts a database status to its protobuf value
+func StatusFromDB(status string) ticketpb.TicketStatus {
+	switch status {
+	case "IN_PROGRESS":
+		return ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
+	case "RESOLVED":
+		return ticketpb.TicketStatus_TICKET_STATUS_RESOLVED
+	case "CLOSED":
+		return ticketpb.TicketStatus_TICKET_STATUS_CLOSED
+	default:
+		return ticketpb.TicketStatus_TICKET_STATUS_OPEN
+	}
+}
+
+// PriorityToDB converts a protobuf priority to its database value
+func PriorityToDB(priority ticketpb.TicketPriority) string {
+	switch priority {
+	case ticketpb.TicketPriority_TICKET_PRIORITY_LOW:
+		return "LOW"
+	case ticketpb.TicketPriority_TICKET_PRIORITY_HIGH:
+		return "HIGH"
+	case ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL:
+		return "CRITICAL"
+	default:
+		return "MEDIUM"
+	}
+}
+
+// PriorityFromDB converts a database priority to its protobuf value
+func PriorityFromDB(priority string) ticketpb.TicketPriority {
+	switch priority {
+	case "LOW":
+		return ticketpb.TicketPriority_TICKET_PRIORITY_LOW
+	case "HIGH":
+		return ticketpb.TicketPriority_TICKET_PRIORITY_HIGH
+	case "CRITICAL":
+		return ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL
+	default:
+		return ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
+	}
+}
+
+// LinkTypeToDB converts a protobuf link type to its database value
+func LinkTypeToDB(linkType ticketpb.LinkType) string {
+	return strings.TrimPrefix(linkType.String(), "LINK_TYPE_")
+}
+
+// LinkTypeFromDB converts a database link type to its protobuf value
+func LinkTypeFromDB(linkType string) ticketpb.LinkType {
+	return ticketpb.LinkType(ticketpb.LinkType_value["LINK_TYPE_"+linkType])
+}
+
+// SLAStatusToDB converts a protobuf SLA status to its stored value
+func SLAStatusToDB(status ticketpb.SLAStatus) string {
+	return strings.TrimPrefix(status.String(), "SLA_STATUS_")
+}
+
+// SLAStatusFromDB converts a stored SLA status to its protobuf value
+func SLAStatusFromDB(status string) ticketpb.SLAStatus {
+	return ticketpb.SLAStatus(ticketpb.SLAStatus_value["SLA_STATUS_"+status])
+}
+
+// CustomFieldTypeToDB converts a protobuf custom field type to its stored value
+func CustomFieldTypeToDB(fieldType ticketpb.CustomFieldType) string {
+	return strings.TrimPrefix(fieldType.String(), "CUSTOM_FIELD_TYPE_")
+}
+
+// CustomFieldTypeFromDB converts a stored custom field type to its protobuf value
+func CustomFieldTypeFromDB(fieldType string) ticketpb.CustomFieldType {
+	return ticketpb.CustomFieldType(ticketpb.CustomFieldType_value["CUSTOM_FIELD_TYPE_"+fieldType])
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// PostgresAttachments keeps attachment metadata in PostgreSQL. Like
+// PostgresStore it runs every call in a transaction scoped to the caller's
+// organization.
+type PostgresAttachments struct {
+	store *PostgresStore
+}
+
+// Attachments returns an attachment metadata store in the same database
+func (s *PostgresStore) Attachments() *PostgresAttachments {
+	return &PostgresAttachments{store: s}
+}
+
+// scoped runs fn with an attachment repository limited to the organization of ctx
+func (a *PostgresAttachments) scoped(ctx context.Context, fn func(repo *database.AttachmentRepository) error) error {
+	return database.WithOrganization(ctx, a.store.db, tenant.ID(ctx), func(tx database.DBTX) error {
+		return fn(database.NewAttachmentRepository(tx))
+	})
+}
+
+// Create stores attachment metadata in the caller's organization
+func (a *PostgresAttachments) Create(ctx context.Context, attachment *database.Attachment) error {
+	attachment.OrganizationID = tenant.ID(ctx)
+	return a.scoped(ctx, func(repo *database.AttachmentRepository) error {
+		return repo.Create(ctx, attachment)
+	})
+}
+
+// GetByID retrieves attachment metadata by ID
+func (a *PostgresAttachments) GetByID(ctx context.Context, id string) (*database.Attachment, error) {
+	if !validID(id) {
+		return nil, fmt.Errorf("attachment not found: %s", id)
+	}
+	var attachment *database.Attachment
+	err := a.scoped(ctx, func(repo *database.AttachmentRepository) (err error) {
+		attachment, err = repo.GetByID(ctx, id)
+		return err
+	})
+	return attachment, err
+}
+
+// ListByTicket retrieves the attachments of a ticket, oldest first
+func (a *PostgresAttachments) ListByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error) {
+	var list []*database.Attachment
+	err := a.scoped(ctx, func(repo *database.AttachmentRepository) (err error) {
+		list, err = repo.ListByTicket(ctx, ticketID)
+		return err
+	})
+	return list, err
+}
+
+// DeleteByTicket removes the metadata of every attachment of a ticket and
+// returns it
+func (a *PostgresAttachments) DeleteByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error) {
+	var deleted []*database.Attachment
+	err := a.scoped(ctx, func(repo *database.AttachmentRepository) (err error) {
+		deleted, err = repo.DeleteByTicket(ctx, ticketID)
+		return err
+	})
+	return deleted, err
+}
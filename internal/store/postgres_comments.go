@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PostgresComments keeps comments and email threads in PostgreSQL. Like
+// PostgresStore it runs every call in a transaction scoped to the caller's
+// organization.
+type PostgresComments struct {
+	store *PostgresStore
+}
+
+// Comments returns a comment store in the same database
+func (s *PostgresStore) Comments() *PostgresComments {
+	return &PostgresComments{store: s}
+}
+
+// scoped runs fn with a comment repository limited to the organization of ctx
+func (c *PostgresComments) scoped(ctx context.Context, fn func(repo *database.CommentRepository) error) error {
+	return database.WithOrganization(ctx, c.store.db, tenant.ID(ctx), func(tx database.DBTX) error {
+		return fn(database.NewCommentRepository(tx))
+	})
+}
+
+// AddComment stores a new comment
+func (c *PostgresComments) AddComment(ctx context.Context, comment *ticketpb.Comment) error {
+	if !validID(comment.TicketId) {
+		return ErrNotFound
+	}
+	comment.OrganizationId = tenant.ID(ctx)
+	return c.scoped(ctx, func(repo *database.CommentRepository) error {
+		return repo.Create(ctx, &database.Comment{
+			ID:             comment.Id,
+			OrganizationID: comment.OrganizationId,
+			TicketID:       comment.TicketId,
+			AuthorID:       comment.AuthorId,
+			Body:           comment.Body,
+			CreatedAt:      comment.CreatedAt.AsTime(),
+		})
+	})
+}
+
+// ListComments returns the comments of a ticket, oldest first
+func (c *PostgresComments) ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error) {
+	if !validID(ticketID) {
+		return nil, nil
+	}
+	var rows []*database.Comment
+	err := c.scoped(ctx, func(repo *database.CommentRepository) (err error) {
+		rows, err = repo.List(ctx, ticketID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*ticketpb.Comment, len(rows))
+	for i, row := range rows {
+		comments[i] = &ticketpb.Comment{
+			Id:             row.ID,
+			OrganizationId: row.OrganizationID,
+			TicketId:       row.TicketID,
+			AuthorId:       row.AuthorID,
+			Body:           row.Body,
+			CreatedAt:      timestamppb.New(row.CreatedAt),
+		}
+	}
+	return comments, nil
+}
+
+// RecordMessage adds an email to the thread of a ticket
+func (c *PostgresComments) RecordMessage(ctx context.Context, messageID, ticketID string, at time.Time) (bool, error) {
+	if !validID(ticketID) {
+		return false, ErrNotFound
+	}
+	var recorded bool
+	err := c.scoped(ctx, func(repo *database.CommentRepository) (err error) {
+		recorded, err = repo.RecordMessage(ctx, tenant.ID(ctx), messageID, ticketID, at)
+		return err
+	})
+	return recorded, err
+}
+
+// FindThread returns the ticket of the first recorded message
+func (c *PostgresComments) FindThread(ctx context.Context, messageIDs []string) (string, error) {
+	if len(messageIDs) == 0 {
+		return "", ErrNotFound
+	}
+	var ticketID string
+	err := c.scoped(ctx, func(repo *database.CommentRepository) (err error) {
+		ticketID, err = repo.FindThread(ctx, messageIDs)
+		return err
+	})
+	if err != nil {
+		return "", notFound(err)
+	}
+	return ticketID, nil
+}
+
+// DeleteTicket removes the comments and the email thread of a ticket
+func (c *PostgresComments) DeleteTicket(ctx context.Context, ticketID string) error {
+	if !validID(ticketID) {
+		return nil
+	}
+	return c.scoped(ctx, func(repo *database.CommentRepository) error {
+		return repo.DeleteTicket(ctx, ticketID)
+	})
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// PostgresIdempotency keeps idempotency keys in PostgreSQL. Like
+// PostgresStore it runs every call in a transaction scoped to the caller's
+// organization.
+type PostgresIdempotency struct {
+	store *PostgresStore
+}
+
+var _ idempotency.Store = (*PostgresIdempotency)(nil)
+
+// Idempotency returns an idempotency key store in the same database
+func (s *PostgresStore) Idempotency() *PostgresIdempotency {
+	return &PostgresIdempotency{store: s}
+}
+
+// scoped runs fn with an idempotency key repository limited to the
+// organization of ctx
+func (i *PostgresIdempotency) scoped(ctx context.Context, fn func(repo *database.IdempotencyRepository) error) error {
+	return database.WithOrganization(ctx, i.store.db, tenant.ID(ctx), func(tx database.DBTX) error {
+		return fn(database.NewIdempotencyRepository(tx))
+	})
+}
+
+// Reserve stores record unless its key holds a live record, purging the
+// organization's expired keys first
+func (i *PostgresIdempotency) Reserve(ctx context.Context, record *idempotency.Record, staleBefore time.Time) (*idempotency.Record, error) {
+	var existing *idempotency.Record
+	err := i.scoped(ctx, func(repo *database.IdempotencyRepository) error {
+		if err := repo.Purge(ctx, record.CreatedAt); err != nil {
+			return err
+		}
+		reserved, err := repo.Reserve(ctx, &database.IdempotencyKey{
+			OrganizationID: tenant.ID(ctx),
+			Key:            record.Key,
+			Method:         record.Method,
+			RequestHash:    record.RequestHash,
+			CreatedAt:      record.CreatedAt,
+			ExpiresAt:      record.ExpiresAt,
+		}, staleBefore)
+		if err != nil || reserved {
+			return err
+		}
+
+		row, err := repo.Get(ctx, record.Key)
+		if err != nil {
+			return err
+		}
+		existing = &idempotency.Record{
+			Key:         row.Key,
+			Method:      row.Method,
+			RequestHash: row.RequestHash,
+			Response:    row.Response,
+			CreatedAt:   row.CreatedAt,
+			CompletedAt: row.CompletedAt.Time,
+			ExpiresAt:   row.ExpiresAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// Complete saves the response of a reserved key
+func (i *PostgresIdempotency) Complete(ctx context.Context, key string, response []byte, completedAt time.Time) error {
+	return i.scoped(ctx, func(repo *database.IdempotencyRepository) error {
+		return repo.Complete(ctx, key, response, completedAt)
+	})
+}
+
+// Release removes a key that is still pending
+func (i *PostgresIdempotency) Release(ctx context.Context, key string) error {
+	return i.scoped(ctx, func(repo *database.IdempotencyRepository) error {
+		return repo.Release(ctx, key)
+	})
+}
@@ -0,0 +1,262 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PostgresNotifications keeps watchers, notifications and notification
+// preferences in PostgreSQL. Like PostgresStore it runs every call in a
+// transaction scoped to the caller's organization.
+type PostgresNotifications struct {
+	store *PostgresStore
+}
+
+// Notifications returns a notification store in the same database
+func (s *PostgresStore) Notifications() *PostgresNotifications {
+	return &PostgresNotifications{store: s}
+}
+
+// scoped runs fn with a notification repository limited to the organization of ctx
+func (n *PostgresNotifications) scoped(ctx context.Context, fn func(repo *database.NotificationRepository) error) error {
+	return database.WithOrganization(ctx, n.store.db, tenant.ID(ctx), func(tx database.DBTX) error {
+		return fn(database.NewNotificationRepository(tx))
+	})
+}
+
+// Watch adds a watcher to a ticket
+func (n *PostgresNotifications) Watch(ctx context.Context, ticketID, userID string, at time.Time) error {
+	if !validID(ticketID) {
+		return ErrNotFound
+	}
+	return n.scoped(ctx, func(repo *database.NotificationRepository) error {
+		return repo.Watch(ctx, tenant.ID(ctx), ticketID, userID, at)
+	})
+}
+
+// Unwatch removes a watcher
+func (n *PostgresNotifications) Unwatch(ctx context.Context, ticketID, userID string) (bool, error) {
+	if !validID(ticketID) {
+		return false, nil
+	}
+	var watching bool
+	err := n.scoped(ctx, func(repo *database.NotificationRepository) (err error) {
+		watching, err = repo.Unwatch(ctx, ticketID, userID)
+		return err
+	})
+	return watching, err
+}
+
+// Watchers returns the watchers of a ticket
+func (n *PostgresNotifications) Watchers(ctx context.Context, ticketID string) ([]string, error) {
+	if !validID(ticketID) {
+		return nil, nil
+	}
+	var users []string
+	err := n.scoped(ctx, func(repo *database.NotificationRepository) (err error) {
+		users, err = repo.Watchers(ctx, ticketID)
+		return err
+	})
+	return users, err
+}
+
+// DeleteWatchers removes every watcher of a ticket
+func (n *PostgresNotifications) DeleteWatchers(ctx context.Context, ticketID string) error {
+	if !validID(ticketID) {
+		return nil
+	}
+	return n.scoped(ctx, func(repo *database.NotificationRepository) error {
+		return repo.DeleteWatchers(ctx, ticketID)
+	})
+}
+
+// CreateNotifications stores new notifications in one transaction
+func (n *PostgresNotifications) CreateNotifications(ctx context.Context, notifications []*ticketpb.Notification) error {
+	for _, notification := range notifications {
+		notification.OrganizationId = tenant.ID(ctx)
+	}
+	return n.scoped(ctx, func(repo *database.NotificationRepository) error {
+		for _, notification := range notifications {
+			if err := repo.Create(ctx, notificationToDB(notification)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListNotifications returns the notifications of a user, newest first
+func (n *PostgresNotifications) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]*ticketpb.Notification, error) {
+	var rows []*database.Notification
+	err := n.scoped(ctx, func(repo *database.NotificationRepository) (err error) {
+		rows, err = repo.List(ctx, userID, unreadOnly, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notificationsFromDB(rows), nil
+}
+
+// MarkRead sets the read time of a notification
+func (n *PostgresNotifications) MarkRead(ctx context.Context, id string, at time.Time) (*ticketpb.Notification, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	var row *database.Notification
+	err := n.scoped(ctx, func(repo *database.NotificationRepository) (err error) {
+		row, err = repo.MarkRead(ctx, id, at)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return notificationFromDB(row), nil
+}
+
+// DueNotifications returns the notifications of every organization whose
+// delivery is due
+func (n *PostgresNotifications) DueNotifications(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Notification, error) {
+	var rows []*database.Notification
+	err := database.WithAllOrganizations(ctx, n.store.db, func(tx database.DBTX) (err error) {
+		rows, err = database.NewNotificationRepository(tx).ListDue(ctx, now, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notificationsFromDB(rows), nil
+}
+
+// MarkDelivered records the delivery of notifications
+func (n *PostgresNotifications) MarkDelivered(ctx context.Context, ids []string, at time.Time) error {
+	return n.scoped(ctx, func(repo *database.NotificationRepository) error {
+		return repo.MarkDelivered(ctx, ids, at)
+	})
+}
+
+// GetPreferences retrieves the preferences of a user
+func (n *PostgresNotifications) GetPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error) {
+	var row *database.NotificationPreferences
+	err := n.scoped(ctx, func(repo *database.NotificationRepository) (err error) {
+		row, err = repo.GetPreferences(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return preferencesFromDB(row), nil
+}
+
+// SetPreferences stores the preferences of a user
+func (n *PostgresNotifications) SetPreferences(ctx context.Context, preferences *ticketpb.NotificationPreferences) error {
+	preferences.OrganizationId = tenant.ID(ctx)
+	return n.scoped(ctx, func(repo *database.NotificationRepository) error {
+		return repo.SetPreferences(ctx, preferencesToDB(preferences))
+	})
+}
+
+// notificationToDB converts a protobuf notification to its database row
+func notificationToDB(notification *ticketpb.Notification) *database.Notification {
+	return &database.Notification{
+		ID:             notification.Id,
+		OrganizationID: notification.OrganizationId,
+		UserID:         notification.UserId,
+		Kind:           NotificationKindToDB(notification.Kind),
+		TicketID:       notification.TicketId,
+		EventID:        notification.EventId,
+		Message:        notification.Message,
+		CreatedAt:      notification.CreatedAt.AsTime(),
+		ReadAt:         nullTime(notification.ReadAt),
+		DeliverAt:      nullTime(notification.DeliverAt),
+		DeliveredAt:    nullTime(notification.DeliveredAt),
+	}
+}
+
+// notificationFromDB converts a database row to a protobuf notification
+func notificationFromDB(row *database.Notification) *ticketpb.Notification {
+	return &ticketpb.Notification{
+		Id:             row.ID,
+		OrganizationId: row.OrganizationID,
+		UserId:         row.UserID,
+		Kind:           NotificationKindFromDB(row.Kind),
+		TicketId:       row.TicketID,
+		EventId:        row.EventID,
+		Message:        row.Message,
+		CreatedAt:      timestamppb.New(row.CreatedAt),
+		ReadAt:         timestampFromDB(row.ReadAt),
+		DeliverAt:      timestampFromDB(row.DeliverAt),
+		DeliveredAt:    timestampFromDB(row.DeliveredAt),
+	}
+}
+
+func notificationsFromDB(rows []*database.Notification) []*ticketpb.Notification {
+	notifications := make([]*ticketpb.Notification, len(rows))
+	for i, row := range rows {
+		notifications[i] = notificationFromDB(row)
+	}
+	return notifications
+}
+
+// preferencesToDB converts protobuf notification preferences to their
+// database row
+func preferencesToDB(preferences *ticketpb.NotificationPreferences) *database.NotificationPreferences {
+	mutedKinds := make([]string, len(preferences.MutedKinds))
+	for i, kind := range preferences.MutedKinds {
+		mutedKinds[i] = NotificationKindToDB(kind)
+	}
+	return &database.NotificationPreferences{
+		OrganizationID: preferences.OrganizationId,
+		UserID:         preferences.UserId,
+		Email:          preferences.Email,
+		Delivery:       NotificationDeliveryToDB(preferences.Delivery),
+		DigestHour:     preferences.DigestHour,
+		MutedKinds:     mutedKinds,
+		UpdatedAt:      preferences.UpdatedAt.AsTime(),
+	}
+}
+
+// preferencesFromDB converts a database row to protobuf notification
+// preferences
+func preferencesFromDB(row *database.NotificationPreferences) *ticketpb.NotificationPreferences {
+	preferences := &ticketpb.NotificationPreferences{
+		UserId:         row.UserID,
+		OrganizationId: row.OrganizationID,
+		Email:          row.Email,
+		Delivery:       NotificationDeliveryFromDB(row.Delivery),
+		DigestHour:     row.DigestHour,
+		UpdatedAt:      timestamppb.New(row.UpdatedAt),
+	}
+	for _, kind := range row.MutedKinds {
+		preferences.MutedKinds = append(preferences.MutedKinds, NotificationKindFromDB(kind))
+	}
+	return preferences
+}
+
+// NotificationKindToDB converts a protobuf notification kind to its stored value
+func NotificationKindToDB(kind ticketpb.NotificationKind) string {
+	return strings.TrimPrefix(kind.String(), "NOTIFICATION_KIND_")
+}
+
+// NotificationKindFromDB converts a stored notification kind to its protobuf value
+func NotificationKindFromDB(kind string) ticketpb.NotificationKind {
+	return ticketpb.NotificationKind(ticketpb.NotificationKind_value["NOTIFICATION_KIND_"+kind])
+}
+
+// NotificationDeliveryToDB converts a protobuf notification delivery to its
+// stored value
+func NotificationDeliveryToDB(delivery ticketpb.NotificationDelivery) string {
+	return strings.TrimPrefix(delivery.String(), "NOTIFICATION_DELIVERY_")
+}
+
+// NotificationDeliveryFromDB converts a stored notification delivery to its
+// protobuf value
+func NotificationDeliveryFromDB(delivery string) ticketpb.NotificationDelivery {
+	return ticketpb.NotificationDelivery(ticketpb.NotificationDelivery_value["NOTIFICATION_DELIVERY_"+delivery])
+}
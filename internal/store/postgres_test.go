@@ -0,0 +1,157 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/store/storetest"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	"github.com/lib/pq"
+)
+
+// postgresDSNEnv names the variable holding the DSN of a PostgreSQL database
+// with migrations/ applied. The tests empty its tables, so never point it at
+// a database whose data you want to keep.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+// appRole is the role the services connect as (see migrations/018)
+const appRole = "ticket_app"
+
+// openPostgres connects to the test database and empties it
+func openPostgres(t *testing.T) *store.PostgresStore {
+	t.Helper()
+	return store.NewPostgresStore(emptyPostgres(t))
+}
+
+// emptyPostgres empties the test database and returns a connection to it
+func emptyPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`TRUNCATE tickets, ticket_links, projects, attachments, webhooks,
+		webhook_deliveries, outbox, ticket_watchers, notifications, notification_preferences,
+		comments, email_messages, idempotency_keys CASCADE`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("failed to empty database: %v", err)
+	}
+	return db
+}
+
+// roleConnector switches every new connection to a role
+type roleConnector struct {
+	driver.Connector
+	role string
+}
+
+func (c roleConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.(driver.ExecerContext).ExecContext(ctx, `SET ROLE `+pq.QuoteIdentifier(c.role), nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to switch to role %s: %w", c.role, err)
+	}
+	return conn, nil
+}
+
+// openPostgresAsApp empties the test database and connects to it with the
+// privileges of the services rather than those of the tables' owner, so
+// row-level security applies even when the test DSN names a superuser
+func openPostgresAsApp(t *testing.T) *store.PostgresStore {
+	t.Helper()
+	emptyPostgres(t).Close()
+
+	connector, err := pq.NewConnector(os.Getenv(postgresDSNEnv))
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", postgresDSNEnv, err)
+	}
+	db := sql.OpenDB(roleConnector{Connector: connector, role: appRole})
+	bypass, err := database.BypassesRowSecurity(context.Background(), db)
+	if err != nil {
+		db.Close()
+		t.Fatalf("failed to connect as %s: %v", appRole, err)
+	}
+	if bypass {
+		db.Close()
+		t.Fatalf("%s bypasses row-level security", appRole)
+	}
+	return store.NewPostgresStore(db)
+}
+
+func TestPostgresStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.TicketStore {
+		return openPostgres(t)
+	})
+}
+
+func TestPostgresWebhooks(t *testing.T) {
+	storetest.RunWebhooks(t, func(t *testing.T) webhooks.Store {
+		s := openPostgres(t)
+		t.Cleanup(func() { s.Close() })
+		return s.Webhooks()
+	})
+}
+
+func TestPostgresNotifications(t *testing.T) {
+	storetest.RunNotifications(t, func(t *testing.T) notifications.Store {
+		s := openPostgres(t)
+		t.Cleanup(func() { s.Close() })
+		return s.Notifications()
+	})
+}
+
+func TestPostgresComments(t *testing.T) {
+	storetest.RunComments(t, func(t *testing.T) comments.Store {
+		s := openPostgres(t)
+		t.Cleanup(func() { s.Close() })
+		return s.Comments()
+	})
+}
+
+// TestPostgresAppRole runs the suites, tenant isolation included, as the
+// services' role, which row-level security applies to
+func TestPostgresAppRole(t *testing.T) {
+	t.Run("Tickets", func(t *testing.T) {
+		storetest.Run(t, func(t *testing.T) store.TicketStore {
+			return openPostgresAsApp(t)
+		})
+	})
+	t.Run("Webhooks", func(t *testing.T) {
+		storetest.RunWebhooks(t, func(t *testing.T) webhooks.Store {
+			s := openPostgresAsApp(t)
+			t.Cleanup(func() { s.Close() })
+			return s.Webhooks()
+		})
+	})
+	t.Run("Notifications", func(t *testing.T) {
+		storetest.RunNotifications(t, func(t *testing.T) notifications.Store {
+			s := openPostgresAsApp(t)
+			t.Cleanup(func() { s.Close() })
+			return s.Notifications()
+		})
+	})
+	t.Run("Comments", func(t *testing.T) {
+		storetest.RunComments(t, func(t *testing.T) comments.Store {
+			s := openPostgresAsApp(t)
+			t.Cleanup(func() { s.Close() })
+			return s.Comments()
+		})
+	})
+}
@@ -0,0 +1,295 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PostgresWebhooks keeps webhooks and their deliveries in PostgreSQL. Like
+// PostgresStore it runs every call in a transaction scoped to the caller's
+// organization.
+type PostgresWebhooks struct {
+	store *PostgresStore
+}
+
+// Webhooks returns a webhook store in the same database
+func (s *PostgresStore) Webhooks() *PostgresWebhooks {
+	return &PostgresWebhooks{store: s}
+}
+
+// scoped runs fn with a webhook repository limited to the organization of ctx
+func (w *PostgresWebhooks) scoped(ctx context.Context, fn func(repo *database.WebhookRepository) error) error {
+	return database.WithOrganization(ctx, w.store.db, tenant.ID(ctx), func(tx database.DBTX) error {
+		return fn(database.NewWebhookRepository(tx))
+	})
+}
+
+// CreateWebhook stores a new webhook
+func (w *PostgresWebhooks) CreateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error {
+	webhook.OrganizationId = tenant.ID(ctx)
+	return w.scoped(ctx, func(repo *database.WebhookRepository) error {
+		return repo.Create(ctx, webhookToDB(webhook))
+	})
+}
+
+// GetWebhook retrieves a webhook
+func (w *PostgresWebhooks) GetWebhook(ctx context.Context, id string) (*ticketpb.Webhook, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	var webhook *database.Webhook
+	err := w.scoped(ctx, func(repo *database.WebhookRepository) (err error) {
+		webhook, err = repo.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return webhookFromDB(webhook), nil
+}
+
+// ListWebhooks returns every webhook, oldest first
+func (w *PostgresWebhooks) ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error) {
+	var rows []*database.Webhook
+	err := w.scoped(ctx, func(repo *database.WebhookRepository) (err error) {
+		rows, err = repo.List(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	webhooks := make([]*ticketpb.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = webhookFromDB(row)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook replaces a stored webhook
+func (w *PostgresWebhooks) UpdateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error {
+	if !validID(webhook.Id) {
+		return ErrNotFound
+	}
+	webhook.OrganizationId = tenant.ID(ctx)
+	return notFound(w.scoped(ctx, func(repo *database.WebhookRepository) error {
+		return repo.Update(ctx, webhookToDB(webhook))
+	}))
+}
+
+// DeleteWebhook removes a webhook and its deliveries
+func (w *PostgresWebhooks) DeleteWebhook(ctx context.Context, id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+	return notFound(w.scoped(ctx, func(repo *database.WebhookRepository) error {
+		return repo.Delete(ctx, id)
+	}))
+}
+
+// CreateDeliveries stores new deliveries in one transaction
+func (w *PostgresWebhooks) CreateDeliveries(ctx context.Context, deliveries []*ticketpb.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		if !validID(delivery.WebhookId) {
+			return ErrNotFound
+		}
+		delivery.OrganizationId = tenant.ID(ctx)
+	}
+	return notFound(w.scoped(ctx, func(repo *database.WebhookRepository) error {
+		for _, delivery := range deliveries {
+			if err := repo.CreateDelivery(ctx, deliveryToDB(delivery)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// GetDelivery retrieves a delivery
+func (w *PostgresWebhooks) GetDelivery(ctx context.Context, id string) (*ticketpb.WebhookDelivery, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	var delivery *database.WebhookDelivery
+	err := w.scoped(ctx, func(repo *database.WebhookRepository) (err error) {
+		delivery, err = repo.GetDelivery(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return deliveryFromDB(delivery), nil
+}
+
+// ListDeliveries returns the deliveries of a webhook, newest first
+func (w *PostgresWebhooks) ListDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int) ([]*ticketpb.WebhookDelivery, error) {
+	if !validID(webhookID) {
+		return nil, ErrNotFound
+	}
+	var rows []*database.WebhookDelivery
+	err := w.scoped(ctx, func(repo *database.WebhookRepository) (err error) {
+		if _, err := repo.GetByID(ctx, webhookID); err != nil {
+			return err
+		}
+		rows, err = repo.ListDeliveries(ctx, webhookID, deliveryStatusFilter(status), limit)
+		return err
+	})
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return deliveriesFromDB(rows), nil
+}
+
+// UpdateDelivery replaces a stored delivery
+func (w *PostgresWebhooks) UpdateDelivery(ctx context.Context, delivery *ticketpb.WebhookDelivery) error {
+	if !validID(delivery.Id) {
+		return ErrNotFound
+	}
+	return notFound(w.scoped(ctx, func(repo *database.WebhookRepository) error {
+		return repo.UpdateDelivery(ctx, deliveryToDB(delivery))
+	}))
+}
+
+// DueDeliveries returns the pending deliveries of every organization whose
+// next attempt is due
+func (w *PostgresWebhooks) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*ticketpb.WebhookDelivery, error) {
+	var rows []*database.WebhookDelivery
+	err := database.WithAllOrganizations(ctx, w.store.db, func(tx database.DBTX) (err error) {
+		rows, err = database.NewWebhookRepository(tx).ListDueDeliveries(ctx, now, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveriesFromDB(rows), nil
+}
+
+// webhookToDB converts a protobuf webhook to its database row
+func webhookToDB(webhook *ticketpb.Webhook) *database.Webhook {
+	return &database.Webhook{
+		ID:             webhook.Id,
+		OrganizationID: webhook.OrganizationId,
+		URL:            webhook.Url,
+		Secret:         webhook.Secret,
+		EventTypes:     webhook.EventTypes,
+		Filter:         webhookFilterToDB(webhook.Filter),
+		Active:         webhook.Active,
+		CreatedAt:      webhook.CreatedAt.AsTime(),
+		UpdatedAt:      webhook.UpdatedAt.AsTime(),
+	}
+}
+
+// webhookFromDB converts a database row to a protobuf webhook
+func webhookFromDB(row *database.Webhook) *ticketpb.Webhook {
+	return &ticketpb.Webhook{
+		Id:             row.ID,
+		OrganizationId: row.OrganizationID,
+		Url:            row.URL,
+		Secret:         row.Secret,
+		EventTypes:     row.EventTypes,
+		Filter:         webhookFilterFromDB(row.Filter),
+		Active:         row.Active,
+		CreatedAt:      timestamppb.New(row.CreatedAt),
+		UpdatedAt:      timestamppb.New(row.UpdatedAt),
+	}
+}
+
+// webhookFilterToDB converts a protobuf webhook filter to its stored form
+func webhookFilterToDB(filter *ticketpb.WebhookFilter) database.WebhookFilter {
+	var row database.WebhookFilter
+	if filter == nil {
+		return row
+	}
+	row.ProjectID = filter.ProjectId
+	row.Tags = filter.Tags
+	for _, priority := range filter.Priorities {
+		row.Priorities = append(row.Priorities, PriorityToDB(priority))
+	}
+	return row
+}
+
+// webhookFilterFromDB converts a stored webhook filter to its protobuf form,
+// nil when it has no conditions
+func webhookFilterFromDB(row database.WebhookFilter) *ticketpb.WebhookFilter {
+	if row.ProjectID == "" && len(row.Tags) == 0 && len(row.Priorities) == 0 {
+		return nil
+	}
+	filter := &ticketpb.WebhookFilter{ProjectId: row.ProjectID, Tags: row.Tags}
+	for _, priority := range row.Priorities {
+		filter.Priorities = append(filter.Priorities, PriorityFromDB(priority))
+	}
+	return filter
+}
+
+// deliveryToDB converts a protobuf delivery to its database row
+func deliveryToDB(delivery *ticketpb.WebhookDelivery) *database.WebhookDelivery {
+	return &database.WebhookDelivery{
+		ID:             delivery.Id,
+		OrganizationID: delivery.OrganizationId,
+		WebhookID:      delivery.WebhookId,
+		EventID:        delivery.EventId,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         DeliveryStatusToDB(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  nullTime(delivery.NextAttemptAt),
+		LastAttemptAt:  nullTime(delivery.LastAttemptAt),
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.AsTime(),
+		DeliveredAt:    nullTime(delivery.DeliveredAt),
+	}
+}
+
+// deliveryFromDB converts a database row to a protobuf delivery
+func deliveryFromDB(row *database.WebhookDelivery) *ticketpb.WebhookDelivery {
+	return &ticketpb.WebhookDelivery{
+		Id:             row.ID,
+		OrganizationId: row.OrganizationID,
+		WebhookId:      row.WebhookID,
+		EventId:        row.EventID,
+		EventType:      row.EventType,
+		Payload:        row.Payload,
+		Status:         DeliveryStatusFromDB(row.Status),
+		Attempts:       row.Attempts,
+		NextAttemptAt:  timestampFromDB(row.NextAttemptAt),
+		LastAttemptAt:  timestampFromDB(row.LastAttemptAt),
+		LastStatusCode: row.LastStatusCode,
+		LastError:      row.LastError,
+		CreatedAt:      timestamppb.New(row.CreatedAt),
+		DeliveredAt:    timestampFromDB(row.DeliveredAt),
+	}
+}
+
+// deliveriesFromDB converts database rows to protobuf deliveries
+func deliveriesFromDB(rows []*database.WebhookDelivery) []*ticketpb.WebhookDelivery {
+	deliveries := make([]*ticketpb.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = deliveryFromDB(row)
+	}
+	return deliveries
+}
+
+// deliveryStatusFilter returns the stored status a delivery list is limited
+// to, "" for every status
+func deliveryStatusFilter(status ticketpb.WebhookDeliveryStatus) string {
+	if status == ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return DeliveryStatusToDB(status)
+}
+
+// DeliveryStatusToDB converts a protobuf delivery status to its stored value
+func DeliveryStatusToDB(status ticketpb.WebhookDeliveryStatus) string {
+	return strings.TrimPrefix(status.String(), "WEBHOOK_DELIVERY_STATUS_")
+}
+
+// DeliveryStatusFromDB converts a stored delivery status to its protobuf value
+func DeliveryStatusFromDB(status string) ticketpb.WebhookDeliveryStatus {
+	return ticketpb.WebhookDeliveryStatus(ticketpb.WebhookDeliveryStatus_value["WEBHOOK_DELIVERY_STATUS_"+status])
+}
@@ -0,0 +1,1099 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	_ "modernc.org/sqlite" // pure-Go SQLite driver
+)
+
+//go:embed sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// SQLiteStore keeps tickets in a single SQLite database file in WAL mode
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var _ TicketStore = (*SQLiteStore)(nil)
+
+// OpenSQLite opens (creating if needed) the database at path and applies
+// pending migrations
+func OpenSQLite(path string) (*SQLiteStore, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	// Writers take the lock when the transaction starts so concurrent writers
+	// wait on busy_timeout instead of failing to upgrade a read lock
+	query := url.Values{
+		"_pragma": {"journal_mode(WAL)", "synchronous(NORMAL)", "foreign_keys(ON)", "busy_timeout(5000)"},
+		"_txlock": {"immediate"},
+	}
+	// Escape the path so a '?' or '#' in it is not read as the start of the
+	// query or fragment of the URI
+	dsn := (&url.URL{Scheme: "file", Opaque: url.PathEscape(path), RawQuery: query.Encode()}).String()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := migrateSQLite(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	log.Printf("✅ SQLite database ready at %s", path)
+	return &SQLiteStore{db: db}, nil
+}
+
+// migrateSQLite applies the embedded migrations newer than the database's
+// user_version. Migrations run with foreign keys off so they can rebuild
+// referenced tables; the references are checked before each one commits.
+func migrateSQLite(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	var current int
+	if err := conn.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`)
+
+	files, err := fs.Glob(sqliteMigrations, "sqlite/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version, err := strconv.Atoi(strings.SplitN(filepath.Base(file), "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("invalid migration name %s: %w", file, err)
+		}
+		if version <= current {
+			continue
+		}
+
+		script, err := sqliteMigrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %s: %w", file, err)
+		}
+		if err := checkForeignKeys(ctx, tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s broke foreign keys: %w", file, err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, version)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", file, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", file, err)
+		}
+		log.Printf("✅ Applied SQLite migration %s", filepath.Base(file))
+	}
+	return nil
+}
+
+// checkForeignKeys fails when any row references a missing parent
+func checkForeignKeys(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		var table, parent string
+		var rowid sql.NullInt64
+		var fk int
+		if err := rows.Scan(&table, &rowid, &parent, &fk); err != nil {
+			return err
+		}
+		return fmt.Errorf("row %d of %s references a missing %s row", rowid.Int64, table, parent)
+	}
+	return rows.Err()
+}
+
+// DB returns the underlying database handle
+func (s *SQLiteStore) DB() *sql.DB {
+	return s.db
+}
+
+// Attachments returns an attachment metadata store in the same database
+func (s *SQLiteStore) Attachments() *SQLiteAttachments {
+	return &SQLiteAttachments{db: s.db}
+}
+
+const sqliteTicketColumns = `id, title, description, status, priority, assignee_id, reporter_id, created_at, updated_at, project_id, key, organization_id, custom_fields, ` +
+	`due_date, sla_first_response_due_at, sla_resolution_due_at, sla_first_responded_at, sla_resolved_at, sla_status, sla_next_check_at`
+
+// Create stores a new ticket
+func (s *SQLiteStore) Create(ctx context.Context, ticket *ticketpb.Ticket) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ticket.OrganizationId = tenant.ID(ctx)
+	// The transaction holds the write lock, so numbers are handed out one at a time
+	if ticket.ProjectId != "" {
+		var projectKey string
+		var number int64
+		err := tx.QueryRowContext(ctx, `
+			UPDATE projects SET last_number = last_number + 1
+			WHERE id = ? AND organization_id = ?
+			RETURNING key, last_number`,
+			ticket.ProjectId, ticket.OrganizationId).Scan(&projectKey, &number)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProjectNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to number ticket: %w", err)
+		}
+		ticket.Key = TicketKey(projectKey, number)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO tickets (`+sqliteTicketColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		ticket.Id,
+		ticket.Title,
+		nullString(ticket.Description),
+		StatusToDB(ticket.Status),
+		PriorityToDB(ticket.Priority),
+		nullString(ticket.AssigneeId),
+		nullString(ticket.ReporterId),
+		ticket.CreatedAt.AsTime().UnixMicro(),
+		ticket.UpdatedAt.AsTime().UnixMicro(),
+		nullString(ticket.ProjectId),
+		nullString(ticket.Key),
+		ticket.OrganizationId,
+		customFieldsJSON(ticket.CustomFields),
+		nullMicros(ticket.DueDate),
+		nullMicros(ticket.Sla.GetFirstResponseDueAt()),
+		nullMicros(ticket.Sla.GetResolutionDueAt()),
+		nullMicros(ticket.Sla.GetFirstRespondedAt()),
+		nullMicros(ticket.Sla.GetResolvedAt()),
+		sqliteSLAStatus(ticket.Sla),
+		nullMicros(ticket.Sla.GetNextCheckAt()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if inserted == 0 {
+		return ErrTicketExists
+	}
+	if err := replaceTags(ctx, tx, ticket.Id, ticket.Tags); err != nil {
+		return err
+	}
+	if err := recordSQLite(ctx, tx, nil, ticket); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// recordSQLite adds the events of a change to the outbox, unless ctx records
+// none
+func recordSQLite(ctx context.Context, tx *sql.Tx, previous, ticket *ticketpb.Ticket) error {
+	if !events.Recorded(ctx) {
+		return nil
+	}
+	for _, event := range events.Changes(previous, ticket, time.Now()) {
+		row, err := outboxToDB(event)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO outbox (event_id, organization_id, event_type, occurred_at, payload)
+			VALUES (?, ?, ?, ?, ?)`,
+			row.EventID, row.OrganizationID, row.EventType, row.OccurredAt.UnixMicro(), row.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+	return nil
+}
+
+// Get retrieves a ticket by ID
+func (s *SQLiteStore) Get(ctx context.Context, id string) (*ticketpb.Ticket, error) {
+	return getSQLiteTicket(ctx, s.db, id)
+}
+
+// GetByKey retrieves a ticket by its project key
+func (s *SQLiteStore) GetByKey(ctx context.Context, key string) (*ticketpb.Ticket, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `SELECT id FROM tickets WHERE organization_id = ? AND key = ?`,
+		tenant.ID(ctx), key).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	return getSQLiteTicket(ctx, s.db, id)
+}
+
+// List returns a page of tickets, newest first
+func (s *SQLiteStore) List(ctx context.Context, opts ListOptions) ([]*ticketpb.Ticket, string, error) {
+	cursor, err := DecodeCursor(opts.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conditions := []string{`organization_id = ?`}
+	args := []interface{}{tenant.ID(ctx)}
+	if cursor != nil {
+		micros := cursor.CreatedAt.UnixMicro()
+		conditions = append(conditions, `(created_at < ? OR (created_at = ? AND id < ?))`)
+		args = append(args, micros, micros, cursor.ID)
+	}
+	if opts.ProjectID != "" {
+		conditions = append(conditions, `project_id = ?`)
+		args = append(args, opts.ProjectID)
+	}
+	for _, tag := range opts.Tags {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM ticket_tags tt WHERE tt.ticket_id = tickets.id AND tt.tag = ?)`)
+		args = append(args, tag)
+	}
+	for key, value := range opts.CustomFields {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM json_each(tickets.custom_fields) cf WHERE cf.key = ? AND cf.value = ?)`)
+		args = append(args, key, value)
+	}
+	if opts.Query != "" {
+		pattern := "%" + escapeLike(opts.Query) + "%"
+		conditions = append(conditions, `(title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	query := `SELECT ` + sqliteTicketColumns + ` FROM tickets WHERE ` + strings.Join(conditions, " AND ")
+	// Fetch one extra row to know whether another page follows
+	limit := opts.Limit()
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list tickets: %w", err)
+	}
+	var tickets []*ticketpb.Ticket
+	for rows.Next() {
+		ticket, err := scanSQLiteTicket(rows)
+		if err != nil {
+			rows.Close()
+			return nil, "", fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to iterate tickets: %w", err)
+	}
+
+	var next string
+	if len(tickets) > limit {
+		tickets = tickets[:limit]
+		next = CursorAfter(tickets[limit-1]).Encode()
+	}
+	if err := loadTags(ctx, s.db, tickets); err != nil {
+		return nil, "", err
+	}
+	return tickets, next, nil
+}
+
+// Update applies a partial update to a ticket
+func (s *SQLiteStore) Update(ctx context.Context, id string, update TicketUpdate) (*ticketpb.Ticket, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ticket, err := updateSQLiteTicket(ctx, tx, id, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit update: %w", err)
+	}
+	return ticket, nil
+}
+
+// BulkUpdate applies updates to several tickets in one transaction
+func (s *SQLiteStore) BulkUpdate(ctx context.Context, changes []TicketChange) ([]*ticketpb.Ticket, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	updated := make([]*ticketpb.Ticket, len(changes))
+	for i, change := range changes {
+		ticket, err := updateSQLiteTicket(ctx, tx, change.ID, change.Update)
+		if errors.Is(err, ErrNotFound) {
+			return nil, &BulkError{Index: i, ID: change.ID, Err: err}
+		}
+		if err != nil {
+			return nil, err
+		}
+		updated[i] = ticket
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit bulk update: %w", err)
+	}
+	return updated, nil
+}
+
+// updateSQLiteTicket applies a partial update to a ticket within tx
+func updateSQLiteTicket(ctx context.Context, tx *sql.Tx, id string, update TicketUpdate) (*ticketpb.Ticket, error) {
+	ticket, err := getSQLiteTicket(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := proto.Clone(ticket).(*ticketpb.Ticket)
+	update.Apply(ticket)
+	ticket.UpdatedAt = timestamppb.New(update.UpdatedAt)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tickets
+		SET title = ?, description = ?, status = ?, priority = ?, assignee_id = ?, custom_fields = ?, due_date = ?,
+			sla_first_response_due_at = ?, sla_resolution_due_at = ?, sla_first_responded_at = ?, sla_resolved_at = ?,
+			sla_status = ?, sla_next_check_at = ?, updated_at = ?
+		WHERE id = ?`,
+		ticket.Title,
+		nullString(ticket.Description),
+		StatusToDB(ticket.Status),
+		PriorityToDB(ticket.Priority),
+		nullString(ticket.AssigneeId),
+		customFieldsJSON(ticket.CustomFields),
+		nullMicros(ticket.DueDate),
+		nullMicros(ticket.Sla.GetFirstResponseDueAt()),
+		nullMicros(ticket.Sla.GetResolutionDueAt()),
+		nullMicros(ticket.Sla.GetFirstRespondedAt()),
+		nullMicros(ticket.Sla.GetResolvedAt()),
+		sqliteSLAStatus(ticket.Sla),
+		nullMicros(ticket.Sla.GetNextCheckAt()),
+		ticket.UpdatedAt.AsTime().UnixMicro(),
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update ticket: %w", err)
+	}
+	if update.Tags != nil {
+		if err := replaceTags(ctx, tx, id, ticket.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if err := recordSQLite(ctx, tx, previous, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// SLADue returns the tickets of every organization whose SLA is due for evaluation
+func (s *SQLiteStore) SLADue(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Ticket, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+sqliteTicketColumns+` FROM tickets
+		WHERE sla_next_check_at <= ?
+		ORDER BY sla_next_check_at, id
+		LIMIT ?`, now.UnixMicro(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SLA due tickets: %w", err)
+	}
+	var tickets []*ticketpb.Ticket
+	for rows.Next() {
+		ticket, err := scanSQLiteTicket(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tickets: %w", err)
+	}
+	if err := loadTags(ctx, s.db, tickets); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// Delete removes a ticket and its tags
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := deleteSQLiteTicket(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// BulkDelete removes several tickets in one transaction
+func (s *SQLiteStore) BulkDelete(ctx context.Context, ids []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, id := range ids {
+		err := deleteSQLiteTicket(ctx, tx, id)
+		if errors.Is(err, ErrNotFound) {
+			return &BulkError{Index: i, ID: id, Err: err}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// deleteSQLiteTicket removes a ticket within tx
+func deleteSQLiteTicket(ctx context.Context, tx *sql.Tx, id string) error {
+	ticket, err := getSQLiteTicket(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tickets WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete ticket: %w", err)
+	}
+	return recordSQLite(ctx, tx, ticket, nil)
+}
+
+// PendingEvents returns the oldest unpublished events of every organization
+func (s *SQLiteStore) PendingEvents(ctx context.Context, limit int) ([]events.Event, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT sequence, event_id, organization_id, event_type, occurred_at, payload
+		FROM outbox
+		ORDER BY sequence
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*database.OutboxEvent
+	for rows.Next() {
+		var row database.OutboxEvent
+		var occurredAt int64
+		if err := rows.Scan(&row.Sequence, &row.EventID, &row.OrganizationID, &row.EventType, &occurredAt, &row.Payload); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		row.OccurredAt = time.UnixMicro(occurredAt)
+		pending = append(pending, &row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate events: %w", err)
+	}
+	return outboxFromDB(pending)
+}
+
+// MarkPublished removes a published event from the outbox
+func (s *SQLiteStore) MarkPublished(ctx context.Context, event events.Event) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM outbox WHERE event_id = ?`, event.ID); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return nil
+}
+
+// Tags returns the tag catalogue
+func (s *SQLiteStore) Tags(ctx context.Context, query TagQuery) ([]*ticketpb.TagCount, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT tag, COUNT(DISTINCT ticket_id)
+		FROM ticket_tags
+		JOIN tickets ON tickets.id = ticket_tags.ticket_id
+		WHERE tickets.organization_id = ? AND tag LIKE ? ESCAPE '\'
+		GROUP BY tag
+		ORDER BY COUNT(DISTINCT ticket_id) DESC, tag
+		LIMIT ?`, tenant.ID(ctx), escapeLike(query.Prefix)+"%", query.Limit())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*ticketpb.TagCount
+	for rows.Next() {
+		var tag ticketpb.TagCount
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag count: %w", err)
+		}
+		tags = append(tags, &tag)
+	}
+	return tags, rows.Err()
+}
+
+// MergeTags replaces the source tags with target on every ticket
+func (s *SQLiteStore) MergeTags(ctx context.Context, sources []string, target string, updatedAt time.Time) (int, error) {
+	if len(sources) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	args := []interface{}{tenant.ID(ctx)}
+	for _, source := range sources {
+		args = append(args, source)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(sources)), ",")
+	rows, err := tx.QueryContext(ctx, `
+		SELECT DISTINCT ticket_id
+		FROM ticket_tags
+		JOIN tickets ON tickets.id = ticket_tags.ticket_id
+		WHERE tickets.organization_id = ? AND tag IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find tagged tickets: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan ticket id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate tagged tickets: %w", err)
+	}
+
+	changed := 0
+	for _, id := range ids {
+		previous, err := getSQLiteTicket(ctx, tx, id)
+		if err != nil {
+			return 0, err
+		}
+		tags, ok := MergeTagList(previous.Tags, sources, target)
+		if !ok {
+			continue
+		}
+		if err := replaceTags(ctx, tx, id, tags); err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE tickets SET updated_at = ? WHERE id = ?`,
+			updatedAt.UnixMicro(), id); err != nil {
+			return 0, fmt.Errorf("failed to update ticket: %w", err)
+		}
+		ticket := proto.Clone(previous).(*ticketpb.Ticket)
+		ticket.Tags = tags
+		ticket.UpdatedAt = timestamppb.New(updatedAt)
+		if err := recordSQLite(ctx, tx, previous, ticket); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit tag merge: %w", err)
+	}
+	return changed, nil
+}
+
+// Link stores a link between two tickets
+func (s *SQLiteStore) Link(ctx context.Context, link *ticketpb.TicketLink) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var tickets int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM tickets WHERE id IN (?, ?) AND organization_id = ?`,
+		link.SourceId, link.TargetId, tenant.ID(ctx)).Scan(&tickets)
+	if err != nil {
+		return fmt.Errorf("failed to check linked tickets: %w", err)
+	}
+	if tickets != 2 {
+		return ErrNotFound
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO ticket_links (source_id, target_id, link_type, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		link.SourceId, link.TargetId, LinkTypeToDB(link.Type), link.CreatedAt.AsTime().UnixMicro())
+	if err != nil {
+		return fmt.Errorf("failed to create link: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if inserted == 0 {
+		return ErrLinkExists
+	}
+
+	return tx.Commit()
+}
+
+// Unlink removes a link
+func (s *SQLiteStore) Unlink(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) error {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM ticket_links
+		WHERE source_id = ? AND target_id = ? AND link_type = ?
+		AND source_id IN (SELECT id FROM tickets WHERE organization_id = ?)`,
+		sourceID, targetID, LinkTypeToDB(linkType), tenant.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete link: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Links returns the links from or to a ticket
+func (s *SQLiteStore) Links(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT source_id, target_id, link_type, created_at
+		FROM ticket_links
+		WHERE (source_id = ? OR target_id = ?)
+		AND EXISTS (SELECT 1 FROM tickets WHERE id = ? AND organization_id = ?)
+		ORDER BY created_at, rowid`, ticketID, ticketID, ticketID, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*ticketpb.TicketLink
+	for rows.Next() {
+		var (
+			link      ticketpb.TicketLink
+			linkType  string
+			createdAt int64
+		)
+		if err := rows.Scan(&link.SourceId, &link.TargetId, &linkType, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		link.Type = LinkTypeFromDB(linkType)
+		link.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+		links = append(links, &link)
+	}
+	return links, rows.Err()
+}
+
+const sqliteProjectColumns = `id, key, name, description, last_number, created_at, organization_id, custom_fields`
+
+// CreateProject stores a new project
+func (s *SQLiteStore) CreateProject(ctx context.Context, project *ticketpb.Project) error {
+	project.OrganizationId = tenant.ID(ctx)
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO projects (`+sqliteProjectColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		project.Id,
+		project.Key,
+		project.Name,
+		nullString(project.Description),
+		project.LastNumber,
+		project.CreatedAt.AsTime().UnixMicro(),
+		project.OrganizationId,
+		definitionsJSON(project.CustomFields),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if inserted == 0 {
+		return ErrProjectExists
+	}
+	return nil
+}
+
+// GetProject retrieves a project by ID
+func (s *SQLiteStore) GetProject(ctx context.Context, id string) (*ticketpb.Project, error) {
+	return s.getProject(ctx, `id = ?`, id)
+}
+
+// GetProjectByKey retrieves a project by key
+func (s *SQLiteStore) GetProjectByKey(ctx context.Context, key string) (*ticketpb.Project, error) {
+	return s.getProject(ctx, `key = ?`, key)
+}
+
+func (s *SQLiteStore) getProject(ctx context.Context, condition string, arg string) (*ticketpb.Project, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+sqliteProjectColumns+` FROM projects WHERE organization_id = ? AND `+condition, tenant.ID(ctx), arg)
+	project, err := scanSQLiteProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// ListProjects returns every project ordered by key
+func (s *SQLiteStore) ListProjects(ctx context.Context) ([]*ticketpb.Project, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+sqliteProjectColumns+` FROM projects WHERE organization_id = ? ORDER BY key`, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*ticketpb.Project
+	for rows.Next() {
+		project, err := scanSQLiteProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+// SetCustomFields replaces the custom field definitions of a project
+func (s *SQLiteStore) SetCustomFields(ctx context.Context, projectID string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error) {
+	row := s.db.QueryRowContext(ctx, `
+		UPDATE projects SET custom_fields = ?
+		WHERE id = ? AND organization_id = ?
+		RETURNING `+sqliteProjectColumns,
+		definitionsJSON(fields), projectID, tenant.ID(ctx))
+	project, err := scanSQLiteProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update custom fields: %w", err)
+	}
+	return project, nil
+}
+
+func scanSQLiteProject(row interface{ Scan(...any) error }) (*ticketpb.Project, error) {
+	var (
+		project      ticketpb.Project
+		description  sql.NullString
+		createdAt    int64
+		customFields string
+	)
+	err := row.Scan(&project.Id, &project.Key, &project.Name, &description, &project.LastNumber, &createdAt,
+		&project.OrganizationId, &customFields)
+	if err != nil {
+		return nil, err
+	}
+	var definitions []database.CustomFieldDefinition
+	if err := json.Unmarshal([]byte(customFields), &definitions); err != nil {
+		return nil, fmt.Errorf("invalid custom field definitions: %w", err)
+	}
+	project.CustomFields = definitionsFromDB(definitions)
+	project.Description = description.String
+	project.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+	return &project, nil
+}
+
+// Close closes the database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func getSQLiteTicket(ctx context.Context, q queryer, id string) (*ticketpb.Ticket, error) {
+	row := q.QueryRowContext(ctx,
+		`SELECT `+sqliteTicketColumns+` FROM tickets WHERE id = ? AND organization_id = ?`, id, tenant.ID(ctx))
+	ticket, err := scanSQLiteTicket(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if err := loadTags(ctx, q, []*ticketpb.Ticket{ticket}); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func scanSQLiteTicket(row interface{ Scan(...any) error }) (*ticketpb.Ticket, error) {
+	var (
+		ticket                              ticketpb.Ticket
+		status, priority                    string
+		description, assignee, reporter     sql.NullString
+		projectID, key                      sql.NullString
+		createdAt, updatedAt                int64
+		customFields                        string
+		dueDate, nextCheckAt                sql.NullInt64
+		firstResponseDueAt, resolutionDueAt sql.NullInt64
+		firstRespondedAt, resolvedAt        sql.NullInt64
+		slaStatus                           sql.NullString
+	)
+	err := row.Scan(&ticket.Id, &ticket.Title, &description, &status, &priority,
+		&assignee, &reporter, &createdAt, &updatedAt, &projectID, &key, &ticket.OrganizationId, &customFields,
+		&dueDate, &firstResponseDueAt, &resolutionDueAt, &firstRespondedAt, &resolvedAt, &slaStatus, &nextCheckAt)
+	if err != nil {
+		return nil, err
+	}
+	ticket.DueDate = timestampFromMicros(dueDate)
+	if slaStatus.Valid {
+		ticket.Sla = &ticketpb.TicketSLA{
+			FirstResponseDueAt: timestampFromMicros(firstResponseDueAt),
+			ResolutionDueAt:    timestampFromMicros(resolutionDueAt),
+			FirstRespondedAt:   timestampFromMicros(firstRespondedAt),
+			ResolvedAt:         timestampFromMicros(resolvedAt),
+			Status:             SLAStatusFromDB(slaStatus.String),
+			NextCheckAt:        timestampFromMicros(nextCheckAt),
+		}
+	}
+	if err := json.Unmarshal([]byte(customFields), &ticket.CustomFields); err != nil {
+		return nil, fmt.Errorf("invalid custom fields: %w", err)
+	}
+	ticket.Description = description.String
+	ticket.Status = StatusFromDB(status)
+	ticket.Priority = PriorityFromDB(priority)
+	ticket.AssigneeId = assignee.String
+	ticket.ReporterId = reporter.String
+	ticket.ProjectId = projectID.String
+	ticket.Key = key.String
+	ticket.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+	ticket.UpdatedAt = timestamppb.New(time.UnixMicro(updatedAt))
+	return &ticket, nil
+}
+
+// loadTags fills in the tags of the given tickets with a single query
+func loadTags(ctx context.Context, q queryer, tickets []*ticketpb.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*ticketpb.Ticket, len(tickets))
+	args := make([]interface{}, len(tickets))
+	for i, ticket := range tickets {
+		byID[ticket.Id] = ticket
+		args[i] = ticket.Id
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(tickets)), ",")
+	rows, err := q.QueryContext(ctx,
+		`SELECT ticket_id, tag FROM ticket_tags WHERE ticket_id IN (`+placeholders+`) ORDER BY ticket_id, position`,
+		args...)
+	if err != nil {
+		return fmt.Errorf("failed to load tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, tag string
+		if err := rows.Scan(&id, &tag); err != nil {
+			return fmt.Errorf("failed to scan tag: %w", err)
+		}
+		byID[id].Tags = append(byID[id].Tags, tag)
+	}
+	return rows.Err()
+}
+
+// replaceTags replaces the tags of a ticket
+func replaceTags(ctx context.Context, tx *sql.Tx, id string, tags []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ticket_tags WHERE ticket_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	for i, tag := range tags {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO ticket_tags (ticket_id, position, tag) VALUES (?, ?, ?)`, id, i, tag); err != nil {
+			return fmt.Errorf("failed to store tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// nullMicros converts an optional timestamp to nullable Unix microseconds
+func nullMicros(ts *timestamppb.Timestamp) sql.NullInt64 {
+	if ts == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: ts.AsTime().UnixMicro(), Valid: true}
+}
+
+// timestampFromMicros converts nullable Unix microseconds to an optional timestamp
+func timestampFromMicros(micros sql.NullInt64) *timestamppb.Timestamp {
+	if !micros.Valid {
+		return nil
+	}
+	return timestamppb.New(time.UnixMicro(micros.Int64))
+}
+
+// sqliteSLAStatus returns the sla_status column of a ticket, NULL without an SLA
+func sqliteSLAStatus(sla *ticketpb.TicketSLA) sql.NullString {
+	if sla == nil {
+		return sql.NullString{}
+	}
+	return nullString(SLAStatusToDB(sla.Status))
+}
+
+// customFieldsJSON encodes custom field values for the custom_fields column
+func customFieldsJSON(values map[string]string) string {
+	if len(values) == 0 {
+		return "{}"
+	}
+	data, _ := json.Marshal(values) // string maps always encode
+	return string(data)
+}
+
+// definitionsJSON encodes custom field definitions for the custom_fields column of projects
+func definitionsJSON(fields []*ticketpb.CustomFieldDefinition) string {
+	if len(fields) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(definitionsToDB(fields)) // plain structs always encode
+	return string(data)
+}
+
+// escapeLike escapes the LIKE wildcards in s for use with ESCAPE '\'
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// SQLiteAttachments keeps attachment metadata in the SQLite database
+type SQLiteAttachments struct {
+	db *sql.DB
+}
+
+// sqliteAttachmentColumns is the column list scanned by scanSQLiteAttachment
+const sqliteAttachmentColumns = `id, organization_id, ticket_id, filename, content_type, size, sha256, storage_key, created_at`
+
+// Create stores attachment metadata in the caller's organization
+func (a *SQLiteAttachments) Create(ctx context.Context, attachment *database.Attachment) error {
+	attachment.OrganizationID = tenant.ID(ctx)
+	_, err := a.db.ExecContext(ctx, `
+		INSERT INTO attachments (`+sqliteAttachmentColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		attachment.ID,
+		attachment.OrganizationID,
+		attachment.TicketID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.SHA256,
+		attachment.StorageKey,
+		attachment.CreatedAt.UnixMicro(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves attachment metadata by ID
+func (a *SQLiteAttachments) GetByID(ctx context.Context, id string) (*database.Attachment, error) {
+	row := a.db.QueryRowContext(ctx, `
+		SELECT `+sqliteAttachmentColumns+`
+		FROM attachments WHERE id = ? AND organization_id = ?`, id, tenant.ID(ctx))
+	attachment, err := scanSQLiteAttachment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("attachment not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// ListByTicket retrieves the attachments of a ticket, oldest first
+func (a *SQLiteAttachments) ListByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error) {
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT `+sqliteAttachmentColumns+`
+		FROM attachments WHERE organization_id = ? AND ticket_id = ? ORDER BY created_at`, tenant.ID(ctx), ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return scanSQLiteAttachments(rows)
+}
+
+// DeleteByTicket removes the metadata of every attachment of a ticket and
+// returns it
+func (a *SQLiteAttachments) DeleteByTicket(ctx context.Context, ticketID string) ([]*database.Attachment, error) {
+	rows, err := a.db.QueryContext(ctx, `
+		DELETE FROM attachments WHERE organization_id = ? AND ticket_id = ?
+		RETURNING `+sqliteAttachmentColumns, tenant.ID(ctx), ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete attachments: %w", err)
+	}
+	return scanSQLiteAttachments(rows)
+}
+
+// scanSQLiteAttachments scans and closes rows selected with sqliteAttachmentColumns
+func scanSQLiteAttachments(rows *sql.Rows) ([]*database.Attachment, error) {
+	defer rows.Close()
+
+	var attachments []*database.Attachment
+	for rows.Next() {
+		attachment, err := scanSQLiteAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+func scanSQLiteAttachment(row interface{ Scan(...any) error }) (*database.Attachment, error) {
+	var attachment database.Attachment
+	var createdAt int64
+	err := row.Scan(&attachment.ID, &attachment.OrganizationID, &attachment.TicketID, &attachment.Filename, &attachment.ContentType,
+		&attachment.Size, &attachment.SHA256, &attachment.StorageKey, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	attachment.CreatedAt = time.UnixMicro(createdAt)
+	return &attachment, nil
+}
@@ -0,0 +1,27 @@
+-- Create tickets table; timestamps are Unix microseconds
+CREATE TABLE IF NOT EXISTS tickets (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL DEFAULT 'OPEN',
+    priority TEXT NOT NULL DEFAULT 'MEDIUM',
+    assignee_id TEXT,
+    reporter_id TEXT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tickets_status ON tickets(status);
+CREATE INDEX IF NOT EXISTS idx_tickets_priority ON tickets(priority);
+CREATE INDEX IF NOT EXISTS idx_tickets_assignee ON tickets(assignee_id);
+CREATE INDEX IF NOT EXISTS idx_tickets_created_at ON tickets(created_at DESC, id DESC);
+
+-- Tags are normalised so they can be searched; position keeps their order
+CREATE TABLE IF NOT EXISTS ticket_tags (
+    ticket_id TEXT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (ticket_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_tags_tag ON ticket_tags(tag);
@@ -0,0 +1,16 @@
+-- Create projects; last_number is the number of the last ticket created in the project
+CREATE TABLE IF NOT EXISTS projects (
+    id TEXT PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT,
+    last_number INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL
+);
+
+-- Tickets created in a project carry its key and their number, e.g. WEB-42
+ALTER TABLE tickets ADD COLUMN project_id TEXT REFERENCES projects(id);
+ALTER TABLE tickets ADD COLUMN key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_key ON tickets(key);
+CREATE INDEX IF NOT EXISTS idx_tickets_project_id ON tickets(project_id, created_at DESC, id DESC);
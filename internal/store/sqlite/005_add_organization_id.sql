@@ -0,0 +1,26 @@
+-- Partition tickets and projects by organization; existing rows belong to the default organization.
+-- Project keys become unique per organization, which needs the projects table rebuilt.
+-- The migration runner turns foreign keys off while tickets briefly reference the dropped table.
+
+CREATE TABLE projects_new (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL DEFAULT 'default',
+    key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    last_number INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    UNIQUE (organization_id, key)
+);
+
+INSERT INTO projects_new (id, key, name, description, last_number, created_at)
+SELECT id, key, name, description, last_number, created_at FROM projects;
+
+DROP TABLE projects;
+ALTER TABLE projects_new RENAME TO projects;
+
+ALTER TABLE tickets ADD COLUMN organization_id TEXT NOT NULL DEFAULT 'default';
+
+DROP INDEX IF EXISTS idx_tickets_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_key ON tickets(organization_id, key);
+CREATE INDEX IF NOT EXISTS idx_tickets_organization_id ON tickets(organization_id, created_at DESC, id DESC);
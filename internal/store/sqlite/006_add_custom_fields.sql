@@ -0,0 +1,3 @@
+-- Custom fields: projects define them, tickets hold their values keyed by field key (JSON text)
+ALTER TABLE projects ADD COLUMN custom_fields TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tickets ADD COLUMN custom_fields TEXT NOT NULL DEFAULT '{}';
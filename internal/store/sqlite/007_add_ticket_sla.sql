@@ -0,0 +1,10 @@
+-- Due dates and SLA deadlines in Unix microseconds; sla_status is NULL for tickets without an SLA
+ALTER TABLE tickets ADD COLUMN due_date INTEGER;
+ALTER TABLE tickets ADD COLUMN sla_first_response_due_at INTEGER;
+ALTER TABLE tickets ADD COLUMN sla_resolution_due_at INTEGER;
+ALTER TABLE tickets ADD COLUMN sla_first_responded_at INTEGER;
+ALTER TABLE tickets ADD COLUMN sla_resolved_at INTEGER;
+ALTER TABLE tickets ADD COLUMN sla_status TEXT;
+ALTER TABLE tickets ADD COLUMN sla_next_check_at INTEGER;
+
+CREATE INDEX idx_tickets_sla_next_check_at ON tickets(sla_next_check_at) WHERE sla_next_check_at IS NOT NULL;
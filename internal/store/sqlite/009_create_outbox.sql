@@ -0,0 +1,13 @@
+-- Ticket events recorded by the transaction that caused them, until the
+-- relay publishes them; payload is the JSON encoding of the event
+CREATE TABLE IF NOT EXISTS outbox (
+    sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL UNIQUE,
+    organization_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    occurred_at INTEGER NOT NULL,
+    payload TEXT NOT NULL
+);
+
+-- An event relayed twice is queued once per webhook
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(webhook_id, event_id);
@@ -0,0 +1,40 @@
+-- Users watching a ticket, removed by the notifier once told the ticket was deleted
+CREATE TABLE IF NOT EXISTS ticket_watchers (
+    organization_id TEXT NOT NULL,
+    ticket_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (organization_id, ticket_id, user_id)
+);
+
+-- In-app inbox entries; deliver_at is when the delivery channels send one,
+-- NULL when they do not or already did
+CREATE TABLE IF NOT EXISTS notifications (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    ticket_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    read_at INTEGER,
+    deliver_at INTEGER,
+    delivered_at INTEGER,
+    UNIQUE (organization_id, user_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(organization_id, user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_deliver_at ON notifications(deliver_at) WHERE deliver_at IS NOT NULL;
+
+-- muted_kinds is a JSON array of kinds such as ASSIGNED
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    organization_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    delivery TEXT NOT NULL,
+    digest_hour INTEGER NOT NULL,
+    muted_kinds TEXT NOT NULL DEFAULT '[]',
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (organization_id, user_id)
+);
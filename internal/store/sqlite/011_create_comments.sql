@@ -0,0 +1,23 @@
+-- Comments on tickets, removed by the ticket service when it deletes the ticket
+CREATE TABLE IF NOT EXISTS comments (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    ticket_id TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_ticket_id ON comments(organization_id, ticket_id, created_at, id);
+
+-- The email thread of each ticket: the Message-IDs of the emails that
+-- created it or were added to it as comments
+CREATE TABLE IF NOT EXISTS email_messages (
+    organization_id TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    ticket_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (organization_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_messages_ticket_id ON email_messages(organization_id, ticket_id);
@@ -0,0 +1,14 @@
+-- Idempotency keys of ticket service calls and the responses they replay
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    organization_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    method TEXT NOT NULL,
+    request_hash BLOB NOT NULL,
+    response BLOB,
+    created_at INTEGER NOT NULL,
+    completed_at INTEGER,
+    expires_at INTEGER NOT NULL,
+    PRIMARY KEY (organization_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(organization_id, expires_at);
@@ -0,0 +1,10 @@
+-- Attachments belong to the organization of their ticket; existing rows take
+-- it from the ticket, or the default organization when it is gone
+ALTER TABLE attachments ADD COLUMN organization_id TEXT NOT NULL DEFAULT 'default';
+
+UPDATE attachments
+SET organization_id = (SELECT organization_id FROM tickets WHERE tickets.id = attachments.ticket_id)
+WHERE EXISTS (SELECT 1 FROM tickets WHERE tickets.id = attachments.ticket_id);
+
+DROP INDEX IF EXISTS idx_attachments_ticket_id;
+CREATE INDEX IF NOT EXISTS idx_attachments_ticket_id ON attachments(organization_id, ticket_id, created_at);
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SQLiteComments keeps comments and email threads in the SQLite database
+type SQLiteComments struct {
+	db *sql.DB
+}
+
+// Comments returns a comment store in the same database
+func (s *SQLiteStore) Comments() *SQLiteComments {
+	return &SQLiteComments{db: s.db}
+}
+
+// AddComment stores a new comment
+func (c *SQLiteComments) AddComment(ctx context.Context, comment *ticketpb.Comment) error {
+	comment.OrganizationId = tenant.ID(ctx)
+	_, err := c.db.ExecContext(ctx, `
+		INSERT INTO comments (id, organization_id, ticket_id, author_id, body, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		comment.Id,
+		comment.OrganizationId,
+		comment.TicketId,
+		comment.AuthorId,
+		comment.Body,
+		comment.CreatedAt.AsTime().UnixMicro(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+// ListComments returns the comments of a ticket, oldest first
+func (c *SQLiteComments) ListComments(ctx context.Context, ticketID string) ([]*ticketpb.Comment, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT id, organization_id, ticket_id, author_id, body, created_at
+		FROM comments WHERE organization_id = ? AND ticket_id = ?
+		ORDER BY created_at, id`,
+		tenant.ID(ctx), ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*ticketpb.Comment
+	for rows.Next() {
+		var comment ticketpb.Comment
+		var createdAt int64
+		err := rows.Scan(&comment.Id, &comment.OrganizationId, &comment.TicketId, &comment.AuthorId,
+			&comment.Body, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+		comments = append(comments, &comment)
+	}
+	return comments, rows.Err()
+}
+
+// RecordMessage adds an email to the thread of a ticket
+func (c *SQLiteComments) RecordMessage(ctx context.Context, messageID, ticketID string, at time.Time) (bool, error) {
+	result, err := c.db.ExecContext(ctx, `
+		INSERT INTO email_messages (organization_id, message_id, ticket_id, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		tenant.ID(ctx), messageID, ticketID, at.UnixMicro())
+	if err != nil {
+		return false, fmt.Errorf("failed to record email message: %w", err)
+	}
+	if err := sqliteExpectRow(result); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// FindThread returns the ticket of the first recorded message
+func (c *SQLiteComments) FindThread(ctx context.Context, messageIDs []string) (string, error) {
+	for _, messageID := range messageIDs {
+		var ticketID string
+		err := c.db.QueryRowContext(ctx,
+			`SELECT ticket_id FROM email_messages WHERE organization_id = ? AND message_id = ?`,
+			tenant.ID(ctx), messageID).Scan(&ticketID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to find email thread: %w", err)
+		}
+		return ticketID, nil
+	}
+	return "", ErrNotFound
+}
+
+// DeleteTicket removes the comments and the email thread of a ticket
+func (c *SQLiteComments) DeleteTicket(ctx context.Context, ticketID string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM comments WHERE organization_id = ? AND ticket_id = ?`, tenant.ID(ctx), ticketID); err != nil {
+		return fmt.Errorf("failed to delete comments: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM email_messages WHERE organization_id = ? AND ticket_id = ?`, tenant.ID(ctx), ticketID); err != nil {
+		return fmt.Errorf("failed to delete email messages: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
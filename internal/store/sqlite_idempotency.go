@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/idempotency"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+)
+
+// SQLiteIdempotency keeps idempotency keys in the SQLite database
+type SQLiteIdempotency struct {
+	db *sql.DB
+}
+
+var _ idempotency.Store = (*SQLiteIdempotency)(nil)
+
+// Idempotency returns an idempotency key store in the same database
+func (s *SQLiteStore) Idempotency() *SQLiteIdempotency {
+	return &SQLiteIdempotency{db: s.db}
+}
+
+// Reserve stores record unless its key holds a live record, purging the
+// organization's expired keys first
+func (i *SQLiteIdempotency) Reserve(ctx context.Context, record *idempotency.Record, staleBefore time.Time) (*idempotency.Record, error) {
+	// The transaction holds the write lock, so one caller reserves a key
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	organizationID := tenant.ID(ctx)
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE organization_id = ? AND expires_at <= ?`,
+		organizationID, record.CreatedAt.UnixMicro()); err != nil {
+		return nil, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+
+	var existing idempotency.Record
+	var createdAt, expiresAt int64
+	var completedAt sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT key, method, request_hash, response, created_at, completed_at, expires_at
+		FROM idempotency_keys WHERE organization_id = ? AND key = ?`,
+		organizationID, record.Key).Scan(&existing.Key, &existing.Method, &existing.RequestHash,
+		&existing.Response, &createdAt, &completedAt, &expiresAt)
+	switch {
+	case err == nil:
+		existing.CreatedAt = time.UnixMicro(createdAt).UTC()
+		existing.ExpiresAt = time.UnixMicro(expiresAt).UTC()
+		if completedAt.Valid {
+			existing.CompletedAt = time.UnixMicro(completedAt.Int64).UTC()
+		}
+		if !existing.Pending() || !existing.CreatedAt.Before(staleBefore) {
+			return &existing, nil
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT OR REPLACE INTO idempotency_keys (organization_id, key, method, request_hash, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		organizationID, record.Key, record.Method, record.RequestHash,
+		record.CreatedAt.UnixMicro(), record.ExpiresAt.UnixMicro())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil, nil
+}
+
+// Complete saves the response of a reserved key
+func (i *SQLiteIdempotency) Complete(ctx context.Context, key string, response []byte, completedAt time.Time) error {
+	_, err := i.db.ExecContext(ctx,
+		`UPDATE idempotency_keys SET response = ?, completed_at = ? WHERE organization_id = ? AND key = ?`,
+		response, completedAt.UnixMicro(), tenant.ID(ctx), key)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release removes a key that is still pending
+func (i *SQLiteIdempotency) Release(ctx context.Context, key string) error {
+	_, err := i.db.ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE organization_id = ? AND key = ? AND completed_at IS NULL`,
+		tenant.ID(ctx), key)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
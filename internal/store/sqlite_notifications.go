@@ -0,0 +1,288 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SQLiteNotifications keeps watchers, notifications and notification
+// preferences in the SQLite database
+type SQLiteNotifications struct {
+	db *sql.DB
+}
+
+// Notifications returns a notification store in the same database
+func (s *SQLiteStore) Notifications() *SQLiteNotifications {
+	return &SQLiteNotifications{db: s.db}
+}
+
+const sqliteNotificationColumns = `id, organization_id, user_id, kind, ticket_id, event_id, message, ` +
+	`created_at, read_at, deliver_at, delivered_at`
+
+// Watch adds a watcher to a ticket
+func (n *SQLiteNotifications) Watch(ctx context.Context, ticketID, userID string, at time.Time) error {
+	_, err := n.db.ExecContext(ctx, `
+		INSERT INTO ticket_watchers (organization_id, ticket_id, user_id, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		tenant.ID(ctx), ticketID, userID, at.UnixMicro())
+	if err != nil {
+		return fmt.Errorf("failed to watch ticket: %w", err)
+	}
+	return nil
+}
+
+// Unwatch removes a watcher
+func (n *SQLiteNotifications) Unwatch(ctx context.Context, ticketID, userID string) (bool, error) {
+	result, err := n.db.ExecContext(ctx,
+		`DELETE FROM ticket_watchers WHERE organization_id = ? AND ticket_id = ? AND user_id = ?`,
+		tenant.ID(ctx), ticketID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwatch ticket: %w", err)
+	}
+	if err := sqliteExpectRow(result); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Watchers returns the watchers of a ticket
+func (n *SQLiteNotifications) Watchers(ctx context.Context, ticketID string) ([]string, error) {
+	rows, err := n.db.QueryContext(ctx, `
+		SELECT user_id FROM ticket_watchers
+		WHERE organization_id = ? AND ticket_id = ?
+		ORDER BY created_at, user_id`,
+		tenant.ID(ctx), ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchers: %w", err)
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var user string
+		if err := rows.Scan(&user); err != nil {
+			return nil, fmt.Errorf("failed to scan watcher: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// DeleteWatchers removes every watcher of a ticket
+func (n *SQLiteNotifications) DeleteWatchers(ctx context.Context, ticketID string) error {
+	_, err := n.db.ExecContext(ctx,
+		`DELETE FROM ticket_watchers WHERE organization_id = ? AND ticket_id = ?`, tenant.ID(ctx), ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to delete watchers: %w", err)
+	}
+	return nil
+}
+
+// CreateNotifications stores new notifications in one transaction
+func (n *SQLiteNotifications) CreateNotifications(ctx context.Context, notifications []*ticketpb.Notification) error {
+	tx, err := n.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, notification := range notifications {
+		notification.OrganizationId = tenant.ID(ctx)
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notifications (`+sqliteNotificationColumns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (organization_id, user_id, event_id) DO NOTHING`,
+			notification.Id,
+			notification.OrganizationId,
+			notification.UserId,
+			NotificationKindToDB(notification.Kind),
+			notification.TicketId,
+			notification.EventId,
+			notification.Message,
+			notification.CreatedAt.AsTime().UnixMicro(),
+			nullMicros(notification.ReadAt),
+			nullMicros(notification.DeliverAt),
+			nullMicros(notification.DeliveredAt),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications returns the notifications of a user, newest first
+func (n *SQLiteNotifications) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]*ticketpb.Notification, error) {
+	return n.listNotifications(ctx, `
+		SELECT `+sqliteNotificationColumns+` FROM notifications
+		WHERE organization_id = ? AND user_id = ? AND (NOT ? OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?`,
+		tenant.ID(ctx), userID, unreadOnly, limit)
+}
+
+// MarkRead sets the read time of a notification
+func (n *SQLiteNotifications) MarkRead(ctx context.Context, id string, at time.Time) (*ticketpb.Notification, error) {
+	result, err := n.db.ExecContext(ctx,
+		`UPDATE notifications SET read_at = COALESCE(read_at, ?) WHERE id = ? AND organization_id = ?`,
+		at.UnixMicro(), id, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if err := sqliteExpectRow(result); err != nil {
+		return nil, err
+	}
+
+	row := n.db.QueryRowContext(ctx,
+		`SELECT `+sqliteNotificationColumns+` FROM notifications WHERE id = ? AND organization_id = ?`, id, tenant.ID(ctx))
+	notification, err := scanSQLiteNotification(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	return notification, nil
+}
+
+// DueNotifications returns the notifications of every organization whose
+// delivery is due
+func (n *SQLiteNotifications) DueNotifications(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Notification, error) {
+	return n.listNotifications(ctx, `
+		SELECT `+sqliteNotificationColumns+` FROM notifications
+		WHERE deliver_at <= ?
+		ORDER BY deliver_at, id
+		LIMIT ?`,
+		now.UnixMicro(), limit)
+}
+
+// MarkDelivered records the delivery of notifications
+func (n *SQLiteNotifications) MarkDelivered(ctx context.Context, ids []string, at time.Time) error {
+	tx, err := n.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE notifications SET deliver_at = NULL, delivered_at = ? WHERE id = ? AND organization_id = ?`,
+			at.UnixMicro(), id, tenant.ID(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to mark notification delivered: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetPreferences retrieves the preferences of a user
+func (n *SQLiteNotifications) GetPreferences(ctx context.Context, userID string) (*ticketpb.NotificationPreferences, error) {
+	var preferences ticketpb.NotificationPreferences
+	var delivery, mutedKinds string
+	var updatedAt int64
+	err := n.db.QueryRowContext(ctx, `
+		SELECT organization_id, user_id, email, delivery, digest_hour, muted_kinds, updated_at
+		FROM notification_preferences WHERE organization_id = ? AND user_id = ?`,
+		tenant.ID(ctx), userID).Scan(&preferences.OrganizationId, &preferences.UserId, &preferences.Email,
+		&delivery, &preferences.DigestHour, &mutedKinds, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	var kinds []string
+	if err := json.Unmarshal([]byte(mutedKinds), &kinds); err != nil {
+		return nil, fmt.Errorf("invalid muted notification kinds: %w", err)
+	}
+	for _, kind := range kinds {
+		preferences.MutedKinds = append(preferences.MutedKinds, NotificationKindFromDB(kind))
+	}
+	preferences.Delivery = NotificationDeliveryFromDB(delivery)
+	preferences.UpdatedAt = timestamppb.New(time.UnixMicro(updatedAt))
+	return &preferences, nil
+}
+
+// SetPreferences stores the preferences of a user
+func (n *SQLiteNotifications) SetPreferences(ctx context.Context, preferences *ticketpb.NotificationPreferences) error {
+	preferences.OrganizationId = tenant.ID(ctx)
+	kinds := make([]string, len(preferences.MutedKinds))
+	for i, kind := range preferences.MutedKinds {
+		kinds[i] = NotificationKindToDB(kind)
+	}
+	mutedKinds, _ := json.Marshal(kinds) // string slices always encode
+	_, err := n.db.ExecContext(ctx, `
+		INSERT INTO notification_preferences (organization_id, user_id, email, delivery, digest_hour, muted_kinds, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET email = excluded.email, delivery = excluded.delivery, digest_hour = excluded.digest_hour,
+			muted_kinds = excluded.muted_kinds, updated_at = excluded.updated_at`,
+		preferences.OrganizationId,
+		preferences.UserId,
+		preferences.Email,
+		NotificationDeliveryToDB(preferences.Delivery),
+		preferences.DigestHour,
+		string(mutedKinds),
+		preferences.UpdatedAt.AsTime().UnixMicro(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set notification preferences: %w", err)
+	}
+	return nil
+}
+
+func (n *SQLiteNotifications) listNotifications(ctx context.Context, query string, args ...any) ([]*ticketpb.Notification, error) {
+	rows, err := n.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*ticketpb.Notification
+	for rows.Next() {
+		notification, err := scanSQLiteNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func scanSQLiteNotification(row interface{ Scan(...any) error }) (*ticketpb.Notification, error) {
+	var notification ticketpb.Notification
+	var kind string
+	var createdAt int64
+	var readAt, deliverAt, deliveredAt sql.NullInt64
+	err := row.Scan(&notification.Id, &notification.OrganizationId, &notification.UserId, &kind,
+		&notification.TicketId, &notification.EventId, &notification.Message, &createdAt,
+		&readAt, &deliverAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	notification.Kind = NotificationKindFromDB(kind)
+	notification.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+	notification.ReadAt = timestampFromMicros(readAt)
+	notification.DeliverAt = timestampFromMicros(deliverAt)
+	notification.DeliveredAt = timestampFromMicros(deliveredAt)
+	return &notification, nil
+}
@@ -0,0 +1,125 @@
+package store_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/store/storetest"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+)
+
+// openSQLite opens a database in a new temporary directory. The directory
+// name has URI delimiters in it to check the path is escaped.
+func openSQLite(t *testing.T) *store.SQLiteStore {
+	t.Helper()
+	s, err := store.OpenSQLite(filepath.Join(t.TempDir(), "run#01", "tickets.db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	return s
+}
+
+func TestSQLiteStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.TicketStore {
+		return openSQLite(t)
+	})
+}
+
+func TestSQLiteWebhooks(t *testing.T) {
+	storetest.RunWebhooks(t, func(t *testing.T) webhooks.Store {
+		s := openSQLite(t)
+		t.Cleanup(func() { s.Close() })
+		return s.Webhooks()
+	})
+}
+
+func TestSQLiteNotifications(t *testing.T) {
+	storetest.RunNotifications(t, func(t *testing.T) notifications.Store {
+		s := openSQLite(t)
+		t.Cleanup(func() { s.Close() })
+		return s.Notifications()
+	})
+}
+
+func TestSQLiteComments(t *testing.T) {
+	storetest.RunComments(t, func(t *testing.T) comments.Store {
+		s := openSQLite(t)
+		t.Cleanup(func() { s.Close() })
+		return s.Comments()
+	})
+}
+
+func TestSQLitePath(t *testing.T) {
+	for _, name := range []string{"tickets#01.db", "tickets?mode=memory.db", "tickets 100%.db"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "run#01", name)
+			s, err := store.OpenSQLite(path)
+			if err != nil {
+				t.Fatalf("failed to open sqlite database: %v", err)
+			}
+			ticket := &ticketpb.Ticket{Id: uuid.NewString(), Title: "Kept across reopening"}
+			if err := s.Create(context.Background(), ticket); err != nil {
+				t.Fatalf("failed to create ticket: %v", err)
+			}
+			s.Close()
+
+			if _, err := os.Stat(path); err != nil {
+				t.Fatalf("database is not at %s: %v", path, err)
+			}
+			s, err = store.OpenSQLite(path)
+			if err != nil {
+				t.Fatalf("failed to reopen sqlite database: %v", err)
+			}
+			defer s.Close()
+			if _, err := s.Get(context.Background(), ticket.Id); err != nil {
+				t.Fatalf("ticket is gone after reopening: %v", err)
+			}
+		})
+	}
+}
+
+func TestSQLiteAttachments(t *testing.T) {
+	s := openSQLite(t)
+	t.Cleanup(func() { s.Close() })
+	metadata := s.Attachments()
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+
+	ticketID := uuid.NewString()
+	for _, ctx := range []context.Context{acme, acme, globex} {
+		err := metadata.Create(ctx, &database.Attachment{ID: uuid.NewString(), TicketID: ticketID,
+			Filename: "notes.txt", ContentType: "text/plain", StorageKey: ticketID + "/" + uuid.NewString(), CreatedAt: time.Now()})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	list, err := metadata.ListByTicket(acme, ticketID)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListByTicket = %d attachments, %v; want 2", len(list), err)
+	}
+	if _, err := metadata.GetByID(globex, list[0].ID); err == nil {
+		t.Error("another organization can get the attachment")
+	}
+
+	deleted, err := metadata.DeleteByTicket(acme, ticketID)
+	if err != nil || len(deleted) != 2 {
+		t.Fatalf("DeleteByTicket = %d attachments, %v; want 2", len(deleted), err)
+	}
+	if list, _ := metadata.ListByTicket(acme, ticketID); len(list) != 0 {
+		t.Errorf("ListByTicket after DeleteByTicket = %d attachments, want none", len(list))
+	}
+	if list, _ := metadata.ListByTicket(globex, ticketID); len(list) != 1 {
+		t.Errorf("DeleteByTicket removed another organization's attachment")
+	}
+}
@@ -0,0 +1,304 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/database"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SQLiteWebhooks keeps webhooks and their deliveries in the SQLite database
+type SQLiteWebhooks struct {
+	db *sql.DB
+}
+
+// Webhooks returns a webhook store in the same database
+func (s *SQLiteStore) Webhooks() *SQLiteWebhooks {
+	return &SQLiteWebhooks{db: s.db}
+}
+
+const sqliteWebhookColumns = `id, organization_id, url, secret, event_types, filter, active, created_at, updated_at`
+
+const sqliteDeliveryColumns = `id, organization_id, webhook_id, event_id, event_type, payload, status, attempts, ` +
+	`next_attempt_at, last_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+// CreateWebhook stores a new webhook
+func (w *SQLiteWebhooks) CreateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error {
+	webhook.OrganizationId = tenant.ID(ctx)
+	eventTypes, _ := json.Marshal(webhook.EventTypes) // string slices always encode
+	_, err := w.db.ExecContext(ctx,
+		`INSERT INTO webhooks (`+sqliteWebhookColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		webhook.Id,
+		webhook.OrganizationId,
+		webhook.Url,
+		webhook.Secret,
+		string(eventTypes),
+		webhookFilterToDB(webhook.Filter).JSON(),
+		webhook.Active,
+		webhook.CreatedAt.AsTime().UnixMicro(),
+		webhook.UpdatedAt.AsTime().UnixMicro(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhook retrieves a webhook
+func (w *SQLiteWebhooks) GetWebhook(ctx context.Context, id string) (*ticketpb.Webhook, error) {
+	row := w.db.QueryRowContext(ctx,
+		`SELECT `+sqliteWebhookColumns+` FROM webhooks WHERE id = ? AND organization_id = ?`, id, tenant.ID(ctx))
+	webhook, err := scanSQLiteWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// ListWebhooks returns every webhook, oldest first
+func (w *SQLiteWebhooks) ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error) {
+	rows, err := w.db.QueryContext(ctx,
+		`SELECT `+sqliteWebhookColumns+` FROM webhooks WHERE organization_id = ? ORDER BY created_at, id`, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*ticketpb.Webhook
+	for rows.Next() {
+		webhook, err := scanSQLiteWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// UpdateWebhook replaces a stored webhook
+func (w *SQLiteWebhooks) UpdateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error {
+	webhook.OrganizationId = tenant.ID(ctx)
+	eventTypes, _ := json.Marshal(webhook.EventTypes)
+	result, err := w.db.ExecContext(ctx, `
+		UPDATE webhooks SET url = ?, secret = ?, event_types = ?, filter = ?, active = ?, updated_at = ?
+		WHERE id = ? AND organization_id = ?`,
+		webhook.Url,
+		webhook.Secret,
+		string(eventTypes),
+		webhookFilterToDB(webhook.Filter).JSON(),
+		webhook.Active,
+		webhook.UpdatedAt.AsTime().UnixMicro(),
+		webhook.Id,
+		webhook.OrganizationId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return sqliteExpectRow(result)
+}
+
+// DeleteWebhook removes a webhook; its deliveries cascade
+func (w *SQLiteWebhooks) DeleteWebhook(ctx context.Context, id string) error {
+	result, err := w.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ? AND organization_id = ?`, id, tenant.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return sqliteExpectRow(result)
+}
+
+// CreateDeliveries stores new deliveries in one transaction
+func (w *SQLiteWebhooks) CreateDeliveries(ctx context.Context, deliveries []*ticketpb.WebhookDelivery) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	organizationID := tenant.ID(ctx)
+	for _, delivery := range deliveries {
+		delivery.OrganizationId = organizationID
+		// Select from webhooks so deliveries only reach webhooks of the organization
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (`+sqliteDeliveryColumns+`)
+			SELECT ?, ?, id, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+			FROM webhooks WHERE id = ? AND organization_id = ?
+			ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+			delivery.Id,
+			delivery.OrganizationId,
+			delivery.EventId,
+			delivery.EventType,
+			delivery.Payload,
+			DeliveryStatusToDB(delivery.Status),
+			delivery.Attempts,
+			nullMicros(delivery.NextAttemptAt),
+			nullMicros(delivery.LastAttemptAt),
+			delivery.LastStatusCode,
+			delivery.LastError,
+			delivery.CreatedAt.AsTime().UnixMicro(),
+			nullMicros(delivery.DeliveredAt),
+			delivery.WebhookId,
+			organizationID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create delivery: %w", err)
+		}
+		if err := sqliteExpectRow(result); err != nil {
+			// No row is inserted for a duplicate either
+			var exists bool
+			err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = ? AND organization_id = ?)`,
+				delivery.WebhookId, organizationID).Scan(&exists)
+			if err != nil {
+				return fmt.Errorf("failed to get webhook: %w", err)
+			}
+			if !exists {
+				return ErrNotFound
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery retrieves a delivery
+func (w *SQLiteWebhooks) GetDelivery(ctx context.Context, id string) (*ticketpb.WebhookDelivery, error) {
+	row := w.db.QueryRowContext(ctx,
+		`SELECT `+sqliteDeliveryColumns+` FROM webhook_deliveries WHERE id = ? AND organization_id = ?`, id, tenant.ID(ctx))
+	delivery, err := scanSQLiteDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries returns the deliveries of a webhook, newest first
+func (w *SQLiteWebhooks) ListDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int) ([]*ticketpb.WebhookDelivery, error) {
+	if _, err := w.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return w.listDeliveries(ctx, `
+		SELECT `+sqliteDeliveryColumns+` FROM webhook_deliveries
+		WHERE webhook_id = ? AND organization_id = ? AND (? = '' OR status = ?)
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?`,
+		webhookID, tenant.ID(ctx), deliveryStatusFilter(status), deliveryStatusFilter(status), limit)
+}
+
+// UpdateDelivery replaces a stored delivery
+func (w *SQLiteWebhooks) UpdateDelivery(ctx context.Context, delivery *ticketpb.WebhookDelivery) error {
+	result, err := w.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?,
+			last_status_code = ?, last_error = ?, delivered_at = ?
+		WHERE id = ? AND organization_id = ?`,
+		DeliveryStatusToDB(delivery.Status),
+		delivery.Attempts,
+		nullMicros(delivery.NextAttemptAt),
+		nullMicros(delivery.LastAttemptAt),
+		delivery.LastStatusCode,
+		delivery.LastError,
+		nullMicros(delivery.DeliveredAt),
+		delivery.Id,
+		tenant.ID(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+	return sqliteExpectRow(result)
+}
+
+// DueDeliveries returns the pending deliveries of every organization whose
+// next attempt is due
+func (w *SQLiteWebhooks) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*ticketpb.WebhookDelivery, error) {
+	return w.listDeliveries(ctx, `
+		SELECT `+sqliteDeliveryColumns+` FROM webhook_deliveries
+		WHERE status = 'PENDING' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?`,
+		now.UnixMicro(), limit)
+}
+
+func (w *SQLiteWebhooks) listDeliveries(ctx context.Context, query string, args ...any) ([]*ticketpb.WebhookDelivery, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*ticketpb.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanSQLiteDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// sqliteExpectRow returns ErrNotFound when a statement changed no row
+func sqliteExpectRow(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanSQLiteWebhook(row interface{ Scan(...any) error }) (*ticketpb.Webhook, error) {
+	var webhook ticketpb.Webhook
+	var eventTypes, filter string
+	var createdAt, updatedAt int64
+	err := row.Scan(&webhook.Id, &webhook.OrganizationId, &webhook.Url, &webhook.Secret,
+		&eventTypes, &filter, &webhook.Active, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(eventTypes), &webhook.EventTypes); err != nil {
+		return nil, fmt.Errorf("invalid webhook event types: %w", err)
+	}
+	var stored database.WebhookFilter
+	if err := json.Unmarshal([]byte(filter), &stored); err != nil {
+		return nil, fmt.Errorf("invalid webhook filter: %w", err)
+	}
+	webhook.Filter = webhookFilterFromDB(stored)
+	webhook.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+	webhook.UpdatedAt = timestamppb.New(time.UnixMicro(updatedAt))
+	return &webhook, nil
+}
+
+func scanSQLiteDelivery(row interface{ Scan(...any) error }) (*ticketpb.WebhookDelivery, error) {
+	var delivery ticketpb.WebhookDelivery
+	var status string
+	var createdAt int64
+	var nextAttemptAt, lastAttemptAt, deliveredAt sql.NullInt64
+	err := row.Scan(&delivery.Id, &delivery.OrganizationId, &delivery.WebhookId, &delivery.EventId,
+		&delivery.EventType, &delivery.Payload, &status, &delivery.Attempts, &nextAttemptAt, &lastAttemptAt,
+		&delivery.LastStatusCode, &delivery.LastError, &createdAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Status = DeliveryStatusFromDB(status)
+	delivery.NextAttemptAt = timestampFromMicros(nextAttemptAt)
+	delivery.LastAttemptAt = timestampFromMicros(lastAttemptAt)
+	delivery.CreatedAt = timestamppb.New(time.UnixMicro(createdAt))
+	delivery.DeliveredAt = timestampFromMicros(deliveredAt)
+	return &delivery, nil
+}
@@ -0,0 +1,403 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNotFound is returned when a ticket does not exist
+var ErrNotFound = errors.New("ticket not found")
+
+// ErrTicketExists is returned when creating a ticket whose ID is taken, in
+// any organization
+var ErrTicketExists = errors.New("ticket already exists")
+
+// ErrProjectNotFound is returned when a project does not exist
+var ErrProjectNotFound = errors.New("project not found")
+
+// ErrProjectExists is returned when creating a project whose key is taken
+var ErrProjectExists = errors.New("project key already exists")
+
+// ErrLinkExists is returned when linking tickets that are already linked the same way
+var ErrLinkExists = errors.New("link already exists")
+
+// ErrInvalidPageToken is returned when listing with a page token no store issued
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+const (
+	// DefaultPageSize is used when a list request does not set a page size
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size of list requests
+	MaxPageSize = 100
+)
+
+// TicketStore persists tickets for the ticket service.
+//
+// Every implementation must pass the conformance suite in storetest: tickets
+// are listed newest first (ties broken by descending ID), page tokens are
+// opaque cursors, and Get, Update and Delete return ErrNotFound for unknown IDs.
+//
+// Every method acts for the organization of ctx (see tenant.ID). Tickets,
+// links and projects of other organizations behave as if they did not exist,
+// and project and ticket keys are only unique within an organization.
+type TicketStore interface {
+	// Create stores a new ticket. The caller sets the ID and timestamps; the
+	// store sets OrganizationId from ctx. When ProjectId is set the store
+	// assigns ticket.Key from the project's next number, or returns
+	// ErrProjectNotFound. Create, Update and Delete record the events of the
+	// change (see events.Changes) in the outbox along with it, unless ctx
+	// records none (see events.WithoutEvents).
+	Create(ctx context.Context, ticket *ticketpb.Ticket) error
+	// Get retrieves a ticket by ID
+	Get(ctx context.Context, id string) (*ticketpb.Ticket, error)
+	// GetByKey retrieves a ticket by its project key (e.g. WEB-42)
+	GetByKey(ctx context.Context, key string) (*ticketpb.Ticket, error)
+	// List returns a page of tickets and the token of the next page ("" on the last page)
+	List(ctx context.Context, opts ListOptions) ([]*ticketpb.Ticket, string, error)
+	// Update applies a partial update and returns the updated ticket
+	Update(ctx context.Context, id string, update TicketUpdate) (*ticketpb.Ticket, error)
+	// Delete removes a ticket
+	Delete(ctx context.Context, id string) error
+	// BulkUpdate applies updates to several distinct tickets in one
+	// transaction and returns the updated tickets in the order of changes.
+	// When a ticket does not exist no ticket is changed and it returns a
+	// *BulkError wrapping ErrNotFound.
+	BulkUpdate(ctx context.Context, changes []TicketChange) ([]*ticketpb.Ticket, error)
+	// BulkDelete removes several distinct tickets in one transaction, or
+	// none of them as BulkUpdate does
+	BulkDelete(ctx context.Context, ids []string) error
+	// Tags returns the tag catalogue: how many tickets carry each tag, most used
+	// first (ties ordered by name)
+	Tags(ctx context.Context, query TagQuery) ([]*ticketpb.TagCount, error)
+	// MergeTags replaces every source tag with target on the tickets carrying
+	// one, setting their update time, and returns the number of tickets changed
+	MergeTags(ctx context.Context, sources []string, target string, updatedAt time.Time) (int, error)
+	// Close releases the resources held by the store
+	Close() error
+
+	LinkStore
+	ProjectStore
+	SLAStore
+	OutboxStore
+}
+
+// OutboxStore holds the events recorded by ticket writes until a relay has
+// published them. Like SLADue it spans all organizations.
+type OutboxStore interface {
+	// PendingEvents returns up to limit unpublished events in the order they
+	// were recorded
+	PendingEvents(ctx context.Context, limit int) ([]events.Event, error)
+	// MarkPublished removes a published event from the outbox. Marking an
+	// event that is no longer pending is not an error.
+	MarkPublished(ctx context.Context, event events.Event) error
+}
+
+// SLAStore finds the tickets whose SLA needs another look
+type SLAStore interface {
+	// SLADue returns up to limit tickets whose SLA NextCheckAt is at or before
+	// now, earliest first. Unlike every other method it spans all
+	// organizations: the SLA evaluator uses it to find work, then updates each
+	// ticket acting for the ticket's own organization.
+	SLADue(ctx context.Context, now time.Time, limit int) ([]*ticketpb.Ticket, error)
+}
+
+// ProjectStore persists projects. Ticket numbers of a project are assigned
+// by TicketStore.Create and never reused, even after tickets are deleted.
+type ProjectStore interface {
+	// CreateProject stores a new project, setting OrganizationId from ctx. It
+	// returns ErrProjectExists when its key is taken in the organization.
+	CreateProject(ctx context.Context, project *ticketpb.Project) error
+	// GetProject retrieves a project by ID
+	GetProject(ctx context.Context, id string) (*ticketpb.Project, error)
+	// GetProjectByKey retrieves a project by key
+	GetProjectByKey(ctx context.Context, key string) (*ticketpb.Project, error)
+	// ListProjects returns every project ordered by key
+	ListProjects(ctx context.Context) ([]*ticketpb.Project, error)
+	// SetCustomFields replaces the custom field definitions of a project and
+	// returns the updated project, or ErrProjectNotFound
+	SetCustomFields(ctx context.Context, projectID string, fields []*ticketpb.CustomFieldDefinition) (*ticketpb.Project, error)
+}
+
+// TicketKey returns the key of the ticket numbered number in a project
+func TicketKey(projectKey string, number int64) string {
+	return projectKey + "-" + strconv.FormatInt(number, 10)
+}
+
+// LinkStore persists typed links between tickets. Deleting a ticket deletes
+// its links.
+type LinkStore interface {
+	// Link stores a link. It returns ErrNotFound when either ticket does not
+	// exist and ErrLinkExists when the link is already stored.
+	Link(ctx context.Context, link *ticketpb.TicketLink) error
+	// Unlink removes a link, returning ErrNotFound when it does not exist
+	Unlink(ctx context.Context, sourceID, targetID string, linkType ticketpb.LinkType) error
+	// Links returns the links from or to a ticket, oldest first
+	Links(ctx context.Context, ticketID string) ([]*ticketpb.TicketLink, error)
+}
+
+// SameLink reports whether a link connects source to target with the given type
+func SameLink(link *ticketpb.TicketLink, sourceID, targetID string, linkType ticketpb.LinkType) bool {
+	return link.SourceId == sourceID && link.TargetId == targetID && link.Type == linkType
+}
+
+// ListOptions selects a page of tickets
+type ListOptions struct {
+	PageSize  int
+	PageToken string
+	Tags      []string // only tickets carrying every tag
+	Query     string   // case-insensitive substring of the title or description
+	ProjectID string   // only tickets of this project
+	// Only tickets whose custom fields have exactly these values
+	CustomFields map[string]string
+}
+
+// Limit returns the page size clamped to [1, MaxPageSize]
+func (o ListOptions) Limit() int {
+	if o.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	if o.PageSize > MaxPageSize {
+		return MaxPageSize
+	}
+	return o.PageSize
+}
+
+// Matches reports whether a ticket passes the project, tag, custom field and text filters
+func (o ListOptions) Matches(ticket *ticketpb.Ticket) bool {
+	if o.ProjectID != "" && ticket.ProjectId != o.ProjectID {
+		return false
+	}
+	for _, tag := range o.Tags {
+		if !slices.Contains(ticket.Tags, tag) {
+			return false
+		}
+	}
+	for key, value := range o.CustomFields {
+		if actual, ok := ticket.CustomFields[key]; !ok || actual != value {
+			return false
+		}
+	}
+	if o.Query != "" {
+		query := strings.ToLower(o.Query)
+		if !strings.Contains(strings.ToLower(ticket.Title), query) &&
+			!strings.Contains(strings.ToLower(ticket.Description), query) {
+			return false
+		}
+	}
+	return true
+}
+
+// TagQuery selects entries of the tag catalogue
+type TagQuery struct {
+	Prefix string // case-insensitive tag prefix, for autocomplete
+	Max    int
+}
+
+// Limit returns the number of tags to return clamped to [1, MaxPageSize]
+func (q TagQuery) Limit() int {
+	return ListOptions{PageSize: q.Max}.Limit()
+}
+
+// HasPrefix reports whether a tag starts with the query prefix, ignoring case
+func (q TagQuery) HasPrefix(tag string) bool {
+	return strings.HasPrefix(strings.ToLower(tag), strings.ToLower(q.Prefix))
+}
+
+// CountTags builds the tag catalogue of a set of tickets
+func CountTags(tickets []*ticketpb.Ticket, query TagQuery) []*ticketpb.TagCount {
+	counts := make(map[string]int32)
+	for _, ticket := range tickets {
+		seen := make(map[string]bool, len(ticket.Tags))
+		for _, tag := range ticket.Tags {
+			if !seen[tag] && query.HasPrefix(tag) {
+				seen[tag] = true
+				counts[tag]++
+			}
+		}
+	}
+
+	tags := make([]*ticketpb.TagCount, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, &ticketpb.TagCount{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	if limit := query.Limit(); len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags
+}
+
+// MergeTagList returns tags with every source tag replaced by target, keeping
+// the first occurrence of each tag, and whether anything changed
+func MergeTagList(tags, sources []string, target string) ([]string, bool) {
+	merged := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if slices.Contains(sources, tag) {
+			tag = target
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged, !slices.Equal(merged, tags)
+}
+
+// TicketUpdate is a partial update; nil fields are left unchanged
+type TicketUpdate struct {
+	Title       *string
+	Description *string
+	Status      *ticketpb.TicketStatus
+	Priority    *ticketpb.TicketPriority
+	AssigneeID  *string
+	Tags        []string // replaces the tags when non-nil
+	// Replaces the custom field values when non-nil
+	CustomFields map[string]string
+	DueDate      *time.Time          // a zero time clears the due date
+	SLA          *ticketpb.TicketSLA // replaces the SLA state when non-nil
+	UpdatedAt    time.Time
+}
+
+// Apply applies the update to a ticket in place
+func (u TicketUpdate) Apply(ticket *ticketpb.Ticket) {
+	if u.Title != nil {
+		ticket.Title = *u.Title
+	}
+	if u.Description != nil {
+		ticket.Description = *u.Description
+	}
+	if u.Status != nil {
+		ticket.Status = *u.Status
+	}
+	if u.Priority != nil {
+		ticket.Priority = *u.Priority
+	}
+	if u.AssigneeID != nil {
+		ticket.AssigneeId = *u.AssigneeID
+	}
+	if u.Tags != nil {
+		ticket.Tags = append([]string(nil), u.Tags...)
+	}
+	if u.CustomFields != nil {
+		ticket.CustomFields = maps.Clone(u.CustomFields)
+	}
+	if u.DueDate != nil {
+		ticket.DueDate = nil
+		if !u.DueDate.IsZero() {
+			ticket.DueDate = timestamppb.New(*u.DueDate)
+		}
+	}
+	if u.SLA != nil {
+		ticket.Sla = proto.Clone(u.SLA).(*ticketpb.TicketSLA)
+	}
+}
+
+// TicketChange is the update of one ticket of a bulk update
+type TicketChange struct {
+	ID     string
+	Update TicketUpdate
+}
+
+// BulkError names the ticket that made a bulk write fail. Bulk writes are
+// all or nothing, so no other ticket was changed either.
+type BulkError struct {
+	Index int // position of the ticket in the request
+	ID    string
+	Err   error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("ticket %s: %v", e.ID, e.Err)
+}
+
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// SLADue reports whether the SLA of a ticket is due for evaluation at now
+func SLADue(ticket *ticketpb.Ticket, now time.Time) bool {
+	return ticket.Sla != nil && ticket.Sla.NextCheckAt != nil && !ticket.Sla.NextCheckAt.AsTime().After(now)
+}
+
+// LessSLADue orders tickets by the next check of their SLA, breaking ties by ID
+func LessSLADue(a, b *ticketpb.Ticket) bool {
+	at, bt := a.Sla.NextCheckAt.AsTime(), b.Sla.NextCheckAt.AsTime()
+	if !at.Equal(bt) {
+		return at.Before(bt)
+	}
+	return a.Id < b.Id
+}
+
+// Cursor is the position after which the next page starts
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// CursorAfter returns the cursor positioned after a ticket
+func CursorAfter(ticket *ticketpb.Ticket) Cursor {
+	return Cursor{CreatedAt: ticket.CreatedAt.AsTime(), ID: ticket.Id}
+}
+
+// Before reports whether a ticket sorts after the cursor in list order
+func (c Cursor) Before(ticket *ticketpb.Ticket) bool {
+	createdAt := ticket.CreatedAt.AsTime()
+	if !createdAt.Equal(c.CreatedAt) {
+		return createdAt.Before(c.CreatedAt)
+	}
+	return ticket.Id < c.ID
+}
+
+// Encode returns the cursor as an opaque page token
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a page token. An empty token yields a nil cursor.
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	nanos, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	unix, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	return &Cursor{CreatedAt: time.Unix(0, unix).UTC(), ID: id}, nil
+}
+
+// Less orders tickets newest first, breaking ties by descending ID
+func Less(a, b *ticketpb.Ticket) bool {
+	at, bt := a.CreatedAt.AsTime(), b.CreatedAt.AsTime()
+	if !at.Equal(bt) {
+		return at.After(bt)
+	}
+	return a.Id > b.Id
+}
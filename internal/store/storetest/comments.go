@@ -0,0 +1,190 @@
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CommentFactory returns an empty comment store for a single test
+type CommentFactory func(t *testing.T) comments.Store
+
+// RunComments runs the conformance suite of comments.Store against stores
+// created by newStore
+func RunComments(t *testing.T, newStore CommentFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s comments.Store)
+	}{
+		{"Comments", testComments},
+		{"EmailThreads", testEmailThreads},
+		{"DeleteTicketComments", testDeleteTicketComments},
+		{"CommentTenantIsolation", testCommentTenantIsolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+// newComment returns a comment on ticketID created offset after base
+func newComment(ticketID, body string, offset time.Duration) *ticketpb.Comment {
+	return &ticketpb.Comment{
+		Id:        uuid.New().String(),
+		TicketId:  ticketID,
+		AuthorId:  "customer@example.com",
+		Body:      body,
+		CreatedAt: timestamppb.New(base.Add(offset).Truncate(time.Microsecond)),
+	}
+}
+
+func mustAddComment(t *testing.T, ctx context.Context, s comments.Store, comment *ticketpb.Comment) {
+	t.Helper()
+	if err := s.AddComment(ctx, comment); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+}
+
+func commentBodies(list []*ticketpb.Comment) []string {
+	bodies := make([]string, len(list))
+	for i, comment := range list {
+		bodies[i] = comment.Body
+	}
+	return bodies
+}
+
+func testComments(t *testing.T, s comments.Store) {
+	ctx := context.Background()
+	ticketID := uuid.New().String()
+	mustAddComment(t, ctx, s, newComment(ticketID, "first", 0))
+	mustAddComment(t, ctx, s, newComment(ticketID, "second", time.Minute))
+	mustAddComment(t, ctx, s, newComment(uuid.New().String(), "elsewhere", 0))
+
+	list, err := s.ListComments(ctx, ticketID)
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if got := commentBodies(list); len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Fatalf("ListComments = %v, want [first second]", got)
+	}
+	comment := list[0]
+	if comment.TicketId != ticketID || comment.AuthorId != "customer@example.com" {
+		t.Errorf("comment = %+v, want ticket %s by customer@example.com", comment, ticketID)
+	}
+	if !comment.CreatedAt.AsTime().Equal(base) {
+		t.Errorf("CreatedAt = %v, want %v", comment.CreatedAt.AsTime(), base)
+	}
+	if comment.OrganizationId != tenant.ID(ctx) {
+		t.Errorf("OrganizationId = %q, want %q", comment.OrganizationId, tenant.ID(ctx))
+	}
+
+	list, err = s.ListComments(ctx, uuid.New().String())
+	if err != nil {
+		t.Fatalf("ListComments of a ticket without comments: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListComments of a ticket without comments = %v, want none", commentBodies(list))
+	}
+}
+
+func testEmailThreads(t *testing.T, s comments.Store) {
+	ctx := context.Background()
+	ticketID := uuid.New().String()
+
+	recorded, err := s.RecordMessage(ctx, "first@mail.example.com", ticketID, base)
+	if err != nil || !recorded {
+		t.Fatalf("RecordMessage = %v, %v, want true", recorded, err)
+	}
+	recorded, err = s.RecordMessage(ctx, "first@mail.example.com", uuid.New().String(), base)
+	if err != nil || recorded {
+		t.Errorf("RecordMessage of a recorded message = %v, %v, want false", recorded, err)
+	}
+	if _, err := s.RecordMessage(ctx, "reply@mail.example.com", ticketID, base); err != nil {
+		t.Fatalf("RecordMessage: %v", err)
+	}
+
+	found, err := s.FindThread(ctx, []string{"unknown@mail.example.com", "reply@mail.example.com"})
+	if err != nil {
+		t.Fatalf("FindThread: %v", err)
+	}
+	if found != ticketID {
+		t.Errorf("FindThread = %s, want %s", found, ticketID)
+	}
+	if _, err := s.FindThread(ctx, []string{"unknown@mail.example.com"}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("FindThread of unknown messages error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.FindThread(ctx, nil); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("FindThread of no messages error = %v, want ErrNotFound", err)
+	}
+}
+
+func testDeleteTicketComments(t *testing.T, s comments.Store) {
+	ctx := context.Background()
+	ticketID, otherID := uuid.New().String(), uuid.New().String()
+	mustAddComment(t, ctx, s, newComment(ticketID, "deleted", 0))
+	mustAddComment(t, ctx, s, newComment(otherID, "kept", 0))
+	if _, err := s.RecordMessage(ctx, "deleted@mail.example.com", ticketID, base); err != nil {
+		t.Fatalf("RecordMessage: %v", err)
+	}
+	if _, err := s.RecordMessage(ctx, "kept@mail.example.com", otherID, base); err != nil {
+		t.Fatalf("RecordMessage: %v", err)
+	}
+
+	if err := s.DeleteTicket(ctx, ticketID); err != nil {
+		t.Fatalf("DeleteTicket: %v", err)
+	}
+
+	if list, err := s.ListComments(ctx, ticketID); err != nil || len(list) != 0 {
+		t.Errorf("ListComments of a deleted ticket = %v, %v, want none", commentBodies(list), err)
+	}
+	if list, err := s.ListComments(ctx, otherID); err != nil || len(list) != 1 {
+		t.Errorf("ListComments of another ticket = %v, %v, want [kept]", commentBodies(list), err)
+	}
+	if _, err := s.FindThread(ctx, []string{"deleted@mail.example.com"}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("FindThread of a deleted ticket's message error = %v, want ErrNotFound", err)
+	}
+	if found, err := s.FindThread(ctx, []string{"kept@mail.example.com"}); err != nil || found != otherID {
+		t.Errorf("FindThread of another ticket's message = %s, %v, want %s", found, err, otherID)
+	}
+}
+
+func testCommentTenantIsolation(t *testing.T, s comments.Store) {
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+	ticketID := uuid.New().String()
+	mustAddComment(t, acme, s, newComment(ticketID, "private", 0))
+	if _, err := s.RecordMessage(acme, "private@mail.example.com", ticketID, base); err != nil {
+		t.Fatalf("RecordMessage: %v", err)
+	}
+
+	list, err := s.ListComments(globex, ticketID)
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListComments returned %v of another organization", commentBodies(list))
+	}
+	if _, err := s.FindThread(globex, []string{"private@mail.example.com"}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("FindThread of another organization's message error = %v, want ErrNotFound", err)
+	}
+	recorded, err := s.RecordMessage(globex, "private@mail.example.com", ticketID, base)
+	if err != nil || !recorded {
+		t.Errorf("RecordMessage of another organization's message = %v, %v, want true", recorded, err)
+	}
+	if err := s.DeleteTicket(globex, ticketID); err != nil {
+		t.Fatalf("DeleteTicket: %v", err)
+	}
+	if list, err := s.ListComments(acme, ticketID); err != nil || len(list) != 1 {
+		t.Errorf("ListComments after another organization's DeleteTicket = %v, %v, want [private]", commentBodies(list), err)
+	}
+}
@@ -0,0 +1,377 @@
+package storetest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NotificationFactory returns an empty notification store for a single test
+type NotificationFactory func(t *testing.T) notifications.Store
+
+// RunNotifications runs the conformance suite of notifications.Store against
+// stores created by newStore
+func RunNotifications(t *testing.T, newStore NotificationFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s notifications.Store)
+	}{
+		{"Watchers", testWatchers},
+		{"Notifications", testNotifications},
+		{"DueNotifications", testDueNotifications},
+		{"Preferences", testPreferences},
+		{"NotificationTenantIsolation", testNotificationTenantIsolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+// newNotification returns an unread notification of user created offset
+// after base, not due for delivery
+func newNotification(userID string, offset time.Duration) *ticketpb.Notification {
+	return &ticketpb.Notification{
+		Id:        uuid.New().String(),
+		UserId:    userID,
+		Kind:      ticketpb.NotificationKind_NOTIFICATION_KIND_ASSIGNED,
+		TicketId:  uuid.New().String(),
+		EventId:   uuid.New().String(),
+		Message:   `WEB-1 "Login fails" was assigned to you`,
+		CreatedAt: timestamppb.New(base.Add(offset).Truncate(time.Microsecond)),
+	}
+}
+
+func mustCreateNotifications(t *testing.T, ctx context.Context, s notifications.Store, notifications ...*ticketpb.Notification) {
+	t.Helper()
+	if err := s.CreateNotifications(ctx, notifications); err != nil {
+		t.Fatalf("CreateNotifications: %v", err)
+	}
+}
+
+func notificationIDs(notifications []*ticketpb.Notification) []string {
+	out := make([]string, len(notifications))
+	for i, notification := range notifications {
+		out[i] = notification.Id
+	}
+	return out
+}
+
+func testWatchers(t *testing.T, s notifications.Store) {
+	ctx := context.Background()
+	ticketID, other := uuid.New().String(), uuid.New().String()
+	for i, userID := range []string{"bob", "alice", "bob"} {
+		if err := s.Watch(ctx, ticketID, userID, base.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("Watch(%s): %v", userID, err)
+		}
+	}
+	if err := s.Watch(ctx, other, "carol", base); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	// Watching twice keeps the first watch
+	watchers, err := s.Watchers(ctx, ticketID)
+	if err != nil {
+		t.Fatalf("Watchers: %v", err)
+	}
+	if want := []string{"bob", "alice"}; !slices.Equal(watchers, want) {
+		t.Errorf("Watchers = %v, want %v", watchers, want)
+	}
+
+	watching, err := s.Unwatch(ctx, ticketID, "bob")
+	if err != nil || !watching {
+		t.Errorf("Unwatch = %v, %v, want true", watching, err)
+	}
+	watching, err = s.Unwatch(ctx, ticketID, "bob")
+	if err != nil || watching {
+		t.Errorf("Unwatch of a user not watching = %v, %v, want false", watching, err)
+	}
+	watchers, err = s.Watchers(ctx, ticketID)
+	if err != nil {
+		t.Fatalf("Watchers after unwatch: %v", err)
+	}
+	if want := []string{"alice"}; !slices.Equal(watchers, want) {
+		t.Errorf("Watchers after unwatch = %v, want %v", watchers, want)
+	}
+
+	if err := s.DeleteWatchers(ctx, ticketID); err != nil {
+		t.Fatalf("DeleteWatchers: %v", err)
+	}
+	watchers, err = s.Watchers(ctx, ticketID)
+	if err != nil {
+		t.Fatalf("Watchers after delete: %v", err)
+	}
+	if len(watchers) != 0 {
+		t.Errorf("Watchers after delete = %v, want none", watchers)
+	}
+	watchers, err = s.Watchers(ctx, other)
+	if err != nil {
+		t.Fatalf("Watchers of another ticket: %v", err)
+	}
+	if want := []string{"carol"}; !slices.Equal(watchers, want) {
+		t.Errorf("Watchers of another ticket = %v, want %v", watchers, want)
+	}
+}
+
+func testNotifications(t *testing.T, s notifications.Store) {
+	ctx := context.Background()
+	oldest, middle, newest := newNotification("alice", 0), newNotification("alice", time.Minute), newNotification("alice", 2*time.Minute)
+	newest.Kind = ticketpb.NotificationKind_NOTIFICATION_KIND_RESOLVED
+	mustCreateNotifications(t, ctx, s, oldest, middle, newest, newNotification("bob", 0))
+	if oldest.OrganizationId != tenant.Default {
+		t.Errorf("CreateNotifications set organization %q, want %q", oldest.OrganizationId, tenant.Default)
+	}
+
+	// Another notification of an event its user already has is skipped
+	duplicate := newNotification("alice", 3*time.Minute)
+	duplicate.EventId = middle.EventId
+	mustCreateNotifications(t, ctx, s, duplicate)
+
+	list, err := s.ListNotifications(ctx, "alice", false, 10)
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if got, want := notificationIDs(list), []string{newest.Id, middle.Id, oldest.Id}; !slices.Equal(got, want) {
+		t.Fatalf("ListNotifications = %v, want %v", got, want)
+	}
+	assertProtoEqual(t, list[0], newest)
+
+	read := base.Add(time.Hour)
+	got, err := s.MarkRead(ctx, middle.Id, read)
+	if err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+	middle.ReadAt = timestamppb.New(read)
+	assertProtoEqual(t, got, middle)
+
+	// Reading again keeps the first read time
+	got, err = s.MarkRead(ctx, middle.Id, read.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("MarkRead again: %v", err)
+	}
+	assertProtoEqual(t, got, middle)
+
+	tests := []struct {
+		unreadOnly bool
+		limit      int
+		want       []string
+	}{
+		{false, 2, []string{newest.Id, middle.Id}},
+		{true, 10, []string{newest.Id, oldest.Id}},
+		{true, 1, []string{newest.Id}},
+	}
+	for _, tt := range tests {
+		list, err := s.ListNotifications(ctx, "alice", tt.unreadOnly, tt.limit)
+		if err != nil {
+			t.Fatalf("ListNotifications(%v, %d): %v", tt.unreadOnly, tt.limit, err)
+		}
+		if got := notificationIDs(list); !slices.Equal(got, tt.want) {
+			t.Errorf("ListNotifications(%v, %d) = %v, want %v", tt.unreadOnly, tt.limit, got, tt.want)
+		}
+	}
+
+	for _, id := range []string{uuid.New().String(), "not-a-uuid"} {
+		if _, err := s.MarkRead(ctx, id, read); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("MarkRead(%q) error = %v, want ErrNotFound", id, err)
+		}
+	}
+}
+
+func testDueNotifications(t *testing.T, s notifications.Store) {
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+
+	late := newNotification("alice", 0)
+	late.DeliverAt = timestamppb.New(base.Add(2 * time.Minute))
+	early := newNotification("bob", 0)
+	early.DeliverAt = timestamppb.New(base.Add(time.Minute))
+	digest := newNotification("alice", 0)
+	digest.DeliverAt = timestamppb.New(base.Add(24 * time.Hour))
+	inboxOnly := newNotification("bob", 0)
+	mustCreateNotifications(t, acme, s, late, digest)
+	mustCreateNotifications(t, globex, s, early, inboxOnly)
+
+	// Due notifications of every organization, earliest first
+	now := base.Add(10 * time.Minute)
+	due, err := s.DueNotifications(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("DueNotifications: %v", err)
+	}
+	if got, want := notificationIDs(due), []string{early.Id, late.Id}; !slices.Equal(got, want) {
+		t.Fatalf("DueNotifications = %v, want %v", got, want)
+	}
+	assertProtoEqual(t, due[1], late)
+	if due[0].OrganizationId != "globex" || due[1].OrganizationId != "acme" {
+		t.Errorf("DueNotifications organizations = %q and %q, want globex and acme", due[0].OrganizationId, due[1].OrganizationId)
+	}
+
+	due, err = s.DueNotifications(context.Background(), now, 1)
+	if err != nil {
+		t.Fatalf("DueNotifications with limit: %v", err)
+	}
+	if got := notificationIDs(due); !slices.Equal(got, []string{early.Id}) {
+		t.Errorf("DueNotifications with limit 1 = %v, want [%s]", got, early.Id)
+	}
+
+	// Delivered notifications leave the due list
+	delivered := now.Add(time.Second)
+	if err := s.MarkDelivered(acme, []string{late.Id}, delivered); err != nil {
+		t.Fatalf("MarkDelivered: %v", err)
+	}
+	due, err = s.DueNotifications(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("DueNotifications after delivery: %v", err)
+	}
+	if got := notificationIDs(due); !slices.Equal(got, []string{early.Id}) {
+		t.Errorf("DueNotifications after delivery = %v, want [%s]", got, early.Id)
+	}
+	list, err := s.ListNotifications(acme, "alice", false, 10)
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	for _, notification := range list {
+		if notification.Id != late.Id {
+			continue
+		}
+		late.DeliverAt = nil
+		late.DeliveredAt = timestamppb.New(delivered)
+		assertProtoEqual(t, notification, late)
+	}
+
+	// Another organization cannot mark a notification delivered
+	if err := s.MarkDelivered(acme, []string{early.Id}, delivered); err != nil {
+		t.Fatalf("MarkDelivered of another organization: %v", err)
+	}
+	due, err = s.DueNotifications(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("DueNotifications: %v", err)
+	}
+	if got := notificationIDs(due); !slices.Equal(got, []string{early.Id}) {
+		t.Errorf("DueNotifications after delivery by another organization = %v, want [%s]", got, early.Id)
+	}
+}
+
+func testPreferences(t *testing.T, s notifications.Store) {
+	ctx := context.Background()
+	if _, err := s.GetPreferences(ctx, "alice"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetPreferences of a user without preferences error = %v, want ErrNotFound", err)
+	}
+	defaults, err := notifications.Preferences(ctx, s, "alice")
+	if err != nil {
+		t.Fatalf("Preferences: %v", err)
+	}
+	if defaults.Delivery != ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_IMMEDIATE ||
+		defaults.DigestHour != notifications.DefaultDigestHour {
+		t.Errorf("Preferences defaults = %v", defaults)
+	}
+
+	preferences := &ticketpb.NotificationPreferences{
+		UserId:     "alice",
+		Email:      "alice@example.com",
+		Delivery:   ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_DAILY_DIGEST,
+		DigestHour: 17,
+		MutedKinds: []ticketpb.NotificationKind{
+			ticketpb.NotificationKind_NOTIFICATION_KIND_UPDATED,
+			ticketpb.NotificationKind_NOTIFICATION_KIND_SLA_AT_RISK,
+		},
+		UpdatedAt: timestamppb.New(base),
+	}
+	if err := s.SetPreferences(ctx, preferences); err != nil {
+		t.Fatalf("SetPreferences: %v", err)
+	}
+	if preferences.OrganizationId != tenant.Default {
+		t.Errorf("SetPreferences set organization %q, want %q", preferences.OrganizationId, tenant.Default)
+	}
+	got, err := s.GetPreferences(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetPreferences: %v", err)
+	}
+	assertProtoEqual(t, got, preferences)
+
+	// Setting preferences again replaces every setting
+	preferences.Email = ""
+	preferences.Delivery = ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_OFF
+	preferences.DigestHour = 0
+	preferences.MutedKinds = nil
+	preferences.UpdatedAt = timestamppb.New(base.Add(time.Hour))
+	if err := s.SetPreferences(ctx, preferences); err != nil {
+		t.Fatalf("SetPreferences again: %v", err)
+	}
+	got, err = s.GetPreferences(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetPreferences after update: %v", err)
+	}
+	assertProtoEqual(t, got, preferences)
+}
+
+func testNotificationTenantIsolation(t *testing.T, s notifications.Store) {
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+	ticketID := uuid.New().String()
+	if err := s.Watch(acme, ticketID, "alice", base); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	notification := newNotification("alice", 0)
+	mustCreateNotifications(t, acme, s, notification)
+	preferences := &ticketpb.NotificationPreferences{
+		UserId:     "alice",
+		Email:      "alice@acme.example.com",
+		Delivery:   ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_IMMEDIATE,
+		DigestHour: notifications.DefaultDigestHour,
+		UpdatedAt:  timestamppb.New(base),
+	}
+	if err := s.SetPreferences(acme, preferences); err != nil {
+		t.Fatalf("SetPreferences: %v", err)
+	}
+
+	watchers, err := s.Watchers(globex, ticketID)
+	if err != nil {
+		t.Fatalf("Watchers: %v", err)
+	}
+	if len(watchers) != 0 {
+		t.Errorf("Watchers returned %v of another organization", watchers)
+	}
+	if watching, err := s.Unwatch(globex, ticketID, "alice"); err != nil || watching {
+		t.Errorf("Unwatch of another organization's watcher = %v, %v, want false", watching, err)
+	}
+	if err := s.DeleteWatchers(globex, ticketID); err != nil {
+		t.Fatalf("DeleteWatchers: %v", err)
+	}
+	list, err := s.ListNotifications(globex, "alice", false, 10)
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListNotifications returned %d notifications of another organization", len(list))
+	}
+	if _, err := s.MarkRead(globex, notification.Id, base); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("MarkRead of another organization's notification error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.GetPreferences(globex, "alice"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetPreferences of another organization's user error = %v, want ErrNotFound", err)
+	}
+
+	watchers, err = s.Watchers(acme, ticketID)
+	if err != nil {
+		t.Fatalf("Watchers in acme: %v", err)
+	}
+	if !slices.Equal(watchers, []string{"alice"}) {
+		t.Errorf("Watchers in acme = %v, want [alice]", watchers)
+	}
+	got, err := s.GetPreferences(acme, "alice")
+	if err != nil {
+		t.Fatalf("GetPreferences in acme: %v", err)
+	}
+	assertProtoEqual(t, got, preferences)
+}
@@ -0,0 +1,1394 @@
+// Package storetest is the conformance suite every store.TicketStore
+// implementation must pass. Call Run from the implementation's tests:
+//
+//	func TestMemoryStore(t *testing.T) {
+//		storetest.Run(t, func(t *testing.T) store.TicketStore {
+//			return store.NewMemoryStore()
+//		})
+//	}
+//
+// RunWebhooks, RunNotifications and RunComments do the same for the
+// webhooks.Store, notifications.Store and comments.Store implementations.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Factory returns an empty store for a single test. It should register any
+// cleanup with t.Cleanup.
+type Factory func(t *testing.T) store.TicketStore
+
+// Run runs the conformance suite against stores created by newStore
+func Run(t *testing.T, newStore Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s store.TicketStore)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"GetMissing", testGetMissing},
+		{"CreateDuplicate", testCreateDuplicate},
+		{"ReturnedTicketsAreCopies", testReturnedTicketsAreCopies},
+		{"ListOrder", testListOrder},
+		{"ListPagination", testListPagination},
+		{"ListPageSize", testListPageSize},
+		{"ListInvalidToken", testListInvalidToken},
+		{"ListFilterTags", testListFilterTags},
+		{"ListSearch", testListSearch},
+		{"UpdatePartial", testUpdatePartial},
+		{"UpdateTags", testUpdateTags},
+		{"UpdateMissing", testUpdateMissing},
+		{"Delete", testDelete},
+		{"DeleteMissing", testDeleteMissing},
+		{"BulkUpdate", testBulkUpdate},
+		{"BulkUpdateMissing", testBulkUpdateMissing},
+		{"BulkDelete", testBulkDelete},
+		{"BulkDeleteMissing", testBulkDeleteMissing},
+		{"ConcurrentCreates", testConcurrentCreates},
+		{"Tags", testTags},
+		{"MergeTags", testMergeTags},
+		{"Links", testLinks},
+		{"LinkErrors", testLinkErrors},
+		{"DeleteRemovesLinks", testDeleteRemovesLinks},
+		{"Projects", testProjects},
+		{"ProjectTicketKeys", testProjectTicketKeys},
+		{"ListFilterProject", testListFilterProject},
+		{"ConcurrentProjectCreates", testConcurrentProjectCreates},
+		{"CustomFields", testCustomFields},
+		{"SLA", testSLA},
+		{"Outbox", testOutbox},
+		{"WithoutEvents", testWithoutEvents},
+		{"TenantIsolation", testTenantIsolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore(t)
+			t.Cleanup(func() { s.Close() })
+			tt.fn(t, s)
+		})
+	}
+}
+
+// base is the creation time of the tickets made by newTicket
+var base = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTicket returns a ticket created offset after base, with microsecond
+// precision timestamps as stored by SQL databases
+func newTicket(offset time.Duration) *ticketpb.Ticket {
+	created := timestamppb.New(base.Add(offset).Truncate(time.Microsecond))
+	return &ticketpb.Ticket{
+		Id:          uuid.New().String(),
+		Title:       "Ticket " + offset.String(),
+		Description: "Conformance ticket",
+		Status:      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+		Priority:    ticketpb.TicketPriority_TICKET_PRIORITY_HIGH,
+		AssigneeId:  "user-1",
+		ReporterId:  "user-2",
+		Tags:        []string{"bug", "urgent"},
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+}
+
+func mustCreate(t *testing.T, s store.TicketStore, tickets ...*ticketpb.Ticket) {
+	t.Helper()
+	for _, ticket := range tickets {
+		if err := s.Create(context.Background(), ticket); err != nil {
+			t.Fatalf("Create(%s): %v", ticket.Id, err)
+		}
+	}
+}
+
+func assertEqual(t *testing.T, got, want *ticketpb.Ticket) {
+	t.Helper()
+	if !proto.Equal(got, want) {
+		t.Fatalf("ticket mismatch:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func ids(tickets []*ticketpb.Ticket) []string {
+	out := make([]string, len(tickets))
+	for i, ticket := range tickets {
+		out[i] = ticket.Id
+	}
+	return out
+}
+
+func testCreateAndGet(t *testing.T, s store.TicketStore) {
+	ticket := newTicket(0)
+	mustCreate(t, s, ticket)
+
+	got, err := s.Get(context.Background(), ticket.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, got, ticket)
+}
+
+func testGetMissing(t *testing.T, s store.TicketStore) {
+	for _, id := range []string{uuid.New().String(), "not-a-uuid"} {
+		if _, err := s.Get(context.Background(), id); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want ErrNotFound", id, err)
+		}
+	}
+}
+
+func testCreateDuplicate(t *testing.T, s store.TicketStore) {
+	ticket := newTicket(0)
+	mustCreate(t, s, ticket)
+
+	if err := s.Create(context.Background(), ticket); !errors.Is(err, store.ErrTicketExists) {
+		t.Fatalf("Create with a duplicate ID = %v, want ErrTicketExists", err)
+	}
+	// IDs are unique across organizations
+	if err := s.Create(tenant.WithOrganization(context.Background(), "acme"), ticket); !errors.Is(err, store.ErrTicketExists) {
+		t.Fatalf("Create with the ID of another organization's ticket = %v, want ErrTicketExists", err)
+	}
+}
+
+func testReturnedTicketsAreCopies(t *testing.T, s store.TicketStore) {
+	ticket := newTicket(0)
+	mustCreate(t, s, ticket)
+	ticket.Title = "changed after create"
+
+	got, err := s.Get(context.Background(), ticket.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title == ticket.Title {
+		t.Fatal("store shares state with the ticket passed to Create")
+	}
+	got.Tags[0] = "changed after get"
+
+	again, err := s.Get(context.Background(), ticket.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if again.Tags[0] == "changed after get" {
+		t.Fatal("store shares state with tickets returned by Get")
+	}
+}
+
+func testListOrder(t *testing.T, s store.TicketStore) {
+	older, newer := newTicket(0), newTicket(time.Hour)
+	// Same creation time: ties are broken by descending ID
+	tieA, tieB := newTicket(30*time.Minute), newTicket(30*time.Minute)
+	tieA.Id, tieB.Id = "00000000-0000-0000-0000-00000000000a", "00000000-0000-0000-0000-00000000000b"
+	mustCreate(t, s, older, tieA, newer, tieB)
+
+	tickets, next, err := s.List(context.Background(), store.ListOptions{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if next != "" {
+		t.Errorf("next page token = %q, want empty on the last page", next)
+	}
+	want := []string{newer.Id, tieB.Id, tieA.Id, older.Id}
+	if fmt.Sprint(ids(tickets)) != fmt.Sprint(want) {
+		t.Fatalf("List order = %v, want %v", ids(tickets), want)
+	}
+	assertEqual(t, tickets[0], newer)
+}
+
+func testListPagination(t *testing.T, s store.TicketStore) {
+	const total = 7
+	want := make([]string, total) // newest first
+	for i := 0; i < total; i++ {
+		ticket := newTicket(time.Duration(i) * time.Minute)
+		mustCreate(t, s, ticket)
+		want[total-1-i] = ticket.Id
+	}
+
+	var got []string
+	token := ""
+	for pages := 0; ; pages++ {
+		if pages > total {
+			t.Fatal("pagination did not terminate")
+		}
+		tickets, next, err := s.List(context.Background(), store.ListOptions{PageSize: 3, PageToken: token})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(tickets) > 3 {
+			t.Fatalf("page has %d tickets, want at most 3", len(tickets))
+		}
+		got = append(got, ids(tickets)...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("paginated IDs = %v, want %v", got, want)
+	}
+
+	// A ticket created after the first page was read must not shift later pages
+	first, next, err := s.List(context.Background(), store.ListOptions{PageSize: 3})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	mustCreate(t, s, newTicket(time.Hour))
+	second, _, err := s.List(context.Background(), store.ListOptions{PageSize: 3, PageToken: next})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if fmt.Sprint(append(ids(first), ids(second)...)) != fmt.Sprint(want[:6]) {
+		t.Fatalf("pages after insert = %v %v, want %v", ids(first), ids(second), want[:6])
+	}
+}
+
+func testListPageSize(t *testing.T, s store.TicketStore) {
+	for i := 0; i < store.MaxPageSize+5; i++ {
+		mustCreate(t, s, newTicket(time.Duration(i)*time.Second))
+	}
+
+	tickets, next, err := s.List(context.Background(), store.ListOptions{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tickets) != store.DefaultPageSize || next == "" {
+		t.Errorf("default page: %d tickets (next %q), want %d and a next token", len(tickets), next, store.DefaultPageSize)
+	}
+
+	tickets, _, err = s.List(context.Background(), store.ListOptions{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tickets) != store.MaxPageSize {
+		t.Errorf("oversized page: %d tickets, want %d", len(tickets), store.MaxPageSize)
+	}
+}
+
+func testListInvalidToken(t *testing.T, s store.TicketStore) {
+	forged := store.Cursor{CreatedAt: base, ID: "not-a-ticket-id"}.Encode()
+	for _, token := range []string{"%%%", "bm8tc2VwYXJhdG9y", forged} {
+		_, _, err := s.List(context.Background(), store.ListOptions{PageToken: token})
+		if token == forged && err == nil {
+			// Stores with free-form IDs may accept any ID in a cursor
+			continue
+		}
+		if !errors.Is(err, store.ErrInvalidPageToken) {
+			t.Fatalf("List with page token %q returned %v, want ErrInvalidPageToken", token, err)
+		}
+	}
+}
+
+func testListFilterTags(t *testing.T, s store.TicketStore) {
+	bug, feature, both := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	bug.Tags = []string{"bug"}
+	feature.Tags = []string{"feature"}
+	both.Tags = []string{"feature", "bug", "ui"}
+	mustCreate(t, s, bug, feature, both)
+
+	for _, tt := range []struct {
+		tags []string
+		want []string
+	}{
+		{[]string{"bug"}, []string{both.Id, bug.Id}},
+		{[]string{"bug", "feature"}, []string{both.Id}},
+		{[]string{"missing"}, []string{}},
+	} {
+		tickets, _, err := s.List(context.Background(), store.ListOptions{Tags: tt.tags})
+		if err != nil {
+			t.Fatalf("List(%v): %v", tt.tags, err)
+		}
+		if fmt.Sprint(ids(tickets)) != fmt.Sprint(tt.want) {
+			t.Errorf("List(tags %v) = %v, want %v", tt.tags, ids(tickets), tt.want)
+		}
+	}
+
+	// Filtered pages must paginate over the filtered set only
+	tickets, next, err := s.List(context.Background(), store.ListOptions{Tags: []string{"bug"}, PageSize: 1})
+	if err != nil || next == "" {
+		t.Fatalf("List first page: %v (next %q)", err, next)
+	}
+	rest, next, err := s.List(context.Background(), store.ListOptions{Tags: []string{"bug"}, PageSize: 1, PageToken: next})
+	if err != nil {
+		t.Fatalf("List second page: %v", err)
+	}
+	if got := append(ids(tickets), ids(rest)...); fmt.Sprint(got) != fmt.Sprint([]string{both.Id, bug.Id}) || next != "" {
+		t.Errorf("filtered pages = %v (next %q), want [%s %s]", got, next, both.Id, bug.Id)
+	}
+
+	// Tag order is preserved
+	got, err := s.Get(context.Background(), both.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if fmt.Sprint(got.Tags) != "[feature bug ui]" {
+		t.Errorf("tags = %v, want [feature bug ui]", got.Tags)
+	}
+}
+
+func testListSearch(t *testing.T, s store.TicketStore) {
+	login, dark, percent := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	login.Title, login.Description = "Fix login bug", "Users cannot sign in"
+	dark.Title, dark.Description = "Add dark mode", "Better LOGIN screen contrast"
+	percent.Title, percent.Description = "Discount of 100%", "Rounding error"
+	mustCreate(t, s, login, dark, percent)
+
+	for _, tt := range []struct {
+		query string
+		want  []string
+	}{
+		{"login", []string{dark.Id, login.Id}},
+		{"DARK", []string{dark.Id}},
+		{"100%", []string{percent.Id}},
+		{"%", []string{percent.Id}},
+		{"_", []string{}},
+	} {
+		tickets, _, err := s.List(context.Background(), store.ListOptions{Query: tt.query})
+		if err != nil {
+			t.Fatalf("List(%q): %v", tt.query, err)
+		}
+		if fmt.Sprint(ids(tickets)) != fmt.Sprint(tt.want) {
+			t.Errorf("List(query %q) = %v, want %v", tt.query, ids(tickets), tt.want)
+		}
+	}
+}
+
+func testUpdatePartial(t *testing.T, s store.TicketStore) {
+	ticket := newTicket(0)
+	mustCreate(t, s, ticket)
+
+	title := "Updated title"
+	status := ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
+	updatedAt := base.Add(time.Hour)
+	got, err := s.Update(context.Background(), ticket.Id, store.TicketUpdate{
+		Title:     &title,
+		Status:    &status,
+		UpdatedAt: updatedAt,
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	want := proto.Clone(ticket).(*ticketpb.Ticket)
+	want.Title = title
+	want.Status = status
+	want.UpdatedAt = timestamppb.New(updatedAt)
+	assertEqual(t, got, want)
+
+	stored, err := s.Get(context.Background(), ticket.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, stored, want)
+}
+
+func testUpdateTags(t *testing.T, s store.TicketStore) {
+	ticket := newTicket(0)
+	mustCreate(t, s, ticket)
+
+	got, err := s.Update(context.Background(), ticket.Id, store.TicketUpdate{
+		Tags:      []string{"feature"},
+		UpdatedAt: base,
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if fmt.Sprint(got.Tags) != "[feature]" {
+		t.Errorf("tags = %v, want [feature]", got.Tags)
+	}
+
+	// Nil tags leave the tags unchanged; an empty slice clears them
+	got, err = s.Update(context.Background(), ticket.Id, store.TicketUpdate{UpdatedAt: base})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if fmt.Sprint(got.Tags) != "[feature]" {
+		t.Errorf("tags after empty update = %v, want [feature]", got.Tags)
+	}
+	got, err = s.Update(context.Background(), ticket.Id, store.TicketUpdate{Tags: []string{}, UpdatedAt: base})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(got.Tags) != 0 {
+		t.Errorf("tags after clearing = %v, want none", got.Tags)
+	}
+}
+
+func testUpdateMissing(t *testing.T, s store.TicketStore) {
+	title := "missing"
+	_, err := s.Update(context.Background(), uuid.New().String(), store.TicketUpdate{Title: &title, UpdatedAt: base})
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Update error = %v, want ErrNotFound", err)
+	}
+}
+
+func testDelete(t *testing.T, s store.TicketStore) {
+	ticket, other := newTicket(0), newTicket(time.Minute)
+	mustCreate(t, s, ticket, other)
+
+	if err := s.Delete(context.Background(), ticket.Id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(context.Background(), ticket.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Get(context.Background(), other.Id); err != nil {
+		t.Errorf("Delete removed another ticket: %v", err)
+	}
+}
+
+func testDeleteMissing(t *testing.T, s store.TicketStore) {
+	if err := s.Delete(context.Background(), uuid.New().String()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func testBulkUpdate(t *testing.T, s store.TicketStore) {
+	first, second, untouched := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	mustCreate(t, s, first, second, untouched)
+
+	status := ticketpb.TicketStatus_TICKET_STATUS_CLOSED
+	title := "Renamed"
+	updatedAt := base.Add(time.Hour)
+	got, err := s.BulkUpdate(context.Background(), []store.TicketChange{
+		{ID: second.Id, Update: store.TicketUpdate{Status: &status, UpdatedAt: updatedAt}},
+		{ID: first.Id, Update: store.TicketUpdate{Title: &title, Tags: []string{"triaged"}, UpdatedAt: updatedAt}},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdate: %v", err)
+	}
+
+	wantSecond := proto.Clone(second).(*ticketpb.Ticket)
+	wantSecond.Status = status
+	wantSecond.UpdatedAt = timestamppb.New(updatedAt)
+	wantFirst := proto.Clone(first).(*ticketpb.Ticket)
+	wantFirst.Title = title
+	wantFirst.Tags = []string{"triaged"}
+	wantFirst.UpdatedAt = timestamppb.New(updatedAt)
+	if len(got) != 2 {
+		t.Fatalf("BulkUpdate returned %d tickets, want 2", len(got))
+	}
+	assertEqual(t, got[0], wantSecond)
+	assertEqual(t, got[1], wantFirst)
+
+	for _, want := range []*ticketpb.Ticket{wantFirst, wantSecond, untouched} {
+		stored, err := s.Get(context.Background(), want.Id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertEqual(t, stored, want)
+	}
+}
+
+func testBulkUpdateMissing(t *testing.T, s store.TicketStore) {
+	ctx := context.Background()
+	acme := tenant.WithOrganization(ctx, "acme")
+	ticket, foreign := newTicket(0), newTicket(time.Minute)
+	mustCreate(t, s, ticket)
+	if err := s.Create(acme, foreign); err != nil {
+		t.Fatalf("Create in acme: %v", err)
+	}
+
+	// A missing ticket, or one of another organization, fails the whole write
+	title := "changed"
+	for _, missing := range []string{uuid.New().String(), "not-a-uuid", foreign.Id} {
+		_, err := s.BulkUpdate(ctx, []store.TicketChange{
+			{ID: ticket.Id, Update: store.TicketUpdate{Title: &title, UpdatedAt: base.Add(time.Hour)}},
+			{ID: missing, Update: store.TicketUpdate{Title: &title, UpdatedAt: base.Add(time.Hour)}},
+		})
+		var bulkErr *store.BulkError
+		if !errors.As(err, &bulkErr) || !errors.Is(err, store.ErrNotFound) || bulkErr.Index != 1 || bulkErr.ID != missing {
+			t.Fatalf("BulkUpdate with %s error = %v, want a BulkError for item 1 wrapping ErrNotFound", missing, err)
+		}
+	}
+	for _, c := range []struct {
+		ctx  context.Context
+		want *ticketpb.Ticket
+	}{{ctx, ticket}, {acme, foreign}} {
+		stored, err := s.Get(c.ctx, c.want.Id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertEqual(t, stored, c.want)
+	}
+}
+
+func testBulkDelete(t *testing.T, s store.TicketStore) {
+	first, second, kept := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	mustCreate(t, s, first, second, kept)
+	mustLink(t, s, newLink(first, kept, ticketpb.LinkType_LINK_TYPE_RELATES_TO, 0))
+
+	if err := s.BulkDelete(context.Background(), []string{first.Id, second.Id}); err != nil {
+		t.Fatalf("BulkDelete: %v", err)
+	}
+	for _, id := range []string{first.Id, second.Id} {
+		if _, err := s.Get(context.Background(), id); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Get after BulkDelete error = %v, want ErrNotFound", err)
+		}
+	}
+	if _, err := s.Get(context.Background(), kept.Id); err != nil {
+		t.Errorf("BulkDelete removed another ticket: %v", err)
+	}
+	if links, err := s.Links(context.Background(), kept.Id); err != nil || len(links) != 0 {
+		t.Errorf("Links after BulkDelete = %v, %v; want none", links, err)
+	}
+}
+
+func testBulkDeleteMissing(t *testing.T, s store.TicketStore) {
+	ticket := newTicket(0)
+	mustCreate(t, s, ticket)
+
+	missing := uuid.New().String()
+	err := s.BulkDelete(context.Background(), []string{ticket.Id, missing})
+	var bulkErr *store.BulkError
+	if !errors.As(err, &bulkErr) || !errors.Is(err, store.ErrNotFound) || bulkErr.Index != 1 || bulkErr.ID != missing {
+		t.Fatalf("BulkDelete error = %v, want a BulkError for item 1 wrapping ErrNotFound", err)
+	}
+	if _, err := s.Get(context.Background(), ticket.Id); err != nil {
+		t.Errorf("failed BulkDelete removed a ticket: %v", err)
+	}
+}
+
+func testConcurrentCreates(t *testing.T, s store.TicketStore) {
+	const workers, perWorker = 8, 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				errs <- s.Create(context.Background(), newTicket(time.Duration(w*perWorker+i)*time.Second))
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Create: %v", err)
+		}
+	}
+
+	seen := 0
+	token := ""
+	for {
+		tickets, next, err := s.List(context.Background(), store.ListOptions{PageSize: store.MaxPageSize, PageToken: token})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		seen += len(tickets)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if seen != workers*perWorker {
+		t.Fatalf("listed %d tickets, want %d", seen, workers*perWorker)
+	}
+}
+
+func tagCounts(tags []*ticketpb.TagCount) string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = fmt.Sprintf("%s:%d", tag.Name, tag.Count)
+	}
+	return fmt.Sprint(out)
+}
+
+func testTags(t *testing.T, s store.TicketStore) {
+	for i, tags := range [][]string{
+		{"bug", "urgent"},
+		{"bug", "ui"},
+		{"Backend", "bug"},
+		{"ui"},
+		{},
+	} {
+		ticket := newTicket(time.Duration(i) * time.Minute)
+		ticket.Tags = tags
+		mustCreate(t, s, ticket)
+	}
+
+	for _, tt := range []struct {
+		query store.TagQuery
+		want  string
+	}{
+		{store.TagQuery{}, "[bug:3 ui:2 Backend:1 urgent:1]"},
+		{store.TagQuery{Max: 2}, "[bug:3 ui:2]"},
+		{store.TagQuery{Prefix: "b"}, "[bug:3 Backend:1]"},
+		{store.TagQuery{Prefix: "UR"}, "[urgent:1]"},
+		{store.TagQuery{Prefix: "%"}, "[]"},
+	} {
+		tags, err := s.Tags(context.Background(), tt.query)
+		if err != nil {
+			t.Fatalf("Tags(%+v): %v", tt.query, err)
+		}
+		if got := tagCounts(tags); got != tt.want {
+			t.Errorf("Tags(%+v) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func testMergeTags(t *testing.T, s store.TicketStore) {
+	a, b, c := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	a.Tags = []string{"bug", "defect", "ui"}
+	b.Tags = []string{"defect"}
+	c.Tags = []string{"feature"}
+	mustCreate(t, s, a, b, c)
+
+	updatedAt := base.Add(time.Hour)
+	changed, err := s.MergeTags(context.Background(), []string{"defect", "issue"}, "bug", updatedAt)
+	if err != nil {
+		t.Fatalf("MergeTags: %v", err)
+	}
+	if changed != 2 {
+		t.Errorf("MergeTags changed %d tickets, want 2", changed)
+	}
+
+	for _, tt := range []struct {
+		ticket  *ticketpb.Ticket
+		tags    string
+		updated bool
+	}{
+		{a, "[bug ui]", true},
+		{b, "[bug]", true},
+		{c, "[feature]", false},
+	} {
+		got, err := s.Get(context.Background(), tt.ticket.Id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if fmt.Sprint(got.Tags) != tt.tags {
+			t.Errorf("tags = %v, want %s", got.Tags, tt.tags)
+		}
+		if wasUpdated := got.UpdatedAt.AsTime().Equal(updatedAt); wasUpdated != tt.updated {
+			t.Errorf("ticket %s updated_at = %v, updated = %v want %v", got.Id, got.UpdatedAt.AsTime(), wasUpdated, tt.updated)
+		}
+	}
+
+	// Each changed ticket has a ticket.updated event, in any order
+	pending, err := s.PendingEvents(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+	merged := make(map[string]events.Event)
+	for _, event := range pending {
+		if event.Type == events.TicketUpdated {
+			merged[event.Ticket.Id] = event
+		}
+	}
+	if len(merged) != 2 || len(pending) != 5 {
+		t.Fatalf("PendingEvents after MergeTags = %v, want 3 creations and 2 updates", eventTypes(pending))
+	}
+	for _, tt := range []struct {
+		ticket         *ticketpb.Ticket
+		previous, tags string
+	}{
+		{a, "[bug defect ui]", "[bug ui]"},
+		{b, "[defect]", "[bug]"},
+	} {
+		event, ok := merged[tt.ticket.Id]
+		if !ok {
+			t.Fatalf("no ticket.updated event for ticket %s", tt.ticket.Id)
+		}
+		if got := fmt.Sprint(event.Previous.GetTags()); got != tt.previous {
+			t.Errorf("event previous tags = %s, want %s", got, tt.previous)
+		}
+		if got := fmt.Sprint(event.Ticket.Tags); got != tt.tags {
+			t.Errorf("event tags = %s, want %s", got, tt.tags)
+		}
+		if !event.Ticket.UpdatedAt.AsTime().Equal(updatedAt) {
+			t.Errorf("event updated_at = %v, want %v", event.Ticket.UpdatedAt.AsTime(), updatedAt)
+		}
+	}
+
+	// Renaming to a tag that is already in place changes nothing
+	changed, err = s.MergeTags(context.Background(), []string{"feature"}, "feature", updatedAt)
+	if err != nil {
+		t.Fatalf("MergeTags: %v", err)
+	}
+	if changed != 0 {
+		t.Errorf("no-op rename changed %d tickets, want 0", changed)
+	}
+	if again, err := s.PendingEvents(context.Background(), 10); err != nil || len(again) != len(pending) {
+		t.Errorf("no-op rename recorded %d events, want none", len(again)-len(pending))
+	}
+
+	tags, err := s.Tags(context.Background(), store.TagQuery{})
+	if err != nil {
+		t.Fatalf("Tags: %v", err)
+	}
+	if got := tagCounts(tags); got != "[bug:2 feature:1 ui:1]" {
+		t.Errorf("Tags after merge = %s, want [bug:2 feature:1 ui:1]", got)
+	}
+}
+
+func newLink(source, target *ticketpb.Ticket, linkType ticketpb.LinkType, offset time.Duration) *ticketpb.TicketLink {
+	return &ticketpb.TicketLink{
+		SourceId:  source.Id,
+		TargetId:  target.Id,
+		Type:      linkType,
+		CreatedAt: timestamppb.New(base.Add(offset).Truncate(time.Microsecond)),
+	}
+}
+
+func mustLink(t *testing.T, s store.TicketStore, links ...*ticketpb.TicketLink) {
+	t.Helper()
+	for _, link := range links {
+		if err := s.Link(context.Background(), link); err != nil {
+			t.Fatalf("Link(%s -> %s): %v", link.SourceId, link.TargetId, err)
+		}
+	}
+}
+
+func testLinks(t *testing.T, s store.TicketStore) {
+	a, b, c := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	mustCreate(t, s, a, b, c)
+
+	blocks := newLink(a, b, ticketpb.LinkType_LINK_TYPE_BLOCKS, 0)
+	parent := newLink(c, a, ticketpb.LinkType_LINK_TYPE_PARENT_OF, time.Minute)
+	// The same pair may be linked in several ways
+	relates := newLink(a, b, ticketpb.LinkType_LINK_TYPE_RELATES_TO, 2*time.Minute)
+	mustLink(t, s, blocks, parent, relates)
+
+	links, err := s.Links(context.Background(), a.Id)
+	if err != nil {
+		t.Fatalf("Links: %v", err)
+	}
+	if len(links) != 3 {
+		t.Fatalf("Links(a) returned %d links, want 3", len(links))
+	}
+	for i, want := range []*ticketpb.TicketLink{blocks, parent, relates} {
+		if !proto.Equal(links[i], want) {
+			t.Errorf("link %d = %v, want %v", i, links[i], want)
+		}
+	}
+
+	links, err = s.Links(context.Background(), b.Id)
+	if err != nil {
+		t.Fatalf("Links: %v", err)
+	}
+	if len(links) != 2 {
+		t.Errorf("Links(b) returned %d links, want 2", len(links))
+	}
+
+	if err := s.Unlink(context.Background(), a.Id, b.Id, ticketpb.LinkType_LINK_TYPE_BLOCKS); err != nil {
+		t.Fatalf("Unlink: %v", err)
+	}
+	links, err = s.Links(context.Background(), b.Id)
+	if err != nil {
+		t.Fatalf("Links: %v", err)
+	}
+	if len(links) != 1 || !proto.Equal(links[0], relates) {
+		t.Errorf("Links(b) after Unlink = %v, want [%v]", links, relates)
+	}
+}
+
+func testLinkErrors(t *testing.T, s store.TicketStore) {
+	a, b := newTicket(0), newTicket(time.Minute)
+	mustCreate(t, s, a, b)
+	mustLink(t, s, newLink(a, b, ticketpb.LinkType_LINK_TYPE_DUPLICATES, 0))
+
+	if err := s.Link(context.Background(), newLink(a, b, ticketpb.LinkType_LINK_TYPE_DUPLICATES, time.Minute)); !errors.Is(err, store.ErrLinkExists) {
+		t.Errorf("duplicate Link error = %v, want ErrLinkExists", err)
+	}
+	missing := &ticketpb.Ticket{Id: uuid.New().String()}
+	if err := s.Link(context.Background(), newLink(a, missing, ticketpb.LinkType_LINK_TYPE_BLOCKS, 0)); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Link to a missing ticket error = %v, want ErrNotFound", err)
+	}
+	if err := s.Unlink(context.Background(), b.Id, a.Id, ticketpb.LinkType_LINK_TYPE_DUPLICATES); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Unlink of a missing link error = %v, want ErrNotFound", err)
+	}
+	links, err := s.Links(context.Background(), uuid.New().String())
+	if err != nil || len(links) != 0 {
+		t.Errorf("Links of an unknown ticket = %v, %v; want none", links, err)
+	}
+}
+
+func testDeleteRemovesLinks(t *testing.T, s store.TicketStore) {
+	a, b, c := newTicket(0), newTicket(time.Minute), newTicket(2*time.Minute)
+	mustCreate(t, s, a, b, c)
+	mustLink(t, s,
+		newLink(a, b, ticketpb.LinkType_LINK_TYPE_BLOCKS, 0),
+		newLink(c, a, ticketpb.LinkType_LINK_TYPE_PARENT_OF, time.Minute),
+		newLink(b, c, ticketpb.LinkType_LINK_TYPE_RELATES_TO, 2*time.Minute),
+	)
+
+	if err := s.Delete(context.Background(), a.Id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	for _, ticket := range []*ticketpb.Ticket{b, c} {
+		links, err := s.Links(context.Background(), ticket.Id)
+		if err != nil {
+			t.Fatalf("Links: %v", err)
+		}
+		if len(links) != 1 || links[0].Type != ticketpb.LinkType_LINK_TYPE_RELATES_TO {
+			t.Errorf("links of %s after deleting a = %v, want only the relates-to link", ticket.Id, links)
+		}
+	}
+}
+
+func newProject(key string) *ticketpb.Project {
+	return &ticketpb.Project{
+		Id:          uuid.New().String(),
+		Key:         key,
+		Name:        key + " project",
+		Description: "Conformance project",
+		CreatedAt:   timestamppb.New(base),
+	}
+}
+
+func mustCreateProject(t *testing.T, s store.TicketStore, projects ...*ticketpb.Project) {
+	t.Helper()
+	for _, project := range projects {
+		if err := s.CreateProject(context.Background(), project); err != nil {
+			t.Fatalf("CreateProject(%s): %v", project.Key, err)
+		}
+	}
+}
+
+// inProject returns a ticket created offset after base in a project
+func inProject(project *ticketpb.Project, offset time.Duration) *ticketpb.Ticket {
+	ticket := newTicket(offset)
+	ticket.ProjectId = project.Id
+	return ticket
+}
+
+func testProjects(t *testing.T, s store.TicketStore) {
+	web, api := newProject("WEB"), newProject("API")
+	mustCreateProject(t, s, web, api)
+
+	got, err := s.GetProject(context.Background(), web.Id)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if !proto.Equal(got, web) {
+		t.Errorf("GetProject = %v, want %v", got, web)
+	}
+	got, err = s.GetProjectByKey(context.Background(), "API")
+	if err != nil {
+		t.Fatalf("GetProjectByKey: %v", err)
+	}
+	if !proto.Equal(got, api) {
+		t.Errorf("GetProjectByKey = %v, want %v", got, api)
+	}
+
+	projects, err := s.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Key != "API" || projects[1].Key != "WEB" {
+		t.Errorf("ListProjects = %v, want API then WEB", projects)
+	}
+
+	if err := s.CreateProject(context.Background(), newProject("WEB")); !errors.Is(err, store.ErrProjectExists) {
+		t.Errorf("CreateProject with a taken key error = %v, want ErrProjectExists", err)
+	}
+	if _, err := s.GetProject(context.Background(), uuid.New().String()); !errors.Is(err, store.ErrProjectNotFound) {
+		t.Errorf("GetProject of a missing project error = %v, want ErrProjectNotFound", err)
+	}
+	if _, err := s.GetProjectByKey(context.Background(), "NOPE"); !errors.Is(err, store.ErrProjectNotFound) {
+		t.Errorf("GetProjectByKey of a missing project error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func testProjectTicketKeys(t *testing.T, s store.TicketStore) {
+	web, api := newProject("WEB"), newProject("API")
+	mustCreateProject(t, s, web, api)
+
+	first, second, other := inProject(web, 0), inProject(web, time.Minute), inProject(api, 2*time.Minute)
+	loose := newTicket(3 * time.Minute)
+	mustCreate(t, s, first, second, other, loose)
+	for ticket, want := range map[*ticketpb.Ticket]string{first: "WEB-1", second: "WEB-2", other: "API-1", loose: ""} {
+		if ticket.Key != want {
+			t.Errorf("Create assigned key %q, want %q", ticket.Key, want)
+		}
+	}
+
+	got, err := s.GetByKey(context.Background(), "WEB-2")
+	if err != nil {
+		t.Fatalf("GetByKey: %v", err)
+	}
+	assertEqual(t, got, second)
+	got, err = s.Get(context.Background(), first.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, got, first)
+	if _, err := s.GetByKey(context.Background(), "WEB-99"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByKey of a missing key error = %v, want ErrNotFound", err)
+	}
+
+	// Numbers are never reused
+	if err := s.Delete(context.Background(), second.Id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.GetByKey(context.Background(), "WEB-2"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByKey of a deleted ticket error = %v, want ErrNotFound", err)
+	}
+	third := inProject(web, 4*time.Minute)
+	mustCreate(t, s, third)
+	if third.Key != "WEB-3" {
+		t.Errorf("key after a delete = %q, want WEB-3", third.Key)
+	}
+	project, err := s.GetProject(context.Background(), web.Id)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if project.LastNumber != 3 {
+		t.Errorf("LastNumber = %d, want 3", project.LastNumber)
+	}
+
+	missing := newTicket(5 * time.Minute)
+	missing.ProjectId = uuid.New().String()
+	if err := s.Create(context.Background(), missing); !errors.Is(err, store.ErrProjectNotFound) {
+		t.Errorf("Create in a missing project error = %v, want ErrProjectNotFound", err)
+	}
+	if _, err := s.Get(context.Background(), missing.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("ticket of a missing project was stored: %v", err)
+	}
+}
+
+func testListFilterProject(t *testing.T, s store.TicketStore) {
+	web, api := newProject("WEB"), newProject("API")
+	mustCreateProject(t, s, web, api)
+	a, b, c, d := inProject(web, 0), inProject(api, time.Minute), inProject(web, 2*time.Minute), newTicket(3*time.Minute)
+	mustCreate(t, s, a, b, c, d)
+
+	tickets, next, err := s.List(context.Background(), store.ListOptions{ProjectID: web.Id, PageSize: 1})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(tickets); !slices.Equal(got, []string{c.Id}) || next == "" {
+		t.Fatalf("first page = %v (next %q), want [%s] and a next page", got, next, c.Id)
+	}
+	tickets, next, err = s.List(context.Background(), store.ListOptions{ProjectID: web.Id, PageSize: 1, PageToken: next})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(tickets); !slices.Equal(got, []string{a.Id}) || next != "" {
+		t.Errorf("second page = %v (next %q), want [%s] and no next page", got, next, a.Id)
+	}
+
+	tickets, _, err = s.List(context.Background(), store.ListOptions{ProjectID: uuid.New().String()})
+	if err != nil || len(tickets) != 0 {
+		t.Errorf("List of an unknown project = %v, %v; want none", ids(tickets), err)
+	}
+}
+
+func testCustomFields(t *testing.T, s store.TicketStore) {
+	ctx := context.Background()
+	web := newProject("WEB")
+	mustCreateProject(t, s, web)
+
+	fields := []*ticketpb.CustomFieldDefinition{
+		{Key: "environment", Name: "Environment", Type: ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_ENUM, Options: []string{"staging", "production"}, Required: true},
+		{Key: "story_points", Name: "Story points", Type: ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_NUMBER},
+	}
+	project, err := s.SetCustomFields(ctx, web.Id, fields)
+	if err != nil {
+		t.Fatalf("SetCustomFields: %v", err)
+	}
+	web.CustomFields = fields
+	if !proto.Equal(project, web) {
+		t.Errorf("SetCustomFields = %v, want %v", project, web)
+	}
+	if got, err := s.GetProject(ctx, web.Id); err != nil || !proto.Equal(got, web) {
+		t.Errorf("GetProject = %v, %v; want %v", got, err, web)
+	}
+	if _, err := s.SetCustomFields(ctx, uuid.New().String(), fields); !errors.Is(err, store.ErrProjectNotFound) {
+		t.Errorf("SetCustomFields of a missing project error = %v, want ErrProjectNotFound", err)
+	}
+
+	staging, production, plain := inProject(web, 0), inProject(web, time.Minute), inProject(web, 2*time.Minute)
+	staging.CustomFields = map[string]string{"environment": "staging", "story_points": "3"}
+	production.CustomFields = map[string]string{"environment": "production", "story_points": "3"}
+	mustCreate(t, s, staging, production, plain)
+
+	got, err := s.Get(ctx, staging.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, got, staging)
+
+	for _, tt := range []struct {
+		filter map[string]string
+		want   []string
+	}{
+		{map[string]string{"story_points": "3"}, []string{production.Id, staging.Id}},
+		{map[string]string{"story_points": "3", "environment": "staging"}, []string{staging.Id}},
+		{map[string]string{"environment": "qa"}, []string{}},
+		{map[string]string{"missing": "3"}, []string{}},
+	} {
+		tickets, _, err := s.List(ctx, store.ListOptions{CustomFields: tt.filter})
+		if err != nil {
+			t.Fatalf("List(%v): %v", tt.filter, err)
+		}
+		if fmt.Sprint(ids(tickets)) != fmt.Sprint(tt.want) {
+			t.Errorf("List(custom fields %v) = %v, want %v", tt.filter, ids(tickets), tt.want)
+		}
+	}
+
+	// Updates replace the values; other updates leave them alone
+	updated, err := s.Update(ctx, staging.Id, store.TicketUpdate{
+		CustomFields: map[string]string{"environment": "production"},
+		UpdatedAt:    base.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if fmt.Sprint(updated.CustomFields) != "map[environment:production]" {
+		t.Errorf("updated custom fields = %v, want map[environment:production]", updated.CustomFields)
+	}
+	title := "Renamed"
+	if _, err := s.Update(ctx, staging.Id, store.TicketUpdate{Title: &title, UpdatedAt: base.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err = s.Get(ctx, staging.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if fmt.Sprint(got.CustomFields) != "map[environment:production]" {
+		t.Errorf("custom fields after a title update = %v, want map[environment:production]", got.CustomFields)
+	}
+	tickets, _, err := s.List(ctx, store.ListOptions{CustomFields: map[string]string{"environment": "production"}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(tickets); !slices.Equal(got, []string{production.Id, staging.Id}) {
+		t.Errorf("List(environment production) = %v, want [%s %s]", got, production.Id, staging.Id)
+	}
+}
+
+func testConcurrentProjectCreates(t *testing.T, s store.TicketStore) {
+	const workers, perWorker = 8, 10
+	web := newProject("WEB")
+	mustCreateProject(t, s, web)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		keys []string
+	)
+	errs := make(chan error, workers*perWorker)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ticket := inProject(web, time.Duration(w*perWorker+i)*time.Second)
+				err := s.Create(context.Background(), ticket)
+				errs <- err
+				if err == nil {
+					mu.Lock()
+					keys = append(keys, ticket.Key)
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Create: %v", err)
+		}
+	}
+
+	// Every number from 1 to N is handed out exactly once
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			t.Fatalf("key %s assigned twice", key)
+		}
+		seen[key] = true
+	}
+	for n := int64(1); n <= workers*perWorker; n++ {
+		if !seen[store.TicketKey("WEB", n)] {
+			t.Errorf("key %s was never assigned", store.TicketKey("WEB", n))
+		}
+	}
+}
+
+// withSLA gives a ticket a due date and an SLA checked next at checkAt
+func withSLA(ticket *ticketpb.Ticket, checkAt time.Time) *ticketpb.Ticket {
+	created := ticket.CreatedAt.AsTime()
+	ticket.DueDate = timestamppb.New(created.Add(48 * time.Hour))
+	ticket.Sla = &ticketpb.TicketSLA{
+		FirstResponseDueAt: timestamppb.New(created.Add(time.Hour)),
+		ResolutionDueAt:    timestamppb.New(created.Add(24 * time.Hour)),
+		Status:             ticketpb.SLAStatus_SLA_STATUS_ON_TRACK,
+		NextCheckAt:        timestamppb.New(checkAt),
+	}
+	return ticket
+}
+
+func testSLA(t *testing.T, s store.TicketStore) {
+	ctx := context.Background()
+	acme := tenant.WithOrganization(ctx, "acme")
+
+	late, early, later, none := withSLA(newTicket(0), base.Add(time.Hour)), withSLA(newTicket(time.Minute), base.Add(30*time.Minute)),
+		withSLA(newTicket(2*time.Minute), base.Add(3*time.Hour)), newTicket(3*time.Minute)
+	if err := s.Create(acme, late); err != nil {
+		t.Fatalf("Create in acme: %v", err)
+	}
+	mustCreate(t, s, early, later, none)
+
+	got, err := s.Get(ctx, early.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	assertEqual(t, got, early)
+
+	// SLADue spans organizations, earliest check first
+	due, err := s.SLADue(ctx, base.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("SLADue: %v", err)
+	}
+	if got, want := ids(due), []string{early.Id, late.Id}; !slices.Equal(got, want) {
+		t.Fatalf("SLADue = %v, want %v", got, want)
+	}
+	assertEqual(t, due[1], late)
+	if due, err := s.SLADue(ctx, base.Add(2*time.Hour), 1); err != nil || len(due) != 1 || due[0].Id != early.Id {
+		t.Errorf("SLADue(limit 1) = %v, %v; want [%s]", ids(due), err, early.Id)
+	}
+
+	// Updates replace the SLA and set or clear the due date; other updates leave them alone
+	met := proto.Clone(early.Sla).(*ticketpb.TicketSLA)
+	met.FirstRespondedAt = timestamppb.New(base.Add(10 * time.Minute))
+	met.Status = ticketpb.SLAStatus_SLA_STATUS_MET
+	met.NextCheckAt = nil
+	var cleared time.Time
+	updated, err := s.Update(ctx, early.Id, store.TicketUpdate{SLA: met, DueDate: &cleared, UpdatedAt: base.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !proto.Equal(updated.Sla, met) || updated.DueDate != nil {
+		t.Errorf("updated SLA = %v, due date %v; want %v and no due date", updated.Sla, updated.DueDate, met)
+	}
+	dueDate := base.Add(72 * time.Hour)
+	title := "Renamed"
+	if _, err := s.Update(ctx, early.Id, store.TicketUpdate{DueDate: &dueDate, UpdatedAt: base.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := s.Update(ctx, early.Id, store.TicketUpdate{Title: &title, UpdatedAt: base.Add(3 * time.Hour)}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err = s.Get(ctx, early.Id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !proto.Equal(got.Sla, met) || !got.DueDate.AsTime().Equal(dueDate) {
+		t.Errorf("after a title update SLA = %v, due date %v; want %v and %v", got.Sla, got.DueDate, met, dueDate)
+	}
+	if due, err := s.SLADue(ctx, base.Add(2*time.Hour), 10); err != nil || !slices.Equal(ids(due), []string{late.Id}) {
+		t.Errorf("SLADue after the SLA was met = %v, %v; want [%s]", ids(due), err, late.Id)
+	}
+}
+
+func eventTypes(pending []events.Event) []events.Type {
+	out := make([]events.Type, len(pending))
+	for i, event := range pending {
+		out[i] = event.Type
+	}
+	return out
+}
+
+func testOutbox(t *testing.T, s store.TicketStore) {
+	ctx := context.Background()
+	acme := tenant.WithOrganization(ctx, "acme")
+
+	ticket, other := newTicket(0), newTicket(time.Minute)
+	mustCreate(t, s, ticket)
+	if err := s.Create(acme, other); err != nil {
+		t.Fatalf("Create in acme: %v", err)
+	}
+	status := ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
+	updated, err := s.Update(ctx, ticket.Id, store.TicketUpdate{Status: &status, UpdatedAt: base.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := s.Delete(acme, other.Id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// Events of every organization, in the order of their writes
+	pending, err := s.PendingEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+	want := []events.Type{events.TicketCreated, events.TicketCreated, events.TicketUpdated, events.TicketTransitioned, events.TicketDeleted}
+	if got := eventTypes(pending); !slices.Equal(got, want) {
+		t.Fatalf("PendingEvents = %v, want %v", got, want)
+	}
+	assertEqual(t, pending[0].Ticket, ticket)
+	assertEqual(t, pending[2].Ticket, updated)
+	assertEqual(t, pending[2].Previous, ticket)
+	assertEqual(t, pending[3].Previous, ticket)
+	assertEqual(t, pending[4].Ticket, other)
+	if pending[1].OrganizationID != "acme" || pending[4].OrganizationID != "acme" {
+		t.Errorf("acme events have organizations %q and %q", pending[1].OrganizationID, pending[4].OrganizationID)
+	}
+	if limited, err := s.PendingEvents(ctx, 2); err != nil || len(limited) != 2 || limited[0].ID != pending[0].ID {
+		t.Errorf("PendingEvents(limit 2) = %v, %v; want the first two events", eventTypes(limited), err)
+	}
+
+	// Published events leave the outbox; publishing one twice is not an error
+	for _, event := range pending[:2] {
+		for range 2 {
+			if err := s.MarkPublished(ctx, event); err != nil {
+				t.Fatalf("MarkPublished(%s): %v", event.Type, err)
+			}
+		}
+	}
+	rest, err := s.PendingEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+	if len(rest) != 3 || rest[0].ID != pending[2].ID {
+		t.Errorf("PendingEvents after publishing = %v, want the last three events", eventTypes(rest))
+	}
+}
+
+func testWithoutEvents(t *testing.T, s store.TicketStore) {
+	ctx := events.WithoutEvents(context.Background())
+
+	ticket := newTicket(0)
+	if err := s.Create(ctx, ticket); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := s.Get(context.Background(), ticket.Id); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	pending, err := s.PendingEvents(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("PendingEvents = %v, want none", eventTypes(pending))
+	}
+
+	// Later writes record their events again
+	status := ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS
+	if _, err := s.Update(context.Background(), ticket.Id, store.TicketUpdate{Status: &status, UpdatedAt: base.Add(time.Hour)}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if pending, err := s.PendingEvents(context.Background(), 10); err != nil || len(pending) == 0 {
+		t.Errorf("PendingEvents after Update = %v, %v; want its events", eventTypes(pending), err)
+	}
+}
+
+func testTenantIsolation(t *testing.T, s store.TicketStore) {
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+
+	// Both organizations may use the same project key and ticket keys
+	acmeWeb, globexWeb := newProject("WEB"), newProject("WEB")
+	for ctx, project := range map[context.Context]*ticketpb.Project{acme: acmeWeb, globex: globexWeb} {
+		if err := s.CreateProject(ctx, project); err != nil {
+			t.Fatalf("CreateProject(%s): %v", tenant.ID(ctx), err)
+		}
+	}
+	secret, blocker, mine := inProject(acmeWeb, 0), newTicket(time.Minute), inProject(globexWeb, 2*time.Minute)
+	secret.Tags = []string{"secret"}
+	for _, ticket := range []*ticketpb.Ticket{secret, blocker} {
+		if err := s.Create(acme, ticket); err != nil {
+			t.Fatalf("Create in acme: %v", err)
+		}
+	}
+	if err := s.Create(globex, mine); err != nil {
+		t.Fatalf("Create in globex: %v", err)
+	}
+	if secret.OrganizationId != "acme" || mine.OrganizationId != "globex" {
+		t.Fatalf("Create set organizations %q and %q, want acme and globex", secret.OrganizationId, mine.OrganizationId)
+	}
+	if secret.Key != "WEB-1" || mine.Key != "WEB-1" {
+		t.Errorf("keys = %q and %q, want WEB-1 in both organizations", secret.Key, mine.Key)
+	}
+	if err := s.Link(acme, newLink(blocker, secret, ticketpb.LinkType_LINK_TYPE_BLOCKS, 0)); err != nil {
+		t.Fatalf("Link in acme: %v", err)
+	}
+
+	// globex cannot read acme's data
+	if _, err := s.Get(globex, secret.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Get of another organization's ticket error = %v, want ErrNotFound", err)
+	}
+	got, err := s.GetByKey(globex, "WEB-1")
+	if err != nil {
+		t.Fatalf("GetByKey: %v", err)
+	}
+	assertEqual(t, got, mine)
+	tickets, _, err := s.List(globex, store.ListOptions{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got := ids(tickets); !slices.Equal(got, []string{mine.Id}) {
+		t.Errorf("List in globex = %v, want only [%s]", got, mine.Id)
+	}
+	if links, err := s.Links(globex, secret.Id); err != nil || len(links) != 0 {
+		t.Errorf("Links of another organization's ticket = %v, %v; want none", links, err)
+	}
+	tags, err := s.Tags(globex, store.TagQuery{})
+	if err != nil {
+		t.Fatalf("Tags: %v", err)
+	}
+	if got := tagCounts(tags); got != "[bug:1 urgent:1]" {
+		t.Errorf("Tags in globex = %s, want [bug:1 urgent:1]", got)
+	}
+	if _, err := s.GetProject(globex, acmeWeb.Id); !errors.Is(err, store.ErrProjectNotFound) {
+		t.Errorf("GetProject of another organization's project error = %v, want ErrProjectNotFound", err)
+	}
+	projects, err := s.ListProjects(globex)
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Id != globexWeb.Id {
+		t.Errorf("ListProjects in globex = %v, want only its own project", projects)
+	}
+
+	// ...nor change it
+	title := "Stolen"
+	if _, err := s.Update(globex, secret.Id, store.TicketUpdate{Title: &title, UpdatedAt: base}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Update of another organization's ticket error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(globex, secret.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Delete of another organization's ticket error = %v, want ErrNotFound", err)
+	}
+	if err := s.Link(globex, newLink(mine, secret, ticketpb.LinkType_LINK_TYPE_RELATES_TO, 0)); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Link to another organization's ticket error = %v, want ErrNotFound", err)
+	}
+	if err := s.Unlink(globex, blocker.Id, secret.Id, ticketpb.LinkType_LINK_TYPE_BLOCKS); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Unlink of another organization's link error = %v, want ErrNotFound", err)
+	}
+	if changed, err := s.MergeTags(globex, []string{"secret"}, "leaked", base); err != nil || changed != 0 {
+		t.Errorf("MergeTags over another organization's tickets = %d, %v; want 0", changed, err)
+	}
+	stray := newTicket(3 * time.Minute)
+	stray.ProjectId = acmeWeb.Id
+	if err := s.Create(globex, stray); !errors.Is(err, store.ErrProjectNotFound) {
+		t.Errorf("Create in another organization's project error = %v, want ErrProjectNotFound", err)
+	}
+
+	// acme's data is untouched, and the default organization sees none of it
+	got, err = s.Get(acme, secret.Id)
+	if err != nil {
+		t.Fatalf("Get in acme: %v", err)
+	}
+	assertEqual(t, got, secret)
+	if links, err := s.Links(acme, secret.Id); err != nil || len(links) != 1 {
+		t.Errorf("Links in acme = %v, %v; want the blocks link", links, err)
+	}
+	if tickets, _, err := s.List(context.Background(), store.ListOptions{}); err != nil || len(tickets) != 0 {
+		t.Errorf("List in the default organization = %v, %v; want none", ids(tickets), err)
+	}
+}
@@ -0,0 +1,371 @@
+package storetest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WebhookFactory returns an empty webhook store for a single test
+type WebhookFactory func(t *testing.T) webhooks.Store
+
+// RunWebhooks runs the conformance suite of webhooks.Store against stores
+// created by newStore
+func RunWebhooks(t *testing.T, newStore WebhookFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s webhooks.Store)
+	}{
+		{"Webhooks", testWebhooks},
+		{"Deliveries", testDeliveries},
+		{"DueDeliveries", testDueDeliveries},
+		{"DeleteWebhookRemovesDeliveries", testDeleteWebhookRemovesDeliveries},
+		{"WebhookTenantIsolation", testWebhookTenantIsolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+// newWebhook returns an active webhook created offset after base
+func newWebhook(offset time.Duration) *ticketpb.Webhook {
+	created := timestamppb.New(base.Add(offset).Truncate(time.Microsecond))
+	return &ticketpb.Webhook{
+		Id:         uuid.New().String(),
+		Url:        "https://hooks.example.com/" + offset.String(),
+		Secret:     "s3cret",
+		EventTypes: []string{"ticket.created", "ticket.deleted"},
+		Active:     true,
+		CreatedAt:  created,
+		UpdatedAt:  created,
+	}
+}
+
+// newDelivery returns a pending delivery to webhook created offset after
+// base, due at its creation
+func newDelivery(webhook *ticketpb.Webhook, offset time.Duration) *ticketpb.WebhookDelivery {
+	created := timestamppb.New(base.Add(offset).Truncate(time.Microsecond))
+	return &ticketpb.WebhookDelivery{
+		Id:            uuid.New().String(),
+		WebhookId:     webhook.Id,
+		EventId:       uuid.New().String(),
+		EventType:     "ticket.created",
+		Payload:       `{"type":"ticket.created"}`,
+		Status:        ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING,
+		NextAttemptAt: created,
+		CreatedAt:     created,
+	}
+}
+
+func mustCreateWebhook(t *testing.T, ctx context.Context, s webhooks.Store, webhooks ...*ticketpb.Webhook) {
+	t.Helper()
+	for _, webhook := range webhooks {
+		if err := s.CreateWebhook(ctx, webhook); err != nil {
+			t.Fatalf("CreateWebhook(%s): %v", webhook.Id, err)
+		}
+	}
+}
+
+func mustCreateDeliveries(t *testing.T, ctx context.Context, s webhooks.Store, deliveries ...*ticketpb.WebhookDelivery) {
+	t.Helper()
+	if err := s.CreateDeliveries(ctx, deliveries); err != nil {
+		t.Fatalf("CreateDeliveries: %v", err)
+	}
+}
+
+func assertProtoEqual(t *testing.T, got, want proto.Message) {
+	t.Helper()
+	if !proto.Equal(got, want) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func deliveryIDs(deliveries []*ticketpb.WebhookDelivery) []string {
+	out := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		out[i] = delivery.Id
+	}
+	return out
+}
+
+func testWebhooks(t *testing.T, s webhooks.Store) {
+	ctx := context.Background()
+	first, second := newWebhook(time.Minute), newWebhook(0)
+	first.Filter = &ticketpb.WebhookFilter{
+		ProjectId:  uuid.New().String(),
+		Tags:       []string{"bug"},
+		Priorities: []ticketpb.TicketPriority{ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL},
+	}
+	mustCreateWebhook(t, ctx, s, first, second)
+	if first.OrganizationId != tenant.Default {
+		t.Errorf("CreateWebhook set organization %q, want %q", first.OrganizationId, tenant.Default)
+	}
+
+	got, err := s.GetWebhook(ctx, first.Id)
+	if err != nil {
+		t.Fatalf("GetWebhook: %v", err)
+	}
+	assertProtoEqual(t, got, first)
+
+	list, err := s.ListWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("ListWebhooks: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("ListWebhooks returned %d webhooks, want 2", len(list))
+	}
+	assertProtoEqual(t, list[0], second)
+	assertProtoEqual(t, list[1], first)
+
+	// Updates replace every setting, the filter included
+	first.Url = "https://hooks.example.com/moved"
+	first.Secret = "rotated"
+	first.EventTypes = []string{"ticket.updated"}
+	first.Filter = nil
+	first.Active = false
+	first.UpdatedAt = timestamppb.New(base.Add(time.Hour))
+	if err := s.UpdateWebhook(ctx, first); err != nil {
+		t.Fatalf("UpdateWebhook: %v", err)
+	}
+	got, err = s.GetWebhook(ctx, first.Id)
+	if err != nil {
+		t.Fatalf("GetWebhook after update: %v", err)
+	}
+	assertProtoEqual(t, got, first)
+
+	missing := newWebhook(0)
+	for _, id := range []string{missing.Id, "not-a-uuid"} {
+		if _, err := s.GetWebhook(ctx, id); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("GetWebhook(%q) error = %v, want ErrNotFound", id, err)
+		}
+		if err := s.DeleteWebhook(ctx, id); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("DeleteWebhook(%q) error = %v, want ErrNotFound", id, err)
+		}
+	}
+	if err := s.UpdateWebhook(ctx, missing); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateWebhook of a missing webhook error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.DeleteWebhook(ctx, second.Id); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	if _, err := s.GetWebhook(ctx, second.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetWebhook after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func testDeliveries(t *testing.T, s webhooks.Store) {
+	ctx := context.Background()
+	webhook, other := newWebhook(0), newWebhook(time.Minute)
+	mustCreateWebhook(t, ctx, s, webhook, other)
+
+	oldest, middle, newest := newDelivery(webhook, 0), newDelivery(webhook, time.Minute), newDelivery(webhook, 2*time.Minute)
+	mustCreateDeliveries(t, ctx, s, oldest, middle, newest, newDelivery(other, 0))
+
+	// Another delivery of an event its webhook already has is skipped
+	duplicate := newDelivery(webhook, 3*time.Minute)
+	duplicate.EventId = middle.EventId
+	mustCreateDeliveries(t, ctx, s, duplicate)
+	if _, err := s.GetDelivery(ctx, duplicate.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetDelivery of a duplicate delivery error = %v, want ErrNotFound", err)
+	}
+
+	got, err := s.GetDelivery(ctx, middle.Id)
+	if err != nil {
+		t.Fatalf("GetDelivery: %v", err)
+	}
+	assertProtoEqual(t, got, middle)
+
+	// A failed attempt, then the dead-letter list
+	attempted := timestamppb.New(base.Add(time.Hour))
+	oldest.Attempts = 3
+	oldest.LastAttemptAt = attempted
+	oldest.LastStatusCode = 500
+	oldest.LastError = "unexpected response status 500 Internal Server Error"
+	oldest.Status = ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD
+	oldest.NextAttemptAt = nil
+	newest.Attempts = 1
+	newest.LastAttemptAt = attempted
+	newest.LastStatusCode = 204
+	newest.Status = ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+	newest.NextAttemptAt = nil
+	newest.DeliveredAt = attempted
+	for _, delivery := range []*ticketpb.WebhookDelivery{oldest, newest} {
+		if err := s.UpdateDelivery(ctx, delivery); err != nil {
+			t.Fatalf("UpdateDelivery: %v", err)
+		}
+		got, err := s.GetDelivery(ctx, delivery.Id)
+		if err != nil {
+			t.Fatalf("GetDelivery after update: %v", err)
+		}
+		assertProtoEqual(t, got, delivery)
+	}
+
+	tests := []struct {
+		status ticketpb.WebhookDeliveryStatus
+		limit  int
+		want   []string
+	}{
+		{ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED, 10, []string{newest.Id, middle.Id, oldest.Id}},
+		{ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED, 2, []string{newest.Id, middle.Id}},
+		{ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD, 10, []string{oldest.Id}},
+		{ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING, 10, []string{middle.Id}},
+	}
+	for _, tt := range tests {
+		list, err := s.ListDeliveries(ctx, webhook.Id, tt.status, tt.limit)
+		if err != nil {
+			t.Fatalf("ListDeliveries(%s): %v", tt.status, err)
+		}
+		if got := deliveryIDs(list); !slices.Equal(got, tt.want) {
+			t.Errorf("ListDeliveries(%s, %d) = %v, want %v", tt.status, tt.limit, got, tt.want)
+		}
+	}
+
+	missing := newWebhook(0)
+	if _, err := s.ListDeliveries(ctx, missing.Id, 0, 10); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("ListDeliveries of a missing webhook error = %v, want ErrNotFound", err)
+	}
+	if err := s.CreateDeliveries(ctx, []*ticketpb.WebhookDelivery{newDelivery(missing, 0)}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("CreateDeliveries to a missing webhook error = %v, want ErrNotFound", err)
+	}
+	if err := s.UpdateDelivery(ctx, newDelivery(webhook, 0)); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateDelivery of a missing delivery error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.GetDelivery(ctx, uuid.New().String()); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetDelivery of a missing delivery error = %v, want ErrNotFound", err)
+	}
+}
+
+func testDueDeliveries(t *testing.T, s webhooks.Store) {
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+	acmeHook, globexHook := newWebhook(0), newWebhook(0)
+	mustCreateWebhook(t, acme, s, acmeHook)
+	mustCreateWebhook(t, globex, s, globexHook)
+
+	late := newDelivery(acmeHook, 2*time.Minute)
+	early := newDelivery(globexHook, time.Minute)
+	future := newDelivery(acmeHook, time.Hour)
+	dead := newDelivery(globexHook, 0)
+	dead.Status = ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD
+	dead.NextAttemptAt = nil
+	mustCreateDeliveries(t, acme, s, late, future)
+	mustCreateDeliveries(t, globex, s, early, dead)
+
+	// Due deliveries of every organization, earliest first
+	now := base.Add(10 * time.Minute)
+	due, err := s.DueDeliveries(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("DueDeliveries: %v", err)
+	}
+	if got, want := deliveryIDs(due), []string{early.Id, late.Id}; !slices.Equal(got, want) {
+		t.Fatalf("DueDeliveries = %v, want %v", got, want)
+	}
+	assertProtoEqual(t, due[1], late)
+	if due[0].OrganizationId != "globex" || due[1].OrganizationId != "acme" {
+		t.Errorf("DueDeliveries organizations = %q and %q, want globex and acme", due[0].OrganizationId, due[1].OrganizationId)
+	}
+
+	due, err = s.DueDeliveries(context.Background(), now, 1)
+	if err != nil {
+		t.Fatalf("DueDeliveries with limit: %v", err)
+	}
+	if got := deliveryIDs(due); !slices.Equal(got, []string{early.Id}) {
+		t.Errorf("DueDeliveries with limit 1 = %v, want [%s]", got, early.Id)
+	}
+
+	// Moving the next attempt takes a delivery out of the due list
+	early.Attempts = 1
+	early.NextAttemptAt = timestamppb.New(now.Add(time.Minute))
+	if err := s.UpdateDelivery(globex, early); err != nil {
+		t.Fatalf("UpdateDelivery: %v", err)
+	}
+	due, err = s.DueDeliveries(context.Background(), now, 10)
+	if err != nil {
+		t.Fatalf("DueDeliveries after update: %v", err)
+	}
+	if got := deliveryIDs(due); !slices.Equal(got, []string{late.Id}) {
+		t.Errorf("DueDeliveries after update = %v, want [%s]", got, late.Id)
+	}
+}
+
+func testDeleteWebhookRemovesDeliveries(t *testing.T, s webhooks.Store) {
+	ctx := context.Background()
+	webhook := newWebhook(0)
+	mustCreateWebhook(t, ctx, s, webhook)
+	delivery := newDelivery(webhook, 0)
+	mustCreateDeliveries(t, ctx, s, delivery)
+
+	if err := s.DeleteWebhook(ctx, webhook.Id); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	if _, err := s.GetDelivery(ctx, delivery.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetDelivery after deleting its webhook error = %v, want ErrNotFound", err)
+	}
+	due, err := s.DueDeliveries(ctx, base.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("DueDeliveries: %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("DueDeliveries after deleting the webhook = %v, want none", deliveryIDs(due))
+	}
+}
+
+func testWebhookTenantIsolation(t *testing.T, s webhooks.Store) {
+	acme := tenant.WithOrganization(context.Background(), "acme")
+	globex := tenant.WithOrganization(context.Background(), "globex")
+	webhook := newWebhook(0)
+	mustCreateWebhook(t, acme, s, webhook)
+	delivery := newDelivery(webhook, 0)
+	mustCreateDeliveries(t, acme, s, delivery)
+
+	if _, err := s.GetWebhook(globex, webhook.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetWebhook of another organization's webhook error = %v, want ErrNotFound", err)
+	}
+	list, err := s.ListWebhooks(globex)
+	if err != nil {
+		t.Fatalf("ListWebhooks: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListWebhooks returned %d webhooks of another organization", len(list))
+	}
+	stolen := proto.Clone(webhook).(*ticketpb.Webhook)
+	stolen.Url = "https://evil.example.com"
+	if err := s.UpdateWebhook(globex, stolen); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateWebhook of another organization's webhook error = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteWebhook(globex, webhook.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("DeleteWebhook of another organization's webhook error = %v, want ErrNotFound", err)
+	}
+	if err := s.CreateDeliveries(globex, []*ticketpb.WebhookDelivery{newDelivery(webhook, 0)}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("CreateDeliveries to another organization's webhook error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.GetDelivery(globex, delivery.Id); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetDelivery of another organization's delivery error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.ListDeliveries(globex, webhook.Id, 0, 10); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("ListDeliveries of another organization's webhook error = %v, want ErrNotFound", err)
+	}
+	if err := s.UpdateDelivery(globex, delivery); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateDelivery of another organization's delivery error = %v, want ErrNotFound", err)
+	}
+
+	got, err := s.GetWebhook(acme, webhook.Id)
+	if err != nil {
+		t.Fatalf("GetWebhook in acme: %v", err)
+	}
+	assertProtoEqual(t, got, webhook)
+}
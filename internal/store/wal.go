@@ -0,0 +1,168 @@
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Record framing shared by the write-ahead log and snapshots of DurableStore.
+// Every record is
+//
+//	length uint32 | crc32c uint32 | op byte | payload
+//
+// where length counts the op byte and the payload, and the checksum covers both.
+
+const (
+	// recordPut stores the full state of a ticket (protobuf encoded)
+	recordPut byte = 1
+	// recordDelete removes the ticket whose ID is the payload
+	recordDelete byte = 2
+	// recordEnd closes a snapshot; the payload is the number of records before it as a uvarint
+	recordEnd byte = 3
+	// recordLink stores a link (protobuf encoded)
+	recordLink byte = 4
+	// recordUnlink removes a link (protobuf encoded)
+	recordUnlink byte = 5
+	// recordProject stores the full state of a project, including its ticket counter (protobuf encoded)
+	recordProject byte = 6
+	// recordEvent adds an event to the outbox (JSON encoded)
+	recordEvent byte = 7
+	// recordPublished removes the outbox event whose ID is the payload
+	recordPublished byte = 8
+)
+
+const recordHeaderSize = 8
+
+// ErrCorrupt is returned when a data file fails its integrity checks
+var ErrCorrupt = errors.New("corrupt data file")
+
+// errTornRecord marks an incomplete record at the end of a file, left by a
+// crash in the middle of a write
+var errTornRecord = errors.New("incomplete record")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// encodeRecord frames a record
+func encodeRecord(op byte, payload []byte) []byte {
+	buf := make([]byte, recordHeaderSize+1+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(1+len(payload)))
+	buf[recordHeaderSize] = op
+	copy(buf[recordHeaderSize+1:], payload)
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.Checksum(buf[recordHeaderSize:], crcTable))
+	return buf
+}
+
+// decodeRecords calls fn for every record in data. It returns the length of
+// the valid prefix of data along with errTornRecord when the data ends in the
+// middle of its last record, or ErrCorrupt when a damaged record is followed
+// by an intact one: a crash only ever tears the end of a file, so damage
+// anywhere else must not be discarded as a torn write.
+func decodeRecords(data []byte, fn func(op byte, payload []byte) error) (int, error) {
+	offset := 0
+	for offset < len(data) {
+		rest := data[offset:]
+		if len(rest) < recordHeaderSize {
+			return offset, errTornRecord
+		}
+		length := int(binary.LittleEndian.Uint32(rest[0:4]))
+		if length < 1 || length > len(rest)-recordHeaderSize {
+			if intactRecordIn(rest[1:]) {
+				return offset, fmt.Errorf("%w: invalid record length %d at offset %d", ErrCorrupt, length, offset)
+			}
+			return offset, errTornRecord
+		}
+		body := rest[recordHeaderSize : recordHeaderSize+length]
+		if crc32.Checksum(body, crcTable) != binary.LittleEndian.Uint32(rest[4:8]) {
+			if offset+recordHeaderSize+length == len(data) {
+				return offset, errTornRecord
+			}
+			return offset, fmt.Errorf("%w: checksum mismatch at offset %d", ErrCorrupt, offset)
+		}
+		if err := fn(body[0], body[1:]); err != nil {
+			return offset, fmt.Errorf("%w: record at offset %d: %v", ErrCorrupt, offset, err)
+		}
+		offset += recordHeaderSize + length
+	}
+	return offset, nil
+}
+
+// intactRecordIn reports whether a record with a valid checksum starts
+// anywhere in data. It reads data once: the checksum of a candidate record
+// is derived from the checksums of the prefixes of data without reading the
+// record again, so the scan stays linear however many offsets look like the
+// start of a long record.
+func intactRecordIn(data []byte) bool {
+	// prefix[k] is the checksum of data[:k]
+	prefix := make([]uint32, len(data)+1)
+	crc := ^uint32(0)
+	for k, b := range data {
+		crc = crcTable[byte(crc)^b] ^ crc>>8
+		prefix[k+1] = ^crc
+	}
+
+	for i := 0; i+recordHeaderSize < len(data); i++ {
+		rest := data[i:]
+		length := int(binary.LittleEndian.Uint32(rest[0:4]))
+		if length < 1 || length > len(rest)-recordHeaderSize {
+			continue
+		}
+		start, end := i+recordHeaderSize, i+recordHeaderSize+length
+		if checksumBetween(prefix[start], prefix[end], length) == binary.LittleEndian.Uint32(rest[4:8]) {
+			return true
+		}
+	}
+	return false
+}
+
+// checksumBetween returns the checksum of the n bytes following a prefix,
+// given the checksums of the prefix and of the prefix with those bytes, as
+// zlib's crc32_combine relates them
+func checksumBetween(prefix, whole uint32, n int) uint32 {
+	return whole ^ multiplyModP(powerOfX(n), prefix)
+}
+
+// castagnoli is the reflected CRC-32C polynomial
+const castagnoli = 0x82f63b78
+
+// multiplyModP multiplies two polynomials modulo the CRC polynomial, both
+// in reflected bit order
+func multiplyModP(a, b uint32) uint32 {
+	var product uint32
+	for m := uint32(1) << 31; m != 0; m >>= 1 {
+		if a&m != 0 {
+			product ^= b
+			if a&(m-1) == 0 {
+				break
+			}
+		}
+		if b&1 != 0 {
+			b = b>>1 ^ castagnoli
+		} else {
+			b >>= 1
+		}
+	}
+	return product
+}
+
+// bytePowers holds x^(8*2^k) modulo the CRC polynomial
+var bytePowers = func() (powers [64]uint32) {
+	powers[0] = 1 << 23 // x^8
+	for k := 1; k < len(powers); k++ {
+		powers[k] = multiplyModP(powers[k-1], powers[k-1])
+	}
+	return powers
+}()
+
+// powerOfX returns x^(8n) modulo the CRC polynomial: appending n zero bytes
+// multiplies a checksum by it
+func powerOfX(n int) uint32 {
+	power := uint32(1) << 31 // x^0
+	for k := 0; n > 0; k, n = k+1, n>>1 {
+		if n&1 != 0 {
+			power = multiplyModP(bytePowers[k], power)
+		}
+	}
+	return power
+}
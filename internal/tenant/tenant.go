@@ -0,0 +1,230 @@
+// Package tenant carries the organization a request acts for from the
+// GraphQL gateway, over gRPC metadata, down to the ticket stores.
+//
+// The organization is not authenticated here: whoever sets the header or
+// metadata acts for that organization. Deploy the gateway behind a proxy that
+// authenticates callers and sets the header, and pass the proxy's addresses
+// as trusted so other clients cannot name an organization; likewise only let
+// the gateways reach the ticket service. With no trusted addresses no peer
+// may name an organization, and every request acts for Default.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Default owns the data written before organizations existed and serves
+	// requests that name no organization when one is not required
+	Default = "default"
+	// Header is the default HTTP header naming the caller's organization
+	Header = "X-Organization-ID"
+	// MetadataKey is the gRPC metadata key carrying the organization
+	MetadataKey = "x-organization-id"
+)
+
+var (
+	ErrMissing   = errors.New("organization ID is required")
+	ErrInvalid   = errors.New("invalid organization ID")
+	ErrUntrusted = errors.New("organization ID is only accepted from trusted proxies")
+)
+
+// AllPeers trusts every peer to name an organization, for deployments where
+// the network alone keeps other clients away
+var AllPeers = []netip.Prefix{netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")}
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,62}$`)
+
+type contextKey struct{}
+
+// WithOrganization returns a context acting for the given organization
+func WithOrganization(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the organization set on ctx
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// ID returns the organization of ctx, or Default when none is set
+func ID(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return Default
+}
+
+// Parse validates an organization ID, falling back to Default for an empty
+// one unless an organization is required
+func Parse(id string, required bool) (string, error) {
+	id = strings.TrimSpace(id)
+	switch {
+	case id == "" && required:
+		return "", ErrMissing
+	case id == "":
+		return Default, nil
+	case !idPattern.MatchString(id):
+		return "", ErrInvalid
+	}
+	return id, nil
+}
+
+// ParseTrusted parses the addresses allowed to name an organization, each an
+// IP address or a CIDR prefix
+func ParseTrusted(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR prefix", value)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// Trusted reports whether the peer at addr (an IP address, with or without a
+// port) may name an organization. An empty trusted list trusts no peer.
+func Trusted(addr string, trusted []netip.Prefix) bool {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware puts the organization named by the header on the request
+// context. Requests without one are rejected when required is set, and
+// requests naming one from a peer outside trusted are forbidden. Responses
+// vary by the header so shared caches keep organizations apart.
+func Middleware(next http.Handler, header string, required bool, trusted []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", header)
+		raw := r.Header.Get(header)
+		if strings.TrimSpace(raw) != "" && !Trusted(r.RemoteAddr, trusted) {
+			http.Error(w, ErrUntrusted.Error()+" (the "+header+" header was set by "+r.RemoteAddr+")", http.StatusForbidden)
+			return
+		}
+		id, err := Parse(raw, required)
+		if err != nil {
+			code := http.StatusBadRequest
+			if errors.Is(err, ErrMissing) {
+				code = http.StatusUnauthorized
+			}
+			http.Error(w, err.Error()+" (set the "+header+" header)", code)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithOrganization(r.Context(), id)))
+	})
+}
+
+// UnaryClientInterceptor forwards the organization of the context as gRPC metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the organization of the context as gRPC metadata
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoing(ctx context.Context) context.Context {
+	if id, ok := FromContext(ctx); ok {
+		return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	}
+	return ctx
+}
+
+// UnaryServerInterceptor puts the organization named in the call metadata on
+// the handler context. Calls naming one from a peer outside trusted are
+// PermissionDenied.
+func UnaryServerInterceptor(required bool, trusted []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := incoming(ctx, required, trusted)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor puts the organization named in the call metadata on
+// the stream context, like UnaryServerInterceptor
+func StreamServerInterceptor(required bool, trusted []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := incoming(stream.Context(), required, trusted)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func incoming(ctx context.Context, required bool, trusted []netip.Prefix) (context.Context, error) {
+	var raw string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			raw = values[0]
+		}
+	}
+	if strings.TrimSpace(raw) != "" {
+		var addr string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			addr = p.Addr.String()
+		}
+		if !Trusted(addr, trusted) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v (%s was set by %q)", ErrUntrusted, MetadataKey, addr)
+		}
+	}
+	id, err := Parse(raw, required)
+	if errors.Is(err, ErrMissing) {
+		return nil, status.Errorf(codes.Unauthenticated, "%v (set the %s metadata)", err, MetadataKey)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v: %q", err, raw)
+	}
+	return WithOrganization(ctx, id), nil
+}
+
+// serverStream overrides the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
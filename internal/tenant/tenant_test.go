@@ -0,0 +1,185 @@
+package tenant_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func mustParseTrusted(t *testing.T, values ...string) []netip.Prefix {
+	t.Helper()
+	trusted, err := tenant.ParseTrusted(values)
+	if err != nil {
+		t.Fatalf("ParseTrusted(%v): %v", values, err)
+	}
+	return trusted
+}
+
+func TestTrusted(t *testing.T) {
+	trusted := mustParseTrusted(t, "10.0.0.0/8", "192.168.1.7", "fd00::/8")
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"10.1.2.3:4567", true},
+		{"10.1.2.3", true},
+		{"192.168.1.7:80", true},
+		{"192.168.1.8:80", false},
+		{"[::ffff:10.0.0.1]:80", true},
+		{"[fd00::1]:80", true},
+		{"203.0.113.9:80", false},
+		{"bufconn", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := tenant.Trusted(tt.addr, trusted); got != tt.want {
+			t.Errorf("Trusted(%q) = %t, want %t", tt.addr, got, tt.want)
+		}
+	}
+
+	if tenant.Trusted("10.0.0.1:80", nil) {
+		t.Error("an empty trusted list trusts a peer")
+	}
+	for _, addr := range []string{"203.0.113.9:80", "[2001:db8::1]:80", "[::ffff:10.0.0.1]:80"} {
+		if !tenant.Trusted(addr, tenant.AllPeers) {
+			t.Errorf("AllPeers does not trust %s", addr)
+		}
+	}
+	if _, err := tenant.ParseTrusted([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("ParseTrusted accepted an invalid prefix")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := tenant.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(tenant.ID(r.Context())))
+	}), tenant.Header, false, mustParseTrusted(t, "10.0.0.1"))
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		organization string
+		code         int
+		body         string
+	}{
+		{"trusted proxy", "10.0.0.1:1234", "acme", http.StatusOK, "acme"},
+		{"trusted proxy without header", "10.0.0.1:1234", "", http.StatusOK, tenant.Default},
+		{"untrusted client", "203.0.113.9:1234", "acme", http.StatusForbidden, ""},
+		{"untrusted client without header", "203.0.113.9:1234", "", http.StatusOK, tenant.Default},
+		{"invalid organization", "10.0.0.1:1234", "acme corp", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/query", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.organization != "" {
+				req.Header.Set(tenant.Header, tt.organization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.code {
+				t.Fatalf("got status %d (%s), want %d", rec.Code, rec.Body, tt.code)
+			}
+			if tt.code == http.StatusOK && rec.Body.String() != tt.body {
+				t.Errorf("got organization %q, want %q", rec.Body, tt.body)
+			}
+		})
+	}
+}
+
+// dial connects to a ticket service without the tenant client interceptor,
+// so each call sets its organization metadata explicitly
+func dial(t *testing.T, addr string) ticketpb.TicketServiceClient {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return ticketpb.NewTicketServiceClient(conn)
+}
+
+func as(organization string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, organization)
+}
+
+func TestGRPCIsolation(t *testing.T) {
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(false, tenant.AllPeers)),
+		grpc.ChainStreamInterceptor(tenant.StreamServerInterceptor(false, tenant.AllPeers)))
+	client := dial(t, service.Addr)
+
+	created, err := client.CreateTicket(as("acme"), &ticketpb.CreateTicketRequest{Title: "Acme only", Tags: []string{"secret"}})
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	id := created.Ticket.Id
+
+	if _, err := client.GetTicket(as("acme"), &ticketpb.GetTicketRequest{Id: id}); err != nil {
+		t.Fatalf("acme cannot read its ticket: %v", err)
+	}
+
+	for _, organization := range []string{"globex", tenant.Default} {
+		t.Run(organization, func(t *testing.T) {
+			ctx := as(organization)
+			if _, err := client.GetTicket(ctx, &ticketpb.GetTicketRequest{Id: id}); status.Code(err) != codes.NotFound {
+				t.Errorf("GetTicket = %v, want NotFound", err)
+			}
+			list, err := client.ListTickets(ctx, &ticketpb.ListTicketsRequest{})
+			if err != nil || len(list.Tickets) != 0 {
+				t.Errorf("ListTickets = %v, %v; want no tickets", list.GetTickets(), err)
+			}
+			tags, err := client.ListTags(ctx, &ticketpb.ListTagsRequest{})
+			if err != nil || len(tags.Tags) != 0 {
+				t.Errorf("ListTags = %v, %v; want no tags", tags.GetTags(), err)
+			}
+			if _, err := client.UpdateTicket(ctx, &ticketpb.UpdateTicketRequest{Id: id, Title: "Taken over"}); status.Code(err) != codes.NotFound {
+				t.Errorf("UpdateTicket = %v, want NotFound", err)
+			}
+			deleted, err := client.DeleteTicket(ctx, &ticketpb.DeleteTicketRequest{Id: id})
+			if err != nil || deleted.Success {
+				t.Errorf("DeleteTicket = %v, %v; want no deletion", deleted, err)
+			}
+		})
+	}
+
+	got, err := client.GetTicket(as("acme"), &ticketpb.GetTicketRequest{Id: id})
+	if err != nil || got.Ticket.Title != "Acme only" {
+		t.Fatalf("acme ticket after the other organizations' calls = %v, %v", got, err)
+	}
+}
+
+func TestGRPCTrustedProxies(t *testing.T) {
+	// The test client connects from 127.0.0.1, which is not trusted
+	trusted := mustParseTrusted(t, "10.0.0.0/8")
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(false, trusted)),
+		grpc.ChainStreamInterceptor(tenant.StreamServerInterceptor(false, trusted)))
+	client := dial(t, service.Addr)
+
+	if _, err := client.ListTickets(as("acme"), &ticketpb.ListTicketsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListTickets naming an organization = %v, want PermissionDenied", err)
+	}
+	if _, err := client.ListTickets(context.Background(), &ticketpb.ListTicketsRequest{}); err != nil {
+		t.Errorf("ListTickets without an organization: %v", err)
+	}
+
+	loopback := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(false, mustParseTrusted(t, "127.0.0.1"))))
+	if _, err := dial(t, loopback.Addr).ListTickets(as("acme"), &ticketpb.ListTicketsRequest{}); err != nil {
+		t.Errorf("ListTickets from a trusted peer: %v", err)
+	}
+}
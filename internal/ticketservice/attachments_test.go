@@ -0,0 +1,110 @@
+package ticketservice_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// upload attaches content to a ticket and returns the attachment ID
+func upload(t *testing.T, ctx context.Context, client ticketpb.TicketServiceClient, ticketID, content string) string {
+	t.Helper()
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	stream.Send(&ticketpb.UploadAttachmentRequest{Data: &ticketpb.UploadAttachmentRequest_Metadata{
+		Metadata: &ticketpb.AttachmentMetadata{TicketId: ticketID, Filename: "notes.txt"},
+	}})
+	stream.Send(&ticketpb.UploadAttachmentRequest{Data: &ticketpb.UploadAttachmentRequest_Chunk{Chunk: []byte(content)}})
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	return resp.Attachment.Id
+}
+
+// blobs counts the files in the blob store
+func blobs(t *testing.T, dir string) int {
+	t.Helper()
+	n := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			n++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to walk blob store: %v", err)
+	}
+	return n
+}
+
+func TestDeleteTicketDeletesAttachments(t *testing.T) {
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(false, tenant.AllPeers)),
+		grpc.ChainStreamInterceptor(tenant.StreamServerInterceptor(false, tenant.AllPeers)))
+	conn, err := grpc.NewClient(service.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := ticketpb.NewTicketServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "acme")
+
+	var ids []string
+	for _, title := range []string{"Single", "Bulk one", "Bulk two"} {
+		created, err := client.CreateTicket(ctx, &ticketpb.CreateTicketRequest{Title: title})
+		if err != nil {
+			t.Fatalf("CreateTicket: %v", err)
+		}
+		ids = append(ids, created.Ticket.Id)
+		upload(t, ctx, client, created.Ticket.Id, "notes on "+title)
+	}
+	attachment := upload(t, ctx, client, ids[0], "more notes")
+	if n := blobs(t, service.Blobs); n != 4 {
+		t.Fatalf("blob store holds %d files, want 4", n)
+	}
+
+	// Another organization cannot see the attachment
+	other := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "globex")
+	download, err := client.DownloadAttachment(other, &ticketpb.DownloadAttachmentRequest{Id: attachment})
+	if err == nil {
+		_, err = download.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("DownloadAttachment from another organization = %v, want NotFound", err)
+	}
+
+	if _, err := client.DeleteTicket(ctx, &ticketpb.DeleteTicketRequest{Id: ids[0]}); err != nil {
+		t.Fatalf("DeleteTicket: %v", err)
+	}
+	if n := blobs(t, service.Blobs); n != 2 {
+		t.Fatalf("blob store holds %d files after DeleteTicket, want 2", n)
+	}
+	if _, err := client.BulkDeleteTickets(ctx, &ticketpb.BulkDeleteTicketsRequest{Ids: ids[1:], Atomic: true}); err != nil {
+		t.Fatalf("BulkDeleteTickets: %v", err)
+	}
+	if n := blobs(t, service.Blobs); n != 0 {
+		t.Fatalf("blob store holds %d files after BulkDeleteTickets, want none", n)
+	}
+
+	// The metadata is gone with the content
+	download, err = client.DownloadAttachment(ctx, &ticketpb.DownloadAttachmentRequest{Id: attachment})
+	if err == nil {
+		_, err = download.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("DownloadAttachment of a deleted ticket = %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,214 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBulkTickets caps the tickets of one bulk request
+const maxBulkTickets = 500
+
+// errBulkAborted is the result of the tickets an atomic bulk request left
+// unchanged because another ticket failed
+var errBulkAborted = status.Error(codes.Aborted, "not changed: another ticket of the request failed")
+
+// BulkUpdateTickets applies one patch to many tickets. Failures are reported
+// per ticket; in atomic mode one failure leaves every ticket unchanged.
+func (s *Server) BulkUpdateTickets(ctx context.Context, req *ticketpb.BulkUpdateTicketsRequest) (*ticketpb.BulkUpdateTicketsResponse, error) {
+	log.Printf("gRPC: Bulk updating %d tickets - Atomic: %t", len(req.Ids), req.Atomic)
+
+	if err := checkBulkIDs(req.Ids); err != nil {
+		return nil, err
+	}
+	if req.Patch == nil || proto.Size(req.Patch) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "patch changes nothing")
+	}
+
+	var results []*ticketpb.BulkTicketResult
+	if req.Atomic {
+		var err error
+		if results, err = s.bulkUpdateAtomic(ctx, req.Ids, req.Patch); err != nil {
+			return nil, err
+		}
+	} else {
+		results = make([]*ticketpb.BulkTicketResult, len(req.Ids))
+		for i, id := range req.Ids {
+			resp, err := s.UpdateTicket(ctx, patchRequest(id, req.Patch))
+			results[i] = bulkResult(id, resp.GetTicket(), err)
+		}
+	}
+
+	log.Printf("gRPC: Bulk update finished - %d of %d tickets updated", succeeded(results), len(results))
+	return &ticketpb.BulkUpdateTicketsResponse{Results: results}, nil
+}
+
+// bulkUpdateAtomic validates the update of every ticket, then applies them
+// all in one store transaction
+func (s *Server) bulkUpdateAtomic(ctx context.Context, ids []string, patch *ticketpb.TicketPatch) ([]*ticketpb.BulkTicketResult, error) {
+	at := now()
+	// Tickets resolved together do not block each other
+	var resolving []string
+	if patch.Status == ticketpb.TicketStatus_TICKET_STATUS_RESOLVED {
+		resolving = ids
+	}
+
+	changes := make([]store.TicketChange, len(ids))
+	errs := make([]error, len(ids))
+	failed := false
+	for i, id := range ids {
+		update, err := s.ticketUpdate(ctx, patchRequest(id, patch), at, resolving)
+		changes[i], errs[i] = store.TicketChange{ID: id, Update: update}, err
+		failed = failed || err != nil
+	}
+	if !failed && movesSLA(changes[0].Update) {
+		s.slaMu.Lock()
+		defer s.slaMu.Unlock()
+		for i := range changes {
+			errs[i] = s.planSLA(ctx, changes[i].ID, &changes[i].Update)
+			failed = failed || errs[i] != nil
+		}
+	}
+	if failed {
+		return abortedResults(ids, errs), nil
+	}
+
+	tickets, err := s.store.BulkUpdate(ctx, changes)
+	var bulkErr *store.BulkError
+	if errors.As(err, &bulkErr) {
+		errs[bulkErr.Index] = storeError("update", bulkErr.ID, bulkErr.Err)
+		return abortedResults(ids, errs), nil
+	}
+	if err != nil {
+		log.Printf("gRPC: Error bulk updating tickets: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to update tickets: %v", err)
+	}
+
+	results := make([]*ticketpb.BulkTicketResult, len(ids))
+	for i, ticket := range tickets {
+		results[i] = bulkResult(ids[i], ticket, nil)
+	}
+	return results, nil
+}
+
+// BulkDeleteTickets deletes many tickets. Failures are reported per ticket;
+// in atomic mode one failure leaves every ticket in place.
+func (s *Server) BulkDeleteTickets(ctx context.Context, req *ticketpb.BulkDeleteTicketsRequest) (*ticketpb.BulkDeleteTicketsResponse, error) {
+	log.Printf("gRPC: Bulk deleting %d tickets - Atomic: %t", len(req.Ids), req.Atomic)
+
+	if err := checkBulkIDs(req.Ids); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ticketpb.BulkTicketResult, len(req.Ids))
+	if req.Atomic {
+		err := s.store.BulkDelete(ctx, req.Ids)
+		var bulkErr *store.BulkError
+		if errors.As(err, &bulkErr) {
+			errs := make([]error, len(req.Ids))
+			errs[bulkErr.Index] = storeError("delete", bulkErr.ID, bulkErr.Err)
+			return &ticketpb.BulkDeleteTicketsResponse{Results: abortedResults(req.Ids, errs)}, nil
+		}
+		if err != nil {
+			log.Printf("gRPC: Error bulk deleting tickets: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to delete tickets: %v", err)
+		}
+		for i, id := range req.Ids {
+			if err := s.comments.DeleteTicket(ctx, id); err != nil {
+				log.Printf("gRPC: Error deleting comments of ticket %s: %v", id, err)
+			}
+			if err := s.attachments.DeleteTicket(ctx, id); err != nil {
+				log.Printf("gRPC: Error deleting attachments of ticket %s: %v", id, err)
+			}
+			results[i] = bulkResult(id, nil, nil)
+		}
+	} else {
+		for i, id := range req.Ids {
+			resp, err := s.DeleteTicket(ctx, &ticketpb.DeleteTicketRequest{Id: id})
+			if err == nil && !resp.Success {
+				err = status.Errorf(codes.NotFound, "ticket not found: %s", id)
+			}
+			results[i] = bulkResult(id, nil, err)
+		}
+	}
+
+	log.Printf("gRPC: Bulk delete finished - %d of %d tickets deleted", succeeded(results), len(results))
+	return &ticketpb.BulkDeleteTicketsResponse{Results: results}, nil
+}
+
+// checkBulkIDs validates the ticket IDs of a bulk request
+func checkBulkIDs(ids []string) error {
+	if len(ids) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one ticket ID is required")
+	}
+	if len(ids) > maxBulkTickets {
+		return status.Errorf(codes.InvalidArgument, "at most %d tickets can be changed at once", maxBulkTickets)
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return status.Errorf(codes.InvalidArgument, "ticket %s is listed more than once", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// patchRequest returns the update request applying a patch to one ticket
+func patchRequest(id string, patch *ticketpb.TicketPatch) *ticketpb.UpdateTicketRequest {
+	return &ticketpb.UpdateTicketRequest{
+		Id:           id,
+		Title:        patch.Title,
+		Description:  patch.Description,
+		Status:       patch.Status,
+		Priority:     patch.Priority,
+		AssigneeId:   patch.AssigneeId,
+		Tags:         patch.Tags,
+		CustomFields: patch.CustomFields,
+		DueDate:      patch.DueDate,
+		ClearDueDate: patch.ClearDueDate,
+	}
+}
+
+// bulkResult builds the result of one ticket from its outcome
+func bulkResult(id string, ticket *ticketpb.Ticket, err error) *ticketpb.BulkTicketResult {
+	result := &ticketpb.BulkTicketResult{Id: id, Ticket: ticket}
+	if err != nil {
+		st := status.Convert(err)
+		result.Ticket = nil
+		result.ErrorCode = int32(st.Code())
+		result.ErrorMessage = st.Message()
+	}
+	return result
+}
+
+// abortedResults reports the failures of an atomic request, marking the
+// tickets that did not fail as aborted
+func abortedResults(ids []string, errs []error) []*ticketpb.BulkTicketResult {
+	results := make([]*ticketpb.BulkTicketResult, len(ids))
+	for i, id := range ids {
+		err := errs[i]
+		if err == nil {
+			err = errBulkAborted
+		}
+		results[i] = bulkResult(id, nil, err)
+	}
+	return results
+}
+
+// succeeded counts the tickets a bulk request changed
+func succeeded(results []*ticketpb.BulkTicketResult) int {
+	count := 0
+	for _, result := range results {
+		if result.ErrorCode == int32(codes.OK) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,107 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCommentLength caps the size of a comment body in bytes
+const maxCommentLength = 64 << 10
+
+// AddComment adds a comment to a ticket
+func (s *Server) AddComment(ctx context.Context, req *ticketpb.AddCommentRequest) (*ticketpb.AddCommentResponse, error) {
+	log.Printf("gRPC: Adding comment - Ticket: %s, Author: %s", req.TicketId, req.AuthorId)
+
+	author := strings.TrimSpace(req.AuthorId)
+	if author == "" {
+		return nil, status.Error(codes.InvalidArgument, "author_id is required")
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, status.Error(codes.InvalidArgument, "body is required")
+	}
+	if len(body) > maxCommentLength {
+		return nil, status.Errorf(codes.InvalidArgument, "body exceeds %d bytes", maxCommentLength)
+	}
+	if _, err := s.store.Get(ctx, req.TicketId); err != nil {
+		return nil, storeError("comment on", req.TicketId, err)
+	}
+
+	comment := &ticketpb.Comment{
+		Id:        uuid.New().String(),
+		TicketId:  req.TicketId,
+		AuthorId:  author,
+		Body:      body,
+		CreatedAt: timestamppb.New(now()),
+	}
+	if err := s.comments.AddComment(ctx, comment); err != nil {
+		return nil, storeError("comment on", req.TicketId, err)
+	}
+
+	log.Printf("gRPC: Comment added successfully - ID: %s, Ticket: %s", comment.Id, req.TicketId)
+	return &ticketpb.AddCommentResponse{Comment: comment}, nil
+}
+
+// ListComments lists the comments of a ticket, oldest first
+func (s *Server) ListComments(ctx context.Context, req *ticketpb.ListCommentsRequest) (*ticketpb.ListCommentsResponse, error) {
+	list, err := s.comments.ListComments(ctx, req.TicketId)
+	if err != nil {
+		return nil, storeError("list comments of", req.TicketId, err)
+	}
+	return &ticketpb.ListCommentsResponse{Comments: list}, nil
+}
+
+// FindEmailThread finds the ticket whose email thread holds one of the
+// given messages, the first one known
+func (s *Server) FindEmailThread(ctx context.Context, req *ticketpb.FindEmailThreadRequest) (*ticketpb.FindEmailThreadResponse, error) {
+	var messageIDs []string
+	for _, messageID := range req.MessageIds {
+		if messageID = emailMessageID(messageID); messageID != "" {
+			messageIDs = append(messageIDs, messageID)
+		}
+	}
+
+	ticketID, err := s.comments.FindThread(ctx, messageIDs)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "no ticket has these email messages")
+	}
+	if err != nil {
+		log.Printf("gRPC: Error finding email thread: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to find email thread: %v", err)
+	}
+	return &ticketpb.FindEmailThreadResponse{TicketId: ticketID}, nil
+}
+
+// RecordEmailMessage adds an email to the thread of a ticket, so replies to
+// it find the ticket
+func (s *Server) RecordEmailMessage(ctx context.Context, req *ticketpb.RecordEmailMessageRequest) (*ticketpb.RecordEmailMessageResponse, error) {
+	log.Printf("gRPC: Recording email message - ID: %s, Ticket: %s", req.MessageId, req.TicketId)
+
+	messageID := emailMessageID(req.MessageId)
+	if messageID == "" {
+		return nil, status.Error(codes.InvalidArgument, "message_id is required")
+	}
+	if _, err := s.store.Get(ctx, req.TicketId); err != nil {
+		return nil, storeError("record email message of", req.TicketId, err)
+	}
+	recorded, err := s.comments.RecordMessage(ctx, messageID, req.TicketId, now())
+	if err != nil {
+		return nil, storeError("record email message of", req.TicketId, err)
+	}
+
+	return &ticketpb.RecordEmailMessageResponse{Recorded: recorded}, nil
+}
+
+// emailMessageID normalizes a Message-ID, dropping its angle brackets
+func emailMessageID(messageID string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(messageID), "<"), ">")
+}
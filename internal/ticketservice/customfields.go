@@ -0,0 +1,250 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxCustomFields caps the number of custom fields of a project
+	maxCustomFields = 50
+	// maxCustomFieldValue caps the length of a custom field value
+	maxCustomFieldValue = 1000
+)
+
+// customFieldKeyPattern matches custom field keys
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// SetCustomFields replaces the custom field definitions of a project
+func (s *Server) SetCustomFields(ctx context.Context, req *ticketpb.SetCustomFieldsRequest) (*ticketpb.SetCustomFieldsResponse, error) {
+	log.Printf("gRPC: Setting %d custom fields of project %s", len(req.Fields), req.ProjectKey)
+
+	fields, err := validateDefinitions(req.Fields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	projectID, err := s.projectID(ctx, req.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.store.SetCustomFields(ctx, projectID, fields)
+	if err != nil {
+		return nil, projectError(req.ProjectKey, err)
+	}
+
+	log.Printf("gRPC: Custom fields of project %s updated", project.Key)
+	return &ticketpb.SetCustomFieldsResponse{Project: project}, nil
+}
+
+// validateDefinitions checks custom field definitions, returning them with
+// keys, names and options trimmed
+func validateDefinitions(fields []*ticketpb.CustomFieldDefinition) ([]*ticketpb.CustomFieldDefinition, error) {
+	if len(fields) > maxCustomFields {
+		return nil, fmt.Errorf("a project can have at most %d custom fields", maxCustomFields)
+	}
+
+	valid := make([]*ticketpb.CustomFieldDefinition, 0, len(fields))
+	keys := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		key := strings.TrimSpace(field.Key)
+		if !customFieldKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid custom field key %q: use lower-case letters, digits and underscores, starting with a letter", field.Key)
+		}
+		if keys[key] {
+			return nil, fmt.Errorf("duplicate custom field key %q", key)
+		}
+		keys[key] = true
+
+		name := strings.TrimSpace(field.Name)
+		if name == "" {
+			name = key
+		}
+
+		var options []string
+		for _, option := range field.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || slices.Contains(options, option) {
+				return nil, fmt.Errorf("custom field %q has an empty or duplicate option", key)
+			}
+			options = append(options, option)
+		}
+
+		switch field.Type {
+		case ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_ENUM:
+			if len(options) == 0 {
+				return nil, fmt.Errorf("enum custom field %q needs at least one option", key)
+			}
+		case ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_TEXT,
+			ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_NUMBER,
+			ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_DATE,
+			ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_USER:
+			if len(options) > 0 {
+				return nil, fmt.Errorf("only enum custom fields have options, %q is %s", key, store.CustomFieldTypeToDB(field.Type))
+			}
+		default:
+			return nil, fmt.Errorf("custom field %q has no type", key)
+		}
+
+		valid = append(valid, &ticketpb.CustomFieldDefinition{
+			Key:      key,
+			Name:     name,
+			Type:     field.Type,
+			Options:  options,
+			Required: field.Required,
+		})
+	}
+	return valid, nil
+}
+
+// normalizeCustomField validates a value of a custom field and returns it in
+// the canonical form of the field's type
+func normalizeCustomField(field *ticketpb.CustomFieldDefinition, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > maxCustomFieldValue {
+		return "", fmt.Errorf("custom field %q is longer than %d bytes", field.Key, maxCustomFieldValue)
+	}
+
+	switch field.Type {
+	case ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_NUMBER:
+		// ParseFloat also accepts hexadecimal, infinities and NaN
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(number, 0) || math.IsNaN(number) || strings.ContainsAny(value, "xX") {
+			return "", fmt.Errorf("custom field %q must be a number, got %q", field.Key, value)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_ENUM:
+		if !slices.Contains(field.Options, value) {
+			return "", fmt.Errorf("custom field %q must be one of %s, got %q", field.Key, strings.Join(field.Options, ", "), value)
+		}
+	case ticketpb.CustomFieldType_CUSTOM_FIELD_TYPE_DATE:
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return "", fmt.Errorf("custom field %q must be a date (YYYY-MM-DD), got %q", field.Key, value)
+		}
+		return date.Format(time.DateOnly), nil
+	}
+	return value, nil
+}
+
+// definition returns the definition of a project's custom field
+func definition(project *ticketpb.Project, key string) *ticketpb.CustomFieldDefinition {
+	for _, field := range project.CustomFields {
+		if field.Key == key {
+			return field
+		}
+	}
+	return nil
+}
+
+// customFieldValues validates the custom field values of a new ticket in
+// project, which is nil for tickets outside any project
+func customFieldValues(project *ticketpb.Project, values map[string]string) (map[string]string, error) {
+	if len(values) > 0 && project == nil {
+		return nil, errors.New("custom fields can only be set on tickets in a project")
+	}
+	if project == nil {
+		return nil, nil
+	}
+
+	normalized, err := applyCustomFields(project, nil, values)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range project.CustomFields {
+		if _, ok := normalized[field.Key]; field.Required && !ok {
+			return nil, fmt.Errorf("custom field %q is required", field.Key)
+		}
+	}
+	return normalized, nil
+}
+
+// applyCustomFields returns current with the given values set. An empty value
+// clears a field unless it is required. Values of fields the project no
+// longer defines are kept until they are cleared.
+func applyCustomFields(project *ticketpb.Project, current, values map[string]string) (map[string]string, error) {
+	result := maps.Clone(current)
+	if result == nil {
+		result = make(map[string]string, len(values))
+	}
+	for key, value := range values {
+		field := definition(project, key)
+		if strings.TrimSpace(value) == "" {
+			if field != nil && field.Required {
+				return nil, fmt.Errorf("custom field %q is required", key)
+			}
+			delete(result, key)
+			continue
+		}
+		if field == nil {
+			return nil, fmt.Errorf("project %s has no custom field %q", project.Key, key)
+		}
+		normalized, err := normalizeCustomField(field, value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = normalized
+	}
+	return result, nil
+}
+
+// customFieldFilter normalizes the custom field filters of a list request.
+// Without a project the values are only trimmed.
+func customFieldFilter(project *ticketpb.Project, values map[string]string) (map[string]string, error) {
+	filter := make(map[string]string, len(values))
+	for key, value := range values {
+		if !customFieldKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid custom field key %q", key)
+		}
+		value = strings.TrimSpace(value)
+		if project != nil {
+			field := definition(project, key)
+			if field == nil {
+				return nil, fmt.Errorf("project %s has no custom field %q", project.Key, key)
+			}
+			normalized, err := normalizeCustomField(field, value)
+			if err != nil {
+				return nil, err
+			}
+			value = normalized
+		}
+		filter[key] = value
+	}
+	return filter, nil
+}
+
+// updatedCustomFields returns the custom field values of a ticket with the
+// given values set
+func (s *Server) updatedCustomFields(ctx context.Context, id string, values map[string]string) (map[string]string, error) {
+	ticket, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, storeError("update", id, err)
+	}
+	if ticket.ProjectId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "custom fields can only be set on tickets in a project, %s is in none", id)
+	}
+	project, err := s.store.GetProject(ctx, ticket.ProjectId)
+	if err != nil {
+		return nil, projectError(ticket.ProjectId, err)
+	}
+
+	customFields, err := applyCustomFields(project, ticket.CustomFields, values)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return customFields, nil
+}
@@ -0,0 +1,179 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LinkTickets links two tickets. Blocks and parent links may not form cycles,
+// and a ticket has at most one parent.
+func (s *Server) LinkTickets(ctx context.Context, req *ticketpb.LinkTicketsRequest) (*ticketpb.LinkTicketsResponse, error) {
+	log.Printf("gRPC: Linking tickets - %s %s %s", req.SourceId, req.Type, req.TargetId)
+
+	if _, known := ticketpb.LinkType_name[int32(req.Type)]; !known || req.Type == ticketpb.LinkType_LINK_TYPE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "link type is required")
+	}
+	if req.SourceId == req.TargetId {
+		return nil, status.Error(codes.InvalidArgument, "a ticket cannot be linked to itself")
+	}
+
+	// Checks and writes are serialized so concurrent links cannot form a cycle together
+	s.linkMu.Lock()
+	defer s.linkMu.Unlock()
+
+	if err := s.checkLink(ctx, req); err != nil {
+		return nil, err
+	}
+
+	link := &ticketpb.TicketLink{
+		SourceId:  req.SourceId,
+		TargetId:  req.TargetId,
+		Type:      req.Type,
+		CreatedAt: timestamppb.New(now()),
+	}
+	if err := s.store.Link(ctx, link); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return nil, status.Errorf(codes.NotFound, "ticket not found: %s or %s", req.SourceId, req.TargetId)
+		case errors.Is(err, store.ErrLinkExists):
+			return nil, status.Error(codes.AlreadyExists, "tickets are already linked this way")
+		}
+		log.Printf("gRPC: Error linking tickets: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to link tickets: %v", err)
+	}
+
+	log.Printf("gRPC: Tickets linked successfully - %s %s %s", req.SourceId, req.Type, req.TargetId)
+	return &ticketpb.LinkTicketsResponse{Link: link}, nil
+}
+
+// checkLink enforces the rules of a link type before it is stored
+func (s *Server) checkLink(ctx context.Context, req *ticketpb.LinkTicketsRequest) error {
+	switch req.Type {
+	case ticketpb.LinkType_LINK_TYPE_RELATES_TO:
+		// Relates-to is symmetric, so the reverse link is the same link
+		links, err := s.store.Links(ctx, req.TargetId)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list links: %v", err)
+		}
+		for _, link := range links {
+			if store.SameLink(link, req.TargetId, req.SourceId, req.Type) {
+				return status.Error(codes.AlreadyExists, "tickets are already linked this way")
+			}
+		}
+
+	case ticketpb.LinkType_LINK_TYPE_PARENT_OF:
+		links, err := s.store.Links(ctx, req.TargetId)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list links: %v", err)
+		}
+		for _, link := range links {
+			if link.Type == req.Type && link.TargetId == req.TargetId && link.SourceId != req.SourceId {
+				return status.Errorf(codes.FailedPrecondition, "ticket %s already has parent %s", req.TargetId, link.SourceId)
+			}
+		}
+		fallthrough
+
+	case ticketpb.LinkType_LINK_TYPE_BLOCKS:
+		cycle, err := s.reaches(ctx, req.TargetId, req.SourceId, req.Type)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check for cycles: %v", err)
+		}
+		if cycle {
+			return status.Errorf(codes.FailedPrecondition, "linking %s to %s would create a %s cycle",
+				req.SourceId, req.TargetId, strings.ToLower(store.LinkTypeToDB(req.Type)))
+		}
+	}
+	return nil
+}
+
+// reaches reports whether links of the given type lead from one ticket to another
+func (s *Server) reaches(ctx context.Context, from, to string, linkType ticketpb.LinkType) (bool, error) {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		links, err := s.store.Links(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		for _, link := range links {
+			if link.Type != linkType || link.SourceId != id {
+				continue
+			}
+			if link.TargetId == to {
+				return true, nil
+			}
+			if !visited[link.TargetId] {
+				visited[link.TargetId] = true
+				queue = append(queue, link.TargetId)
+			}
+		}
+	}
+	return false, nil
+}
+
+// openBlockers returns the IDs of the unresolved tickets blocking a ticket
+func (s *Server) openBlockers(ctx context.Context, id string) ([]string, error) {
+	links, err := s.store.Links(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []string
+	for _, link := range links {
+		if link.Type != ticketpb.LinkType_LINK_TYPE_BLOCKS || link.TargetId != id {
+			continue
+		}
+		blocker, err := s.store.Get(ctx, link.SourceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blocker %s: %w", link.SourceId, err)
+		}
+		if blocker.Status != ticketpb.TicketStatus_TICKET_STATUS_RESOLVED &&
+			blocker.Status != ticketpb.TicketStatus_TICKET_STATUS_CLOSED {
+			open = append(open, blocker.Id)
+		}
+	}
+	return open, nil
+}
+
+// UnlinkTickets removes a link. Removing an unknown link reports Success false.
+func (s *Server) UnlinkTickets(ctx context.Context, req *ticketpb.UnlinkTicketsRequest) (*ticketpb.UnlinkTicketsResponse, error) {
+	log.Printf("gRPC: Unlinking tickets - %s %s %s", req.SourceId, req.Type, req.TargetId)
+
+	s.linkMu.Lock()
+	defer s.linkMu.Unlock()
+
+	if err := s.store.Unlink(ctx, req.SourceId, req.TargetId, req.Type); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &ticketpb.UnlinkTicketsResponse{Success: false}, nil
+		}
+		log.Printf("gRPC: Error unlinking tickets: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to unlink tickets: %v", err)
+	}
+
+	return &ticketpb.UnlinkTicketsResponse{Success: true}, nil
+}
+
+// ListLinks lists the links from or to a ticket
+func (s *Server) ListLinks(ctx context.Context, req *ticketpb.ListLinksRequest) (*ticketpb.ListLinksResponse, error) {
+	log.Printf("gRPC: Listing links - Ticket: %s", req.TicketId)
+
+	links, err := s.store.Links(ctx, req.TicketId)
+	if err != nil {
+		log.Printf("gRPC: Error listing links: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list links: %v", err)
+	}
+
+	return &ticketpb.ListLinksResponse{Links: links}, nil
+}
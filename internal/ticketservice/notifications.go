@@ -0,0 +1,204 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultNotificationLimit is the number of notifications listed when the request sets none
+	defaultNotificationLimit = 50
+	// maxNotificationLimit caps the number of notifications listed at once
+	maxNotificationLimit = 500
+)
+
+// WatchTicket makes a user a watcher of a ticket, notified of its changes
+func (s *Server) WatchTicket(ctx context.Context, req *ticketpb.WatchTicketRequest) (*ticketpb.WatchTicketResponse, error) {
+	log.Printf("gRPC: Watching ticket - ID: %s, User: %s", req.TicketId, req.UserId)
+
+	userID, err := notificationUser(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.store.Get(ctx, req.TicketId); err != nil {
+		return nil, storeError("watch", req.TicketId, err)
+	}
+	if err := s.notifications.Watch(ctx, req.TicketId, userID, now()); err != nil {
+		return nil, notificationError("watch", req.TicketId, err)
+	}
+	watchers, err := s.notifications.Watchers(ctx, req.TicketId)
+	if err != nil {
+		return nil, notificationError("list watchers of", req.TicketId, err)
+	}
+
+	log.Printf("gRPC: Ticket watched successfully - ID: %s, User: %s", req.TicketId, userID)
+	return &ticketpb.WatchTicketResponse{WatcherIds: watchers}, nil
+}
+
+// UnwatchTicket stops a user watching a ticket. Unwatching a ticket the user
+// does not watch reports Success false.
+func (s *Server) UnwatchTicket(ctx context.Context, req *ticketpb.UnwatchTicketRequest) (*ticketpb.UnwatchTicketResponse, error) {
+	log.Printf("gRPC: Unwatching ticket - ID: %s, User: %s", req.TicketId, req.UserId)
+
+	userID, err := notificationUser(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	watching, err := s.notifications.Unwatch(ctx, req.TicketId, userID)
+	if err != nil {
+		return nil, notificationError("unwatch", req.TicketId, err)
+	}
+
+	return &ticketpb.UnwatchTicketResponse{Success: watching}, nil
+}
+
+// ListWatchers lists the watchers of a ticket in the order they started
+// watching
+func (s *Server) ListWatchers(ctx context.Context, req *ticketpb.ListWatchersRequest) (*ticketpb.ListWatchersResponse, error) {
+	watchers, err := s.notifications.Watchers(ctx, req.TicketId)
+	if err != nil {
+		return nil, notificationError("list watchers of", req.TicketId, err)
+	}
+	return &ticketpb.ListWatchersResponse{WatcherIds: watchers}, nil
+}
+
+// ListNotifications lists the in-app notifications of a user, newest first
+func (s *Server) ListNotifications(ctx context.Context, req *ticketpb.ListNotificationsRequest) (*ticketpb.ListNotificationsResponse, error) {
+	log.Printf("gRPC: Listing notifications - User: %s, Unread only: %v", req.UserId, req.UnreadOnly)
+
+	userID, err := notificationUser(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	switch {
+	case limit <= 0:
+		limit = defaultNotificationLimit
+	case limit > maxNotificationLimit:
+		limit = maxNotificationLimit
+	}
+	list, err := s.notifications.ListNotifications(ctx, userID, req.UnreadOnly, limit)
+	if err != nil {
+		log.Printf("gRPC: Error listing notifications of %s: %v", userID, err)
+		return nil, status.Errorf(codes.Internal, "failed to list notifications: %v", err)
+	}
+
+	return &ticketpb.ListNotificationsResponse{Notifications: list}, nil
+}
+
+// MarkNotificationRead marks a notification read. Marking it again keeps
+// the first read time.
+func (s *Server) MarkNotificationRead(ctx context.Context, req *ticketpb.MarkNotificationReadRequest) (*ticketpb.MarkNotificationReadResponse, error) {
+	log.Printf("gRPC: Marking notification read - ID: %s", req.Id)
+
+	notification, err := s.notifications.MarkRead(ctx, req.Id, now())
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "notification not found: %s", req.Id)
+	}
+	if err != nil {
+		log.Printf("gRPC: Error marking notification %s read: %v", req.Id, err)
+		return nil, status.Errorf(codes.Internal, "failed to mark notification read: %v", err)
+	}
+
+	return &ticketpb.MarkNotificationReadResponse{Notification: notification}, nil
+}
+
+// GetNotificationPreferences returns the notification preferences of a
+// user, the defaults until the user changes them
+func (s *Server) GetNotificationPreferences(ctx context.Context, req *ticketpb.GetNotificationPreferencesRequest) (*ticketpb.GetNotificationPreferencesResponse, error) {
+	userID, err := notificationUser(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	preferences, err := notifications.Preferences(ctx, s.notifications, userID)
+	if err != nil {
+		log.Printf("gRPC: Error loading notification preferences of %s: %v", userID, err)
+		return nil, status.Errorf(codes.Internal, "failed to get notification preferences: %v", err)
+	}
+	return &ticketpb.GetNotificationPreferencesResponse{Preferences: preferences}, nil
+}
+
+// UpdateNotificationPreferences changes the notification preferences of a
+// user. Unset fields are left unchanged; the delivery of notifications
+// already scheduled is not moved, except that turning delivery off drops it.
+func (s *Server) UpdateNotificationPreferences(ctx context.Context, req *ticketpb.UpdateNotificationPreferencesRequest) (*ticketpb.UpdateNotificationPreferencesResponse, error) {
+	log.Printf("gRPC: Updating notification preferences - User: %s", req.UserId)
+
+	userID, err := notificationUser(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	preferences, err := notifications.Preferences(ctx, s.notifications, userID)
+	if err != nil {
+		log.Printf("gRPC: Error loading notification preferences of %s: %v", userID, err)
+		return nil, status.Errorf(codes.Internal, "failed to get notification preferences: %v", err)
+	}
+
+	if req.Email != nil {
+		email := strings.TrimSpace(req.Email.Value)
+		if email != "" {
+			address, err := mail.ParseAddress(email)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid email address %q", email)
+			}
+			email = address.Address
+		}
+		preferences.Email = email
+	}
+	if req.Delivery != ticketpb.NotificationDelivery_NOTIFICATION_DELIVERY_UNSPECIFIED {
+		preferences.Delivery = req.Delivery
+	}
+	if req.DigestHour != nil {
+		preferences.DigestHour = req.DigestHour.Value
+	}
+	if req.SetMutedKinds {
+		preferences.MutedKinds = nil
+		for _, kind := range req.MutedKinds {
+			if !slices.Contains(preferences.MutedKinds, kind) {
+				preferences.MutedKinds = append(preferences.MutedKinds, kind)
+			}
+		}
+	}
+	if err := notifications.ValidatePreferences(preferences); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	preferences.UpdatedAt = timestamppb.New(now())
+
+	if err := s.notifications.SetPreferences(ctx, preferences); err != nil {
+		log.Printf("gRPC: Error saving notification preferences of %s: %v", userID, err)
+		return nil, status.Errorf(codes.Internal, "failed to update notification preferences: %v", err)
+	}
+
+	log.Printf("gRPC: Notification preferences updated successfully - User: %s", userID)
+	return &ticketpb.UpdateNotificationPreferencesResponse{Preferences: preferences}, nil
+}
+
+// notificationUser validates the user a notification request is about
+func notificationUser(userID string) (string, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return "", status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return userID, nil
+}
+
+// notificationError converts a notification store error about a ticket
+// into a gRPC status
+func notificationError(op, ticketID string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "ticket not found: %s", ticketID)
+	}
+	log.Printf("gRPC: Error during %s of ticket %s: %v", op, ticketID, err)
+	return status.Errorf(codes.Internal, "failed to %s ticket: %v", op, err)
+}
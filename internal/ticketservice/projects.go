@@ -0,0 +1,119 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// projectKeyPattern matches project keys: an upper-case letter followed by 1-9
+// upper-case letters or digits
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+// normalizeProjectKey upper-cases a project key given by a client
+func normalizeProjectKey(key string) string {
+	return strings.ToUpper(strings.TrimSpace(key))
+}
+
+// CreateProject creates a new project
+func (s *Server) CreateProject(ctx context.Context, req *ticketpb.CreateProjectRequest) (*ticketpb.CreateProjectResponse, error) {
+	key := normalizeProjectKey(req.Key)
+	log.Printf("gRPC: Creating project - Key: %s", key)
+
+	if !projectKeyPattern.MatchString(key) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid project key %q: use 2-10 letters or digits, starting with a letter", req.Key)
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	project := &ticketpb.Project{
+		Id:          uuid.New().String(),
+		Key:         key,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   timestamppb.New(now()),
+	}
+	if err := s.store.CreateProject(ctx, project); err != nil {
+		if errors.Is(err, store.ErrProjectExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "project key already exists: %s", key)
+		}
+		log.Printf("gRPC: Error creating project: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create project: %v", err)
+	}
+
+	log.Printf("gRPC: Project created successfully - Key: %s, ID: %s", key, project.Id)
+	return &ticketpb.CreateProjectResponse{Project: project}, nil
+}
+
+// GetProject retrieves a project by ID or key
+func (s *Server) GetProject(ctx context.Context, req *ticketpb.GetProjectRequest) (*ticketpb.GetProjectResponse, error) {
+	log.Printf("gRPC: Getting project - ID: %s, Key: %s", req.Id, req.Key)
+
+	var (
+		project *ticketpb.Project
+		err     error
+	)
+	switch {
+	case req.Id != "":
+		project, err = s.store.GetProject(ctx, req.Id)
+	case req.Key != "":
+		project, err = s.store.GetProjectByKey(ctx, normalizeProjectKey(req.Key))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or key is required")
+	}
+	if err != nil {
+		return nil, projectError(req.Id+req.Key, err)
+	}
+
+	return &ticketpb.GetProjectResponse{Project: project}, nil
+}
+
+// ListProjects lists every project ordered by key
+func (s *Server) ListProjects(ctx context.Context, req *ticketpb.ListProjectsRequest) (*ticketpb.ListProjectsResponse, error) {
+	log.Println("gRPC: Listing projects")
+
+	projects, err := s.store.ListProjects(ctx)
+	if err != nil {
+		log.Printf("gRPC: Error listing projects: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list projects: %v", err)
+	}
+
+	return &ticketpb.ListProjectsResponse{Projects: projects}, nil
+}
+
+// projectID resolves a project key given by a client to the project's ID
+func (s *Server) projectID(ctx context.Context, key string) (string, error) {
+	project, err := s.projectByKey(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return project.Id, nil
+}
+
+// projectByKey loads the project with a key given by a client
+func (s *Server) projectByKey(ctx context.Context, key string) (*ticketpb.Project, error) {
+	project, err := s.store.GetProjectByKey(ctx, normalizeProjectKey(key))
+	if err != nil {
+		return nil, projectError(key, err)
+	}
+	return project, nil
+}
+
+// projectError converts a project store error into a gRPC status
+func projectError(ref string, err error) error {
+	if errors.Is(err, store.ErrProjectNotFound) {
+		return status.Errorf(codes.NotFound, "project not found: %s", ref)
+	}
+	log.Printf("gRPC: Error loading project %s: %v", ref, err)
+	return status.Errorf(codes.Internal, "failed to get project: %v", err)
+}
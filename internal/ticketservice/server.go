@@ -0,0 +1,368 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/attachments"
+	"github.com/ayush-pandya/Graphql/internal/blob"
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/sla"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the TicketService gRPC service on top of a TicketStore
+type Server struct {
+	ticketpb.UnimplementedTicketServiceServer
+	store         store.TicketStore
+	attachments   *attachments.Service
+	sla           *sla.Tracker
+	webhooks      webhooks.Store
+	notifications notifications.Store
+	comments      comments.Store
+	linkMu        sync.Mutex // serializes link changes
+	slaMu         sync.Mutex // serializes SLA changes of updates and the evaluator
+}
+
+// NewServer creates a ticket service backed by tickets, keeping attachment
+// content in blobs and attachment metadata in metadata. SLAs follow the
+// policies of tracker, webhook subscriptions are kept in hooks and watchers,
+// notifications and notification preferences in inbox, comments and email
+// threads in discussions. Ticket events are recorded by the store, in its
+// outbox.
+func NewServer(tickets store.TicketStore, blobs blob.Store, metadata attachments.MetadataStore, limits attachments.Limits,
+	tracker *sla.Tracker, hooks webhooks.Store, inbox notifications.Store, discussions comments.Store) *Server {
+	server := &Server{store: tickets, sla: tracker, webhooks: hooks, notifications: inbox, comments: discussions}
+	server.attachments = attachments.NewService(blobs, metadata, limits, server.ticketExists)
+	return server
+}
+
+// now returns the current time at the microsecond precision kept by SQL stores
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// CreateTicket creates a new ticket
+func (s *Server) CreateTicket(ctx context.Context, req *ticketpb.CreateTicketRequest) (*ticketpb.CreateTicketResponse, error) {
+	log.Printf("gRPC: Creating ticket - Title: %s", req.Title)
+
+	if req.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	priority := req.Priority
+	if priority == ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+		priority = ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
+	}
+
+	created := now()
+	createdAt := timestamppb.New(created)
+	ticket := &ticketpb.Ticket{
+		Id:          uuid.New().String(),
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+		Priority:    priority,
+		AssigneeId:  req.AssigneeId,
+		ReporterId:  req.ReporterId,
+		Tags:        req.Tags,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+	var project *ticketpb.Project
+	if req.ProjectKey != "" {
+		var err error
+		if project, err = s.projectByKey(ctx, req.ProjectKey); err != nil {
+			return nil, err
+		}
+		ticket.ProjectId = project.Id
+	}
+	customFields, err := customFieldValues(project, req.CustomFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ticket.CustomFields = customFields
+	if req.DueDate != nil {
+		due, err := dueDate(req.DueDate, created)
+		if err != nil {
+			return nil, err
+		}
+		ticket.DueDate = timestamppb.New(due)
+	}
+	s.sla.Plan(ticket, created)
+
+	if err := s.store.Create(ctx, ticket); err != nil {
+		if errors.Is(err, store.ErrProjectNotFound) {
+			return nil, status.Errorf(codes.NotFound, "project not found: %s", req.ProjectKey)
+		}
+		log.Printf("gRPC: Error creating ticket: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create ticket: %v", err)
+	}
+
+	log.Printf("gRPC: Ticket created successfully - ID: %s, Key: %s", ticket.Id, ticket.Key)
+	return &ticketpb.CreateTicketResponse{Ticket: ticket}, nil
+}
+
+// GetTicket retrieves a ticket by ID, or by key when the ID is empty
+func (s *Server) GetTicket(ctx context.Context, req *ticketpb.GetTicketRequest) (*ticketpb.GetTicketResponse, error) {
+	if req.Id == "" && req.Key != "" {
+		log.Printf("gRPC: Getting ticket - Key: %s", req.Key)
+
+		ticket, err := s.store.GetByKey(ctx, strings.ToUpper(req.Key))
+		if err != nil {
+			return nil, storeError("get", req.Key, err)
+		}
+		return &ticketpb.GetTicketResponse{Ticket: ticket}, nil
+	}
+
+	log.Printf("gRPC: Getting ticket - ID: %s", req.Id)
+
+	ticket, err := s.store.Get(ctx, req.Id)
+	if err != nil {
+		return nil, storeError("get", req.Id, err)
+	}
+
+	return &ticketpb.GetTicketResponse{Ticket: ticket}, nil
+}
+
+// ListTickets retrieves a page of tickets, newest first
+func (s *Server) ListTickets(ctx context.Context, req *ticketpb.ListTicketsRequest) (*ticketpb.ListTicketsResponse, error) {
+	log.Println("gRPC: Listing tickets")
+
+	opts := store.ListOptions{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		Tags:      req.Tags,
+		Query:     req.Query,
+	}
+	if err := s.filterTickets(ctx, &opts, req.ProjectKey, req.CustomFields); err != nil {
+		return nil, err
+	}
+
+	tickets, next, err := s.store.List(ctx, opts)
+	if errors.Is(err, store.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("gRPC: Error listing tickets: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list tickets: %v", err)
+	}
+
+	log.Printf("gRPC: Listed %d tickets", len(tickets))
+	return &ticketpb.ListTicketsResponse{Tickets: tickets, NextPageToken: next}, nil
+}
+
+// filterTickets sets the project and custom field filters of a ticket listing
+func (s *Server) filterTickets(ctx context.Context, opts *store.ListOptions, projectKey string, customFields map[string]string) error {
+	var project *ticketpb.Project
+	if projectKey != "" {
+		var err error
+		if project, err = s.projectByKey(ctx, projectKey); err != nil {
+			return err
+		}
+		opts.ProjectID = project.Id
+	}
+	if len(customFields) > 0 {
+		filter, err := customFieldFilter(project, customFields)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts.CustomFields = filter
+	}
+	return nil
+}
+
+// UpdateTicket updates an existing ticket. Empty fields are left unchanged.
+func (s *Server) UpdateTicket(ctx context.Context, req *ticketpb.UpdateTicketRequest) (*ticketpb.UpdateTicketResponse, error) {
+	log.Printf("gRPC: Updating ticket - ID: %s", req.Id)
+
+	update, err := s.ticketUpdate(ctx, req, now(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if movesSLA(update) {
+		s.slaMu.Lock()
+		defer s.slaMu.Unlock()
+		if err := s.planSLA(ctx, req.Id, &update); err != nil {
+			return nil, err
+		}
+	}
+
+	ticket, err := s.store.Update(ctx, req.Id, update)
+	if err != nil {
+		return nil, storeError("update", req.Id, err)
+	}
+
+	log.Printf("gRPC: Ticket updated successfully - ID: %s", req.Id)
+	return &ticketpb.UpdateTicketResponse{Ticket: ticket}, nil
+}
+
+// ticketUpdate validates an update request and converts it into a store
+// update made at the given time. Tickets in resolving are resolved along
+// with the ticket, so they do not block it.
+func (s *Server) ticketUpdate(ctx context.Context, req *ticketpb.UpdateTicketRequest, at time.Time, resolving []string) (store.TicketUpdate, error) {
+	update := store.TicketUpdate{UpdatedAt: at}
+	if req.Title != "" {
+		update.Title = &req.Title
+	}
+	if req.Description != "" {
+		update.Description = &req.Description
+	}
+	if req.Status != ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED {
+		update.Status = &req.Status
+	}
+	if req.Status == ticketpb.TicketStatus_TICKET_STATUS_RESOLVED {
+		blockers, err := s.openBlockers(ctx, req.Id)
+		if err != nil {
+			return update, storeError("update", req.Id, err)
+		}
+		blockers = slices.DeleteFunc(blockers, func(id string) bool { return slices.Contains(resolving, id) })
+		if len(blockers) > 0 {
+			return update, status.Errorf(codes.FailedPrecondition, "ticket %s is blocked by open tickets: %s",
+				req.Id, strings.Join(blockers, ", "))
+		}
+	}
+	if req.Priority != ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+		update.Priority = &req.Priority
+	}
+	if req.AssigneeId != "" {
+		update.AssigneeID = &req.AssigneeId
+	}
+	if len(req.Tags) > 0 {
+		update.Tags = req.Tags
+	}
+	if len(req.CustomFields) > 0 {
+		customFields, err := s.updatedCustomFields(ctx, req.Id, req.CustomFields)
+		if err != nil {
+			return update, err
+		}
+		update.CustomFields = customFields
+	}
+	if req.ClearDueDate {
+		if req.DueDate != nil {
+			return update, status.Error(codes.InvalidArgument, "due_date and clear_due_date are exclusive")
+		}
+		update.DueDate = &time.Time{}
+	} else if req.DueDate != nil {
+		due, err := dueDate(req.DueDate, at)
+		if err != nil {
+			return update, err
+		}
+		update.DueDate = &due
+	}
+	return update, nil
+}
+
+// movesSLA reports whether an update moves the SLA deadlines of a ticket,
+// which status, priority and due date do
+func movesSLA(update store.TicketUpdate) bool {
+	return update.Status != nil || update.Priority != nil || update.DueDate != nil
+}
+
+// planSLA sets the SLA state an update leaves a ticket in. Callers hold s.slaMu.
+func (s *Server) planSLA(ctx context.Context, id string, update *store.TicketUpdate) error {
+	current, err := s.store.Get(ctx, id)
+	if err != nil {
+		return storeError("update", id, err)
+	}
+	update.SLA = s.plannedSLA(current, *update, update.UpdatedAt)
+	return nil
+}
+
+// DeleteTicket deletes a ticket. Deleting an unknown ticket reports Success false.
+func (s *Server) DeleteTicket(ctx context.Context, req *ticketpb.DeleteTicketRequest) (*ticketpb.DeleteTicketResponse, error) {
+	log.Printf("gRPC: Deleting ticket - ID: %s", req.Id)
+
+	if err := s.store.Delete(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &ticketpb.DeleteTicketResponse{Success: false}, nil
+		}
+		return nil, storeError("delete", req.Id, err)
+	}
+	if err := s.comments.DeleteTicket(ctx, req.Id); err != nil {
+		log.Printf("gRPC: Error deleting comments of ticket %s: %v", req.Id, err)
+	}
+	if err := s.attachments.DeleteTicket(ctx, req.Id); err != nil {
+		log.Printf("gRPC: Error deleting attachments of ticket %s: %v", req.Id, err)
+	}
+
+	log.Printf("gRPC: Ticket deleted successfully - ID: %s", req.Id)
+	return &ticketpb.DeleteTicketResponse{Success: true}, nil
+}
+
+// ListTags returns the tag catalogue, optionally filtered by prefix
+func (s *Server) ListTags(ctx context.Context, req *ticketpb.ListTagsRequest) (*ticketpb.ListTagsResponse, error) {
+	log.Printf("gRPC: Listing tags - Prefix: %q", req.Prefix)
+
+	tags, err := s.store.Tags(ctx, store.TagQuery{Prefix: req.Prefix, Max: int(req.Limit)})
+	if err != nil {
+		log.Printf("gRPC: Error listing tags: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+
+	return &ticketpb.ListTagsResponse{Tags: tags}, nil
+}
+
+// MergeTags replaces the source tags with the target tag on every ticket
+func (s *Server) MergeTags(ctx context.Context, req *ticketpb.MergeTagsRequest) (*ticketpb.MergeTagsResponse, error) {
+	log.Printf("gRPC: Merging tags %v into %q", req.Sources, req.Target)
+
+	target := strings.TrimSpace(req.Target)
+	if target == "" {
+		return nil, status.Error(codes.InvalidArgument, "target tag is required")
+	}
+	if len(req.Sources) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one source tag is required")
+	}
+
+	changed, err := s.store.MergeTags(ctx, req.Sources, target, now())
+	if err != nil {
+		log.Printf("gRPC: Error merging tags: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to merge tags: %v", err)
+	}
+
+	log.Printf("gRPC: Merged tags into %q on %d tickets", target, changed)
+	return &ticketpb.MergeTagsResponse{UpdatedTickets: int32(changed)}, nil
+}
+
+// UploadAttachment stores a file streamed by the client
+func (s *Server) UploadAttachment(stream ticketpb.TicketService_UploadAttachmentServer) error {
+	return s.attachments.UploadAttachment(stream)
+}
+
+// ListAttachments lists the attachments of a ticket
+func (s *Server) ListAttachments(ctx context.Context, req *ticketpb.ListAttachmentsRequest) (*ticketpb.ListAttachmentsResponse, error) {
+	return s.attachments.ListAttachments(ctx, req)
+}
+
+// DownloadAttachment streams an attachment to the client
+func (s *Server) DownloadAttachment(req *ticketpb.DownloadAttachmentRequest, stream ticketpb.TicketService_DownloadAttachmentServer) error {
+	return s.attachments.DownloadAttachment(req, stream)
+}
+
+// ticketExists reports whether a ticket is stored
+func (s *Server) ticketExists(ctx context.Context, id string) bool {
+	_, err := s.store.Get(ctx, id)
+	return err == nil
+}
+
+// storeError converts a store error into a gRPC status
+func storeError(op, id string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "ticket not found: %s", id)
+	}
+	log.Printf("gRPC: Error during %s of ticket %s: %v", op, id, err)
+	return status.Errorf(codes.Internal, "failed to %s ticket: %v", op, err)
+}
@@ -0,0 +1,45 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/attachments"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingStore fails every listing with err
+type failingStore struct {
+	store.TicketStore
+	err error
+}
+
+func (f failingStore) List(ctx context.Context, opts store.ListOptions) ([]*ticketpb.Ticket, string, error) {
+	return nil, "", f.err
+}
+
+func TestListTicketsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		store store.TicketStore
+		token string
+		want  codes.Code
+	}{
+		{"invalid page token", store.NewMemoryStore(), "%%%", codes.InvalidArgument},
+		{"store failure", failingStore{store.NewMemoryStore(), errors.New("connection refused")}, "", codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(tt.store, nil, attachments.NewMemoryMetadata(), attachments.Limits{}, nil, nil, nil, nil)
+			_, err := server.ListTickets(context.Background(), &ticketpb.ListTicketsRequest{PageToken: tt.token})
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Package servicetest runs a ticket service backed by memory stores, for the
+// tests of the clients and gateways in front of it:
+//
+//	func TestClient(t *testing.T) {
+//		service := servicetest.Start(t)
+//		client, err := clients.NewTicketClient(service.Addr)
+//		...
+//	}
+package servicetest
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/attachments"
+	"github.com/ayush-pandya/Graphql/internal/blob"
+	"github.com/ayush-pandya/Graphql/internal/comments"
+	"github.com/ayush-pandya/Graphql/internal/notifications"
+	"github.com/ayush-pandya/Graphql/internal/sla"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+)
+
+// Service is a ticket service serving on a loopback port
+type Service struct {
+	// Addr is the host:port the service listens on
+	Addr    string
+	Tickets *store.MemoryStore
+	// Blobs is the directory holding the content of attachments
+	Blobs  string
+	Server *grpc.Server
+}
+
+// Start serves an empty ticket service until the test ends. opts configure
+// the gRPC server, typically with the interceptors under test.
+func Start(t *testing.T, opts ...grpc.ServerOption) *Service {
+	t.Helper()
+
+	dir := t.TempDir()
+	blobs, err := blob.NewLocalStore(dir)
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+	policies, err := sla.ParsePolicies(sla.DefaultPolicies)
+	if err != nil {
+		t.Fatalf("failed to parse SLA policies: %v", err)
+	}
+
+	tickets := store.NewMemoryStore()
+	server := grpc.NewServer(opts...)
+	ticketpb.RegisterTicketServiceServer(server, ticketservice.NewServer(tickets, blobs,
+		attachments.NewMemoryMetadata(), attachments.Limits{MaxSize: 1 << 20}, sla.NewTracker(policies, 0.25),
+		webhooks.NewMemoryStore(), notifications.NewMemoryStore(), comments.NewMemoryStore()))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return &Service{Addr: lis.Addr().String(), Tickets: tickets, Blobs: dir, Server: server}
+}
@@ -0,0 +1,121 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// slaBatchSize is the number of tickets the SLA evaluator loads at a time
+const slaBatchSize = 100
+
+// ListSLAPolicies returns the SLA policy of every priority that has one
+func (s *Server) ListSLAPolicies(ctx context.Context, req *ticketpb.ListSLAPoliciesRequest) (*ticketpb.ListSLAPoliciesResponse, error) {
+	return &ticketpb.ListSLAPoliciesResponse{Policies: s.sla.List()}, nil
+}
+
+// dueDate validates a requested due date, which must lie after now
+func dueDate(ts *timestamppb.Timestamp, now time.Time) (time.Time, error) {
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid due date: %v", err)
+	}
+	due := ts.AsTime().UTC().Truncate(time.Microsecond)
+	if !due.After(now) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "due date %s is not in the future", due.Format(time.RFC3339))
+	}
+	return due, nil
+}
+
+// plannedSLA returns the SLA of a ticket after an update changing its
+// status, priority or due date. The caller holds s.slaMu.
+func (s *Server) plannedSLA(current *ticketpb.Ticket, update store.TicketUpdate, at time.Time) *ticketpb.TicketSLA {
+	ticket := proto.Clone(current).(*ticketpb.Ticket)
+	update.Apply(ticket)
+	if update.Priority != nil || update.DueDate != nil {
+		s.sla.Plan(ticket, at)
+	}
+	if update.Status != nil {
+		s.sla.Transition(ticket, current.Status, at)
+	}
+	return ticket.Sla
+}
+
+// RunSLAEvaluator evaluates due SLAs every interval until ctx is done. Run
+// it in one ticket service only, or every instance records the same events.
+func (s *Server) RunSLAEvaluator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.EvaluateSLAs(ctx)
+			if err != nil {
+				log.Printf("gRPC: Error evaluating SLAs: %v", err)
+			}
+			if changed > 0 {
+				log.Printf("gRPC: SLA status changed on %d tickets", changed)
+			}
+		}
+	}
+}
+
+// EvaluateSLAs moves every ticket whose SLA is due to its current status,
+// which records at-risk and breach events, and returns the number of tickets
+// whose status changed
+func (s *Server) EvaluateSLAs(ctx context.Context) (int, error) {
+	changed := 0
+	for {
+		due, err := s.store.SLADue(ctx, now(), slaBatchSize)
+		if err != nil {
+			return changed, err
+		}
+		for _, ticket := range due {
+			ok, err := s.evaluateSLA(ctx, ticket.OrganizationId, ticket.Id)
+			if err != nil {
+				return changed, err
+			}
+			if ok {
+				changed++
+			}
+		}
+		if len(due) < slaBatchSize {
+			return changed, nil
+		}
+	}
+}
+
+// evaluateSLA evaluates the SLA of one ticket, acting for its organization,
+// and reports whether its status changed
+func (s *Server) evaluateSLA(ctx context.Context, organizationID, id string) (bool, error) {
+	ctx = tenant.WithOrganization(ctx, organizationID)
+	s.slaMu.Lock()
+	defer s.slaMu.Unlock()
+
+	// Re-read under the lock: an update may have moved the deadlines
+	ticket, err := s.store.Get(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	changed := s.sla.Evaluate(ticket, now())
+
+	// Keeping the update time records only the SLA events
+	if _, err := s.store.Update(ctx, id, store.TicketUpdate{SLA: ticket.Sla, UpdatedAt: ticket.UpdatedAt.AsTime()}); err != nil {
+		return false, err
+	}
+	return changed, nil
+}
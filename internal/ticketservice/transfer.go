@@ -0,0 +1,387 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// importNamespace derives the IDs of remapped tickets from their source IDs
+var importNamespace = uuid.MustParse("3cc273e4-3399-44ae-94ad-8bad52995742")
+
+// ExportTickets streams the tickets matching the filters as records, one
+// page per message
+func (s *Server) ExportTickets(req *ticketpb.ExportTicketsRequest, stream ticketpb.TicketService_ExportTicketsServer) error {
+	ctx := stream.Context()
+	log.Println("gRPC: Exporting tickets")
+
+	opts := store.ListOptions{
+		PageSize: store.MaxPageSize,
+		Tags:     req.Tags,
+		Query:    req.Query,
+	}
+	if err := s.filterTickets(ctx, &opts, req.ProjectKey, req.CustomFields); err != nil {
+		return err
+	}
+
+	projectKeys := make(map[string]string)
+	exported := 0
+	for {
+		tickets, next, err := s.store.List(ctx, opts)
+		if err != nil {
+			log.Printf("gRPC: Error exporting tickets: %v", err)
+			return status.Errorf(codes.Internal, "failed to list tickets: %v", err)
+		}
+
+		records := make([]*ticketpb.TicketRecord, len(tickets))
+		for i, ticket := range tickets {
+			key, err := s.projectKey(ctx, projectKeys, ticket.ProjectId)
+			if err != nil {
+				return err
+			}
+			records[i] = ticketRecord(ticket, key)
+		}
+		if len(records) > 0 {
+			if err := stream.Send(&ticketpb.ExportTicketsResponse{Tickets: records}); err != nil {
+				return err
+			}
+		}
+		exported += len(records)
+
+		if next == "" {
+			break
+		}
+		opts.PageToken = next
+	}
+
+	log.Printf("gRPC: Exported %d tickets", exported)
+	return nil
+}
+
+// projectKey returns the key of a project, caching it in keys
+func (s *Server) projectKey(ctx context.Context, keys map[string]string, projectID string) (string, error) {
+	if projectID == "" {
+		return "", nil
+	}
+	if key, ok := keys[projectID]; ok {
+		return key, nil
+	}
+	project, err := s.store.GetProject(ctx, projectID)
+	if err != nil {
+		return "", projectError(projectID, err)
+	}
+	keys[projectID] = project.Key
+	return project.Key, nil
+}
+
+// ticketRecord converts a ticket into its portable record
+func ticketRecord(ticket *ticketpb.Ticket, projectKey string) *ticketpb.TicketRecord {
+	return &ticketpb.TicketRecord{
+		Id:           ticket.Id,
+		Key:          ticket.Key,
+		ProjectKey:   projectKey,
+		Title:        ticket.Title,
+		Description:  ticket.Description,
+		Status:       ticket.Status,
+		Priority:     ticket.Priority,
+		AssigneeId:   ticket.AssigneeId,
+		ReporterId:   ticket.ReporterId,
+		Tags:         ticket.Tags,
+		CustomFields: ticket.CustomFields,
+		CreatedAt:    ticket.CreatedAt,
+		UpdatedAt:    ticket.UpdatedAt,
+		DueDate:      ticket.DueDate,
+	}
+}
+
+// ImportTickets creates a ticket from every record of the stream. Records
+// whose ticket already exists are skipped, so an interrupted import can be
+// run again. Bad records are reported rather than failing the import.
+// Imported tickets record no events, so webhooks and notifications are not
+// sent for history.
+func (s *Server) ImportTickets(stream ticketpb.TicketService_ImportTicketsServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive import options: %v", err)
+	}
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	}
+	switch opts.IdMode {
+	case ticketpb.ImportIDMode_IMPORT_ID_MODE_UNSPECIFIED, ticketpb.ImportIDMode_IMPORT_ID_MODE_PRESERVE, ticketpb.ImportIDMode_IMPORT_ID_MODE_REMAP:
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown ID mode %d", opts.IdMode)
+	}
+	log.Printf("gRPC: Importing tickets - ID mode: %s, Dry run: %t", opts.IdMode, opts.DryRun)
+
+	imp := &importer{
+		server:   s,
+		opts:     opts,
+		at:       now(),
+		seen:     make(map[string]bool),
+		projects: make(map[string]*ticketpb.Project),
+	}
+	if opts.ProjectKey != "" {
+		if _, err := imp.project(ctx, opts.ProjectKey); err != nil {
+			return err
+		}
+	}
+
+	resp := &ticketpb.ImportTicketsResponse{DryRun: opts.DryRun}
+	for n := int32(1); ; n++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Keep the code of status errors, e.g. the rate limit charging
+			// every record
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+			return status.Errorf(codes.InvalidArgument, "failed to receive ticket record: %v", err)
+		}
+		record := req.GetTicket()
+		if record == nil {
+			return status.Error(codes.InvalidArgument, "only the first message may carry import options")
+		}
+
+		result := imp.importRecord(ctx, n, record)
+		switch result.Outcome {
+		case ticketpb.ImportOutcome_IMPORT_OUTCOME_CREATED:
+			resp.Created++
+		case ticketpb.ImportOutcome_IMPORT_OUTCOME_SKIPPED:
+			resp.Skipped++
+		case ticketpb.ImportOutcome_IMPORT_OUTCOME_INVALID:
+			resp.Invalid++
+		case ticketpb.ImportOutcome_IMPORT_OUTCOME_FAILED:
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	log.Printf("gRPC: Import finished - Created: %d, Skipped: %d, Invalid: %d, Failed: %d, Dry run: %t",
+		resp.Created, resp.Skipped, resp.Invalid, resp.Failed, opts.DryRun)
+	return stream.SendAndClose(resp)
+}
+
+// importer holds the state of one import
+type importer struct {
+	server *Server
+	opts   *ticketpb.ImportOptions
+	at     time.Time
+	// IDs of the tickets imported so far
+	seen map[string]bool
+	// Projects by key; nil for keys naming no project
+	projects map[string]*ticketpb.Project
+}
+
+// importRecord validates one record and creates its ticket
+func (imp *importer) importRecord(ctx context.Context, n int32, record *ticketpb.TicketRecord) *ticketpb.ImportResult {
+	result := &ticketpb.ImportResult{Record: n, SourceId: record.Id}
+	fail := func(outcome ticketpb.ImportOutcome, err error) *ticketpb.ImportResult {
+		result.Outcome = outcome
+		result.Error = status.Convert(err).Message()
+		return result
+	}
+
+	id, err := imp.ticketID(ctx, record.Id)
+	if err != nil {
+		return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_INVALID, err)
+	}
+	result.TicketId = id
+	if imp.seen[id] {
+		return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_INVALID, fmt.Errorf("id %q appears in an earlier record", record.Id))
+	}
+	imp.seen[id] = true
+
+	ticket, err := imp.ticket(ctx, id, record)
+	if err != nil {
+		if status.Code(err) == codes.Internal {
+			return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_FAILED, err)
+		}
+		return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_INVALID, err)
+	}
+
+	existing, err := imp.server.store.Get(ctx, id)
+	switch {
+	case err == nil:
+		result.Key = existing.Key
+		result.Outcome = ticketpb.ImportOutcome_IMPORT_OUTCOME_SKIPPED
+		return result
+	case !errors.Is(err, store.ErrNotFound):
+		log.Printf("gRPC: Error looking up imported ticket %s: %v", id, err)
+		return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_FAILED, err)
+	}
+
+	if !imp.opts.DryRun {
+		// The tickets are history, so they send no webhooks or notifications
+		err := imp.server.store.Create(events.WithoutEvents(ctx), ticket)
+		if errors.Is(err, store.ErrTicketExists) {
+			// The ID is taken in another organization, which must not be
+			// given away, so this reads like any other clash of IDs
+			return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_INVALID, fmt.Errorf("id %q is already in use; import with IDs remapped", record.Id))
+		}
+		if err != nil {
+			log.Printf("gRPC: Error importing ticket %s: %v", id, err)
+			return fail(ticketpb.ImportOutcome_IMPORT_OUTCOME_FAILED, err)
+		}
+		result.Key = ticket.Key
+	}
+	result.Outcome = ticketpb.ImportOutcome_IMPORT_OUTCOME_CREATED
+	return result
+}
+
+// ticketID returns the ID a record's ticket is imported under
+func (imp *importer) ticketID(ctx context.Context, sourceID string) (string, error) {
+	sourceID = strings.TrimSpace(sourceID)
+	if sourceID == "" {
+		return "", errors.New("id is required")
+	}
+	if imp.opts.IdMode == ticketpb.ImportIDMode_IMPORT_ID_MODE_PRESERVE {
+		id, err := uuid.Parse(sourceID)
+		if err != nil {
+			return "", fmt.Errorf("id %q is not a UUID, which preserving IDs requires", sourceID)
+		}
+		return id.String(), nil
+	}
+	// Including the organization keeps tickets imported into several
+	// organizations from the same source apart
+	return uuid.NewSHA1(importNamespace, []byte(tenant.ID(ctx)+"\x00"+sourceID)).String(), nil
+}
+
+// ticket validates a record and converts it into the ticket to create
+func (imp *importer) ticket(ctx context.Context, id string, record *ticketpb.TicketRecord) (*ticketpb.Ticket, error) {
+	title := strings.TrimSpace(record.Title)
+	if title == "" {
+		return nil, errors.New("title is required")
+	}
+
+	statusValue := record.Status
+	if statusValue == ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED {
+		statusValue = ticketpb.TicketStatus_TICKET_STATUS_OPEN
+	}
+	if _, ok := ticketpb.TicketStatus_name[int32(statusValue)]; !ok {
+		return nil, fmt.Errorf("unknown status %d", statusValue)
+	}
+	priority := record.Priority
+	if priority == ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+		priority = ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM
+	}
+	if _, ok := ticketpb.TicketPriority_name[int32(priority)]; !ok {
+		return nil, fmt.Errorf("unknown priority %d", priority)
+	}
+
+	createdAt, err := importTime("created_at", record.CreatedAt, imp.at)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := importTime("updated_at", record.UpdatedAt, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case updatedAt.After(imp.at):
+		return nil, fmt.Errorf("updated_at %s is in the future", updatedAt.Format(time.RFC3339))
+	case updatedAt.Before(createdAt):
+		return nil, errors.New("updated_at is before created_at")
+	}
+
+	var tags []string
+	for _, tag := range record.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	ticket := &ticketpb.Ticket{
+		Id:          id,
+		Title:       title,
+		Description: record.Description,
+		Status:      statusValue,
+		Priority:    priority,
+		AssigneeId:  strings.TrimSpace(record.AssigneeId),
+		ReporterId:  strings.TrimSpace(record.ReporterId),
+		Tags:        tags,
+		CreatedAt:   timestamppb.New(createdAt),
+		UpdatedAt:   timestamppb.New(updatedAt),
+	}
+
+	projectKey := record.ProjectKey
+	if strings.TrimSpace(projectKey) == "" {
+		projectKey = imp.opts.ProjectKey
+	}
+	var project *ticketpb.Project
+	if strings.TrimSpace(projectKey) != "" {
+		if project, err = imp.project(ctx, projectKey); err != nil {
+			return nil, err
+		}
+		ticket.ProjectId = project.Id
+	}
+	if ticket.CustomFields, err = customFieldValues(project, record.CustomFields); err != nil {
+		return nil, err
+	}
+
+	// Past due dates are history here, not mistakes
+	if record.DueDate != nil {
+		due, err := importTime("due_date", record.DueDate, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		ticket.DueDate = timestamppb.New(due)
+	}
+
+	// Replay the ticket's life so far: planned when created, answered and
+	// possibly resolved by its last update, then judged as of now
+	imp.server.sla.Plan(ticket, createdAt)
+	imp.server.sla.Transition(ticket, ticketpb.TicketStatus_TICKET_STATUS_OPEN, updatedAt)
+	imp.server.sla.Evaluate(ticket, imp.at)
+	return ticket, nil
+}
+
+// project returns the project with the given key, caching lookups
+func (imp *importer) project(ctx context.Context, key string) (*ticketpb.Project, error) {
+	key = normalizeProjectKey(key)
+	if project, ok := imp.projects[key]; ok {
+		if project == nil {
+			return nil, status.Errorf(codes.NotFound, "project not found: %s", key)
+		}
+		return project, nil
+	}
+	project, err := imp.server.projectByKey(ctx, key)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			imp.projects[key] = nil
+		}
+		return nil, err
+	}
+	imp.projects[key] = project
+	return project, nil
+}
+
+// importTime validates a timestamp of a record, defaulting a missing one
+func importTime(field string, ts *timestamppb.Timestamp, fallback time.Time) (time.Time, error) {
+	if ts == nil {
+		return fallback, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %v", field, err)
+	}
+	return ts.AsTime().UTC().Truncate(time.Microsecond), nil
+}
@@ -0,0 +1,79 @@
+package ticketservice_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/ticketservice/servicetest"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// importRecords streams records to ImportTickets and returns its summary
+func importRecords(t *testing.T, ctx context.Context, client ticketpb.TicketServiceClient, opts *ticketpb.ImportOptions, records ...*ticketpb.TicketRecord) *ticketpb.ImportTicketsResponse {
+	t.Helper()
+	stream, err := client.ImportTickets(ctx)
+	if err != nil {
+		t.Fatalf("ImportTickets: %v", err)
+	}
+	stream.Send(&ticketpb.ImportTicketsRequest{Data: &ticketpb.ImportTicketsRequest_Options{Options: opts}})
+	for _, record := range records {
+		stream.Send(&ticketpb.ImportTicketsRequest{Data: &ticketpb.ImportTicketsRequest_Ticket{Ticket: record}})
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("ImportTickets: %v", err)
+	}
+	return resp
+}
+
+func TestImportPreservedIDs(t *testing.T) {
+	service := servicetest.Start(t,
+		grpc.ChainUnaryInterceptor(tenant.UnaryServerInterceptor(false, tenant.AllPeers)),
+		grpc.ChainStreamInterceptor(tenant.StreamServerInterceptor(false, tenant.AllPeers)))
+	conn, err := grpc.NewClient(service.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := ticketpb.NewTicketServiceClient(conn)
+	acme := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "acme")
+	globex := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "globex")
+
+	opts := &ticketpb.ImportOptions{IdMode: ticketpb.ImportIDMode_IMPORT_ID_MODE_PRESERVE}
+	record := &ticketpb.TicketRecord{Id: "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b", Title: "Printer on fire"}
+	resp := importRecords(t, acme, client, opts, record)
+	if resp.Created != 1 {
+		t.Fatalf("import: %+v, want one ticket created", resp.Results)
+	}
+
+	// History sends no webhooks or notifications
+	pending, err := service.Tickets.PendingEvents(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("import recorded %d events, want none", len(pending))
+	}
+
+	// A re-run skips the ticket
+	if resp := importRecords(t, acme, client, opts, record); resp.Skipped != 1 {
+		t.Errorf("re-run: %+v, want the ticket skipped", resp.Results)
+	}
+
+	// Another organization is told the ID is taken, and no more
+	resp = importRecords(t, globex, client, opts, record)
+	if resp.Invalid != 1 {
+		t.Fatalf("import into globex: %+v, want the record invalid", resp.Results)
+	}
+	if msg := resp.Results[0].Error; strings.Contains(msg, "acme") || strings.Contains(msg, "organization") {
+		t.Errorf("import into globex failed with %q, which says where the ID lives", msg)
+	}
+	if _, err := client.GetTicket(globex, &ticketpb.GetTicketRequest{Id: record.Id}); err == nil {
+		t.Error("globex can read acme's ticket")
+	}
+}
@@ -0,0 +1,249 @@
+package ticketservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultDeliveryLimit is the number of deliveries listed when the request sets none
+	defaultDeliveryLimit = 50
+	// maxDeliveryLimit caps the number of deliveries listed at once
+	maxDeliveryLimit = 500
+)
+
+// CreateWebhook subscribes a URL to ticket events. The response is the only
+// one carrying the webhook's secret.
+func (s *Server) CreateWebhook(ctx context.Context, req *ticketpb.CreateWebhookRequest) (*ticketpb.CreateWebhookResponse, error) {
+	log.Printf("gRPC: Creating webhook - URL: %s", req.Url)
+
+	if err := webhooks.ValidateURL(req.Url); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	eventTypes, err := webhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := s.webhookFilter(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		secret = webhooks.NewSecret()
+	}
+
+	createdAt := timestamppb.New(now())
+	webhook := &ticketpb.Webhook{
+		Id:         uuid.New().String(),
+		Url:        req.Url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Filter:     filter,
+		Active:     true,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+	if err := s.webhooks.CreateWebhook(ctx, webhook); err != nil {
+		log.Printf("gRPC: Error creating webhook: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create webhook: %v", err)
+	}
+
+	log.Printf("gRPC: Webhook created successfully - ID: %s", webhook.Id)
+	return &ticketpb.CreateWebhookResponse{Webhook: webhook}, nil
+}
+
+// UpdateWebhook changes the settings of a webhook. Unset fields are left
+// unchanged; a new secret takes effect with the next attempt.
+func (s *Server) UpdateWebhook(ctx context.Context, req *ticketpb.UpdateWebhookRequest) (*ticketpb.UpdateWebhookResponse, error) {
+	log.Printf("gRPC: Updating webhook - ID: %s", req.Id)
+
+	webhook, err := s.webhooks.GetWebhook(ctx, req.Id)
+	if err != nil {
+		return nil, webhookError("update", req.Id, err)
+	}
+	if req.Url != "" {
+		if err := webhooks.ValidateURL(req.Url); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		webhook.Url = req.Url
+	}
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if len(req.EventTypes) > 0 {
+		if webhook.EventTypes, err = webhookEventTypes(req.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if req.Filter != nil {
+		if webhook.Filter, err = s.webhookFilter(ctx, req.Filter); err != nil {
+			return nil, err
+		}
+	}
+	if req.Active != nil {
+		webhook.Active = req.Active.Value
+	}
+	webhook.UpdatedAt = timestamppb.New(now())
+
+	if err := s.webhooks.UpdateWebhook(ctx, webhook); err != nil {
+		return nil, webhookError("update", req.Id, err)
+	}
+
+	log.Printf("gRPC: Webhook updated successfully - ID: %s", req.Id)
+	return &ticketpb.UpdateWebhookResponse{Webhook: redacted(webhook)}, nil
+}
+
+// DeleteWebhook deletes a webhook and its deliveries. Deleting an unknown
+// webhook reports Success false.
+func (s *Server) DeleteWebhook(ctx context.Context, req *ticketpb.DeleteWebhookRequest) (*ticketpb.DeleteWebhookResponse, error) {
+	log.Printf("gRPC: Deleting webhook - ID: %s", req.Id)
+
+	if err := s.webhooks.DeleteWebhook(ctx, req.Id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return &ticketpb.DeleteWebhookResponse{Success: false}, nil
+		}
+		return nil, webhookError("delete", req.Id, err)
+	}
+
+	log.Printf("gRPC: Webhook deleted successfully - ID: %s", req.Id)
+	return &ticketpb.DeleteWebhookResponse{Success: true}, nil
+}
+
+// ListWebhooks lists every webhook, oldest first, without their secrets
+func (s *Server) ListWebhooks(ctx context.Context, req *ticketpb.ListWebhooksRequest) (*ticketpb.ListWebhooksResponse, error) {
+	log.Println("gRPC: Listing webhooks")
+
+	list, err := s.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		log.Printf("gRPC: Error listing webhooks: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list webhooks: %v", err)
+	}
+	for _, webhook := range list {
+		redacted(webhook)
+	}
+
+	return &ticketpb.ListWebhooksResponse{Webhooks: list}, nil
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook, newest first.
+// Listing the DEAD ones shows the dead-letter list.
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *ticketpb.ListWebhookDeliveriesRequest) (*ticketpb.ListWebhookDeliveriesResponse, error) {
+	log.Printf("gRPC: Listing webhook deliveries - Webhook: %s, Status: %s", req.WebhookId, req.Status)
+
+	limit := int(req.Limit)
+	switch {
+	case limit <= 0:
+		limit = defaultDeliveryLimit
+	case limit > maxDeliveryLimit:
+		limit = maxDeliveryLimit
+	}
+	deliveries, err := s.webhooks.ListDeliveries(ctx, req.WebhookId, req.Status, limit)
+	if err != nil {
+		return nil, webhookError("read", req.WebhookId, err)
+	}
+
+	return &ticketpb.ListWebhookDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+// RedeliverWebhook queues a delivery again with a fresh set of attempts,
+// typically to retry one from the dead-letter list once its webhook is fixed
+func (s *Server) RedeliverWebhook(ctx context.Context, req *ticketpb.RedeliverWebhookRequest) (*ticketpb.RedeliverWebhookResponse, error) {
+	log.Printf("gRPC: Redelivering webhook delivery - ID: %s", req.DeliveryId)
+
+	delivery, err := s.webhooks.GetDelivery(ctx, req.DeliveryId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery not found: %s", req.DeliveryId)
+	}
+	if err != nil {
+		log.Printf("gRPC: Error loading webhook delivery %s: %v", req.DeliveryId, err)
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery: %v", err)
+	}
+	webhooks.Requeue(delivery, now())
+	if err := s.webhooks.UpdateDelivery(ctx, delivery); err != nil {
+		log.Printf("gRPC: Error requeueing webhook delivery %s: %v", req.DeliveryId, err)
+		return nil, status.Errorf(codes.Internal, "failed to requeue webhook delivery: %v", err)
+	}
+
+	log.Printf("gRPC: Webhook delivery requeued - ID: %s", req.DeliveryId)
+	return &ticketpb.RedeliverWebhookResponse{Delivery: delivery}, nil
+}
+
+// webhookEventTypes validates the event types of a webhook, dropping duplicates
+func webhookEventTypes(types []string) ([]string, error) {
+	var unique []string
+	for _, t := range types {
+		if t = strings.TrimSpace(t); !slices.Contains(unique, t) {
+			unique = append(unique, t)
+		}
+	}
+	if err := webhooks.ValidateEventTypes(unique); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return unique, nil
+}
+
+// webhookFilter validates a filter given by a client, resolving its project
+// key. A filter without conditions is nil.
+func (s *Server) webhookFilter(ctx context.Context, filter *ticketpb.WebhookFilter) (*ticketpb.WebhookFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	normalized := &ticketpb.WebhookFilter{}
+	switch {
+	case filter.ProjectKey != "":
+		projectID, err := s.projectID(ctx, filter.ProjectKey)
+		if err != nil {
+			return nil, err
+		}
+		normalized.ProjectId = projectID
+	case filter.ProjectId != "":
+		if _, err := s.store.GetProject(ctx, filter.ProjectId); err != nil {
+			return nil, projectError(filter.ProjectId, err)
+		}
+		normalized.ProjectId = filter.ProjectId
+	}
+	for _, tag := range filter.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(normalized.Tags, tag) {
+			normalized.Tags = append(normalized.Tags, tag)
+		}
+	}
+	for _, priority := range filter.Priorities {
+		if priority == ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+			return nil, status.Error(codes.InvalidArgument, "webhook filter priorities must be specified")
+		}
+		if !slices.Contains(normalized.Priorities, priority) {
+			normalized.Priorities = append(normalized.Priorities, priority)
+		}
+	}
+	if normalized.ProjectId == "" && len(normalized.Tags) == 0 && len(normalized.Priorities) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+// redacted removes the secret of a webhook before it is returned
+func redacted(webhook *ticketpb.Webhook) *ticketpb.Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+// webhookError converts a webhook store error into a gRPC status
+func webhookError(op, id string, err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "webhook not found: %s", id)
+	}
+	log.Printf("gRPC: Error during %s of webhook %s: %v", op, id, err)
+	return status.Errorf(codes.Internal, "failed to %s webhook: %v", op, err)
+}
@@ -0,0 +1,159 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+// csvReader reads records from CSV with a header row
+type csvReader struct {
+	r       *csv.Reader
+	fields  []string // field of each column; empty for ignored columns
+	ignored []string
+	line    int
+}
+
+// NewCSVReader returns a reader of CSV records. The header row names the
+// columns, which the mapping maps to record fields.
+func NewCSVReader(r io.Reader, mapping Mapping) (Reader, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty CSV: a header row is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	reader := &csvReader{r: cr, fields: make([]string, len(header))}
+	seen := make(map[string]string)
+	for i, column := range header {
+		// Spreadsheets often save a byte order mark
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		field, ok := mapping.Field(column)
+		if !ok {
+			reader.ignored = append(reader.ignored, column)
+			continue
+		}
+		// Several columns may fill the tags; any other field takes one
+		if other, dup := seen[field]; dup && field != FieldTags {
+			return nil, fmt.Errorf("columns %q and %q both map to %s", other, column, field)
+		}
+		seen[field] = column
+		reader.fields[i] = field
+	}
+	return reader, nil
+}
+
+func (r *csvReader) Read() (*ticketpb.TicketRecord, error) {
+	row, err := r.r.Read()
+	if row != nil || err == nil {
+		r.line, _ = r.r.FieldPos(0)
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		r.line = parseErr.StartLine
+		return nil, &RecordError{Line: r.line, Err: parseErr.Err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &ticketpb.TicketRecord{}
+	var errs []error
+	for i, value := range row {
+		if r.fields[i] == "" {
+			continue
+		}
+		if err := setField(record, r.fields[i], value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, &RecordError{Line: r.line, Err: errors.Join(errs...)}
+	}
+	return record, nil
+}
+
+func (r *csvReader) Line() int {
+	return r.line
+}
+
+func (r *csvReader) Ignored() []string {
+	return r.ignored
+}
+
+// csvWriter writes records as CSV with a header row
+type csvWriter struct {
+	w            *csv.Writer
+	customFields []string
+	wroteHeader  bool
+}
+
+// NewCSVWriter returns a writer of CSV records. Every custom field listed
+// gets a custom.<key> column; others are left out, so list every field the
+// records may carry.
+func NewCSVWriter(w io.Writer, customFields []string) Writer {
+	keys := append([]string(nil), customFields...)
+	sort.Strings(keys)
+	return &csvWriter{w: csv.NewWriter(w), customFields: keys}
+}
+
+func (w *csvWriter) Write(record *ticketpb.TicketRecord) error {
+	if !w.wroteHeader {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	row := []string{
+		record.Id,
+		record.Key,
+		record.ProjectKey,
+		record.Title,
+		record.Description,
+		statusName(record.Status),
+		priorityName(record.Priority),
+		record.AssigneeId,
+		record.ReporterId,
+		strings.Join(record.Tags, ","),
+		formatTime(record.CreatedAt),
+		formatTime(record.UpdatedAt),
+		formatTime(record.DueDate),
+	}
+	for _, key := range w.customFields {
+		row = append(row, record.CustomFields[key])
+	}
+	return w.w.Write(row)
+}
+
+// writeHeader writes the header row
+func (w *csvWriter) writeHeader() error {
+	header := append([]string(nil), Fields...)
+	for _, key := range w.customFields {
+		header = append(header, CustomFieldPrefix+key)
+	}
+	w.wroteHeader = true
+	return w.w.Write(header)
+}
+
+func (w *csvWriter) Flush() error {
+	// An export without tickets still names its columns
+	if !w.wroteHeader {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
@@ -0,0 +1,205 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+// ndjsonReader reads records from NDJSON, one object per line
+type ndjsonReader struct {
+	r       *bufio.Reader
+	mapping Mapping
+	ignored map[string]bool
+	line    int // line of the last record
+	next    int // line to read next
+}
+
+// NewNDJSONReader returns a reader of NDJSON records. The mapping maps the
+// keys of each object to record fields; a custom_fields object fills the
+// custom fields.
+func NewNDJSONReader(r io.Reader, mapping Mapping) Reader {
+	return &ndjsonReader{r: bufio.NewReader(r), mapping: mapping, ignored: make(map[string]bool), next: 1}
+}
+
+func (r *ndjsonReader) Read() (*ticketpb.TicketRecord, error) {
+	for {
+		line, err := r.r.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+		r.line = r.next
+		r.next++
+		if line = bytes.TrimSpace(line); len(line) == 0 {
+			continue
+		}
+
+		record, err := r.record(line)
+		if err != nil {
+			return nil, &RecordError{Line: r.line, Err: err}
+		}
+		return record, nil
+	}
+}
+
+// record decodes one line
+func (r *ndjsonReader) record(line []byte) (*ticketpb.TicketRecord, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("invalid JSON object: %w", err)
+	}
+
+	record := &ticketpb.TicketRecord{}
+	var errs []error
+	for key, value := range object {
+		if _, mapped := r.mapping[key]; !mapped && key == FieldCustomFields {
+			if err := setCustomFields(record, value); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		field, ok := r.mapping.Field(key)
+		if !ok {
+			r.ignored[key] = true
+			continue
+		}
+		if err := setJSONField(record, field, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return record, nil
+}
+
+// setCustomFields sets the custom fields of a record from a JSON object
+func setCustomFields(record *ticketpb.TicketRecord, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: expected an object", FieldCustomFields)
+	}
+	for key, value := range fields {
+		if err := setJSONField(record, CustomFieldPrefix+key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setJSONField sets a field of a record from a JSON value. Tags may be an
+// array of strings.
+func setJSONField(record *ticketpb.TicketRecord, field string, value interface{}) error {
+	if values, ok := value.([]interface{}); ok && field == FieldTags {
+		for _, value := range values {
+			text, err := jsonText(field, value)
+			if err != nil {
+				return err
+			}
+			if err := setField(record, field, text); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	text, err := jsonText(field, value)
+	if err != nil {
+		return err
+	}
+	return setField(record, field, text)
+}
+
+// jsonText returns the text of a scalar JSON value
+func jsonText(field string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("%s: expected a string", field)
+}
+
+func (r *ndjsonReader) Line() int {
+	return r.line
+}
+
+func (r *ndjsonReader) Ignored() []string {
+	keys := make([]string, 0, len(r.ignored))
+	for key := range r.ignored {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonRecord is the NDJSON form of a record
+type jsonRecord struct {
+	ID           string            `json:"id"`
+	Key          string            `json:"key,omitempty"`
+	ProjectKey   string            `json:"project_key,omitempty"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	Priority     string            `json:"priority,omitempty"`
+	AssigneeID   string            `json:"assignee_id,omitempty"`
+	ReporterID   string            `json:"reporter_id,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+	CreatedAt    string            `json:"created_at,omitempty"`
+	UpdatedAt    string            `json:"updated_at,omitempty"`
+	DueDate      string            `json:"due_date,omitempty"`
+}
+
+// ndjsonWriter writes records as NDJSON
+type ndjsonWriter struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewNDJSONWriter returns a writer of NDJSON records
+func NewNDJSONWriter(w io.Writer) Writer {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	encoder.SetEscapeHTML(false)
+	return &ndjsonWriter{w: buffered, encoder: encoder}
+}
+
+func (w *ndjsonWriter) Write(record *ticketpb.TicketRecord) error {
+	return w.encoder.Encode(jsonRecord{
+		ID:           record.Id,
+		Key:          record.Key,
+		ProjectKey:   record.ProjectKey,
+		Title:        record.Title,
+		Description:  record.Description,
+		Status:       statusName(record.Status),
+		Priority:     priorityName(record.Priority),
+		AssigneeID:   record.AssigneeId,
+		ReporterID:   record.ReporterId,
+		Tags:         record.Tags,
+		CustomFields: record.CustomFields,
+		CreatedAt:    formatTime(record.CreatedAt),
+		UpdatedAt:    formatTime(record.UpdatedAt),
+		DueDate:      formatTime(record.DueDate),
+	})
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,325 @@
+// Package transfer reads and writes ticket records as CSV and NDJSON, the
+// file formats tickets are exported and imported in
+package transfer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Format is a file format of ticket records
+type Format string
+
+const (
+	// CSV has a header row naming the fields and one ticket per row
+	CSV Format = "csv"
+	// NDJSON has one JSON object per line
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat returns the format with the given name. An empty name picks
+// the format from the extension of path, falling back to CSV.
+func ParseFormat(name, path string) (Format, error) {
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".ndjson", ".jsonl", ".json":
+			return NDJSON, nil
+		}
+		return CSV, nil
+	}
+	switch Format(strings.ToLower(name)) {
+	case CSV:
+		return CSV, nil
+	case NDJSON, "jsonl", "json":
+		return NDJSON, nil
+	}
+	return "", fmt.Errorf("unknown format %q: use csv or ndjson", name)
+}
+
+// Record fields, named as in CSV headers and NDJSON objects
+const (
+	FieldID          = "id"
+	FieldKey         = "key"
+	FieldProjectKey  = "project_key"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldStatus      = "status"
+	FieldPriority    = "priority"
+	FieldAssigneeID  = "assignee_id"
+	FieldReporterID  = "reporter_id"
+	FieldTags        = "tags"
+	FieldCreatedAt   = "created_at"
+	FieldUpdatedAt   = "updated_at"
+	FieldDueDate     = "due_date"
+	// FieldCustomFields holds every custom field of an NDJSON object
+	FieldCustomFields = "custom_fields"
+	// CustomFieldPrefix prefixes the fields of single custom fields, e.g.
+	// custom.sprint
+	CustomFieldPrefix = "custom."
+	// Ignore maps a column to nothing
+	Ignore = "-"
+)
+
+// Fields lists the record fields in column order
+var Fields = []string{
+	FieldID, FieldKey, FieldProjectKey, FieldTitle, FieldDescription, FieldStatus, FieldPriority,
+	FieldAssigneeID, FieldReporterID, FieldTags, FieldCreatedAt, FieldUpdatedAt, FieldDueDate,
+}
+
+// isField reports whether name is a record field
+func isField(name string) bool {
+	if strings.HasPrefix(name, CustomFieldPrefix) {
+		return len(name) > len(CustomFieldPrefix)
+	}
+	for _, field := range Fields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Mapping maps the columns of a source file to record fields. Columns it
+// does not name are read as the field of the same name, if there is one.
+type Mapping map[string]string
+
+// ParseMapping parses a mapping given as comma-separated column=field pairs,
+// e.g. "Summary=title,Issue key=id,Sprint=custom.sprint,Votes=-"
+func ParseMapping(spec string) (Mapping, error) {
+	mapping := make(Mapping)
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(spec, ",") {
+		column, field, ok := strings.Cut(pair, "=")
+		column, field = strings.TrimSpace(column), strings.ToLower(strings.TrimSpace(field))
+		if !ok || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q: use column=field", pair)
+		}
+		if field != Ignore && !isField(field) {
+			return nil, fmt.Errorf("invalid mapping %q: unknown field %q", pair, field)
+		}
+		mapping[column] = field
+	}
+	return mapping, nil
+}
+
+// Field returns the record field a source column is read as, or false when
+// the column is ignored
+func (m Mapping) Field(column string) (string, bool) {
+	field, ok := m[column]
+	if !ok {
+		field = strings.ToLower(strings.TrimSpace(column))
+	}
+	if field == Ignore || !isField(field) {
+		return "", false
+	}
+	return field, true
+}
+
+// statusAliases maps the status names of other trackers to statuses
+var statusAliases = map[string]ticketpb.TicketStatus{
+	"NEW":       ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+	"TODO":      ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+	"TO_DO":     ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+	"BACKLOG":   ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+	"REOPENED":  ticketpb.TicketStatus_TICKET_STATUS_OPEN,
+	"IN_REVIEW": ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS,
+	"DONE":      ticketpb.TicketStatus_TICKET_STATUS_RESOLVED,
+	"FIXED":     ticketpb.TicketStatus_TICKET_STATUS_RESOLVED,
+	"WONT_FIX":  ticketpb.TicketStatus_TICKET_STATUS_CLOSED,
+	"CANCELLED": ticketpb.TicketStatus_TICKET_STATUS_CLOSED,
+	"CANCELED":  ticketpb.TicketStatus_TICKET_STATUS_CLOSED,
+	"DUPLICATE": ticketpb.TicketStatus_TICKET_STATUS_CLOSED,
+	"INVALID":   ticketpb.TicketStatus_TICKET_STATUS_CLOSED,
+	"WONTFIX":   ticketpb.TicketStatus_TICKET_STATUS_CLOSED,
+}
+
+// priorityAliases maps the priority names of other trackers to priorities
+var priorityAliases = map[string]ticketpb.TicketPriority{
+	"LOWEST":  ticketpb.TicketPriority_TICKET_PRIORITY_LOW,
+	"MINOR":   ticketpb.TicketPriority_TICKET_PRIORITY_LOW,
+	"NORMAL":  ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM,
+	"MAJOR":   ticketpb.TicketPriority_TICKET_PRIORITY_HIGH,
+	"HIGHEST": ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL,
+	"URGENT":  ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL,
+	"BLOCKER": ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL,
+	"P1":      ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL,
+	"P2":      ticketpb.TicketPriority_TICKET_PRIORITY_HIGH,
+	"P3":      ticketpb.TicketPriority_TICKET_PRIORITY_MEDIUM,
+	"P4":      ticketpb.TicketPriority_TICKET_PRIORITY_LOW,
+}
+
+// enumName normalizes a status or priority as written in a file, e.g.
+// "In progress" becomes IN_PROGRESS
+func enumName(value string) string {
+	return strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// ParseStatus parses a status by its name with or without the
+// TICKET_STATUS_ prefix, in any case, or by a common alias such as Done
+func ParseStatus(value string) (ticketpb.TicketStatus, error) {
+	name := strings.TrimPrefix(enumName(value), "TICKET_STATUS_")
+	if name == "" {
+		return ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED, nil
+	}
+	if status, ok := ticketpb.TicketStatus_value["TICKET_STATUS_"+name]; ok && status != 0 {
+		return ticketpb.TicketStatus(status), nil
+	}
+	if status, ok := statusAliases[name]; ok {
+		return status, nil
+	}
+	return 0, fmt.Errorf("unknown status %q", value)
+}
+
+// ParsePriority parses a priority by its name with or without the
+// TICKET_PRIORITY_ prefix, in any case, or by a common alias such as Major
+func ParsePriority(value string) (ticketpb.TicketPriority, error) {
+	name := strings.TrimPrefix(enumName(value), "TICKET_PRIORITY_")
+	if name == "" {
+		return ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED, nil
+	}
+	if priority, ok := ticketpb.TicketPriority_value["TICKET_PRIORITY_"+name]; ok && priority != 0 {
+		return ticketpb.TicketPriority(priority), nil
+	}
+	if priority, ok := priorityAliases[name]; ok {
+		return priority, nil
+	}
+	return 0, fmt.Errorf("unknown priority %q", value)
+}
+
+// timeLayouts are the accepted timestamp layouts; times without a zone are UTC
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// parseTime parses a timestamp, returning nil for an empty value
+func parseTime(value string) (*timestamppb.Timestamp, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return timestamppb.New(t), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time %q: use RFC 3339, e.g. 2024-05-01T09:30:00Z", value)
+}
+
+// formatTime formats a timestamp as RFC 3339 in UTC, or empty when it is nil
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+// statusName returns the name a status is written as, e.g. IN_PROGRESS
+func statusName(status ticketpb.TicketStatus) string {
+	if status == ticketpb.TicketStatus_TICKET_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return strings.TrimPrefix(status.String(), "TICKET_STATUS_")
+}
+
+// priorityName returns the name a priority is written as, e.g. HIGH
+func priorityName(priority ticketpb.TicketPriority) string {
+	if priority == ticketpb.TicketPriority_TICKET_PRIORITY_UNSPECIFIED {
+		return ""
+	}
+	return strings.TrimPrefix(priority.String(), "TICKET_PRIORITY_")
+}
+
+// splitTags splits a comma-separated list of tags
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// setField sets a field of a record from its text
+func setField(record *ticketpb.TicketRecord, field, value string) error {
+	var err error
+	switch field {
+	case FieldID:
+		record.Id = strings.TrimSpace(value)
+	case FieldKey:
+		record.Key = strings.TrimSpace(value)
+	case FieldProjectKey:
+		record.ProjectKey = strings.TrimSpace(value)
+	case FieldTitle:
+		record.Title = value
+	case FieldDescription:
+		record.Description = value
+	case FieldStatus:
+		record.Status, err = ParseStatus(value)
+	case FieldPriority:
+		record.Priority, err = ParsePriority(value)
+	case FieldAssigneeID:
+		record.AssigneeId = strings.TrimSpace(value)
+	case FieldReporterID:
+		record.ReporterId = strings.TrimSpace(value)
+	case FieldTags:
+		record.Tags = append(record.Tags, splitTags(value)...)
+	case FieldCreatedAt:
+		record.CreatedAt, err = parseTime(value)
+	case FieldUpdatedAt:
+		record.UpdatedAt, err = parseTime(value)
+	case FieldDueDate:
+		record.DueDate, err = parseTime(value)
+	default:
+		key := strings.TrimPrefix(field, CustomFieldPrefix)
+		if value = strings.TrimSpace(value); value == "" {
+			return nil
+		}
+		if record.CustomFields == nil {
+			record.CustomFields = make(map[string]string)
+		}
+		record.CustomFields[key] = value
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	return nil
+}
+
+// RecordError reports a record that could not be read. Reading can go on
+// with the next record.
+type RecordError struct {
+	Line int
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads ticket records from a file
+type Reader interface {
+	// Read returns the next record, a *RecordError for a bad record, or
+	// io.EOF after the last one
+	Read() (*ticketpb.TicketRecord, error)
+	// Line returns the line the last record read starts on
+	Line() int
+	// Ignored lists the source columns not read into any field so far
+	Ignored() []string
+}
+
+// Writer writes ticket records to a file
+type Writer interface {
+	Write(record *ticketpb.TicketRecord) error
+	// Flush writes any buffered records
+	Flush() error
+}
@@ -0,0 +1,227 @@
+package transfer_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/transfer"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// records are tickets as exported, using every field
+func records() []*ticketpb.TicketRecord {
+	at := time.Date(2024, 5, 1, 9, 30, 0, 123456000, time.UTC)
+	return []*ticketpb.TicketRecord{
+		{
+			Id:           "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b",
+			Key:          "WEB-1",
+			ProjectKey:   "WEB",
+			Title:        `Login "broken", again`,
+			Description:  "Steps:\n1. Open the page\n2. Log in, with a comma",
+			Status:       ticketpb.TicketStatus_TICKET_STATUS_IN_PROGRESS,
+			Priority:     ticketpb.TicketPriority_TICKET_PRIORITY_HIGH,
+			AssigneeId:   "bob",
+			ReporterId:   "alice",
+			Tags:         []string{"auth", "web"},
+			CustomFields: map[string]string{"sprint": "24", "team": "identity"},
+			CreatedAt:    timestamppb.New(at),
+			UpdatedAt:    timestamppb.New(at.Add(time.Hour)),
+			DueDate:      timestamppb.New(at.Add(48 * time.Hour)),
+		},
+		{
+			Id:        "legacy-2",
+			Title:     "Café <menu> & prices",
+			CreatedAt: timestamppb.New(at),
+			UpdatedAt: timestamppb.New(at),
+		},
+	}
+}
+
+// roundTrip writes records and reads them back
+func roundTrip(t *testing.T, format transfer.Format, records []*ticketpb.TicketRecord) []*ticketpb.TicketRecord {
+	t.Helper()
+	var buf bytes.Buffer
+	var writer transfer.Writer
+	if format == transfer.CSV {
+		writer = transfer.NewCSVWriter(&buf, []string{"team", "sprint"})
+	} else {
+		writer = transfer.NewNDJSONWriter(&buf)
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	var reader transfer.Reader
+	if format == transfer.CSV {
+		var err error
+		if reader, err = transfer.NewCSVReader(&buf, nil); err != nil {
+			t.Fatalf("NewCSVReader: %v", err)
+		}
+	} else {
+		reader = transfer.NewNDJSONReader(&buf, nil)
+	}
+	var read []*ticketpb.TicketRecord
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		read = append(read, record)
+	}
+	if ignored := reader.Ignored(); len(ignored) > 0 {
+		t.Errorf("exported columns %v were ignored", ignored)
+	}
+	return read
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []transfer.Format{transfer.CSV, transfer.NDJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			want := records()
+			got := roundTrip(t, format, want)
+			if len(got) != len(want) {
+				t.Fatalf("read %d records, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if !proto.Equal(got[i], want[i]) {
+					t.Errorf("record %d:\ngot  %v\nwant %v", i+1, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmptyCSVExport(t *testing.T) {
+	var buf bytes.Buffer
+	writer := transfer.NewCSVWriter(&buf, nil)
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	reader, err := transfer.NewCSVReader(&buf, nil)
+	if err != nil {
+		t.Fatalf("NewCSVReader: %v", err)
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("Read = %v, want io.EOF", err)
+	}
+}
+
+func TestReadMapped(t *testing.T) {
+	mapping, err := transfer.ParseMapping("Issue key=id, Summary=title, Sprint=custom.sprint, Created=created_at, Votes=-")
+	if err != nil {
+		t.Fatalf("ParseMapping: %v", err)
+	}
+	want := &ticketpb.TicketRecord{
+		Id:           "JIRA-7",
+		Title:        "Slow search",
+		Status:       ticketpb.TicketStatus_TICKET_STATUS_RESOLVED,
+		Priority:     ticketpb.TicketPriority_TICKET_PRIORITY_CRITICAL,
+		Tags:         []string{"search", "perf"},
+		CustomFields: map[string]string{"sprint": "24"},
+		CreatedAt:    timestamppb.New(time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)),
+	}
+
+	// Source files are in the columns, names and date formats of the tracker
+	// they come from
+	tests := []struct {
+		name   string
+		format transfer.Format
+		source string
+	}{
+		{"csv", transfer.CSV, "\ufeffIssue key,Summary,Status,Priority,Tags,Tags,Sprint,Votes,Created,Reporter\n" +
+			"JIRA-7,Slow search,Done,P1,search,perf,24,12,2024-05-01 09:30,alice\n"},
+		{"ndjson", transfer.NDJSON, "\n" + `{"Issue key": "JIRA-7", "Summary": "Slow search", "status": "done", "priority": "Highest", "tags": ["search", "perf"], "Sprint": 24, "Votes": 12, "created_at": "2024-05-01T09:30:00Z", "Reporter": "alice"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reader transfer.Reader
+			if tt.format == transfer.CSV {
+				if reader, err = transfer.NewCSVReader(strings.NewReader(tt.source), mapping); err != nil {
+					t.Fatalf("NewCSVReader: %v", err)
+				}
+			} else {
+				reader = transfer.NewNDJSONReader(strings.NewReader(tt.source), mapping)
+			}
+			record, err := reader.Read()
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if !proto.Equal(record, want) {
+				t.Errorf("got  %v\nwant %v", record, want)
+			}
+			if line := reader.Line(); line != 2 {
+				t.Errorf("record read from line %d, want 2", line)
+			}
+			if ignored := slices.Sorted(slices.Values(reader.Ignored())); !slices.Equal(ignored, []string{"Reporter", "Votes"}) {
+				t.Errorf("ignored columns %q, want Reporter and Votes", ignored)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format transfer.Format
+		source string
+		want   string
+		line   int
+	}{
+		{"csv", transfer.CSV, "id,title,status,due_date\n1,One,OPEN,\n2,Two,Sleeping,tomorrow\n3,Three,,\n", "status", 3},
+		{"ndjson", transfer.NDJSON, `{"id": "1", "title": "One"}` + "\n" + `{"id": "2", "title": {"text": "Two"}}` + "\n" + `{"id": "3", "title": "Three"}` + "\n", "title", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reader transfer.Reader
+			var err error
+			if tt.format == transfer.CSV {
+				if reader, err = transfer.NewCSVReader(strings.NewReader(tt.source), nil); err != nil {
+					t.Fatalf("NewCSVReader: %v", err)
+				}
+			} else {
+				reader = transfer.NewNDJSONReader(strings.NewReader(tt.source), nil)
+			}
+
+			// A bad record is reported with its line, and reading goes on
+			var ids []string
+			var recordErr *transfer.RecordError
+			for {
+				record, err := reader.Read()
+				if err == io.EOF {
+					break
+				}
+				if errors.As(err, &recordErr) {
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Read: %v", err)
+				}
+				ids = append(ids, record.Id)
+			}
+			if strings.Join(ids, ",") != "1,3" {
+				t.Errorf("read records %q, want 1 and 3", ids)
+			}
+			if recordErr == nil || !strings.Contains(recordErr.Error(), tt.want) {
+				t.Fatalf("record error %v, want one naming %s", recordErr, tt.want)
+			}
+			if recordErr.Line != tt.line {
+				t.Errorf("record error on line %d, want %d", recordErr.Line, tt.line)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// reserved lists the special-purpose ranges not covered by the netip
+// predicates that no webhook receiver should live in
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which maps to any IPv4 address
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// Public reports whether addr is a public unicast address. Loopback, private,
+// link-local (such as the 169.254.169.254 metadata service), multicast and
+// other special-purpose addresses are not.
+func Public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// refusePrivate is a net.Dialer Control hook that refuses to connect to
+// addresses that are not public. It runs on the resolved address, so a
+// hostname that resolves, or later rebinds, to an internal address is
+// refused too.
+func refusePrivate(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("webhooks: refusing to connect to %s: %w", address, err)
+	}
+	if !Public(addrPort.Addr()) {
+		return fmt.Errorf("webhooks: refusing to connect to non-public address %s", addrPort.Addr())
+	}
+	return nil
+}
+
+// newTransport returns the transport of the dispatcher's client. Unless
+// private networks are allowed it only connects to public addresses, and it
+// ignores proxy settings so the check applies to the receiver itself.
+func newTransport(allowPrivate bool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if allowPrivate {
+		return transport
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   refusePrivate,
+	}
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
@@ -0,0 +1,256 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// batchSize is the number of due deliveries loaded at a time
+	batchSize = 100
+	// concurrency is the number of deliveries of a batch attempted at once
+	concurrency = 8
+	// maxResponseBody is how much of a response is read before closing it
+	maxResponseBody = 64 << 10
+)
+
+// Options controls how deliveries are attempted
+type Options struct {
+	// Attempts made before a delivery is moved to the dead-letter list
+	MaxAttempts int
+	// Wait after the first failed attempt, doubled after every further one
+	Backoff time.Duration
+	// Longest wait between two attempts
+	MaxBackoff time.Duration
+	// Timeout of one attempt
+	Timeout time.Duration
+	// Deliver to loopback, private and link-local addresses too. Only for
+	// tests and deployments where every organization is trusted.
+	AllowPrivateNetworks bool
+}
+
+// Dispatcher queues a delivery for every webhook subscribed to an event and
+// POSTs queued deliveries, retrying failures with exponential backoff
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	opts   Options
+}
+
+// NewDispatcher creates a dispatcher that keeps deliveries in store. Redirects
+// are not followed: a webhook must answer with a 2xx status itself. Unless
+// opts allows private networks, attempts to reach an address that is not
+// public fail without connecting.
+func NewDispatcher(store Store, opts Options) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		client: &http.Client{
+			Transport: newTransport(opts.AllowPrivateNetworks),
+			Timeout:   opts.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		opts: opts,
+	}
+}
+
+// now returns the current time at the microsecond precision kept by SQL stores
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// Queue queues a delivery of event for every matching webhook of its
+// organization. Queueing an event again is harmless: webhooks keep one
+// delivery per event, so the outbox relay may hand it over more than once.
+func (d *Dispatcher) Queue(ctx context.Context, event events.Event) error {
+	ctx = tenant.WithOrganization(ctx, event.OrganizationID)
+	webhooks, err := d.store.ListWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks of %s: %w", event.OrganizationID, err)
+	}
+
+	var deliveries []*ticketpb.WebhookDelivery
+	var body string
+	for _, webhook := range webhooks {
+		if !Matches(webhook, event) {
+			continue
+		}
+		if body == "" {
+			if body, err = Payload(event); err != nil {
+				return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+			}
+		}
+		queuedAt := timestamppb.New(now())
+		deliveries = append(deliveries, &ticketpb.WebhookDelivery{
+			Id:            uuid.New().String(),
+			WebhookId:     webhook.Id,
+			EventId:       event.ID,
+			EventType:     string(event.Type),
+			Payload:       body,
+			Status:        ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING,
+			NextAttemptAt: queuedAt,
+			CreatedAt:     queuedAt,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := d.store.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to queue %d deliveries of event %s: %w", len(deliveries), event.ID, err)
+	}
+	return nil
+}
+
+// Run attempts due deliveries every interval until ctx is done. Run it in
+// one ticket service only, or deliveries may be sent twice.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DeliverDue(ctx); err != nil {
+				log.Printf("Webhooks: Error delivering webhooks: %v", err)
+			}
+		}
+	}
+}
+
+// DeliverDue attempts every delivery whose next attempt is due and returns
+// the number of attempts made
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		due, err := d.store.DueDeliveries(ctx, now(), batchSize)
+		if err != nil {
+			return attempted, err
+		}
+
+		errs := make([]error, len(due))
+		slots := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, delivery := range due {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				errs[i] = d.attempt(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		attempted += len(due)
+		if err := errors.Join(errs...); err != nil {
+			return attempted, err
+		}
+		if len(due) < batchSize {
+			return attempted, nil
+		}
+	}
+}
+
+// attempt POSTs a delivery once and records the outcome, acting for the
+// delivery's organization
+func (d *Dispatcher) attempt(ctx context.Context, delivery *ticketpb.WebhookDelivery) error {
+	ctx = tenant.WithOrganization(ctx, delivery.OrganizationId)
+	webhook, err := d.store.GetWebhook(ctx, delivery.WebhookId)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil // deleted, taking its deliveries with it
+	}
+	if err != nil {
+		return err
+	}
+
+	at := now()
+	code, err := d.post(ctx, webhook, delivery, at)
+	delivery.Attempts++
+	delivery.LastAttemptAt = timestamppb.New(at)
+	delivery.LastStatusCode = int32(code)
+	delivery.LastError = ""
+	switch {
+	case err == nil:
+		delivery.Status = ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+		delivery.DeliveredAt = timestamppb.New(at)
+		delivery.NextAttemptAt = nil
+	case int(delivery.Attempts) >= d.opts.MaxAttempts:
+		log.Printf("Webhooks: delivery %s to %s failed %d times, moved to the dead-letter list: %v",
+			delivery.Id, webhook.Url, delivery.Attempts, err)
+		delivery.LastError = err.Error()
+		delivery.Status = ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD
+		delivery.NextAttemptAt = nil
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = timestamppb.New(at.Add(d.backoff(int(delivery.Attempts))))
+	}
+
+	if err := d.store.UpdateDelivery(ctx, delivery); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("failed to record delivery %s: %w", delivery.Id, err)
+	}
+	return nil
+}
+
+// post sends a delivery signed at at and returns the response status, or 0
+// when there was no response
+func (d *Dispatcher) post(ctx context.Context, webhook *ticketpb.Webhook, delivery *ticketpb.WebhookDelivery, at time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ticket-service-webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.Id)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(at.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, at, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait after the given number of failed attempts
+func (d *Dispatcher) backoff(failed int) time.Duration {
+	wait := d.opts.Backoff
+	for i := 1; i < failed && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.opts.MaxBackoff)
+}
+
+// Requeue prepares a delivery for redelivery at at with a fresh set of
+// attempts; its last attempt is kept for reference
+func Requeue(delivery *ticketpb.WebhookDelivery, at time.Time) {
+	delivery.Status = ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = timestamppb.New(at)
+	delivery.DeliveredAt = nil
+}
@@ -0,0 +1,219 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	"github.com/ayush-pandya/Graphql/internal/webhooks"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+const secret = "test-secret"
+
+// receiver is a webhook endpoint answering with the queued statuses, then 200
+type receiver struct {
+	t *testing.T
+
+	mu         sync.Mutex
+	statuses   []int
+	deliveries []string // DeliveryHeader of every request received
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("failed to read delivery: %v", err)
+	}
+	err = webhooks.Verify(secret, req.Header.Get(webhooks.TimestampHeader), req.Header.Get(webhooks.SignatureHeader),
+		body, time.Minute, time.Now())
+	if err != nil {
+		r.t.Errorf("Verify: %v", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, req.Header.Get(webhooks.DeliveryHeader))
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// received returns the delivery IDs of the requests received
+func (r *receiver) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.deliveries...)
+}
+
+// setup queues one delivery to a webhook at url and returns its ID
+func setup(t *testing.T, d *webhooks.Dispatcher, store *webhooks.MemoryStore, url string) (context.Context, string) {
+	t.Helper()
+	ctx := tenant.WithOrganization(context.Background(), "acme")
+	err := store.CreateWebhook(ctx, &ticketpb.Webhook{
+		Id:         "webhook-1",
+		Url:        url,
+		Secret:     secret,
+		EventTypes: []string{string(events.TicketCreated)},
+		Active:     true,
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+
+	event := events.New(events.TicketCreated, &ticketpb.Ticket{Id: "ticket-1", Title: "Printer on fire"}, time.Now())
+	event.OrganizationID = "acme"
+	if err := d.Queue(context.Background(), event); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	deliveries, err := store.ListDeliveries(ctx, "webhook-1", ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED, 10)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("ListDeliveries = %d deliveries, %v; want 1", len(deliveries), err)
+	}
+	return ctx, deliveries[0].Id
+}
+
+// deliverDue attempts the due deliveries once and returns the delivery
+func deliverDue(t *testing.T, ctx context.Context, d *webhooks.Dispatcher, store *webhooks.MemoryStore, id string) *ticketpb.WebhookDelivery {
+	t.Helper()
+	if _, err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("DeliverDue: %v", err)
+	}
+	delivery, err := store.GetDelivery(ctx, id)
+	if err != nil {
+		t.Fatalf("GetDelivery: %v", err)
+	}
+	return delivery
+}
+
+// waitUntilDue sleeps until the next attempt of a delivery is due
+func waitUntilDue(delivery *ticketpb.WebhookDelivery) {
+	time.Sleep(time.Until(delivery.NextAttemptAt.AsTime()) + time.Millisecond)
+}
+
+func TestDispatcherRetriesAndDeadLetters(t *testing.T) {
+	recv := &receiver{t: t, statuses: []int{
+		http.StatusInternalServerError, http.StatusFound, http.StatusBadGateway,
+	}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	store := webhooks.NewMemoryStore()
+	d := webhooks.NewDispatcher(store, webhooks.Options{
+		MaxAttempts:          3,
+		Backoff:              20 * time.Millisecond,
+		MaxBackoff:           30 * time.Millisecond,
+		Timeout:              5 * time.Second,
+		AllowPrivateNetworks: true,
+	})
+	ctx, id := setup(t, d, store, server.URL)
+
+	// Failures are retried after the backoff, doubled up to the maximum
+	for attempt, wait := range []time.Duration{20 * time.Millisecond, 30 * time.Millisecond} {
+		delivery := deliverDue(t, ctx, d, store, id)
+		if delivery.Status != ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING || delivery.Attempts != int32(attempt+1) {
+			t.Fatalf("after attempt %d: status %s with %d attempts, want PENDING", attempt+1, delivery.Status, delivery.Attempts)
+		}
+		if got := delivery.NextAttemptAt.AsTime().Sub(delivery.LastAttemptAt.AsTime()); got != wait {
+			t.Errorf("after attempt %d: next attempt in %s, want %s", attempt+1, got, wait)
+		}
+		if n, _ := d.DeliverDue(context.Background()); n != 0 {
+			t.Fatalf("after attempt %d: DeliverDue made %d attempts before the backoff", attempt+1, n)
+		}
+		waitUntilDue(delivery)
+	}
+
+	// The last allowed failure moves the delivery to the dead-letter list
+	delivery := deliverDue(t, ctx, d, store, id)
+	if delivery.Status != ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD || delivery.LastStatusCode != http.StatusBadGateway {
+		t.Fatalf("after the last attempt: status %s, code %d; want DEAD, 502", delivery.Status, delivery.LastStatusCode)
+	}
+	if n, _ := d.DeliverDue(context.Background()); n != 0 {
+		t.Fatalf("DeliverDue attempted a dead delivery %d times", n)
+	}
+
+	// Redelivery starts a fresh set of attempts
+	webhooks.Requeue(delivery, time.Now())
+	if err := store.UpdateDelivery(ctx, delivery); err != nil {
+		t.Fatalf("UpdateDelivery: %v", err)
+	}
+	delivery = deliverDue(t, ctx, d, store, id)
+	if delivery.Status != ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+		t.Fatalf("after redelivery: status %s with %d attempts, want SUCCEEDED after 1", delivery.Status, delivery.Attempts)
+	}
+
+	received := recv.received()
+	if len(received) != 4 {
+		t.Fatalf("receiver got %d requests, want 4", len(received))
+	}
+	for _, got := range received {
+		if got != id {
+			t.Errorf("%s = %q, want the stable delivery ID %q", webhooks.DeliveryHeader, got, id)
+		}
+	}
+}
+
+func TestDispatcherRefusesPrivateAddresses(t *testing.T) {
+	recv := &receiver{t: t}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	store := webhooks.NewMemoryStore()
+	d := webhooks.NewDispatcher(store, webhooks.Options{
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  time.Hour,
+		Timeout:     5 * time.Second,
+	})
+	ctx, id := setup(t, d, store, server.URL)
+
+	delivery := deliverDue(t, ctx, d, store, id)
+	if delivery.Status != ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING || !strings.Contains(delivery.LastError, "non-public address") {
+		t.Fatalf("delivery to %s: status %s, error %q; want a refused attempt", server.URL, delivery.Status, delivery.LastError)
+	}
+	if len(recv.received()) != 0 {
+		t.Fatal("the receiver on a loopback address got the delivery")
+	}
+}
+
+func TestPublic(t *testing.T) {
+	for _, tt := range []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	} {
+		if got := webhooks.Public(netip.MustParseAddr(tt.addr)); got != tt.public {
+			t.Errorf("Public(%s) = %t, want %t", tt.addr, got, tt.public)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package webhooks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/store"
+	"github.com/ayush-pandya/Graphql/internal/tenant"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+	"google.golang.org/protobuf/proto"
+)
+
+// MemoryStore keeps webhooks and deliveries in process. Like store.MemoryStore
+// it copies them on the way in and out.
+type MemoryStore struct {
+	mu         sync.RWMutex
+	webhooks   map[string]*ticketpb.Webhook
+	deliveries map[string]*ticketpb.WebhookDelivery
+}
+
+var _ Store = (*MemoryStore)(nil)
+var _ Store = (*store.SQLiteWebhooks)(nil)
+var _ Store = (*store.PostgresWebhooks)(nil)
+
+// NewMemoryStore creates an empty in-memory webhook store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		webhooks:   make(map[string]*ticketpb.Webhook),
+		deliveries: make(map[string]*ticketpb.WebhookDelivery),
+	}
+}
+
+// CreateWebhook stores a new webhook
+func (m *MemoryStore) CreateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	webhook.OrganizationId = tenant.ID(ctx)
+	m.webhooks[webhook.Id] = proto.Clone(webhook).(*ticketpb.Webhook)
+	return nil
+}
+
+// GetWebhook retrieves a webhook
+func (m *MemoryStore) GetWebhook(ctx context.Context, id string) (*ticketpb.Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	webhook := m.webhookLocked(ctx, id)
+	if webhook == nil {
+		return nil, store.ErrNotFound
+	}
+	return proto.Clone(webhook).(*ticketpb.Webhook), nil
+}
+
+// webhookLocked returns a webhook of the organization of ctx, or nil.
+// Callers hold m.mu.
+func (m *MemoryStore) webhookLocked(ctx context.Context, id string) *ticketpb.Webhook {
+	webhook, exists := m.webhooks[id]
+	if !exists || webhook.OrganizationId != tenant.ID(ctx) {
+		return nil
+	}
+	return webhook
+}
+
+// ListWebhooks returns every webhook, oldest first
+func (m *MemoryStore) ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	organizationID := tenant.ID(ctx)
+	var webhooks []*ticketpb.Webhook
+	for _, webhook := range m.webhooks {
+		if webhook.OrganizationId == organizationID {
+			webhooks = append(webhooks, proto.Clone(webhook).(*ticketpb.Webhook))
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		a, b := webhooks[i], webhooks[j]
+		if !a.CreatedAt.AsTime().Equal(b.CreatedAt.AsTime()) {
+			return a.CreatedAt.AsTime().Before(b.CreatedAt.AsTime())
+		}
+		return a.Id < b.Id
+	})
+	return webhooks, nil
+}
+
+// UpdateWebhook replaces a stored webhook
+func (m *MemoryStore) UpdateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.webhookLocked(ctx, webhook.Id) == nil {
+		return store.ErrNotFound
+	}
+	webhook.OrganizationId = tenant.ID(ctx)
+	m.webhooks[webhook.Id] = proto.Clone(webhook).(*ticketpb.Webhook)
+	return nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries
+func (m *MemoryStore) DeleteWebhook(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.webhookLocked(ctx, id) == nil {
+		return store.ErrNotFound
+	}
+	delete(m.webhooks, id)
+	for deliveryID, delivery := range m.deliveries {
+		if delivery.WebhookId == id {
+			delete(m.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// CreateDeliveries stores new deliveries
+func (m *MemoryStore) CreateDeliveries(ctx context.Context, deliveries []*ticketpb.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		if m.webhookLocked(ctx, delivery.WebhookId) == nil {
+			return store.ErrNotFound
+		}
+	}
+	for _, delivery := range deliveries {
+		delivery.OrganizationId = tenant.ID(ctx)
+		if !m.queuedLocked(delivery.WebhookId, delivery.EventId) {
+			m.deliveries[delivery.Id] = proto.Clone(delivery).(*ticketpb.WebhookDelivery)
+		}
+	}
+	return nil
+}
+
+// queuedLocked reports whether a webhook has a delivery of an event. Callers
+// hold m.mu.
+func (m *MemoryStore) queuedLocked(webhookID, eventID string) bool {
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookId == webhookID && delivery.EventId == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDelivery retrieves a delivery
+func (m *MemoryStore) GetDelivery(ctx context.Context, id string) (*ticketpb.WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	delivery, exists := m.deliveries[id]
+	if !exists || delivery.OrganizationId != tenant.ID(ctx) {
+		return nil, store.ErrNotFound
+	}
+	return proto.Clone(delivery).(*ticketpb.WebhookDelivery), nil
+}
+
+// ListDeliveries returns the deliveries of a webhook, newest first
+func (m *MemoryStore) ListDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int) ([]*ticketpb.WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.webhookLocked(ctx, webhookID) == nil {
+		return nil, store.ErrNotFound
+	}
+	var deliveries []*ticketpb.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookId == webhookID &&
+			(status == ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED || delivery.Status == status) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		a, b := deliveries[i], deliveries[j]
+		if !a.CreatedAt.AsTime().Equal(b.CreatedAt.AsTime()) {
+			return a.CreatedAt.AsTime().After(b.CreatedAt.AsTime())
+		}
+		return a.Id > b.Id
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return cloneDeliveries(deliveries), nil
+}
+
+// UpdateDelivery replaces a stored delivery
+func (m *MemoryStore) UpdateDelivery(ctx context.Context, delivery *ticketpb.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.deliveries[delivery.Id]
+	if !exists || current.OrganizationId != tenant.ID(ctx) {
+		return store.ErrNotFound
+	}
+	delivery.OrganizationId = current.OrganizationId
+	m.deliveries[delivery.Id] = proto.Clone(delivery).(*ticketpb.WebhookDelivery)
+	return nil
+}
+
+// DueDeliveries returns the pending deliveries of every organization whose
+// next attempt is due
+func (m *MemoryStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*ticketpb.WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var due []*ticketpb.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.Status == ticketpb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING &&
+			!delivery.NextAttemptAt.AsTime().After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		a, b := due[i], due[j]
+		if !a.NextAttemptAt.AsTime().Equal(b.NextAttemptAt.AsTime()) {
+			return a.NextAttemptAt.AsTime().Before(b.NextAttemptAt.AsTime())
+		}
+		return a.Id < b.Id
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return cloneDeliveries(due), nil
+}
+
+// cloneDeliveries copies deliveries handed out by the store
+func cloneDeliveries(deliveries []*ticketpb.WebhookDelivery) []*ticketpb.WebhookDelivery {
+	cloned := make([]*ticketpb.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		cloned[i] = proto.Clone(delivery).(*ticketpb.WebhookDelivery)
+	}
+	return cloned
+}
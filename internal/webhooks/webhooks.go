@@ -0,0 +1,160 @@
+// Package webhooks delivers ticket events to subscribed URLs as signed JSON
+// POSTs, retrying failed deliveries with exponential backoff until they
+// succeed or land on the dead-letter list
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ayush-pandya/Graphql/internal/events"
+	ticketpb "github.com/ayush-pandya/Graphql/proto/ticket"
+)
+
+// Headers set on every delivery
+const (
+	// EventHeader names the event type
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader carries the delivery ID, which stays the same across retries
+	DeliveryHeader = "X-Webhook-Delivery"
+	// TimestampHeader carries the Unix time of the attempt, covered by the signature
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the webhook's secret
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Store persists webhooks and their deliveries. Every method acts for the
+// organization of ctx (see tenant.ID) except DueDeliveries, and returns
+// store.ErrNotFound for unknown IDs. Deleting a webhook deletes its
+// deliveries.
+type Store interface {
+	// CreateWebhook stores a new webhook, setting OrganizationId from ctx
+	CreateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error
+	// GetWebhook retrieves a webhook, secret included
+	GetWebhook(ctx context.Context, id string) (*ticketpb.Webhook, error)
+	// ListWebhooks returns every webhook, secrets included, oldest first
+	ListWebhooks(ctx context.Context) ([]*ticketpb.Webhook, error)
+	// UpdateWebhook replaces a stored webhook
+	UpdateWebhook(ctx context.Context, webhook *ticketpb.Webhook) error
+	// DeleteWebhook removes a webhook and its deliveries
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// CreateDeliveries stores new deliveries, setting OrganizationId from ctx.
+	// Deliveries of an event its webhook already has are skipped, so an event
+	// handled twice is queued once.
+	CreateDeliveries(ctx context.Context, deliveries []*ticketpb.WebhookDelivery) error
+	// GetDelivery retrieves a delivery
+	GetDelivery(ctx context.Context, id string) (*ticketpb.WebhookDelivery, error)
+	// ListDeliveries returns up to limit deliveries of a webhook, newest
+	// first, only those with status unless it is UNSPECIFIED
+	ListDeliveries(ctx context.Context, webhookID string, status ticketpb.WebhookDeliveryStatus, limit int) ([]*ticketpb.WebhookDelivery, error)
+	// UpdateDelivery replaces a stored delivery
+	UpdateDelivery(ctx context.Context, delivery *ticketpb.WebhookDelivery) error
+	// DueDeliveries returns up to limit pending deliveries whose next attempt
+	// is at or before now, earliest first. Like store.SLAStore it spans all
+	// organizations: the dispatcher then acts for each delivery's own.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*ticketpb.WebhookDelivery, error)
+}
+
+// ValidateURL checks that a webhook URL is an absolute http or https URL
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL %q: %w", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: want an absolute http or https URL", raw)
+	}
+	return nil
+}
+
+// ValidateEventTypes checks that at least one event type is given and that
+// every one is known
+func ValidateEventTypes(types []string) error {
+	if len(types) == 0 {
+		return fmt.Errorf("at least one event type is required")
+	}
+	for _, t := range types {
+		if !events.Type(t).Valid() {
+			return fmt.Errorf("unknown event type %q", t)
+		}
+	}
+	return nil
+}
+
+// NewSecret returns a random signing secret
+func NewSecret() string {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("webhooks: failed to generate secret: %v", err))
+	}
+	return hex.EncodeToString(key)
+}
+
+// Matches reports whether an active webhook subscribes to an event about
+// its ticket
+func Matches(webhook *ticketpb.Webhook, event events.Event) bool {
+	if !webhook.Active || !slices.Contains(webhook.EventTypes, string(event.Type)) {
+		return false
+	}
+	filter, ticket := webhook.Filter, event.Ticket
+	if filter == nil {
+		return true
+	}
+	if filter.ProjectId != "" && filter.ProjectId != ticket.ProjectId {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(ticket.Tags, tag) {
+			return false
+		}
+	}
+	return len(filter.Priorities) == 0 || slices.Contains(filter.Priorities, ticket.Priority)
+}
+
+// Payload encodes an event as the JSON body of its deliveries (see
+// events.Event.MarshalJSON)
+func Payload(event events.Event) (string, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// Sign returns the SignatureHeader value of a body sent at timestamp
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a received delivery and that its timestamp
+// lies within tolerance of now, which guards against replays. Receivers
+// written in Go can call it with the header values.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook timestamp %q", timestamp)
+	}
+	sent := time.Unix(unix, 0)
+	if sent.Before(now.Add(-tolerance)) || sent.After(now.Add(tolerance)) {
+		return fmt.Errorf("webhook timestamp %s is outside the tolerance", sent.UTC().Format(time.RFC3339))
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, sent, body))) {
+		return fmt.Errorf("webhook signature mismatch")
+	}
+	return nil
+}
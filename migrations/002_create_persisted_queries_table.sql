@@ -0,0 +1,6 @@
+-- Create persisted queries table used by the gateway's postgres APQ store
+CREATE TABLE IF NOT EXISTS persisted_queries (
+    hash CHAR(64) PRIMARY KEY,
+    query TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
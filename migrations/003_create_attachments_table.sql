@@ -0,0 +1,13 @@
+-- Create attachments table; content is kept in the configured blob store
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    ticket_id VARCHAR(100) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_ticket_id ON attachments(ticket_id);
@@ -0,0 +1,2 @@
+-- Add the reporter column written by the ticket service
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS reporter_id VARCHAR(100);
@@ -0,0 +1,2 @@
+-- Index tag arrays so tag filters (tags @> ...) and tag merges (tags && ...) avoid full scans
+CREATE INDEX IF NOT EXISTS idx_tickets_tags ON tickets USING GIN (tags);
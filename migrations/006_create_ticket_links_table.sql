@@ -0,0 +1,11 @@
+-- Create typed links between tickets; "source blocks target", "source is the parent of target", ...
+CREATE TABLE IF NOT EXISTS ticket_links (
+    source_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    link_type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_id, target_id, link_type),
+    CHECK (source_id <> target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_links_target_id ON ticket_links(target_id);
@@ -0,0 +1,15 @@
+-- Create projects; last_number is the number of the last ticket created in the project
+CREATE TABLE IF NOT EXISTS projects (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key VARCHAR(10) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    last_number BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Tickets created in a project carry its key and their number, e.g. WEB-42
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS project_id UUID REFERENCES projects(id);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS key VARCHAR(32) UNIQUE;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_project_id ON tickets(project_id, created_at DESC);
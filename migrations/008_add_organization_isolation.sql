@@ -0,0 +1,40 @@
+-- Partition tickets and projects by organization; existing rows belong to the default organization
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS organization_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS organization_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+-- Project and ticket keys are unique within an organization
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_key_key;
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_key_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_organization_key ON projects(organization_id, key);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_organization_key ON tickets(organization_id, key);
+CREATE INDEX IF NOT EXISTS idx_tickets_organization_id ON tickets(organization_id, created_at DESC);
+
+-- Row-level security admits only the rows of the organization set with
+-- set_config('app.organization_id', ...) by the current transaction; without
+-- one no rows are visible. FORCE applies the policies to the table owner too,
+-- so the service must connect as a role that is neither a superuser nor BYPASSRLS.
+ALTER TABLE tickets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tickets FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tickets_organization_isolation ON tickets;
+CREATE POLICY tickets_organization_isolation ON tickets
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+ALTER TABLE projects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE projects FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS projects_organization_isolation ON projects;
+CREATE POLICY projects_organization_isolation ON projects
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+-- Links belong to the organization of their tickets; the subqueries are
+-- themselves filtered by the tickets policy
+ALTER TABLE ticket_links ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_links FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS ticket_links_organization_isolation ON ticket_links;
+CREATE POLICY ticket_links_organization_isolation ON ticket_links
+    USING (EXISTS (SELECT 1 FROM tickets WHERE tickets.id = ticket_links.source_id))
+    WITH CHECK (
+        EXISTS (SELECT 1 FROM tickets WHERE tickets.id = ticket_links.source_id)
+        AND EXISTS (SELECT 1 FROM tickets WHERE tickets.id = ticket_links.target_id)
+    );
@@ -0,0 +1,6 @@
+-- Custom fields: projects define them, tickets hold their values keyed by field key
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+-- Serves the custom_fields @> filters of ListTickets
+CREATE INDEX IF NOT EXISTS idx_tickets_custom_fields ON tickets USING GIN (custom_fields jsonb_path_ops);
@@ -0,0 +1,19 @@
+-- Due dates and SLA deadlines; sla_status is NULL for tickets without an SLA
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS due_date TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_first_response_due_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_resolution_due_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_first_responded_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_resolved_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_status VARCHAR(20);
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sla_next_check_at TIMESTAMP WITH TIME ZONE;
+
+-- Serves the SLA evaluator's scan for tickets whose next check is due
+CREATE INDEX IF NOT EXISTS idx_tickets_sla_next_check_at ON tickets(sla_next_check_at) WHERE sla_next_check_at IS NOT NULL;
+
+-- The SLA evaluator reads tickets of every organization in read-only
+-- transactions that set app.sla_evaluation (database.WithAllOrganizations).
+-- Policies are permissive, so this only adds read access; writes are still
+-- checked against app.organization_id.
+DROP POLICY IF EXISTS tickets_sla_evaluation ON tickets;
+CREATE POLICY tickets_sla_evaluation ON tickets FOR SELECT
+    USING (current_setting('app.sla_evaluation', true) = 'on');
@@ -0,0 +1,63 @@
+-- Webhook subscriptions; filter holds the project ID, tags and priorities a webhook is limited to
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id VARCHAR(63) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}'::jsonb,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_organization_id ON webhooks(organization_id, created_at);
+
+-- One event on its way to one webhook; status is PENDING, SUCCEEDED or DEAD
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id VARCHAR(63) NOT NULL,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(63) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_status_code INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at DESC);
+-- Serves the dispatcher's scan for deliveries whose next attempt is due
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+
+ALTER TABLE webhooks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE webhooks FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS webhooks_organization_isolation ON webhooks;
+CREATE POLICY webhooks_organization_isolation ON webhooks
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+ALTER TABLE webhook_deliveries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE webhook_deliveries FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS webhook_deliveries_organization_isolation ON webhook_deliveries;
+CREATE POLICY webhook_deliveries_organization_isolation ON webhook_deliveries
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+-- Background workers (the SLA evaluator and the webhook dispatcher) read
+-- across organizations in read-only transactions that set
+-- app.all_organizations (database.WithAllOrganizations); this replaces the
+-- SLA-only setting of migration 010
+DROP POLICY IF EXISTS tickets_sla_evaluation ON tickets;
+DROP POLICY IF EXISTS tickets_all_organizations ON tickets;
+CREATE POLICY tickets_all_organizations ON tickets FOR SELECT
+    USING (current_setting('app.all_organizations', true) = 'on');
+
+DROP POLICY IF EXISTS webhook_deliveries_all_organizations ON webhook_deliveries;
+CREATE POLICY webhook_deliveries_all_organizations ON webhook_deliveries FOR SELECT
+    USING (current_setting('app.all_organizations', true) = 'on');
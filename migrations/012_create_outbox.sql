@@ -0,0 +1,27 @@
+-- Ticket events recorded by the transaction that caused them, until the
+-- relay publishes them in sequence order; payload is the JSON encoding of
+-- the event
+CREATE TABLE IF NOT EXISTS outbox (
+    sequence BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    organization_id VARCHAR(63) NOT NULL,
+    event_type VARCHAR(63) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    payload JSONB NOT NULL
+);
+
+ALTER TABLE outbox ENABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS outbox_organization_isolation ON outbox;
+CREATE POLICY outbox_organization_isolation ON outbox
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+-- The relay reads every organization's events (database.WithAllOrganizations)
+-- and deletes each one acting for its organization
+DROP POLICY IF EXISTS outbox_all_organizations ON outbox;
+CREATE POLICY outbox_all_organizations ON outbox FOR SELECT
+    USING (current_setting('app.all_organizations', true) = 'on');
+
+-- An event relayed twice is queued once per webhook
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(webhook_id, event_id);
@@ -0,0 +1,70 @@
+-- Users watching a ticket. No foreign key to tickets: the notifier tells
+-- the watchers of a deleted ticket and then removes them.
+CREATE TABLE IF NOT EXISTS ticket_watchers (
+    organization_id VARCHAR(63) NOT NULL,
+    ticket_id UUID NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, ticket_id, user_id)
+);
+
+-- In-app inbox entries; deliver_at is when the delivery channels (email)
+-- send one, NULL when they do not or already did. A user is notified once
+-- per event.
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id VARCHAR(63) NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    kind VARCHAR(31) NOT NULL,
+    ticket_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP WITH TIME ZONE,
+    deliver_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (organization_id, user_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(organization_id, user_id, created_at DESC);
+-- Serves the notifier's scan for notifications whose delivery is due
+CREATE INDEX IF NOT EXISTS idx_notifications_deliver_at ON notifications(deliver_at) WHERE deliver_at IS NOT NULL;
+
+-- delivery is IMMEDIATE, DAILY_DIGEST or OFF; muted_kinds holds kinds such as ASSIGNED
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    organization_id VARCHAR(63) NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    email VARCHAR(254) NOT NULL DEFAULT '',
+    delivery VARCHAR(20) NOT NULL,
+    digest_hour SMALLINT NOT NULL,
+    muted_kinds TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+ALTER TABLE ticket_watchers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_watchers FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS ticket_watchers_organization_isolation ON ticket_watchers;
+CREATE POLICY ticket_watchers_organization_isolation ON ticket_watchers
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+ALTER TABLE notifications ENABLE ROW LEVEL SECURITY;
+ALTER TABLE notifications FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS notifications_organization_isolation ON notifications;
+CREATE POLICY notifications_organization_isolation ON notifications
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+ALTER TABLE notification_preferences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE notification_preferences FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS notification_preferences_organization_isolation ON notification_preferences;
+CREATE POLICY notification_preferences_organization_isolation ON notification_preferences
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+-- The notifier looks for due notifications across organizations
+-- (database.WithAllOrganizations) and sends each one acting for its organization
+DROP POLICY IF EXISTS notifications_all_organizations ON notifications;
+CREATE POLICY notifications_all_organizations ON notifications FOR SELECT
+    USING (current_setting('app.all_organizations', true) = 'on');
@@ -0,0 +1,38 @@
+-- Comments on tickets. No foreign key to tickets, like ticket_watchers: the
+-- ticket service removes the comments of a ticket it deletes.
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id VARCHAR(63) NOT NULL,
+    ticket_id UUID NOT NULL,
+    author_id VARCHAR(254) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_ticket_id ON comments(organization_id, ticket_id, created_at, id);
+
+-- The email thread of each ticket: the Message-IDs of the emails that
+-- created it or were added to it as comments, so replies find it
+CREATE TABLE IF NOT EXISTS email_messages (
+    organization_id VARCHAR(63) NOT NULL,
+    message_id VARCHAR(998) NOT NULL,
+    ticket_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_messages_ticket_id ON email_messages(organization_id, ticket_id);
+
+ALTER TABLE comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comments FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS comments_organization_isolation ON comments;
+CREATE POLICY comments_organization_isolation ON comments
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
+
+ALTER TABLE email_messages ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_messages FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS email_messages_organization_isolation ON email_messages;
+CREATE POLICY email_messages_organization_isolation ON email_messages
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
@@ -0,0 +1,23 @@
+-- Idempotency keys of ticket service calls: the method and request hash a
+-- key was first used with and, once that call succeeded, its response.
+-- Rows live until expires_at; the ticket service purges expired ones.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    organization_id VARCHAR(63) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    request_hash BYTEA NOT NULL,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (organization_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(organization_id, expires_at);
+
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS idempotency_keys_organization_isolation ON idempotency_keys;
+CREATE POLICY idempotency_keys_organization_isolation ON idempotency_keys
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
@@ -0,0 +1,19 @@
+-- Attachments belong to the organization of their ticket. Existing rows take
+-- it from the ticket, or the default organization when it is gone; run this
+-- as the table owner so the tickets policy does not hide the tickets.
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS organization_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+UPDATE attachments
+SET organization_id = tickets.organization_id
+FROM tickets
+WHERE tickets.id::text = attachments.ticket_id;
+
+DROP INDEX IF EXISTS idx_attachments_ticket_id;
+CREATE INDEX IF NOT EXISTS idx_attachments_ticket_id ON attachments(organization_id, ticket_id, created_at);
+
+ALTER TABLE attachments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attachments FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS attachments_organization_isolation ON attachments;
+CREATE POLICY attachments_organization_isolation ON attachments
+    USING (organization_id = current_setting('app.organization_id', true))
+    WITH CHECK (organization_id = current_setting('app.organization_id', true));
@@ -0,0 +1,5 @@
+-- Track when each persisted query was last used, so the gateway can expire
+-- unused queries and evict the least recently used ones
+ALTER TABLE persisted_queries ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_persisted_queries_last_used_at ON persisted_queries(last_used_at);
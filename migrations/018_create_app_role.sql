@@ -0,0 +1,20 @@
+-- The services connect as a member of ticket_app. Row-level security does not
+-- apply to superusers or BYPASSRLS roles, so the role that runs these
+-- migrations and owns the tables must not be the one the services use. Give
+-- each service a login role of its own, e.g.
+--   CREATE ROLE ticket_service LOGIN PASSWORD '...' IN ROLE ticket_app;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'ticket_app') THEN
+        CREATE ROLE ticket_app NOLOGIN NOSUPERUSER NOBYPASSRLS;
+    END IF;
+END
+$$;
+
+GRANT USAGE ON SCHEMA public TO ticket_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO ticket_app;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO ticket_app;
+
+-- Tables created by later migrations, run by the same owner, are granted too
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO ticket_app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO ticket_app;
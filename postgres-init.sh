@@ -0,0 +1,23 @@
+#!/bin/bash
+
+# Initializes a new PostgreSQL container: applies migrations/ as POSTGRES_USER,
+# which owns the tables, then creates the APP_DB_USER login role the services
+# connect as. Row-level security does not apply to superusers, so the services
+# must never connect as POSTGRES_USER.
+#
+# docker-compose.yml and start-postgres.sh mount this script in
+# /docker-entrypoint-initdb.d and migrations/ in /migrations. PostgreSQL runs
+# it only when the data volume is empty.
+
+set -euo pipefail
+
+for migration in /migrations/*.sql; do
+    echo "📜 Applying ${migration##*/}"
+    psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" -f "$migration"
+done
+
+echo "👤 Creating role $APP_DB_USER"
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" \
+    -v user="$APP_DB_USER" -v password="$APP_DB_PASSWORD" <<'SQL'
+CREATE ROLE :"user" LOGIN PASSWORD :'password' IN ROLE ticket_app;
+SQL
@@ -0,0 +1,9 @@
+package ticket
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI (Swagger 2.0) document of the REST API mapped by
+// the google.api.http options of ticket.proto
+//
+//go:embed ticket.swagger.json
+var OpenAPI []byte
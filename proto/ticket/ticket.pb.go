@@ -7,9 +7,13 @@
 package ticket
 
 import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +26,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Where a ticket stands against its SLA deadlines
+type SLAStatus int32
+
+const (
+	SLAStatus_SLA_STATUS_UNSPECIFIED SLAStatus = 0
+	SLAStatus_SLA_STATUS_ON_TRACK    SLAStatus = 1
+	// Less than the at-risk share of the pending deadline's window is left
+	SLAStatus_SLA_STATUS_AT_RISK SLAStatus = 2
+	// A deadline was missed; a breached ticket stays breached
+	SLAStatus_SLA_STATUS_BREACHED SLAStatus = 3
+	// Every deadline was met
+	SLAStatus_SLA_STATUS_MET SLAStatus = 4
+)
+
+// Enum value maps for SLAStatus.
+var (
+	SLAStatus_name = map[int32]string{
+		0: "SLA_STATUS_UNSPECIFIED",
+		1: "SLA_STATUS_ON_TRACK",
+		2: "SLA_STATUS_AT_RISK",
+		3: "SLA_STATUS_BREACHED",
+		4: "SLA_STATUS_MET",
+	}
+	SLAStatus_value = map[string]int32{
+		"SLA_STATUS_UNSPECIFIED": 0,
+		"SLA_STATUS_ON_TRACK":    1,
+		"SLA_STATUS_AT_RISK":     2,
+		"SLA_STATUS_BREACHED":    3,
+		"SLA_STATUS_MET":         4,
+	}
+)
+
+func (x SLAStatus) Enum() *SLAStatus {
+	p := new(SLAStatus)
+	*p = x
+	return p
+}
+
+func (x SLAStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SLAStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[0].Descriptor()
+}
+
+func (SLAStatus) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[0]
+}
+
+func (x SLAStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SLAStatus.Descriptor instead.
+func (SLAStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{0}
+}
+
 // Enums
 type TicketStatus int32
 
@@ -62,11 +125,11 @@ func (x TicketStatus) String() string {
 }
 
 func (TicketStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_ticket_ticket_proto_enumTypes[0].Descriptor()
+	return file_proto_ticket_ticket_proto_enumTypes[1].Descriptor()
 }
 
 func (TicketStatus) Type() protoreflect.EnumType {
-	return &file_proto_ticket_ticket_proto_enumTypes[0]
+	return &file_proto_ticket_ticket_proto_enumTypes[1]
 }
 
 func (x TicketStatus) Number() protoreflect.EnumNumber {
@@ -75,7 +138,7 @@ func (x TicketStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TicketStatus.Descriptor instead.
 func (TicketStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{0}
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{1}
 }
 
 type TicketPriority int32
@@ -117,11 +180,11 @@ func (x TicketPriority) String() string {
 }
 
 func (TicketPriority) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_ticket_ticket_proto_enumTypes[1].Descriptor()
+	return file_proto_ticket_ticket_proto_enumTypes[2].Descriptor()
 }
 
 func (TicketPriority) Type() protoreflect.EnumType {
-	return &file_proto_ticket_ticket_proto_enumTypes[1]
+	return &file_proto_ticket_ticket_proto_enumTypes[2]
 }
 
 func (x TicketPriority) Number() protoreflect.EnumNumber {
@@ -130,21 +193,453 @@ func (x TicketPriority) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TicketPriority.Descriptor instead.
 func (TicketPriority) EnumDescriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{1}
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{2}
+}
+
+// How the source ticket of a link relates to its target
+type LinkType int32
+
+const (
+	LinkType_LINK_TYPE_UNSPECIFIED LinkType = 0
+	// The source must be resolved before the target
+	LinkType_LINK_TYPE_BLOCKS     LinkType = 1
+	LinkType_LINK_TYPE_DUPLICATES LinkType = 2
+	LinkType_LINK_TYPE_RELATES_TO LinkType = 3
+	// The target is a sub-task of the source
+	LinkType_LINK_TYPE_PARENT_OF LinkType = 4
+)
+
+// Enum value maps for LinkType.
+var (
+	LinkType_name = map[int32]string{
+		0: "LINK_TYPE_UNSPECIFIED",
+		1: "LINK_TYPE_BLOCKS",
+		2: "LINK_TYPE_DUPLICATES",
+		3: "LINK_TYPE_RELATES_TO",
+		4: "LINK_TYPE_PARENT_OF",
+	}
+	LinkType_value = map[string]int32{
+		"LINK_TYPE_UNSPECIFIED": 0,
+		"LINK_TYPE_BLOCKS":      1,
+		"LINK_TYPE_DUPLICATES":  2,
+		"LINK_TYPE_RELATES_TO":  3,
+		"LINK_TYPE_PARENT_OF":   4,
+	}
+)
+
+func (x LinkType) Enum() *LinkType {
+	p := new(LinkType)
+	*p = x
+	return p
+}
+
+func (x LinkType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LinkType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[3].Descriptor()
+}
+
+func (LinkType) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[3]
+}
+
+func (x LinkType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LinkType.Descriptor instead.
+func (LinkType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{3}
+}
+
+// Type of the value of a custom field
+type CustomFieldType int32
+
+const (
+	CustomFieldType_CUSTOM_FIELD_TYPE_UNSPECIFIED CustomFieldType = 0
+	CustomFieldType_CUSTOM_FIELD_TYPE_TEXT        CustomFieldType = 1
+	// Decimal number, stored without trailing zeros (e.g. 2.5)
+	CustomFieldType_CUSTOM_FIELD_TYPE_NUMBER CustomFieldType = 2
+	// One of the definition's options
+	CustomFieldType_CUSTOM_FIELD_TYPE_ENUM CustomFieldType = 3
+	// Calendar date, stored as YYYY-MM-DD
+	CustomFieldType_CUSTOM_FIELD_TYPE_DATE CustomFieldType = 4
+	// User ID
+	CustomFieldType_CUSTOM_FIELD_TYPE_USER CustomFieldType = 5
+)
+
+// Enum value maps for CustomFieldType.
+var (
+	CustomFieldType_name = map[int32]string{
+		0: "CUSTOM_FIELD_TYPE_UNSPECIFIED",
+		1: "CUSTOM_FIELD_TYPE_TEXT",
+		2: "CUSTOM_FIELD_TYPE_NUMBER",
+		3: "CUSTOM_FIELD_TYPE_ENUM",
+		4: "CUSTOM_FIELD_TYPE_DATE",
+		5: "CUSTOM_FIELD_TYPE_USER",
+	}
+	CustomFieldType_value = map[string]int32{
+		"CUSTOM_FIELD_TYPE_UNSPECIFIED": 0,
+		"CUSTOM_FIELD_TYPE_TEXT":        1,
+		"CUSTOM_FIELD_TYPE_NUMBER":      2,
+		"CUSTOM_FIELD_TYPE_ENUM":        3,
+		"CUSTOM_FIELD_TYPE_DATE":        4,
+		"CUSTOM_FIELD_TYPE_USER":        5,
+	}
+)
+
+func (x CustomFieldType) Enum() *CustomFieldType {
+	p := new(CustomFieldType)
+	*p = x
+	return p
+}
+
+func (x CustomFieldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomFieldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[4].Descriptor()
+}
+
+func (CustomFieldType) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[4]
+}
+
+func (x CustomFieldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomFieldType.Descriptor instead.
+func (CustomFieldType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{4}
+}
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED WebhookDeliveryStatus = 0
+	// Waiting for its next attempt
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING   WebhookDeliveryStatus = 1
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED WebhookDeliveryStatus = 2
+	// Every attempt failed; the delivery is on the dead-letter list
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD WebhookDeliveryStatus = 3
+)
+
+// Enum value maps for WebhookDeliveryStatus.
+var (
+	WebhookDeliveryStatus_name = map[int32]string{
+		0: "WEBHOOK_DELIVERY_STATUS_UNSPECIFIED",
+		1: "WEBHOOK_DELIVERY_STATUS_PENDING",
+		2: "WEBHOOK_DELIVERY_STATUS_SUCCEEDED",
+		3: "WEBHOOK_DELIVERY_STATUS_DEAD",
+	}
+	WebhookDeliveryStatus_value = map[string]int32{
+		"WEBHOOK_DELIVERY_STATUS_UNSPECIFIED": 0,
+		"WEBHOOK_DELIVERY_STATUS_PENDING":     1,
+		"WEBHOOK_DELIVERY_STATUS_SUCCEEDED":   2,
+		"WEBHOOK_DELIVERY_STATUS_DEAD":        3,
+	}
+)
+
+func (x WebhookDeliveryStatus) Enum() *WebhookDeliveryStatus {
+	p := new(WebhookDeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[5].Descriptor()
+}
+
+func (WebhookDeliveryStatus) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[5]
+}
+
+func (x WebhookDeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryStatus.Descriptor instead.
+func (WebhookDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{5}
+}
+
+// NotificationKind says why a user was notified
+type NotificationKind int32
+
+const (
+	NotificationKind_NOTIFICATION_KIND_UNSPECIFIED NotificationKind = 0
+	// The ticket was assigned to the user
+	NotificationKind_NOTIFICATION_KIND_ASSIGNED NotificationKind = 1
+	// A watched or reported ticket moved to another status
+	NotificationKind_NOTIFICATION_KIND_STATUS_CHANGED NotificationKind = 2
+	// A watched, reported or assigned ticket was resolved or closed
+	NotificationKind_NOTIFICATION_KIND_RESOLVED NotificationKind = 3
+	// A watched ticket was updated without changing its status
+	NotificationKind_NOTIFICATION_KIND_UPDATED NotificationKind = 4
+	// A watched ticket was deleted
+	NotificationKind_NOTIFICATION_KIND_DELETED NotificationKind = 5
+	// A watched or assigned ticket's SLA is at risk
+	NotificationKind_NOTIFICATION_KIND_SLA_AT_RISK NotificationKind = 6
+	// A watched or assigned ticket breached its SLA
+	NotificationKind_NOTIFICATION_KIND_SLA_BREACHED NotificationKind = 7
+)
+
+// Enum value maps for NotificationKind.
+var (
+	NotificationKind_name = map[int32]string{
+		0: "NOTIFICATION_KIND_UNSPECIFIED",
+		1: "NOTIFICATION_KIND_ASSIGNED",
+		2: "NOTIFICATION_KIND_STATUS_CHANGED",
+		3: "NOTIFICATION_KIND_RESOLVED",
+		4: "NOTIFICATION_KIND_UPDATED",
+		5: "NOTIFICATION_KIND_DELETED",
+		6: "NOTIFICATION_KIND_SLA_AT_RISK",
+		7: "NOTIFICATION_KIND_SLA_BREACHED",
+	}
+	NotificationKind_value = map[string]int32{
+		"NOTIFICATION_KIND_UNSPECIFIED":    0,
+		"NOTIFICATION_KIND_ASSIGNED":       1,
+		"NOTIFICATION_KIND_STATUS_CHANGED": 2,
+		"NOTIFICATION_KIND_RESOLVED":       3,
+		"NOTIFICATION_KIND_UPDATED":        4,
+		"NOTIFICATION_KIND_DELETED":        5,
+		"NOTIFICATION_KIND_SLA_AT_RISK":    6,
+		"NOTIFICATION_KIND_SLA_BREACHED":   7,
+	}
+)
+
+func (x NotificationKind) Enum() *NotificationKind {
+	p := new(NotificationKind)
+	*p = x
+	return p
+}
+
+func (x NotificationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[6].Descriptor()
+}
+
+func (NotificationKind) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[6]
+}
+
+func (x NotificationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationKind.Descriptor instead.
+func (NotificationKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{6}
+}
+
+// NotificationDelivery says how notifications reach the delivery channels
+type NotificationDelivery int32
+
+const (
+	NotificationDelivery_NOTIFICATION_DELIVERY_UNSPECIFIED NotificationDelivery = 0
+	// Each notification is sent as it happens
+	NotificationDelivery_NOTIFICATION_DELIVERY_IMMEDIATE NotificationDelivery = 1
+	// Notifications are batched into one message a day
+	NotificationDelivery_NOTIFICATION_DELIVERY_DAILY_DIGEST NotificationDelivery = 2
+	// Notifications only reach the in-app inbox
+	NotificationDelivery_NOTIFICATION_DELIVERY_OFF NotificationDelivery = 3
+)
+
+// Enum value maps for NotificationDelivery.
+var (
+	NotificationDelivery_name = map[int32]string{
+		0: "NOTIFICATION_DELIVERY_UNSPECIFIED",
+		1: "NOTIFICATION_DELIVERY_IMMEDIATE",
+		2: "NOTIFICATION_DELIVERY_DAILY_DIGEST",
+		3: "NOTIFICATION_DELIVERY_OFF",
+	}
+	NotificationDelivery_value = map[string]int32{
+		"NOTIFICATION_DELIVERY_UNSPECIFIED":  0,
+		"NOTIFICATION_DELIVERY_IMMEDIATE":    1,
+		"NOTIFICATION_DELIVERY_DAILY_DIGEST": 2,
+		"NOTIFICATION_DELIVERY_OFF":          3,
+	}
+)
+
+func (x NotificationDelivery) Enum() *NotificationDelivery {
+	p := new(NotificationDelivery)
+	*p = x
+	return p
+}
+
+func (x NotificationDelivery) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationDelivery) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[7].Descriptor()
+}
+
+func (NotificationDelivery) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[7]
+}
+
+func (x NotificationDelivery) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationDelivery.Descriptor instead.
+func (NotificationDelivery) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{7}
+}
+
+// How imported tickets are identified
+type ImportIDMode int32
+
+const (
+	// REMAP
+	ImportIDMode_IMPORT_ID_MODE_UNSPECIFIED ImportIDMode = 0
+	// Keeps the source IDs, which must be UUIDs. Suits moving tickets to
+	// another database.
+	ImportIDMode_IMPORT_ID_MODE_PRESERVE ImportIDMode = 1
+	// Derives each ticket's ID from its source ID and the organization, the
+	// same on every run. Suits importing from another tracker.
+	ImportIDMode_IMPORT_ID_MODE_REMAP ImportIDMode = 2
+)
+
+// Enum value maps for ImportIDMode.
+var (
+	ImportIDMode_name = map[int32]string{
+		0: "IMPORT_ID_MODE_UNSPECIFIED",
+		1: "IMPORT_ID_MODE_PRESERVE",
+		2: "IMPORT_ID_MODE_REMAP",
+	}
+	ImportIDMode_value = map[string]int32{
+		"IMPORT_ID_MODE_UNSPECIFIED": 0,
+		"IMPORT_ID_MODE_PRESERVE":    1,
+		"IMPORT_ID_MODE_REMAP":       2,
+	}
+)
+
+func (x ImportIDMode) Enum() *ImportIDMode {
+	p := new(ImportIDMode)
+	*p = x
+	return p
+}
+
+func (x ImportIDMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportIDMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[8].Descriptor()
+}
+
+func (ImportIDMode) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[8]
+}
+
+func (x ImportIDMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportIDMode.Descriptor instead.
+func (ImportIDMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{8}
+}
+
+type ImportOutcome int32
+
+const (
+	ImportOutcome_IMPORT_OUTCOME_UNSPECIFIED ImportOutcome = 0
+	// The ticket was created, or would be in a dry run
+	ImportOutcome_IMPORT_OUTCOME_CREATED ImportOutcome = 1
+	// The ticket already exists, e.g. from an earlier run, and was left unchanged
+	ImportOutcome_IMPORT_OUTCOME_SKIPPED ImportOutcome = 2
+	// The record was rejected; error says why
+	ImportOutcome_IMPORT_OUTCOME_INVALID ImportOutcome = 3
+	// The record was valid but storing it failed; running the import again
+	// retries it
+	ImportOutcome_IMPORT_OUTCOME_FAILED ImportOutcome = 4
+)
+
+// Enum value maps for ImportOutcome.
+var (
+	ImportOutcome_name = map[int32]string{
+		0: "IMPORT_OUTCOME_UNSPECIFIED",
+		1: "IMPORT_OUTCOME_CREATED",
+		2: "IMPORT_OUTCOME_SKIPPED",
+		3: "IMPORT_OUTCOME_INVALID",
+		4: "IMPORT_OUTCOME_FAILED",
+	}
+	ImportOutcome_value = map[string]int32{
+		"IMPORT_OUTCOME_UNSPECIFIED": 0,
+		"IMPORT_OUTCOME_CREATED":     1,
+		"IMPORT_OUTCOME_SKIPPED":     2,
+		"IMPORT_OUTCOME_INVALID":     3,
+		"IMPORT_OUTCOME_FAILED":      4,
+	}
+)
+
+func (x ImportOutcome) Enum() *ImportOutcome {
+	p := new(ImportOutcome)
+	*p = x
+	return p
+}
+
+func (x ImportOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticket_ticket_proto_enumTypes[9].Descriptor()
+}
+
+func (ImportOutcome) Type() protoreflect.EnumType {
+	return &file_proto_ticket_ticket_proto_enumTypes[9]
+}
+
+func (x ImportOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportOutcome.Descriptor instead.
+func (ImportOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{9}
 }
 
 // Ticket message definition
 type Ticket struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Status        TicketStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=ticket.TicketStatus" json:"status,omitempty"`
-	Priority      TicketPriority         `protobuf:"varint,5,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
-	AssigneeId    string                 `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
-	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      TicketStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=ticket.TicketStatus" json:"status,omitempty"`
+	Priority    TicketPriority         `protobuf:"varint,5,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
+	AssigneeId  string                 `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Tags        []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReporterId  string                 `protobuf:"bytes,10,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	// Project the ticket belongs to, empty for tickets outside any project
+	ProjectId string `protobuf:"bytes,11,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Human-readable key such as WEB-42, assigned when the ticket is created in a project
+	Key string `protobuf:"bytes,12,opt,name=key,proto3" json:"key,omitempty"`
+	// Organization owning the ticket; set by the service from the caller's organization
+	OrganizationId string `protobuf:"bytes,13,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Values of the project's custom fields keyed by field key, in the
+	// canonical form of each field's type
+	CustomFields map[string]string `protobuf:"bytes,14,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// When the ticket should be resolved, set by users; unset when there is none
+	DueDate *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Response and resolution deadlines; unset when no SLA policy or due date applies
+	Sla           *TicketSLA `protobuf:"bytes,16,opt,name=sla,proto3" json:"sla,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -242,105 +737,171 @@ func (x *Ticket) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Request/Response messages
-type CreateTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Priority      TicketPriority         `protobuf:"varint,3,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
-	AssigneeId    string                 `protobuf:"bytes,4,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
-	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
-	ReporterId    string                 `protobuf:"bytes,6,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Ticket) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
 }
 
-func (x *CreateTicketRequest) Reset() {
-	*x = CreateTicketRequest{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Ticket) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
 }
 
-func (x *CreateTicketRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Ticket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
 }
 
-func (*CreateTicketRequest) ProtoMessage() {}
-
-func (x *CreateTicketRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[1]
+func (x *Ticket) GetOrganizationId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OrganizationId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CreateTicketRequest.ProtoReflect.Descriptor instead.
-func (*CreateTicketRequest) Descriptor() ([]byte, []int) {
+func (x *Ticket) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *Ticket) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Ticket) GetSla() *TicketSLA {
+	if x != nil {
+		return x.Sla
+	}
+	return nil
+}
+
+// SLA deadlines of a ticket, computed by the service from the policy of its
+// priority and its due date
+type TicketSLA struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FirstResponseDueAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=first_response_due_at,json=firstResponseDueAt,proto3" json:"first_response_due_at,omitempty"`
+	// The earlier of the policy's resolution deadline and the due date
+	ResolutionDueAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=resolution_due_at,json=resolutionDueAt,proto3" json:"resolution_due_at,omitempty"`
+	// When the ticket first left OPEN
+	FirstRespondedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_responded_at,json=firstRespondedAt,proto3" json:"first_responded_at,omitempty"`
+	// When the ticket was last resolved or closed; cleared when it is reopened
+	ResolvedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Status     SLAStatus              `protobuf:"varint,5,opt,name=status,proto3,enum=ticket.SLAStatus" json:"status,omitempty"`
+	// When the SLA evaluator next looks at the ticket; unset once nothing is pending
+	NextCheckAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=next_check_at,json=nextCheckAt,proto3" json:"next_check_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketSLA) Reset() {
+	*x = TicketSLA{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketSLA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketSLA) ProtoMessage() {}
+
+func (x *TicketSLA) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketSLA.ProtoReflect.Descriptor instead.
+func (*TicketSLA) Descriptor() ([]byte, []int) {
 	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateTicketRequest) GetTitle() string {
+func (x *TicketSLA) GetFirstResponseDueAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Title
+		return x.FirstResponseDueAt
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateTicketRequest) GetDescription() string {
+func (x *TicketSLA) GetResolutionDueAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Description
+		return x.ResolutionDueAt
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateTicketRequest) GetPriority() TicketPriority {
+func (x *TicketSLA) GetFirstRespondedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Priority
+		return x.FirstRespondedAt
 	}
-	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+	return nil
 }
 
-func (x *CreateTicketRequest) GetAssigneeId() string {
+func (x *TicketSLA) GetResolvedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AssigneeId
+		return x.ResolvedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateTicketRequest) GetTags() []string {
+func (x *TicketSLA) GetStatus() SLAStatus {
 	if x != nil {
-		return x.Tags
+		return x.Status
+	}
+	return SLAStatus_SLA_STATUS_UNSPECIFIED
+}
+
+func (x *TicketSLA) GetNextCheckAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextCheckAt
 	}
 	return nil
 }
 
-type CreateTicketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+// Response and resolution targets for tickets of one priority
+type SLAPolicy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Priority TicketPriority         `protobuf:"varint,1,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
+	// Time from creation to the first response; zero when not tracked
+	FirstResponse *durationpb.Duration `protobuf:"bytes,2,opt,name=first_response,json=firstResponse,proto3" json:"first_response,omitempty"`
+	// Time from creation to resolution; zero when not tracked
+	Resolution    *durationpb.Duration `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTicketResponse) Reset() {
-	*x = CreateTicketResponse{}
+func (x *SLAPolicy) Reset() {
+	*x = SLAPolicy{}
 	mi := &file_proto_ticket_ticket_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTicketResponse) String() string {
+func (x *SLAPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTicketResponse) ProtoMessage() {}
+func (*SLAPolicy) ProtoMessage() {}
 
-func (x *CreateTicketResponse) ProtoReflect() protoreflect.Message {
+func (x *SLAPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ticket_ticket_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -352,39 +913,61 @@ func (x *CreateTicketResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTicketResponse.ProtoReflect.Descriptor instead.
-func (*CreateTicketResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SLAPolicy.ProtoReflect.Descriptor instead.
+func (*SLAPolicy) Descriptor() ([]byte, []int) {
 	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateTicketResponse) GetTicket() *Ticket {
+func (x *SLAPolicy) GetPriority() TicketPriority {
 	if x != nil {
-		return x.Ticket
+		return x.Priority
+	}
+	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+}
+
+func (x *SLAPolicy) GetFirstResponse() *durationpb.Duration {
+	if x != nil {
+		return x.FirstResponse
 	}
 	return nil
 }
 
-type GetTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *SLAPolicy) GetResolution() *durationpb.Duration {
+	if x != nil {
+		return x.Resolution
+	}
+	return nil
+}
+
+// A field a project defines for its tickets
+type CustomFieldDefinition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lower-case letters, digits and underscores, starting with a letter
+	Key  string          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type CustomFieldType `protobuf:"varint,3,opt,name=type,proto3,enum=ticket.CustomFieldType" json:"type,omitempty"`
+	// Allowed values of an ENUM field
+	Options []string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	// Tickets must set the field when they are created
+	Required      bool `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTicketRequest) Reset() {
-	*x = GetTicketRequest{}
+func (x *CustomFieldDefinition) Reset() {
+	*x = CustomFieldDefinition{}
 	mi := &file_proto_ticket_ticket_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTicketRequest) String() string {
+func (x *CustomFieldDefinition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTicketRequest) ProtoMessage() {}
+func (*CustomFieldDefinition) ProtoMessage() {}
 
-func (x *GetTicketRequest) ProtoReflect() protoreflect.Message {
+func (x *CustomFieldDefinition) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ticket_ticket_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -396,40 +979,5469 @@ func (x *GetTicketRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTicketRequest.ProtoReflect.Descriptor instead.
-func (*GetTicketRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CustomFieldDefinition.ProtoReflect.Descriptor instead.
+func (*CustomFieldDefinition) Descriptor() ([]byte, []int) {
 	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetTicketRequest) GetId() string {
+func (x *CustomFieldDefinition) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CustomFieldDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomFieldDefinition) GetType() CustomFieldType {
+	if x != nil {
+		return x.Type
+	}
+	return CustomFieldType_CUSTOM_FIELD_TYPE_UNSPECIFIED
+}
+
+func (x *CustomFieldDefinition) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *CustomFieldDefinition) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// A project groups tickets under a short key used to number them
+type Project struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Upper-case key such as WEB, unique within the organization
+	Key         string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Number of the last ticket created in the project
+	LastNumber int64                  `protobuf:"varint,5,opt,name=last_number,json=lastNumber,proto3" json:"last_number,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Organization owning the project; set by the service from the caller's organization
+	OrganizationId string `protobuf:"bytes,7,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Custom fields of the project's tickets, in display order
+	CustomFields  []*CustomFieldDefinition `protobuf:"bytes,8,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Project) GetId() string {
 	if x != nil {
 		return x.Id
 	}
-	return ""
+	return ""
+}
+
+func (x *Project) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetLastNumber() int64 {
+	if x != nil {
+		return x.LastNumber
+	}
+	return 0
+}
+
+func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Project) GetCustomFields() []*CustomFieldDefinition {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type TicketLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceId      string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Type          LinkType               `protobuf:"varint,3,opt,name=type,proto3,enum=ticket.LinkType" json:"type,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketLink) Reset() {
+	*x = TicketLink{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketLink) ProtoMessage() {}
+
+func (x *TicketLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketLink.ProtoReflect.Descriptor instead.
+func (*TicketLink) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TicketLink) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *TicketLink) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *TicketLink) GetType() LinkType {
+	if x != nil {
+		return x.Type
+	}
+	return LinkType_LINK_TYPE_UNSPECIFIED
+}
+
+func (x *TicketLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Request/Response messages
+type CreateTicketRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Priority    TicketPriority         `protobuf:"varint,3,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
+	AssigneeId  string                 `protobuf:"bytes,4,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Tags        []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	ReporterId  string                 `protobuf:"bytes,6,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	// Creates the ticket in this project, numbering it with the project key
+	ProjectKey string `protobuf:"bytes,7,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	// Values of the project's custom fields, validated against their definitions
+	CustomFields map[string]string      `protobuf:"bytes,8,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DueDate      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Idempotency key: a retry with the same request_id (or idempotency-key
+	// metadata) within the retention window returns the first response
+	// instead of creating another ticket
+	RequestId     string `protobuf:"bytes,10,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketRequest) Reset() {
+	*x = CreateTicketRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketRequest) ProtoMessage() {}
+
+func (x *CreateTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketRequest.ProtoReflect.Descriptor instead.
+func (*CreateTicketRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTicketRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetPriority() TicketPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateTicketRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTicketRequest) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *CreateTicketRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *CreateTicketRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *CreateTicketRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type CreateTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTicketResponse) Reset() {
+	*x = CreateTicketResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTicketResponse) ProtoMessage() {}
+
+func (x *CreateTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTicketResponse.ProtoReflect.Descriptor instead.
+func (*CreateTicketResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateTicketResponse) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+type GetTicketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Looks the ticket up by its key (e.g. WEB-42) when id is empty
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketRequest) Reset() {
+	*x = GetTicketRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketRequest) ProtoMessage() {}
+
+func (x *GetTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTicketRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketResponse) Reset() {
+	*x = GetTicketResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketResponse) ProtoMessage() {}
+
+func (x *GetTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketResponse.ProtoReflect.Descriptor instead.
+func (*GetTicketResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTicketResponse) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+type ListTicketsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only tickets carrying every one of these tags
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Case-insensitive substring match on title and description
+	Query string `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	// Only tickets of the project with this key
+	ProjectKey string `protobuf:"bytes,5,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	// Only tickets whose custom fields have these values. With project_key the
+	// values are validated and normalised like those of a ticket.
+	CustomFields  map[string]string `protobuf:"bytes,6,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsRequest) Reset() {
+	*x = ListTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsRequest) ProtoMessage() {}
+
+func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTicketsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTicketsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTicketsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTicketsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTicketsRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *ListTicketsRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type ListTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsResponse) Reset() {
+	*x = ListTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsResponse) ProtoMessage() {}
+
+func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTicketsResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *ListTicketsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateTicketRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      TicketStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=ticket.TicketStatus" json:"status,omitempty"`
+	Priority    TicketPriority         `protobuf:"varint,5,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
+	AssigneeId  string                 `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Tags        []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Sets these custom fields; an empty value clears a field
+	CustomFields map[string]string      `protobuf:"bytes,8,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DueDate      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Removes the due date; due_date must then be unset
+	ClearDueDate bool `protobuf:"varint,10,opt,name=clear_due_date,json=clearDueDate,proto3" json:"clear_due_date,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,11,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTicketRequest) Reset() {
+	*x = UpdateTicketRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTicketRequest) ProtoMessage() {}
+
+func (x *UpdateTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTicketRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTicketRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetStatus() TicketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TicketStatus_TICKET_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateTicketRequest) GetPriority() TicketPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+}
+
+func (x *UpdateTicketRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *UpdateTicketRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateTicketRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *UpdateTicketRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *UpdateTicketRequest) GetClearDueDate() bool {
+	if x != nil {
+		return x.ClearDueDate
+	}
+	return false
+}
+
+func (x *UpdateTicketRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type UpdateTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTicketResponse) Reset() {
+	*x = UpdateTicketResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTicketResponse) ProtoMessage() {}
+
+func (x *UpdateTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTicketResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTicketResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateTicketResponse) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+type DeleteTicketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketRequest) Reset() {
+	*x = DeleteTicketRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketRequest) ProtoMessage() {}
+
+func (x *DeleteTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTicketRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteTicketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTicketRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type DeleteTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTicketResponse) Reset() {
+	*x = DeleteTicketResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTicketResponse) ProtoMessage() {}
+
+func (x *DeleteTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTicketResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTicketResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteTicketResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CreateProjectRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Key         string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateProjectRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// Looks a project up by id, or by key when id is empty
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProjectRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// Replaces the custom field definitions of a project. Values of removed
+// fields stay on existing tickets but can no longer be set.
+type SetCustomFieldsRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	ProjectKey    string                   `protobuf:"bytes,1,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	Fields        []*CustomFieldDefinition `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCustomFieldsRequest) Reset() {
+	*x = SetCustomFieldsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomFieldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomFieldsRequest) ProtoMessage() {}
+
+func (x *SetCustomFieldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomFieldsRequest.ProtoReflect.Descriptor instead.
+func (*SetCustomFieldsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetCustomFieldsRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *SetCustomFieldsRequest) GetFields() []*CustomFieldDefinition {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type SetCustomFieldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCustomFieldsResponse) Reset() {
+	*x = SetCustomFieldsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomFieldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomFieldsResponse) ProtoMessage() {}
+
+func (x *SetCustomFieldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomFieldsResponse.ProtoReflect.Descriptor instead.
+func (*SetCustomFieldsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetCustomFieldsResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{22}
+}
+
+type ListProjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by key
+	Projects      []*Project `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// Number of tickets carrying a tag
+type TagCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TagCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TagCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive tag prefix, for autocomplete
+	Prefix        string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTagsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most used first, ties ordered by name
+	Tags          []*TagCount `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Replaces every source tag with the target tag on every ticket. Renaming a
+// tag is a merge with a single source.
+type MergeTagsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Sources []string               `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	Target  string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MergeTagsRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *MergeTagsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type MergeTagsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedTickets int32                  `protobuf:"varint,1,opt,name=updated_tickets,json=updatedTickets,proto3" json:"updated_tickets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MergeTagsResponse) Reset() {
+	*x = MergeTagsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsResponse) ProtoMessage() {}
+
+func (x *MergeTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsResponse.ProtoReflect.Descriptor instead.
+func (*MergeTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MergeTagsResponse) GetUpdatedTickets() int32 {
+	if x != nil {
+		return x.UpdatedTickets
+	}
+	return 0
+}
+
+type LinkTicketsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SourceId string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Type     LinkType               `protobuf:"varint,3,opt,name=type,proto3,enum=ticket.LinkType" json:"type,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkTicketsRequest) Reset() {
+	*x = LinkTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkTicketsRequest) ProtoMessage() {}
+
+func (x *LinkTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkTicketsRequest.ProtoReflect.Descriptor instead.
+func (*LinkTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LinkTicketsRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *LinkTicketsRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *LinkTicketsRequest) GetType() LinkType {
+	if x != nil {
+		return x.Type
+	}
+	return LinkType_LINK_TYPE_UNSPECIFIED
+}
+
+func (x *LinkTicketsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type LinkTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *TicketLink            `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkTicketsResponse) Reset() {
+	*x = LinkTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkTicketsResponse) ProtoMessage() {}
+
+func (x *LinkTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkTicketsResponse.ProtoReflect.Descriptor instead.
+func (*LinkTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LinkTicketsResponse) GetLink() *TicketLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type UnlinkTicketsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SourceId string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Type     LinkType               `protobuf:"varint,3,opt,name=type,proto3,enum=ticket.LinkType" json:"type,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkTicketsRequest) Reset() {
+	*x = UnlinkTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkTicketsRequest) ProtoMessage() {}
+
+func (x *UnlinkTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkTicketsRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UnlinkTicketsRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *UnlinkTicketsRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *UnlinkTicketsRequest) GetType() LinkType {
+	if x != nil {
+		return x.Type
+	}
+	return LinkType_LINK_TYPE_UNSPECIFIED
+}
+
+func (x *UnlinkTicketsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type UnlinkTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkTicketsResponse) Reset() {
+	*x = UnlinkTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkTicketsResponse) ProtoMessage() {}
+
+func (x *UnlinkTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkTicketsResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UnlinkTicketsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksRequest) Reset() {
+	*x = ListLinksRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksRequest) ProtoMessage() {}
+
+func (x *ListLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListLinksRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type ListLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Links in either direction, oldest first
+	Links         []*TicketLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksResponse) Reset() {
+	*x = ListLinksResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksResponse) ProtoMessage() {}
+
+func (x *ListLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListLinksResponse) GetLinks() []*TicketLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// Attachment metadata; the content lives in the service's blob store
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AttachmentMetadata struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TicketId    string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	Filename    string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Optional hex SHA-256 of the content, verified after upload
+	Sha256        string `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentMetadata) Reset() {
+	*x = AttachmentMetadata{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMetadata) ProtoMessage() {}
+
+func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AttachmentMetadata) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+// The first message carries the metadata, every following one a chunk of content
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Metadata
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetMetadata() *AttachmentMetadata {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
+}
+
+type UploadAttachmentRequest_Metadata struct {
+	Metadata *AttachmentMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Metadata) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListAttachmentsRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DownloadAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// The first message carries the attachment, every following one a chunk of content
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	Data          isDownloadAttachmentResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DownloadAttachmentResponse) GetData() isDownloadAttachmentResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Data interface {
+	isDownloadAttachmentResponse_Data()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Data() {}
+
+type ListSLAPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSLAPoliciesRequest) Reset() {
+	*x = ListSLAPoliciesRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSLAPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSLAPoliciesRequest) ProtoMessage() {}
+
+func (x *ListSLAPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSLAPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListSLAPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{43}
+}
+
+type ListSLAPoliciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by priority, most urgent first
+	Policies      []*SLAPolicy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSLAPoliciesResponse) Reset() {
+	*x = ListSLAPoliciesResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSLAPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSLAPoliciesResponse) ProtoMessage() {}
+
+func (x *ListSLAPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSLAPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListSLAPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSLAPoliciesResponse) GetPolicies() []*SLAPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+// WebhookFilter narrows the tickets a webhook hears about; empty fields match every ticket
+type WebhookFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only tickets of this project
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Only tickets carrying every tag
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Only tickets with one of these priorities
+	Priorities []TicketPriority `protobuf:"varint,3,rep,packed,name=priorities,proto3,enum=ticket.TicketPriority" json:"priorities,omitempty"`
+	// Set by clients instead of project_id; the service resolves it
+	ProjectKey    string `protobuf:"bytes,4,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookFilter) Reset() {
+	*x = WebhookFilter{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookFilter) ProtoMessage() {}
+
+func (x *WebhookFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookFilter.ProtoReflect.Descriptor instead.
+func (*WebhookFilter) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WebhookFilter) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *WebhookFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WebhookFilter) GetPriorities() []TicketPriority {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *WebhookFilter) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+// Webhook is a subscription POSTing ticket events to a URL
+type Webhook struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Url            string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// Key of the HMAC-SHA256 signature; only returned by CreateWebhook
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Event types delivered, e.g. ticket.created
+	EventTypes    []string               `protobuf:"bytes,5,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Filter        *WebhookFilter         `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
+	Active        bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetFilter() *WebhookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *Webhook) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// WebhookDelivery is one event on its way to one webhook
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	WebhookId      string                 `protobuf:"bytes,3,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId        string                 `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// The JSON body POSTed to the webhook
+	Payload       string                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status        WebhookDeliveryStatus  `protobuf:"varint,7,opt,name=status,proto3,enum=ticket.WebhookDeliveryStatus" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	LastAttemptAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	// HTTP status of the last attempt, 0 when it got no response
+	LastStatusCode int32                  `protobuf:"varint,11,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError      string                 `protobuf:"bytes,12,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Generated when empty
+	Secret     string         `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	EventTypes []string       `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Filter     *WebhookFilter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetFilter() *WebhookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+// Unset fields keep their value
+type UpdateWebhookRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url        string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Secret     string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	EventTypes []string               `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Replaces the filter when set
+	Filter *WebhookFilter        `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	Active *wrapperspb.BoolValue `protobuf:"bytes,6,opt,name=active,proto3" json:"active,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookRequest) Reset() {
+	*x = UpdateWebhookRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookRequest) ProtoMessage() {}
+
+func (x *UpdateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UpdateWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *UpdateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetFilter() *WebhookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetActive() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Active
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type UpdateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookResponse) Reset() {
+	*x = UpdateWebhookResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookResponse) ProtoMessage() {}
+
+func (x *UpdateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UpdateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{54}
+}
+
+type ListWebhooksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first
+	Webhooks      []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Only deliveries with this status, e.g. DEAD for the dead-letter list
+	Status        WebhookDeliveryStatus `protobuf:"varint,2,opt,name=status,proto3,enum=ticket.WebhookDeliveryStatus" json:"status,omitempty"`
+	Limit         int32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Deliveries    []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type RedeliverWebhookRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookRequest) ProtoMessage() {}
+
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RedeliverWebhookRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *RedeliverWebhookRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type RedeliverWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *WebhookDelivery       `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookResponse) Reset() {
+	*x = RedeliverWebhookResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookResponse) ProtoMessage() {}
+
+func (x *RedeliverWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookResponse.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RedeliverWebhookResponse) GetDelivery() *WebhookDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+// Notification is an entry of a user's in-app inbox, also sent on the
+// delivery channels (e.g. email) the user's preferences allow
+type Notification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind           NotificationKind       `protobuf:"varint,4,opt,name=kind,proto3,enum=ticket.NotificationKind" json:"kind,omitempty"`
+	TicketId       string                 `protobuf:"bytes,5,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	// The event that caused the notification; a user is notified once per event
+	EventId string `protobuf:"bytes,6,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// A one-line summary, e.g. WEB-42 "Checkout is down" was assigned to you
+	Message   string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Unset while unread
+	ReadAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	// When the delivery channels send the notification, at once or with the
+	// user's daily digest; unset when it is not sent or already was
+	DeliverAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Notification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Notification) GetKind() NotificationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return NotificationKind_NOTIFICATION_KIND_UNSPECIFIED
+}
+
+func (x *Notification) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *Notification) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Notification) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+func (x *Notification) GetDeliverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return nil
+}
+
+func (x *Notification) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+// NotificationPreferences are a user's notification settings
+type NotificationPreferences struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Address of the email channel; no email is sent when empty
+	Email    string               `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Delivery NotificationDelivery `protobuf:"varint,4,opt,name=delivery,proto3,enum=ticket.NotificationDelivery" json:"delivery,omitempty"`
+	// Hour of the day (UTC, 0-23) the daily digest is sent
+	DigestHour int32 `protobuf:"varint,5,opt,name=digest_hour,json=digestHour,proto3" json:"digest_hour,omitempty"`
+	// Kinds the user is never notified of
+	MutedKinds    []NotificationKind     `protobuf:"varint,6,rep,packed,name=muted_kinds,json=mutedKinds,proto3,enum=ticket.NotificationKind" json:"muted_kinds,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *NotificationPreferences) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetDelivery() NotificationDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return NotificationDelivery_NOTIFICATION_DELIVERY_UNSPECIFIED
+}
+
+func (x *NotificationPreferences) GetDigestHour() int32 {
+	if x != nil {
+		return x.DigestHour
+	}
+	return 0
+}
+
+func (x *NotificationPreferences) GetMutedKinds() []NotificationKind {
+	if x != nil {
+		return x.MutedKinds
+	}
+	return nil
+}
+
+func (x *NotificationPreferences) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type WatchTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTicketRequest) Reset() {
+	*x = WatchTicketRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTicketRequest) ProtoMessage() {}
+
+func (x *WatchTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTicketRequest.ProtoReflect.Descriptor instead.
+func (*WatchTicketRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WatchTicketRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *WatchTicketRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type WatchTicketResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every watcher of the ticket, the user included
+	WatcherIds    []string `protobuf:"bytes,1,rep,name=watcher_ids,json=watcherIds,proto3" json:"watcher_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTicketResponse) Reset() {
+	*x = WatchTicketResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTicketResponse) ProtoMessage() {}
+
+func (x *WatchTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTicketResponse.ProtoReflect.Descriptor instead.
+func (*WatchTicketResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *WatchTicketResponse) GetWatcherIds() []string {
+	if x != nil {
+		return x.WatcherIds
+	}
+	return nil
+}
+
+type UnwatchTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnwatchTicketRequest) Reset() {
+	*x = UnwatchTicketRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnwatchTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnwatchTicketRequest) ProtoMessage() {}
+
+func (x *UnwatchTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnwatchTicketRequest.ProtoReflect.Descriptor instead.
+func (*UnwatchTicketRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *UnwatchTicketRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *UnwatchTicketRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnwatchTicketResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the user was watching the ticket
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnwatchTicketResponse) Reset() {
+	*x = UnwatchTicketResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnwatchTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnwatchTicketResponse) ProtoMessage() {}
+
+func (x *UnwatchTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnwatchTicketResponse.ProtoReflect.Descriptor instead.
+func (*UnwatchTicketResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UnwatchTicketResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListWatchersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchersRequest) Reset() {
+	*x = ListWatchersRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchersRequest) ProtoMessage() {}
+
+func (x *ListWatchersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchersRequest.ProtoReflect.Descriptor instead.
+func (*ListWatchersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListWatchersRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type ListWatchersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order they started watching
+	WatcherIds    []string `protobuf:"bytes,1,rep,name=watcher_ids,json=watcherIds,proto3" json:"watcher_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchersResponse) Reset() {
+	*x = ListWatchersResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchersResponse) ProtoMessage() {}
+
+func (x *ListWatchersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchersResponse.ProtoReflect.Descriptor instead.
+func (*ListWatchersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListWatchersResponse) GetWatcherIds() []string {
+	if x != nil {
+		return x.WatcherIds
+	}
+	return nil
+}
+
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListNotificationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListNotificationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Notifications []*Notification `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+type MarkNotificationReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationReadRequest) Reset() {
+	*x = MarkNotificationReadRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationReadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *MarkNotificationReadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type MarkNotificationReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationReadResponse) Reset() {
+	*x = MarkNotificationReadResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationReadResponse) ProtoMessage() {}
+
+func (x *MarkNotificationReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkNotificationReadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *MarkNotificationReadResponse) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+type GetNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesRequest) Reset() {
+	*x = GetNotificationPreferencesRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetNotificationPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetNotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesResponse) Reset() {
+	*x = GetNotificationPreferencesResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetNotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// Unset fields keep their value
+type UpdateNotificationPreferencesRequest struct {
+	state      protoimpl.MessageState  `protogen:"open.v1"`
+	UserId     string                  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email      *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Delivery   NotificationDelivery    `protobuf:"varint,3,opt,name=delivery,proto3,enum=ticket.NotificationDelivery" json:"delivery,omitempty"`
+	DigestHour *wrapperspb.Int32Value  `protobuf:"bytes,4,opt,name=digest_hour,json=digestHour,proto3" json:"digest_hour,omitempty"`
+	// Replaces the muted kinds when set
+	SetMutedKinds bool               `protobuf:"varint,5,opt,name=set_muted_kinds,json=setMutedKinds,proto3" json:"set_muted_kinds,omitempty"`
+	MutedKinds    []NotificationKind `protobuf:"varint,6,rep,packed,name=muted_kinds,json=mutedKinds,proto3,enum=ticket.NotificationKind" json:"muted_kinds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesRequest) Reset() {
+	*x = UpdateNotificationPreferencesRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetEmail() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetDelivery() NotificationDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return NotificationDelivery_NOTIFICATION_DELIVERY_UNSPECIFIED
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetDigestHour() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.DigestHour
+	}
+	return nil
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetSetMutedKinds() bool {
+	if x != nil {
+		return x.SetMutedKinds
+	}
+	return false
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetMutedKinds() []NotificationKind {
+	if x != nil {
+		return x.MutedKinds
+	}
+	return nil
+}
+
+type UpdateNotificationPreferencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesResponse) Reset() {
+	*x = UpdateNotificationPreferencesResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *UpdateNotificationPreferencesResponse) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// Comment is a remark on a ticket, written in the app or received by email
+type Comment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	TicketId       string                 `protobuf:"bytes,3,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	AuthorId       string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Body           string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Comment) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TicketId string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	AuthorId string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Body     string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *AddCommentRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *AddCommentRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type AddCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentResponse) ProtoMessage() {}
+
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *AddCommentResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListCommentsRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first
+	Comments      []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+// An email is part of the thread of the ticket it created or was added to
+// as a comment; replies name earlier messages in In-Reply-To and References
+type FindEmailThreadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Message-IDs, without angle brackets
+	MessageIds    []string `protobuf:"bytes,1,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindEmailThreadRequest) Reset() {
+	*x = FindEmailThreadRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindEmailThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmailThreadRequest) ProtoMessage() {}
+
+func (x *FindEmailThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmailThreadRequest.ProtoReflect.Descriptor instead.
+func (*FindEmailThreadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *FindEmailThreadRequest) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+type FindEmailThreadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ticket of the first known message
+	TicketId      string `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindEmailThreadResponse) Reset() {
+	*x = FindEmailThreadResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindEmailThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmailThreadResponse) ProtoMessage() {}
+
+func (x *FindEmailThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmailThreadResponse.ProtoReflect.Descriptor instead.
+func (*FindEmailThreadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *FindEmailThreadResponse) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type RecordEmailMessageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Message-ID, without angle brackets
+	MessageId     string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	TicketId      string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEmailMessageRequest) Reset() {
+	*x = RecordEmailMessageRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEmailMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEmailMessageRequest) ProtoMessage() {}
+
+func (x *RecordEmailMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEmailMessageRequest.ProtoReflect.Descriptor instead.
+func (*RecordEmailMessageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *RecordEmailMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RecordEmailMessageRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+type RecordEmailMessageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when the message was already recorded
+	Recorded      bool `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEmailMessageResponse) Reset() {
+	*x = RecordEmailMessageResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEmailMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEmailMessageResponse) ProtoMessage() {}
+
+func (x *RecordEmailMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEmailMessageResponse.ProtoReflect.Descriptor instead.
+func (*RecordEmailMessageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RecordEmailMessageResponse) GetRecorded() bool {
+	if x != nil {
+		return x.Recorded
+	}
+	return false
+}
+
+// The changes a bulk update makes to every ticket. Empty fields are left
+// unchanged, as in UpdateTicketRequest.
+type TicketPatch struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Status      TicketStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=ticket.TicketStatus" json:"status,omitempty"`
+	Priority    TicketPriority         `protobuf:"varint,4,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
+	AssigneeId  string                 `protobuf:"bytes,5,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Tags        []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Sets these custom fields; an empty value clears a field
+	CustomFields map[string]string      `protobuf:"bytes,7,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DueDate      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Removes the due date; due_date must then be unset
+	ClearDueDate  bool `protobuf:"varint,9,opt,name=clear_due_date,json=clearDueDate,proto3" json:"clear_due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketPatch) Reset() {
+	*x = TicketPatch{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketPatch) ProtoMessage() {}
+
+func (x *TicketPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketPatch.ProtoReflect.Descriptor instead.
+func (*TicketPatch) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *TicketPatch) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TicketPatch) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TicketPatch) GetStatus() TicketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TicketStatus_TICKET_STATUS_UNSPECIFIED
+}
+
+func (x *TicketPatch) GetPriority() TicketPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+}
+
+func (x *TicketPatch) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *TicketPatch) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TicketPatch) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *TicketPatch) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *TicketPatch) GetClearDueDate() bool {
+	if x != nil {
+		return x.ClearDueDate
+	}
+	return false
+}
+
+type BulkUpdateTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Distinct ticket IDs, at most 500
+	Ids   []string     `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Patch *TicketPatch `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	// Updates every ticket or none, in one transaction: when one ticket cannot
+	// be updated the others are left unchanged too. Tickets resolved together
+	// do not block each other. Otherwise each ticket is updated on its own.
+	Atomic bool `protobuf:"varint,3,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateTicketsRequest) Reset() {
+	*x = BulkUpdateTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateTicketsRequest) ProtoMessage() {}
+
+func (x *BulkUpdateTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateTicketsRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *BulkUpdateTicketsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkUpdateTicketsRequest) GetPatch() *TicketPatch {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+func (x *BulkUpdateTicketsRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *BulkUpdateTicketsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type BulkUpdateTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per requested ticket, in request order
+	Results       []*BulkTicketResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateTicketsResponse) Reset() {
+	*x = BulkUpdateTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateTicketsResponse) ProtoMessage() {}
+
+func (x *BulkUpdateTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateTicketsResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *BulkUpdateTicketsResponse) GetResults() []*BulkTicketResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BulkDeleteTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Distinct ticket IDs, at most 500
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Deletes every ticket or none, in one transaction
+	Atomic bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	// Idempotency key, as in CreateTicketRequest
+	RequestId     string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteTicketsRequest) Reset() {
+	*x = BulkDeleteTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteTicketsRequest) ProtoMessage() {}
+
+func (x *BulkDeleteTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteTicketsRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *BulkDeleteTicketsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkDeleteTicketsRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *BulkDeleteTicketsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type BulkDeleteTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per requested ticket, in request order
+	Results       []*BulkTicketResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteTicketsResponse) Reset() {
+	*x = BulkDeleteTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteTicketsResponse) ProtoMessage() {}
+
+func (x *BulkDeleteTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteTicketsResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *BulkDeleteTicketsResponse) GetResults() []*BulkTicketResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// The outcome of a bulk operation for one ticket
+type BulkTicketResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The updated ticket; unset for deletions and failures
+	Ticket *Ticket `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// The google.rpc.Code of the failure, 0 (OK) when the ticket was changed.
+	// ABORTED marks the tickets an atomic request left unchanged because
+	// another ticket failed.
+	ErrorCode     int32  `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkTicketResult) Reset() {
+	*x = BulkTicketResult{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkTicketResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkTicketResult) ProtoMessage() {}
+
+func (x *BulkTicketResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkTicketResult.ProtoReflect.Descriptor instead.
+func (*BulkTicketResult) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *BulkTicketResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkTicketResult) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *BulkTicketResult) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *BulkTicketResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// A ticket in the portable form tickets are exported and imported in.
+// Projects are named by key, so records can move between organizations and
+// databases.
+type TicketRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The ticket's key when exported, e.g. WEB-42. Imported tickets are
+	// numbered afresh in their project, so it is ignored on import.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	ProjectKey    string                 `protobuf:"bytes,3,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Status        TicketStatus           `protobuf:"varint,6,opt,name=status,proto3,enum=ticket.TicketStatus" json:"status,omitempty"`
+	Priority      TicketPriority         `protobuf:"varint,7,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
+	AssigneeId    string                 `protobuf:"bytes,8,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	ReporterId    string                 `protobuf:"bytes,9,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomFields  map[string]string      `protobuf:"bytes,11,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketRecord) Reset() {
+	*x = TicketRecord{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketRecord) ProtoMessage() {}
+
+func (x *TicketRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketRecord.ProtoReflect.Descriptor instead.
+func (*TicketRecord) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *TicketRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetStatus() TicketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TicketStatus_TICKET_STATUS_UNSPECIFIED
+}
+
+func (x *TicketRecord) GetPriority() TicketPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+}
+
+func (x *TicketRecord) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *TicketRecord) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TicketRecord) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *TicketRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TicketRecord) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *TicketRecord) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+// Selects the tickets to export, like the filters of ListTickets
+type ExportTicketsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	ProjectKey    string                 `protobuf:"bytes,3,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
+	CustomFields  map[string]string      `protobuf:"bytes,4,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTicketsRequest) Reset() {
+	*x = ExportTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTicketsRequest) ProtoMessage() {}
+
+func (x *ExportTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ExportTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ExportTicketsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ExportTicketsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ExportTicketsRequest) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
+	}
+	return ""
+}
+
+func (x *ExportTicketsRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
 }
 
-type GetTicketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+type ExportTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The next tickets, newest first
+	Tickets       []*TicketRecord `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTicketResponse) Reset() {
-	*x = GetTicketResponse{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[4]
+func (x *ExportTicketsResponse) Reset() {
+	*x = ExportTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTicketResponse) String() string {
+func (x *ExportTicketsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTicketResponse) ProtoMessage() {}
+func (*ExportTicketsResponse) ProtoMessage() {}
 
-func (x *GetTicketResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[4]
+func (x *ExportTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,41 +6452,45 @@ func (x *GetTicketResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTicketResponse.ProtoReflect.Descriptor instead.
-func (*GetTicketResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ExportTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ExportTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *GetTicketResponse) GetTicket() *Ticket {
+func (x *ExportTicketsResponse) GetTickets() []*TicketRecord {
 	if x != nil {
-		return x.Ticket
+		return x.Tickets
 	}
 	return nil
 }
 
-type ListTicketsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+type ImportOptions struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	IdMode ImportIDMode           `protobuf:"varint,1,opt,name=id_mode,json=idMode,proto3,enum=ticket.ImportIDMode" json:"id_mode,omitempty"`
+	// Validates every record and reports what would happen without storing
+	// anything
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Project of the records that name none; empty leaves them outside projects
+	ProjectKey    string `protobuf:"bytes,3,opt,name=project_key,json=projectKey,proto3" json:"project_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTicketsRequest) Reset() {
-	*x = ListTicketsRequest{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[5]
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTicketsRequest) String() string {
+func (x *ImportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTicketsRequest) ProtoMessage() {}
+func (*ImportOptions) ProtoMessage() {}
 
-func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[5]
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,48 +6501,58 @@ func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTicketsRequest.ProtoReflect.Descriptor instead.
-func (*ListTicketsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *ListTicketsRequest) GetPageSize() int32 {
+func (x *ImportOptions) GetIdMode() ImportIDMode {
 	if x != nil {
-		return x.PageSize
+		return x.IdMode
 	}
-	return 0
+	return ImportIDMode_IMPORT_ID_MODE_UNSPECIFIED
 }
 
-func (x *ListTicketsRequest) GetPageToken() string {
+func (x *ImportOptions) GetDryRun() bool {
 	if x != nil {
-		return x.PageToken
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetProjectKey() string {
+	if x != nil {
+		return x.ProjectKey
 	}
 	return ""
 }
 
-type ListTicketsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+type ImportTicketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ImportTicketsRequest_Options
+	//	*ImportTicketsRequest_Ticket
+	Data          isImportTicketsRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTicketsResponse) Reset() {
-	*x = ListTicketsResponse{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[6]
+func (x *ImportTicketsRequest) Reset() {
+	*x = ImportTicketsRequest{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTicketsResponse) String() string {
+func (x *ImportTicketsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTicketsResponse) ProtoMessage() {}
+func (*ImportTicketsRequest) ProtoMessage() {}
 
-func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[6]
+func (x *ImportTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,53 +6563,83 @@ func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
-func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ImportTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *ListTicketsResponse) GetTickets() []*Ticket {
+func (x *ImportTicketsRequest) GetData() isImportTicketsRequest_Data {
 	if x != nil {
-		return x.Tickets
+		return x.Data
 	}
 	return nil
 }
 
-func (x *ListTicketsResponse) GetNextPageToken() string {
+func (x *ImportTicketsRequest) GetOptions() *ImportOptions {
 	if x != nil {
-		return x.NextPageToken
+		if x, ok := x.Data.(*ImportTicketsRequest_Options); ok {
+			return x.Options
+		}
 	}
-	return ""
+	return nil
 }
 
-type UpdateTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Status        TicketStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=ticket.TicketStatus" json:"status,omitempty"`
-	Priority      TicketPriority         `protobuf:"varint,5,opt,name=priority,proto3,enum=ticket.TicketPriority" json:"priority,omitempty"`
-	AssigneeId    string                 `protobuf:"bytes,6,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
-	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+func (x *ImportTicketsRequest) GetTicket() *TicketRecord {
+	if x != nil {
+		if x, ok := x.Data.(*ImportTicketsRequest_Ticket); ok {
+			return x.Ticket
+		}
+	}
+	return nil
+}
+
+type isImportTicketsRequest_Data interface {
+	isImportTicketsRequest_Data()
+}
+
+type ImportTicketsRequest_Options struct {
+	// The first message carries the options
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportTicketsRequest_Ticket struct {
+	Ticket *TicketRecord `protobuf:"bytes,2,opt,name=ticket,proto3,oneof"`
+}
+
+func (*ImportTicketsRequest_Options) isImportTicketsRequest_Data() {}
+
+func (*ImportTicketsRequest_Ticket) isImportTicketsRequest_Data() {}
+
+// The outcome of one imported record
+type ImportResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the record in the import, from 1
+	Record   int32  `protobuf:"varint,1,opt,name=record,proto3" json:"record,omitempty"`
+	SourceId string `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TicketId string `protobuf:"bytes,3,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	// Key the ticket was given; empty outside projects and in dry runs
+	Key           string        `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Outcome       ImportOutcome `protobuf:"varint,5,opt,name=outcome,proto3,enum=ticket.ImportOutcome" json:"outcome,omitempty"`
+	Error         string        `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTicketRequest) Reset() {
-	*x = UpdateTicketRequest{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[7]
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTicketRequest) String() string {
+func (x *ImportResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTicketRequest) ProtoMessage() {}
+func (*ImportResult) ProtoMessage() {}
 
-func (x *UpdateTicketRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[7]
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,82 +6650,81 @@ func (x *UpdateTicketRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTicketRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTicketRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *UpdateTicketRequest) GetId() string {
+func (x *ImportResult) GetRecord() int32 {
 	if x != nil {
-		return x.Id
+		return x.Record
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateTicketRequest) GetTitle() string {
+func (x *ImportResult) GetSourceId() string {
 	if x != nil {
-		return x.Title
+		return x.SourceId
 	}
 	return ""
 }
 
-func (x *UpdateTicketRequest) GetDescription() string {
+func (x *ImportResult) GetTicketId() string {
 	if x != nil {
-		return x.Description
+		return x.TicketId
 	}
 	return ""
 }
 
-func (x *UpdateTicketRequest) GetStatus() TicketStatus {
+func (x *ImportResult) GetKey() string {
 	if x != nil {
-		return x.Status
+		return x.Key
 	}
-	return TicketStatus_TICKET_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (x *UpdateTicketRequest) GetPriority() TicketPriority {
+func (x *ImportResult) GetOutcome() ImportOutcome {
 	if x != nil {
-		return x.Priority
+		return x.Outcome
 	}
-	return TicketPriority_TICKET_PRIORITY_UNSPECIFIED
+	return ImportOutcome_IMPORT_OUTCOME_UNSPECIFIED
 }
 
-func (x *UpdateTicketRequest) GetAssigneeId() string {
+func (x *ImportResult) GetError() string {
 	if x != nil {
-		return x.AssigneeId
+		return x.Error
 	}
 	return ""
 }
 
-func (x *UpdateTicketRequest) GetTags() []string {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
-}
-
-type UpdateTicketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ticket        *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+type ImportTicketsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	DryRun  bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Created int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped int32                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Invalid int32                  `protobuf:"varint,4,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	Failed  int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	// One result per record, in import order
+	Results       []*ImportResult `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTicketResponse) Reset() {
-	*x = UpdateTicketResponse{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[8]
+func (x *ImportTicketsResponse) Reset() {
+	*x = ImportTicketsResponse{}
+	mi := &file_proto_ticket_ticket_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTicketResponse) String() string {
+func (x *ImportTicketsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTicketResponse) ProtoMessage() {}
+func (*ImportTicketsResponse) ProtoMessage() {}
 
-func (x *UpdateTicketResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[8]
+func (x *ImportTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticket_ticket_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,111 +6735,58 @@ func (x *UpdateTicketResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTicketResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTicketResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ImportTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ImportTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *UpdateTicketResponse) GetTicket() *Ticket {
+func (x *ImportTicketsResponse) GetDryRun() bool {
 	if x != nil {
-		return x.Ticket
+		return x.DryRun
 	}
-	return nil
-}
-
-type DeleteTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteTicketRequest) Reset() {
-	*x = DeleteTicketRequest{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteTicketRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return false
 }
 
-func (*DeleteTicketRequest) ProtoMessage() {}
-
-func (x *DeleteTicketRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[9]
+func (x *ImportTicketsResponse) GetCreated() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Created
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteTicketRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTicketRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{9}
+	return 0
 }
 
-func (x *DeleteTicketRequest) GetId() string {
+func (x *ImportTicketsResponse) GetSkipped() int32 {
 	if x != nil {
-		return x.Id
+		return x.Skipped
 	}
-	return ""
-}
-
-type DeleteTicketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteTicketResponse) Reset() {
-	*x = DeleteTicketResponse{}
-	mi := &file_proto_ticket_ticket_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteTicketResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*DeleteTicketResponse) ProtoMessage() {}
-
-func (x *DeleteTicketResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticket_ticket_proto_msgTypes[10]
+func (x *ImportTicketsResponse) GetInvalid() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Invalid
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use DeleteTicketResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTicketResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticket_ticket_proto_rawDescGZIP(), []int{10}
+func (x *ImportTicketsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
 }
 
-func (x *DeleteTicketResponse) GetSuccess() bool {
+func (x *ImportTicketsResponse) GetResults() []*ImportResult {
 	if x != nil {
-		return x.Success
+		return x.Results
 	}
-	return false
+	return nil
 }
 
 var File_proto_ticket_ticket_proto protoreflect.FileDescriptor
 
 const file_proto_ticket_ticket_proto_rawDesc = "" +
 	"\n" +
-	"\x19proto/ticket/ticket.proto\x12\x06ticket\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x02\n" +
+	"\x19proto/ticket/ticket.proto\x12\x06ticket\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xbc\x05\n" +
 	"\x06Ticket\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -797,27 +6799,99 @@ const file_proto_ticket_ticket_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb6\x01\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1f\n" +
+	"\vreporter_id\x18\n" +
+	" \x01(\tR\n" +
+	"reporterId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\v \x01(\tR\tprojectId\x12\x10\n" +
+	"\x03key\x18\f \x01(\tR\x03key\x12'\n" +
+	"\x0forganization_id\x18\r \x01(\tR\x0eorganizationId\x12E\n" +
+	"\rcustom_fields\x18\x0e \x03(\v2 .ticket.Ticket.CustomFieldsEntryR\fcustomFields\x125\n" +
+	"\bdue_date\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12#\n" +
+	"\x03sla\x18\x10 \x01(\v2\x11.ticket.TicketSLAR\x03sla\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x94\x03\n" +
+	"\tTicketSLA\x12M\n" +
+	"\x15first_response_due_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x12firstResponseDueAt\x12F\n" +
+	"\x11resolution_due_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0fresolutionDueAt\x12H\n" +
+	"\x12first_responded_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x10firstRespondedAt\x12;\n" +
+	"\vresolved_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12)\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x11.ticket.SLAStatusR\x06status\x12>\n" +
+	"\rnext_check_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vnextCheckAt\"\xbc\x01\n" +
+	"\tSLAPolicy\x122\n" +
+	"\bpriority\x18\x01 \x01(\x0e2\x16.ticket.TicketPriorityR\bpriority\x12@\n" +
+	"\x0efirst_response\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\rfirstResponse\x129\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"resolution\"\xa0\x01\n" +
+	"\x15CustomFieldDefinition\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12+\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x17.ticket.CustomFieldTypeR\x04type\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\"\xaa\x02\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vlast_number\x18\x05 \x01(\x03R\n" +
+	"lastNumber\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\x0forganization_id\x18\a \x01(\tR\x0eorganizationId\x12B\n" +
+	"\rcustom_fields\x18\b \x03(\v2\x1d.ticket.CustomFieldDefinitionR\fcustomFields\"\xa7\x01\n" +
+	"\n" +
+	"TicketLink\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12$\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x10.ticket.LinkTypeR\x04type\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xe3\x03\n" +
 	"\x13CreateTicketRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x122\n" +
 	"\bpriority\x18\x03 \x01(\x0e2\x16.ticket.TicketPriorityR\bpriority\x12\x1f\n" +
 	"\vassignee_id\x18\x04 \x01(\tR\n" +
 	"assigneeId\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\">\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vreporter_id\x18\x06 \x01(\tR\n" +
+	"reporterId\x12\x1f\n" +
+	"\vproject_key\x18\a \x01(\tR\n" +
+	"projectKey\x12R\n" +
+	"\rcustom_fields\x18\b \x03(\v2-.ticket.CreateTicketRequest.CustomFieldsEntryR\fcustomFields\x125\n" +
+	"\bdue_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\n" +
+	" \x01(\tR\trequestId\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
 	"\x14CreateTicketResponse\x12&\n" +
-	"\x06ticket\x18\x01 \x01(\v2\x0e.ticket.TicketR\x06ticket\"\"\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x0e.ticket.TicketR\x06ticket\"4\n" +
 	"\x10GetTicketRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\";\n" +
 	"\x11GetTicketResponse\x12&\n" +
-	"\x06ticket\x18\x01 \x01(\v2\x0e.ticket.TicketR\x06ticket\"P\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x0e.ticket.TicketR\x06ticket\"\xaf\x02\n" +
 	"\x12ListTicketsRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"g\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x1f\n" +
+	"\vproject_key\x18\x05 \x01(\tR\n" +
+	"projectKey\x12Q\n" +
+	"\rcustom_fields\x18\x06 \x03(\v2,.ticket.ListTicketsRequest.CustomFieldsEntryR\fcustomFields\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"g\n" +
 	"\x13ListTicketsResponse\x12(\n" +
 	"\atickets\x18\x01 \x03(\v2\x0e.ticket.TicketR\atickets\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf4\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x85\x04\n" +
 	"\x13UpdateTicketRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -826,13 +6900,406 @@ const file_proto_ticket_ticket_proto_rawDesc = "" +
 	"\bpriority\x18\x05 \x01(\x0e2\x16.ticket.TicketPriorityR\bpriority\x12\x1f\n" +
 	"\vassignee_id\x18\x06 \x01(\tR\n" +
 	"assigneeId\x12\x12\n" +
-	"\x04tags\x18\a \x03(\tR\x04tags\">\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12R\n" +
+	"\rcustom_fields\x18\b \x03(\v2-.ticket.UpdateTicketRequest.CustomFieldsEntryR\fcustomFields\x125\n" +
+	"\bdue_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12$\n" +
+	"\x0eclear_due_date\x18\n" +
+	" \x01(\bR\fclearDueDate\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\v \x01(\tR\trequestId\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
 	"\x14UpdateTicketResponse\x12&\n" +
-	"\x06ticket\x18\x01 \x01(\v2\x0e.ticket.TicketR\x06ticket\"%\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x0e.ticket.TicketR\x06ticket\"D\n" +
 	"\x13DeleteTicketRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"0\n" +
 	"\x14DeleteTicketResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*\x9a\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"}\n" +
+	"\x14CreateProjectRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"B\n" +
+	"\x15CreateProjectResponse\x12)\n" +
+	"\aproject\x18\x01 \x01(\v2\x0f.ticket.ProjectR\aproject\"5\n" +
+	"\x11GetProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"?\n" +
+	"\x12GetProjectResponse\x12)\n" +
+	"\aproject\x18\x01 \x01(\v2\x0f.ticket.ProjectR\aproject\"p\n" +
+	"\x16SetCustomFieldsRequest\x12\x1f\n" +
+	"\vproject_key\x18\x01 \x01(\tR\n" +
+	"projectKey\x125\n" +
+	"\x06fields\x18\x02 \x03(\v2\x1d.ticket.CustomFieldDefinitionR\x06fields\"D\n" +
+	"\x17SetCustomFieldsResponse\x12)\n" +
+	"\aproject\x18\x01 \x01(\v2\x0f.ticket.ProjectR\aproject\"\x15\n" +
+	"\x13ListProjectsRequest\"C\n" +
+	"\x14ListProjectsResponse\x12+\n" +
+	"\bprojects\x18\x01 \x03(\v2\x0f.ticket.ProjectR\bprojects\"4\n" +
+	"\bTagCount\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"?\n" +
+	"\x0fListTagsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"8\n" +
+	"\x10ListTagsResponse\x12$\n" +
+	"\x04tags\x18\x01 \x03(\v2\x10.ticket.TagCountR\x04tags\"c\n" +
+	"\x10MergeTagsRequest\x12\x18\n" +
+	"\asources\x18\x01 \x03(\tR\asources\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\"<\n" +
+	"\x11MergeTagsResponse\x12'\n" +
+	"\x0fupdated_tickets\x18\x01 \x01(\x05R\x0eupdatedTickets\"\x93\x01\n" +
+	"\x12LinkTicketsRequest\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12$\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x10.ticket.LinkTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"=\n" +
+	"\x13LinkTicketsResponse\x12&\n" +
+	"\x04link\x18\x01 \x01(\v2\x12.ticket.TicketLinkR\x04link\"\x95\x01\n" +
+	"\x14UnlinkTicketsRequest\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12$\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x10.ticket.LinkTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"1\n" +
+	"\x15UnlinkTicketsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"/\n" +
+	"\x10ListLinksRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"=\n" +
+	"\x11ListLinksResponse\x12(\n" +
+	"\x05links\x18\x01 \x03(\v2\x12.ticket.TicketLinkR\x05links\"\xdf\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x88\x01\n" +
+	"\x12AttachmentMetadata\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\"s\n" +
+	"\x17UploadAttachmentRequest\x128\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1a.ticket.AttachmentMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"N\n" +
+	"\x18UploadAttachmentResponse\x122\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x12.ticket.AttachmentR\n" +
+	"attachment\"5\n" +
+	"\x16ListAttachmentsRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"O\n" +
+	"\x17ListAttachmentsResponse\x124\n" +
+	"\vattachments\x18\x01 \x03(\v2\x12.ticket.AttachmentR\vattachments\"+\n" +
+	"\x19DownloadAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"r\n" +
+	"\x1aDownloadAttachmentResponse\x124\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x12.ticket.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\x18\n" +
+	"\x16ListSLAPoliciesRequest\"H\n" +
+	"\x17ListSLAPoliciesResponse\x12-\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x11.ticket.SLAPolicyR\bpolicies\"\x9b\x01\n" +
+	"\rWebhookFilter\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x126\n" +
+	"\n" +
+	"priorities\x18\x03 \x03(\x0e2\x16.ticket.TicketPriorityR\n" +
+	"priorities\x12\x1f\n" +
+	"\vproject_key\x18\x04 \x01(\tR\n" +
+	"projectKey\"\xca\x02\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x05 \x03(\tR\n" +
+	"eventTypes\x12-\n" +
+	"\x06filter\x18\x06 \x01(\v2\x15.ticket.WebhookFilterR\x06filter\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdb\x04\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x03 \x01(\tR\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x05 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x06 \x01(\tR\apayload\x125\n" +
+	"\x06status\x18\a \x01(\x0e2\x1d.ticket.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x05R\battempts\x12B\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12B\n" +
+	"\x0flast_attempt_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rlastAttemptAt\x12(\n" +
+	"\x10last_status_code\x18\v \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\f \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fdelivered_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"\xaf\x01\n" +
+	"\x14CreateWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12-\n" +
+	"\x06filter\x18\x04 \x01(\v2\x15.ticket.WebhookFilterR\x06filter\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x05 \x01(\tR\trequestId\"B\n" +
+	"\x15CreateWebhookResponse\x12)\n" +
+	"\awebhook\x18\x01 \x01(\v2\x0f.ticket.WebhookR\awebhook\"\xf3\x01\n" +
+	"\x14UpdateWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\x12-\n" +
+	"\x06filter\x18\x05 \x01(\v2\x15.ticket.WebhookFilterR\x06filter\x122\n" +
+	"\x06active\x18\x06 \x01(\v2\x1a.google.protobuf.BoolValueR\x06active\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\"B\n" +
+	"\x15UpdateWebhookResponse\x12)\n" +
+	"\awebhook\x18\x01 \x01(\v2\x0f.ticket.WebhookR\awebhook\"E\n" +
+	"\x14DeleteWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"1\n" +
+	"\x15DeleteWebhookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x15\n" +
+	"\x13ListWebhooksRequest\"C\n" +
+	"\x14ListWebhooksResponse\x12+\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x0f.ticket.WebhookR\bwebhooks\"\x8a\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x125\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.ticket.WebhookDeliveryStatusR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"X\n" +
+	"\x1dListWebhookDeliveriesResponse\x127\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x17.ticket.WebhookDeliveryR\n" +
+	"deliveries\"Y\n" +
+	"\x17RedeliverWebhookRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"O\n" +
+	"\x18RedeliverWebhookResponse\x123\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x17.ticket.WebhookDeliveryR\bdelivery\"\xca\x03\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12,\n" +
+	"\x04kind\x18\x04 \x01(\x0e2\x18.ticket.NotificationKindR\x04kind\x12\x1b\n" +
+	"\tticket_id\x18\x05 \x01(\tR\bticketId\x12\x19\n" +
+	"\bevent_id\x18\x06 \x01(\tR\aeventId\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\x129\n" +
+	"\n" +
+	"deliver_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tdeliverAt\x12=\n" +
+	"\fdelivered_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"\xc2\x02\n" +
+	"\x17NotificationPreferences\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x128\n" +
+	"\bdelivery\x18\x04 \x01(\x0e2\x1c.ticket.NotificationDeliveryR\bdelivery\x12\x1f\n" +
+	"\vdigest_hour\x18\x05 \x01(\x05R\n" +
+	"digestHour\x129\n" +
+	"\vmuted_kinds\x18\x06 \x03(\x0e2\x18.ticket.NotificationKindR\n" +
+	"mutedKinds\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"J\n" +
+	"\x12WatchTicketRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"6\n" +
+	"\x13WatchTicketResponse\x12\x1f\n" +
+	"\vwatcher_ids\x18\x01 \x03(\tR\n" +
+	"watcherIds\"L\n" +
+	"\x14UnwatchTicketRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"1\n" +
+	"\x15UnwatchTicketResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"2\n" +
+	"\x13ListWatchersRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"7\n" +
+	"\x14ListWatchersResponse\x12\x1f\n" +
+	"\vwatcher_ids\x18\x01 \x03(\tR\n" +
+	"watcherIds\"j\n" +
+	"\x18ListNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"W\n" +
+	"\x19ListNotificationsResponse\x12:\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x14.ticket.NotificationR\rnotifications\"-\n" +
+	"\x1bMarkNotificationReadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"X\n" +
+	"\x1cMarkNotificationReadResponse\x128\n" +
+	"\fnotification\x18\x01 \x01(\v2\x14.ticket.NotificationR\fnotification\"<\n" +
+	"!GetNotificationPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"g\n" +
+	"\"GetNotificationPreferencesResponse\x12A\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1f.ticket.NotificationPreferencesR\vpreferences\"\xce\x02\n" +
+	"$UpdateNotificationPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\x05email\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\x05email\x128\n" +
+	"\bdelivery\x18\x03 \x01(\x0e2\x1c.ticket.NotificationDeliveryR\bdelivery\x12<\n" +
+	"\vdigest_hour\x18\x04 \x01(\v2\x1b.google.protobuf.Int32ValueR\n" +
+	"digestHour\x12&\n" +
+	"\x0fset_muted_kinds\x18\x05 \x01(\bR\rsetMutedKinds\x129\n" +
+	"\vmuted_kinds\x18\x06 \x03(\x0e2\x18.ticket.NotificationKindR\n" +
+	"mutedKinds\"j\n" +
+	"%UpdateNotificationPreferencesResponse\x12A\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1f.ticket.NotificationPreferencesR\vpreferences\"\xcb\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x1b\n" +
+	"\tticket_id\x18\x03 \x01(\tR\bticketId\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x80\x01\n" +
+	"\x11AddCommentRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"?\n" +
+	"\x12AddCommentResponse\x12)\n" +
+	"\acomment\x18\x01 \x01(\v2\x0f.ticket.CommentR\acomment\"2\n" +
+	"\x13ListCommentsRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"C\n" +
+	"\x14ListCommentsResponse\x12+\n" +
+	"\bcomments\x18\x01 \x03(\v2\x0f.ticket.CommentR\bcomments\"9\n" +
+	"\x16FindEmailThreadRequest\x12\x1f\n" +
+	"\vmessage_ids\x18\x01 \x03(\tR\n" +
+	"messageIds\"6\n" +
+	"\x17FindEmailThreadResponse\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"W\n" +
+	"\x19RecordEmailMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\"8\n" +
+	"\x1aRecordEmailMessageResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\bR\brecorded\"\xc6\x03\n" +
+	"\vTicketPatch\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.ticket.TicketStatusR\x06status\x122\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x16.ticket.TicketPriorityR\bpriority\x12\x1f\n" +
+	"\vassignee_id\x18\x05 \x01(\tR\n" +
+	"assigneeId\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12J\n" +
+	"\rcustom_fields\x18\a \x03(\v2%.ticket.TicketPatch.CustomFieldsEntryR\fcustomFields\x125\n" +
+	"\bdue_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12$\n" +
+	"\x0eclear_due_date\x18\t \x01(\bR\fclearDueDate\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8e\x01\n" +
+	"\x18BulkUpdateTicketsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12)\n" +
+	"\x05patch\x18\x02 \x01(\v2\x13.ticket.TicketPatchR\x05patch\x12\x16\n" +
+	"\x06atomic\x18\x03 \x01(\bR\x06atomic\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"O\n" +
+	"\x19BulkUpdateTicketsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.ticket.BulkTicketResultR\aresults\"c\n" +
+	"\x18BulkDeleteTicketsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tR\trequestId\"O\n" +
+	"\x19BulkDeleteTicketsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.ticket.BulkTicketResultR\aresults\"\x8e\x01\n" +
+	"\x10BulkTicketResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x06ticket\x18\x02 \x01(\v2\x0e.ticket.TicketR\x06ticket\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x05R\terrorCode\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\xfc\x04\n" +
+	"\fTicketRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1f\n" +
+	"\vproject_key\x18\x03 \x01(\tR\n" +
+	"projectKey\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12,\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x14.ticket.TicketStatusR\x06status\x122\n" +
+	"\bpriority\x18\a \x01(\x0e2\x16.ticket.TicketPriorityR\bpriority\x12\x1f\n" +
+	"\vassignee_id\x18\b \x01(\tR\n" +
+	"assigneeId\x12\x1f\n" +
+	"\vreporter_id\x18\t \x01(\tR\n" +
+	"reporterId\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12K\n" +
+	"\rcustom_fields\x18\v \x03(\v2&.ticket.TicketRecord.CustomFieldsEntryR\fcustomFields\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x125\n" +
+	"\bdue_date\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf7\x01\n" +
+	"\x14ExportTicketsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1f\n" +
+	"\vproject_key\x18\x03 \x01(\tR\n" +
+	"projectKey\x12S\n" +
+	"\rcustom_fields\x18\x04 \x03(\v2..ticket.ExportTicketsRequest.CustomFieldsEntryR\fcustomFields\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\x15ExportTicketsResponse\x12.\n" +
+	"\atickets\x18\x01 \x03(\v2\x14.ticket.TicketRecordR\atickets\"x\n" +
+	"\rImportOptions\x12-\n" +
+	"\aid_mode\x18\x01 \x01(\x0e2\x14.ticket.ImportIDModeR\x06idMode\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vproject_key\x18\x03 \x01(\tR\n" +
+	"projectKey\"\x81\x01\n" +
+	"\x14ImportTicketsRequest\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x15.ticket.ImportOptionsH\x00R\aoptions\x12.\n" +
+	"\x06ticket\x18\x02 \x01(\v2\x14.ticket.TicketRecordH\x00R\x06ticketB\x06\n" +
+	"\x04data\"\xb9\x01\n" +
+	"\fImportResult\x12\x16\n" +
+	"\x06record\x18\x01 \x01(\x05R\x06record\x12\x1b\n" +
+	"\tsource_id\x18\x02 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\tticket_id\x18\x03 \x01(\tR\bticketId\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12/\n" +
+	"\aoutcome\x18\x05 \x01(\x0e2\x15.ticket.ImportOutcomeR\aoutcome\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xc6\x01\n" +
+	"\x15ImportTicketsResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x05R\askipped\x12\x18\n" +
+	"\ainvalid\x18\x04 \x01(\x05R\ainvalid\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12.\n" +
+	"\aresults\x18\x06 \x03(\v2\x14.ticket.ImportResultR\aresults*\x85\x01\n" +
+	"\tSLAStatus\x12\x1a\n" +
+	"\x16SLA_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SLA_STATUS_ON_TRACK\x10\x01\x12\x16\n" +
+	"\x12SLA_STATUS_AT_RISK\x10\x02\x12\x17\n" +
+	"\x13SLA_STATUS_BREACHED\x10\x03\x12\x12\n" +
+	"\x0eSLA_STATUS_MET\x10\x04*\x9a\x01\n" +
 	"\fTicketStatus\x12\x1d\n" +
 	"\x19TICKET_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12TICKET_STATUS_OPEN\x10\x01\x12\x1d\n" +
@@ -844,13 +7311,94 @@ const file_proto_ticket_ticket_proto_rawDesc = "" +
 	"\x13TICKET_PRIORITY_LOW\x10\x01\x12\x1a\n" +
 	"\x16TICKET_PRIORITY_MEDIUM\x10\x02\x12\x18\n" +
 	"\x14TICKET_PRIORITY_HIGH\x10\x03\x12\x1c\n" +
-	"\x18TICKET_PRIORITY_CRITICAL\x10\x042\xfa\x02\n" +
-	"\rTicketService\x12I\n" +
-	"\fCreateTicket\x12\x1b.ticket.CreateTicketRequest\x1a\x1c.ticket.CreateTicketResponse\x12@\n" +
-	"\tGetTicket\x12\x18.ticket.GetTicketRequest\x1a\x19.ticket.GetTicketResponse\x12F\n" +
-	"\vListTickets\x12\x1a.ticket.ListTicketsRequest\x1a\x1b.ticket.ListTicketsResponse\x12I\n" +
-	"\fUpdateTicket\x12\x1b.ticket.UpdateTicketRequest\x1a\x1c.ticket.UpdateTicketResponse\x12I\n" +
-	"\fDeleteTicket\x12\x1b.ticket.DeleteTicketRequest\x1a\x1c.ticket.DeleteTicketResponseB.Z,github.com/ayush-pandya/Graphql/proto/ticketb\x06proto3"
+	"\x18TICKET_PRIORITY_CRITICAL\x10\x04*\x88\x01\n" +
+	"\bLinkType\x12\x19\n" +
+	"\x15LINK_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10LINK_TYPE_BLOCKS\x10\x01\x12\x18\n" +
+	"\x14LINK_TYPE_DUPLICATES\x10\x02\x12\x18\n" +
+	"\x14LINK_TYPE_RELATES_TO\x10\x03\x12\x17\n" +
+	"\x13LINK_TYPE_PARENT_OF\x10\x04*\xc2\x01\n" +
+	"\x0fCustomFieldType\x12!\n" +
+	"\x1dCUSTOM_FIELD_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16CUSTOM_FIELD_TYPE_TEXT\x10\x01\x12\x1c\n" +
+	"\x18CUSTOM_FIELD_TYPE_NUMBER\x10\x02\x12\x1a\n" +
+	"\x16CUSTOM_FIELD_TYPE_ENUM\x10\x03\x12\x1a\n" +
+	"\x16CUSTOM_FIELD_TYPE_DATE\x10\x04\x12\x1a\n" +
+	"\x16CUSTOM_FIELD_TYPE_USER\x10\x05*\xae\x01\n" +
+	"\x15WebhookDeliveryStatus\x12'\n" +
+	"#WEBHOOK_DELIVERY_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fWEBHOOK_DELIVERY_STATUS_PENDING\x10\x01\x12%\n" +
+	"!WEBHOOK_DELIVERY_STATUS_SUCCEEDED\x10\x02\x12 \n" +
+	"\x1cWEBHOOK_DELIVERY_STATUS_DEAD\x10\x03*\xa0\x02\n" +
+	"\x10NotificationKind\x12!\n" +
+	"\x1dNOTIFICATION_KIND_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aNOTIFICATION_KIND_ASSIGNED\x10\x01\x12$\n" +
+	" NOTIFICATION_KIND_STATUS_CHANGED\x10\x02\x12\x1e\n" +
+	"\x1aNOTIFICATION_KIND_RESOLVED\x10\x03\x12\x1d\n" +
+	"\x19NOTIFICATION_KIND_UPDATED\x10\x04\x12\x1d\n" +
+	"\x19NOTIFICATION_KIND_DELETED\x10\x05\x12!\n" +
+	"\x1dNOTIFICATION_KIND_SLA_AT_RISK\x10\x06\x12\"\n" +
+	"\x1eNOTIFICATION_KIND_SLA_BREACHED\x10\a*\xa9\x01\n" +
+	"\x14NotificationDelivery\x12%\n" +
+	"!NOTIFICATION_DELIVERY_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fNOTIFICATION_DELIVERY_IMMEDIATE\x10\x01\x12&\n" +
+	"\"NOTIFICATION_DELIVERY_DAILY_DIGEST\x10\x02\x12\x1d\n" +
+	"\x19NOTIFICATION_DELIVERY_OFF\x10\x03*e\n" +
+	"\fImportIDMode\x12\x1e\n" +
+	"\x1aIMPORT_ID_MODE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17IMPORT_ID_MODE_PRESERVE\x10\x01\x12\x18\n" +
+	"\x14IMPORT_ID_MODE_REMAP\x10\x02*\x9e\x01\n" +
+	"\rImportOutcome\x12\x1e\n" +
+	"\x1aIMPORT_OUTCOME_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16IMPORT_OUTCOME_CREATED\x10\x01\x12\x1a\n" +
+	"\x16IMPORT_OUTCOME_SKIPPED\x10\x02\x12\x1a\n" +
+	"\x16IMPORT_OUTCOME_INVALID\x10\x03\x12\x19\n" +
+	"\x15IMPORT_OUTCOME_FAILED\x10\x042\xf5\"\n" +
+	"\rTicketService\x12a\n" +
+	"\fCreateTicket\x12\x1b.ticket.CreateTicketRequest\x1a\x1c.ticket.CreateTicketResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/tickets\x12p\n" +
+	"\tGetTicket\x12\x18.ticket.GetTicketRequest\x1a\x19.ticket.GetTicketResponse\".\x82\xd3\xe4\x93\x02(Z\x14\x12\x12/v1/tickets:lookup\x12\x10/v1/tickets/{id}\x12[\n" +
+	"\vListTickets\x12\x1a.ticket.ListTicketsRequest\x1a\x1b.ticket.ListTicketsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/tickets\x12f\n" +
+	"\fUpdateTicket\x12\x1b.ticket.UpdateTicketRequest\x1a\x1c.ticket.UpdateTicketResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/v1/tickets/{id}\x12c\n" +
+	"\fDeleteTicket\x12\x1b.ticket.DeleteTicketRequest\x1a\x1c.ticket.DeleteTicketResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/tickets/{id}\x12|\n" +
+	"\x11BulkUpdateTickets\x12 .ticket.BulkUpdateTicketsRequest\x1a!.ticket.BulkUpdateTicketsResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/tickets:batchUpdate\x12|\n" +
+	"\x11BulkDeleteTickets\x12 .ticket.BulkDeleteTicketsRequest\x1a!.ticket.BulkDeleteTicketsResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/tickets:batchDelete\x12j\n" +
+	"\rExportTickets\x12\x1c.ticket.ExportTicketsRequest\x1a\x1d.ticket.ExportTicketsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/tickets:export0\x01\x12N\n" +
+	"\rImportTickets\x12\x1c.ticket.ImportTicketsRequest\x1a\x1d.ticket.ImportTicketsResponse(\x01\x12e\n" +
+	"\rCreateProject\x12\x1c.ticket.CreateProjectRequest\x1a\x1d.ticket.CreateProjectResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/projects\x12_\n" +
+	"\n" +
+	"GetProject\x12\x19.ticket.GetProjectRequest\x1a\x1a.ticket.GetProjectResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/projects/{key}\x12_\n" +
+	"\fListProjects\x12\x1b.ticket.ListProjectsRequest\x1a\x1c.ticket.ListProjectsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/projects\x12\x87\x01\n" +
+	"\x0fSetCustomFields\x12\x1e.ticket.SetCustomFieldsRequest\x1a\x1f.ticket.SetCustomFieldsResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\x1a(/v1/projects/{project_key}/custom-fields\x12O\n" +
+	"\bListTags\x12\x17.ticket.ListTagsRequest\x1a\x18.ticket.ListTagsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/tags\x12[\n" +
+	"\tMergeTags\x12\x18.ticket.MergeTagsRequest\x1a\x19.ticket.MergeTagsResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/tags:merge\x12l\n" +
+	"\x0fListSLAPolicies\x12\x1e.ticket.ListSLAPoliciesRequest\x1a\x1f.ticket.ListSLAPoliciesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/sla-policies\x12e\n" +
+	"\rCreateWebhook\x12\x1c.ticket.CreateWebhookRequest\x1a\x1d.ticket.CreateWebhookResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12j\n" +
+	"\rUpdateWebhook\x12\x1c.ticket.UpdateWebhookRequest\x1a\x1d.ticket.UpdateWebhookResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/v1/webhooks/{id}\x12g\n" +
+	"\rDeleteWebhook\x12\x1c.ticket.DeleteWebhookRequest\x1a\x1d.ticket.DeleteWebhookResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/webhooks/{id}\x12_\n" +
+	"\fListWebhooks\x12\x1b.ticket.ListWebhooksRequest\x1a\x1c.ticket.ListWebhooksResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x12\x92\x01\n" +
+	"\x15ListWebhookDeliveries\x12$.ticket.ListWebhookDeliveriesRequest\x1a%.ticket.ListWebhookDeliveriesResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/webhooks/{webhook_id}/deliveries\x12\x90\x01\n" +
+	"\x10RedeliverWebhook\x12\x1f.ticket.RedeliverWebhookRequest\x1a .ticket.RedeliverWebhookResponse\"9\x82\xd3\xe4\x93\x023:\x01*\"./v1/webhook-deliveries/{delivery_id}:redeliver\x12z\n" +
+	"\vWatchTicket\x12\x1a.ticket.WatchTicketRequest\x1a\x1b.ticket.WatchTicketResponse\"2\x82\xd3\xe4\x93\x02,\x1a*/v1/tickets/{ticket_id}/watchers/{user_id}\x12\x80\x01\n" +
+	"\rUnwatchTicket\x12\x1c.ticket.UnwatchTicketRequest\x1a\x1d.ticket.UnwatchTicketResponse\"2\x82\xd3\xe4\x93\x02,**/v1/tickets/{ticket_id}/watchers/{user_id}\x12s\n" +
+	"\fListWatchers\x12\x1b.ticket.ListWatchersRequest\x1a\x1c.ticket.ListWatchersResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/tickets/{ticket_id}/watchers\x12\x83\x01\n" +
+	"\x11ListNotifications\x12 .ticket.ListNotificationsRequest\x1a!.ticket.ListNotificationsResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/users/{user_id}/notifications\x12\x8d\x01\n" +
+	"\x14MarkNotificationRead\x12#.ticket.MarkNotificationReadRequest\x1a$.ticket.MarkNotificationReadResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/notifications/{id}:markRead\x12\xa9\x01\n" +
+	"\x1aGetNotificationPreferences\x12).ticket.GetNotificationPreferencesRequest\x1a*.ticket.GetNotificationPreferencesResponse\"4\x82\xd3\xe4\x93\x02.\x12,/v1/users/{user_id}/notification-preferences\x12\xb5\x01\n" +
+	"\x1dUpdateNotificationPreferences\x12,.ticket.UpdateNotificationPreferencesRequest\x1a-.ticket.UpdateNotificationPreferencesResponse\"7\x82\xd3\xe4\x93\x021:\x01*2,/v1/users/{user_id}/notification-preferences\x12p\n" +
+	"\n" +
+	"AddComment\x12\x19.ticket.AddCommentRequest\x1a\x1a.ticket.AddCommentResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/tickets/{ticket_id}/comments\x12s\n" +
+	"\fListComments\x12\x1b.ticket.ListCommentsRequest\x1a\x1c.ticket.ListCommentsResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/tickets/{ticket_id}/comments\x12R\n" +
+	"\x0fFindEmailThread\x12\x1e.ticket.FindEmailThreadRequest\x1a\x1f.ticket.FindEmailThreadResponse\x12[\n" +
+	"\x12RecordEmailMessage\x12!.ticket.RecordEmailMessageRequest\x1a\".ticket.RecordEmailMessageResponse\x12p\n" +
+	"\vLinkTickets\x12\x1a.ticket.LinkTicketsRequest\x1a\x1b.ticket.LinkTicketsResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/tickets/{source_id}/links\x12\x7f\n" +
+	"\rUnlinkTickets\x12\x1c.ticket.UnlinkTicketsRequest\x1a\x1d.ticket.UnlinkTicketsResponse\"1\x82\xd3\xe4\x93\x02+*)/v1/tickets/{source_id}/links/{target_id}\x12g\n" +
+	"\tListLinks\x12\x18.ticket.ListLinksRequest\x1a\x19.ticket.ListLinksResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/tickets/{ticket_id}/links\x12W\n" +
+	"\x10UploadAttachment\x12\x1f.ticket.UploadAttachmentRequest\x1a .ticket.UploadAttachmentResponse(\x01\x12\x7f\n" +
+	"\x0fListAttachments\x12\x1e.ticket.ListAttachmentsRequest\x1a\x1f.ticket.ListAttachmentsResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/tickets/{ticket_id}/attachments\x12]\n" +
+	"\x12DownloadAttachment\x12!.ticket.DownloadAttachmentRequest\x1a\".ticket.DownloadAttachmentResponse0\x01B\x84\x02\x92A\xd2\x01\x12\xa7\x01\n" +
+	"\n" +
+	"Ticket API\x12\x93\x01REST/JSON API of the ticket service, transcoded to its gRPC API by the gateway. Requests act for the organization named in the organization header.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZ,github.com/ayush-pandya/Graphql/proto/ticketb\x06proto3"
 
 var (
 	file_proto_ticket_ticket_proto_rawDescOnce sync.Once
@@ -864,51 +7412,321 @@ func file_proto_ticket_ticket_proto_rawDescGZIP() []byte {
 	return file_proto_ticket_ticket_proto_rawDescData
 }
 
-var file_proto_ticket_ticket_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_ticket_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_ticket_ticket_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_proto_ticket_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 105)
 var file_proto_ticket_ticket_proto_goTypes = []any{
-	(TicketStatus)(0),             // 0: ticket.TicketStatus
-	(TicketPriority)(0),           // 1: ticket.TicketPriority
-	(*Ticket)(nil),                // 2: ticket.Ticket
-	(*CreateTicketRequest)(nil),   // 3: ticket.CreateTicketRequest
-	(*CreateTicketResponse)(nil),  // 4: ticket.CreateTicketResponse
-	(*GetTicketRequest)(nil),      // 5: ticket.GetTicketRequest
-	(*GetTicketResponse)(nil),     // 6: ticket.GetTicketResponse
-	(*ListTicketsRequest)(nil),    // 7: ticket.ListTicketsRequest
-	(*ListTicketsResponse)(nil),   // 8: ticket.ListTicketsResponse
-	(*UpdateTicketRequest)(nil),   // 9: ticket.UpdateTicketRequest
-	(*UpdateTicketResponse)(nil),  // 10: ticket.UpdateTicketResponse
-	(*DeleteTicketRequest)(nil),   // 11: ticket.DeleteTicketRequest
-	(*DeleteTicketResponse)(nil),  // 12: ticket.DeleteTicketResponse
-	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(SLAStatus)(0),                                // 0: ticket.SLAStatus
+	(TicketStatus)(0),                             // 1: ticket.TicketStatus
+	(TicketPriority)(0),                           // 2: ticket.TicketPriority
+	(LinkType)(0),                                 // 3: ticket.LinkType
+	(CustomFieldType)(0),                          // 4: ticket.CustomFieldType
+	(WebhookDeliveryStatus)(0),                    // 5: ticket.WebhookDeliveryStatus
+	(NotificationKind)(0),                         // 6: ticket.NotificationKind
+	(NotificationDelivery)(0),                     // 7: ticket.NotificationDelivery
+	(ImportIDMode)(0),                             // 8: ticket.ImportIDMode
+	(ImportOutcome)(0),                            // 9: ticket.ImportOutcome
+	(*Ticket)(nil),                                // 10: ticket.Ticket
+	(*TicketSLA)(nil),                             // 11: ticket.TicketSLA
+	(*SLAPolicy)(nil),                             // 12: ticket.SLAPolicy
+	(*CustomFieldDefinition)(nil),                 // 13: ticket.CustomFieldDefinition
+	(*Project)(nil),                               // 14: ticket.Project
+	(*TicketLink)(nil),                            // 15: ticket.TicketLink
+	(*CreateTicketRequest)(nil),                   // 16: ticket.CreateTicketRequest
+	(*CreateTicketResponse)(nil),                  // 17: ticket.CreateTicketResponse
+	(*GetTicketRequest)(nil),                      // 18: ticket.GetTicketRequest
+	(*GetTicketResponse)(nil),                     // 19: ticket.GetTicketResponse
+	(*ListTicketsRequest)(nil),                    // 20: ticket.ListTicketsRequest
+	(*ListTicketsResponse)(nil),                   // 21: ticket.ListTicketsResponse
+	(*UpdateTicketRequest)(nil),                   // 22: ticket.UpdateTicketRequest
+	(*UpdateTicketResponse)(nil),                  // 23: ticket.UpdateTicketResponse
+	(*DeleteTicketRequest)(nil),                   // 24: ticket.DeleteTicketRequest
+	(*DeleteTicketResponse)(nil),                  // 25: ticket.DeleteTicketResponse
+	(*CreateProjectRequest)(nil),                  // 26: ticket.CreateProjectRequest
+	(*CreateProjectResponse)(nil),                 // 27: ticket.CreateProjectResponse
+	(*GetProjectRequest)(nil),                     // 28: ticket.GetProjectRequest
+	(*GetProjectResponse)(nil),                    // 29: ticket.GetProjectResponse
+	(*SetCustomFieldsRequest)(nil),                // 30: ticket.SetCustomFieldsRequest
+	(*SetCustomFieldsResponse)(nil),               // 31: ticket.SetCustomFieldsResponse
+	(*ListProjectsRequest)(nil),                   // 32: ticket.ListProjectsRequest
+	(*ListProjectsResponse)(nil),                  // 33: ticket.ListProjectsResponse
+	(*TagCount)(nil),                              // 34: ticket.TagCount
+	(*ListTagsRequest)(nil),                       // 35: ticket.ListTagsRequest
+	(*ListTagsResponse)(nil),                      // 36: ticket.ListTagsResponse
+	(*MergeTagsRequest)(nil),                      // 37: ticket.MergeTagsRequest
+	(*MergeTagsResponse)(nil),                     // 38: ticket.MergeTagsResponse
+	(*LinkTicketsRequest)(nil),                    // 39: ticket.LinkTicketsRequest
+	(*LinkTicketsResponse)(nil),                   // 40: ticket.LinkTicketsResponse
+	(*UnlinkTicketsRequest)(nil),                  // 41: ticket.UnlinkTicketsRequest
+	(*UnlinkTicketsResponse)(nil),                 // 42: ticket.UnlinkTicketsResponse
+	(*ListLinksRequest)(nil),                      // 43: ticket.ListLinksRequest
+	(*ListLinksResponse)(nil),                     // 44: ticket.ListLinksResponse
+	(*Attachment)(nil),                            // 45: ticket.Attachment
+	(*AttachmentMetadata)(nil),                    // 46: ticket.AttachmentMetadata
+	(*UploadAttachmentRequest)(nil),               // 47: ticket.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),              // 48: ticket.UploadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),                // 49: ticket.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),               // 50: ticket.ListAttachmentsResponse
+	(*DownloadAttachmentRequest)(nil),             // 51: ticket.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),            // 52: ticket.DownloadAttachmentResponse
+	(*ListSLAPoliciesRequest)(nil),                // 53: ticket.ListSLAPoliciesRequest
+	(*ListSLAPoliciesResponse)(nil),               // 54: ticket.ListSLAPoliciesResponse
+	(*WebhookFilter)(nil),                         // 55: ticket.WebhookFilter
+	(*Webhook)(nil),                               // 56: ticket.Webhook
+	(*WebhookDelivery)(nil),                       // 57: ticket.WebhookDelivery
+	(*CreateWebhookRequest)(nil),                  // 58: ticket.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),                 // 59: ticket.CreateWebhookResponse
+	(*UpdateWebhookRequest)(nil),                  // 60: ticket.UpdateWebhookRequest
+	(*UpdateWebhookResponse)(nil),                 // 61: ticket.UpdateWebhookResponse
+	(*DeleteWebhookRequest)(nil),                  // 62: ticket.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),                 // 63: ticket.DeleteWebhookResponse
+	(*ListWebhooksRequest)(nil),                   // 64: ticket.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),                  // 65: ticket.ListWebhooksResponse
+	(*ListWebhookDeliveriesRequest)(nil),          // 66: ticket.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),         // 67: ticket.ListWebhookDeliveriesResponse
+	(*RedeliverWebhookRequest)(nil),               // 68: ticket.RedeliverWebhookRequest
+	(*RedeliverWebhookResponse)(nil),              // 69: ticket.RedeliverWebhookResponse
+	(*Notification)(nil),                          // 70: ticket.Notification
+	(*NotificationPreferences)(nil),               // 71: ticket.NotificationPreferences
+	(*WatchTicketRequest)(nil),                    // 72: ticket.WatchTicketRequest
+	(*WatchTicketResponse)(nil),                   // 73: ticket.WatchTicketResponse
+	(*UnwatchTicketRequest)(nil),                  // 74: ticket.UnwatchTicketRequest
+	(*UnwatchTicketResponse)(nil),                 // 75: ticket.UnwatchTicketResponse
+	(*ListWatchersRequest)(nil),                   // 76: ticket.ListWatchersRequest
+	(*ListWatchersResponse)(nil),                  // 77: ticket.ListWatchersResponse
+	(*ListNotificationsRequest)(nil),              // 78: ticket.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),             // 79: ticket.ListNotificationsResponse
+	(*MarkNotificationReadRequest)(nil),           // 80: ticket.MarkNotificationReadRequest
+	(*MarkNotificationReadResponse)(nil),          // 81: ticket.MarkNotificationReadResponse
+	(*GetNotificationPreferencesRequest)(nil),     // 82: ticket.GetNotificationPreferencesRequest
+	(*GetNotificationPreferencesResponse)(nil),    // 83: ticket.GetNotificationPreferencesResponse
+	(*UpdateNotificationPreferencesRequest)(nil),  // 84: ticket.UpdateNotificationPreferencesRequest
+	(*UpdateNotificationPreferencesResponse)(nil), // 85: ticket.UpdateNotificationPreferencesResponse
+	(*Comment)(nil),                               // 86: ticket.Comment
+	(*AddCommentRequest)(nil),                     // 87: ticket.AddCommentRequest
+	(*AddCommentResponse)(nil),                    // 88: ticket.AddCommentResponse
+	(*ListCommentsRequest)(nil),                   // 89: ticket.ListCommentsRequest
+	(*ListCommentsResponse)(nil),                  // 90: ticket.ListCommentsResponse
+	(*FindEmailThreadRequest)(nil),                // 91: ticket.FindEmailThreadRequest
+	(*FindEmailThreadResponse)(nil),               // 92: ticket.FindEmailThreadResponse
+	(*RecordEmailMessageRequest)(nil),             // 93: ticket.RecordEmailMessageRequest
+	(*RecordEmailMessageResponse)(nil),            // 94: ticket.RecordEmailMessageResponse
+	(*TicketPatch)(nil),                           // 95: ticket.TicketPatch
+	(*BulkUpdateTicketsRequest)(nil),              // 96: ticket.BulkUpdateTicketsRequest
+	(*BulkUpdateTicketsResponse)(nil),             // 97: ticket.BulkUpdateTicketsResponse
+	(*BulkDeleteTicketsRequest)(nil),              // 98: ticket.BulkDeleteTicketsRequest
+	(*BulkDeleteTicketsResponse)(nil),             // 99: ticket.BulkDeleteTicketsResponse
+	(*BulkTicketResult)(nil),                      // 100: ticket.BulkTicketResult
+	(*TicketRecord)(nil),                          // 101: ticket.TicketRecord
+	(*ExportTicketsRequest)(nil),                  // 102: ticket.ExportTicketsRequest
+	(*ExportTicketsResponse)(nil),                 // 103: ticket.ExportTicketsResponse
+	(*ImportOptions)(nil),                         // 104: ticket.ImportOptions
+	(*ImportTicketsRequest)(nil),                  // 105: ticket.ImportTicketsRequest
+	(*ImportResult)(nil),                          // 106: ticket.ImportResult
+	(*ImportTicketsResponse)(nil),                 // 107: ticket.ImportTicketsResponse
+	nil,                                           // 108: ticket.Ticket.CustomFieldsEntry
+	nil,                                           // 109: ticket.CreateTicketRequest.CustomFieldsEntry
+	nil,                                           // 110: ticket.ListTicketsRequest.CustomFieldsEntry
+	nil,                                           // 111: ticket.UpdateTicketRequest.CustomFieldsEntry
+	nil,                                           // 112: ticket.TicketPatch.CustomFieldsEntry
+	nil,                                           // 113: ticket.TicketRecord.CustomFieldsEntry
+	nil,                                           // 114: ticket.ExportTicketsRequest.CustomFieldsEntry
+	(*timestamppb.Timestamp)(nil),                 // 115: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                   // 116: google.protobuf.Duration
+	(*wrapperspb.BoolValue)(nil),                  // 117: google.protobuf.BoolValue
+	(*wrapperspb.StringValue)(nil),                // 118: google.protobuf.StringValue
+	(*wrapperspb.Int32Value)(nil),                 // 119: google.protobuf.Int32Value
 }
 var file_proto_ticket_ticket_proto_depIdxs = []int32{
-	0,  // 0: ticket.Ticket.status:type_name -> ticket.TicketStatus
-	1,  // 1: ticket.Ticket.priority:type_name -> ticket.TicketPriority
-	13, // 2: ticket.Ticket.created_at:type_name -> google.protobuf.Timestamp
-	13, // 3: ticket.Ticket.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 4: ticket.CreateTicketRequest.priority:type_name -> ticket.TicketPriority
-	2,  // 5: ticket.CreateTicketResponse.ticket:type_name -> ticket.Ticket
-	2,  // 6: ticket.GetTicketResponse.ticket:type_name -> ticket.Ticket
-	2,  // 7: ticket.ListTicketsResponse.tickets:type_name -> ticket.Ticket
-	0,  // 8: ticket.UpdateTicketRequest.status:type_name -> ticket.TicketStatus
-	1,  // 9: ticket.UpdateTicketRequest.priority:type_name -> ticket.TicketPriority
-	2,  // 10: ticket.UpdateTicketResponse.ticket:type_name -> ticket.Ticket
-	3,  // 11: ticket.TicketService.CreateTicket:input_type -> ticket.CreateTicketRequest
-	5,  // 12: ticket.TicketService.GetTicket:input_type -> ticket.GetTicketRequest
-	7,  // 13: ticket.TicketService.ListTickets:input_type -> ticket.ListTicketsRequest
-	9,  // 14: ticket.TicketService.UpdateTicket:input_type -> ticket.UpdateTicketRequest
-	11, // 15: ticket.TicketService.DeleteTicket:input_type -> ticket.DeleteTicketRequest
-	4,  // 16: ticket.TicketService.CreateTicket:output_type -> ticket.CreateTicketResponse
-	6,  // 17: ticket.TicketService.GetTicket:output_type -> ticket.GetTicketResponse
-	8,  // 18: ticket.TicketService.ListTickets:output_type -> ticket.ListTicketsResponse
-	10, // 19: ticket.TicketService.UpdateTicket:output_type -> ticket.UpdateTicketResponse
-	12, // 20: ticket.TicketService.DeleteTicket:output_type -> ticket.DeleteTicketResponse
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	1,   // 0: ticket.Ticket.status:type_name -> ticket.TicketStatus
+	2,   // 1: ticket.Ticket.priority:type_name -> ticket.TicketPriority
+	115, // 2: ticket.Ticket.created_at:type_name -> google.protobuf.Timestamp
+	115, // 3: ticket.Ticket.updated_at:type_name -> google.protobuf.Timestamp
+	108, // 4: ticket.Ticket.custom_fields:type_name -> ticket.Ticket.CustomFieldsEntry
+	115, // 5: ticket.Ticket.due_date:type_name -> google.protobuf.Timestamp
+	11,  // 6: ticket.Ticket.sla:type_name -> ticket.TicketSLA
+	115, // 7: ticket.TicketSLA.first_response_due_at:type_name -> google.protobuf.Timestamp
+	115, // 8: ticket.TicketSLA.resolution_due_at:type_name -> google.protobuf.Timestamp
+	115, // 9: ticket.TicketSLA.first_responded_at:type_name -> google.protobuf.Timestamp
+	115, // 10: ticket.TicketSLA.resolved_at:type_name -> google.protobuf.Timestamp
+	0,   // 11: ticket.TicketSLA.status:type_name -> ticket.SLAStatus
+	115, // 12: ticket.TicketSLA.next_check_at:type_name -> google.protobuf.Timestamp
+	2,   // 13: ticket.SLAPolicy.priority:type_name -> ticket.TicketPriority
+	116, // 14: ticket.SLAPolicy.first_response:type_name -> google.protobuf.Duration
+	116, // 15: ticket.SLAPolicy.resolution:type_name -> google.protobuf.Duration
+	4,   // 16: ticket.CustomFieldDefinition.type:type_name -> ticket.CustomFieldType
+	115, // 17: ticket.Project.created_at:type_name -> google.protobuf.Timestamp
+	13,  // 18: ticket.Project.custom_fields:type_name -> ticket.CustomFieldDefinition
+	3,   // 19: ticket.TicketLink.type:type_name -> ticket.LinkType
+	115, // 20: ticket.TicketLink.created_at:type_name -> google.protobuf.Timestamp
+	2,   // 21: ticket.CreateTicketRequest.priority:type_name -> ticket.TicketPriority
+	109, // 22: ticket.CreateTicketRequest.custom_fields:type_name -> ticket.CreateTicketRequest.CustomFieldsEntry
+	115, // 23: ticket.CreateTicketRequest.due_date:type_name -> google.protobuf.Timestamp
+	10,  // 24: ticket.CreateTicketResponse.ticket:type_name -> ticket.Ticket
+	10,  // 25: ticket.GetTicketResponse.ticket:type_name -> ticket.Ticket
+	110, // 26: ticket.ListTicketsRequest.custom_fields:type_name -> ticket.ListTicketsRequest.CustomFieldsEntry
+	10,  // 27: ticket.ListTicketsResponse.tickets:type_name -> ticket.Ticket
+	1,   // 28: ticket.UpdateTicketRequest.status:type_name -> ticket.TicketStatus
+	2,   // 29: ticket.UpdateTicketRequest.priority:type_name -> ticket.TicketPriority
+	111, // 30: ticket.UpdateTicketRequest.custom_fields:type_name -> ticket.UpdateTicketRequest.CustomFieldsEntry
+	115, // 31: ticket.UpdateTicketRequest.due_date:type_name -> google.protobuf.Timestamp
+	10,  // 32: ticket.UpdateTicketResponse.ticket:type_name -> ticket.Ticket
+	14,  // 33: ticket.CreateProjectResponse.project:type_name -> ticket.Project
+	14,  // 34: ticket.GetProjectResponse.project:type_name -> ticket.Project
+	13,  // 35: ticket.SetCustomFieldsRequest.fields:type_name -> ticket.CustomFieldDefinition
+	14,  // 36: ticket.SetCustomFieldsResponse.project:type_name -> ticket.Project
+	14,  // 37: ticket.ListProjectsResponse.projects:type_name -> ticket.Project
+	34,  // 38: ticket.ListTagsResponse.tags:type_name -> ticket.TagCount
+	3,   // 39: ticket.LinkTicketsRequest.type:type_name -> ticket.LinkType
+	15,  // 40: ticket.LinkTicketsResponse.link:type_name -> ticket.TicketLink
+	3,   // 41: ticket.UnlinkTicketsRequest.type:type_name -> ticket.LinkType
+	15,  // 42: ticket.ListLinksResponse.links:type_name -> ticket.TicketLink
+	115, // 43: ticket.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	46,  // 44: ticket.UploadAttachmentRequest.metadata:type_name -> ticket.AttachmentMetadata
+	45,  // 45: ticket.UploadAttachmentResponse.attachment:type_name -> ticket.Attachment
+	45,  // 46: ticket.ListAttachmentsResponse.attachments:type_name -> ticket.Attachment
+	45,  // 47: ticket.DownloadAttachmentResponse.attachment:type_name -> ticket.Attachment
+	12,  // 48: ticket.ListSLAPoliciesResponse.policies:type_name -> ticket.SLAPolicy
+	2,   // 49: ticket.WebhookFilter.priorities:type_name -> ticket.TicketPriority
+	55,  // 50: ticket.Webhook.filter:type_name -> ticket.WebhookFilter
+	115, // 51: ticket.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	115, // 52: ticket.Webhook.updated_at:type_name -> google.protobuf.Timestamp
+	5,   // 53: ticket.WebhookDelivery.status:type_name -> ticket.WebhookDeliveryStatus
+	115, // 54: ticket.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	115, // 55: ticket.WebhookDelivery.last_attempt_at:type_name -> google.protobuf.Timestamp
+	115, // 56: ticket.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	115, // 57: ticket.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	55,  // 58: ticket.CreateWebhookRequest.filter:type_name -> ticket.WebhookFilter
+	56,  // 59: ticket.CreateWebhookResponse.webhook:type_name -> ticket.Webhook
+	55,  // 60: ticket.UpdateWebhookRequest.filter:type_name -> ticket.WebhookFilter
+	117, // 61: ticket.UpdateWebhookRequest.active:type_name -> google.protobuf.BoolValue
+	56,  // 62: ticket.UpdateWebhookResponse.webhook:type_name -> ticket.Webhook
+	56,  // 63: ticket.ListWebhooksResponse.webhooks:type_name -> ticket.Webhook
+	5,   // 64: ticket.ListWebhookDeliveriesRequest.status:type_name -> ticket.WebhookDeliveryStatus
+	57,  // 65: ticket.ListWebhookDeliveriesResponse.deliveries:type_name -> ticket.WebhookDelivery
+	57,  // 66: ticket.RedeliverWebhookResponse.delivery:type_name -> ticket.WebhookDelivery
+	6,   // 67: ticket.Notification.kind:type_name -> ticket.NotificationKind
+	115, // 68: ticket.Notification.created_at:type_name -> google.protobuf.Timestamp
+	115, // 69: ticket.Notification.read_at:type_name -> google.protobuf.Timestamp
+	115, // 70: ticket.Notification.deliver_at:type_name -> google.protobuf.Timestamp
+	115, // 71: ticket.Notification.delivered_at:type_name -> google.protobuf.Timestamp
+	7,   // 72: ticket.NotificationPreferences.delivery:type_name -> ticket.NotificationDelivery
+	6,   // 73: ticket.NotificationPreferences.muted_kinds:type_name -> ticket.NotificationKind
+	115, // 74: ticket.NotificationPreferences.updated_at:type_name -> google.protobuf.Timestamp
+	70,  // 75: ticket.ListNotificationsResponse.notifications:type_name -> ticket.Notification
+	70,  // 76: ticket.MarkNotificationReadResponse.notification:type_name -> ticket.Notification
+	71,  // 77: ticket.GetNotificationPreferencesResponse.preferences:type_name -> ticket.NotificationPreferences
+	118, // 78: ticket.UpdateNotificationPreferencesRequest.email:type_name -> google.protobuf.StringValue
+	7,   // 79: ticket.UpdateNotificationPreferencesRequest.delivery:type_name -> ticket.NotificationDelivery
+	119, // 80: ticket.UpdateNotificationPreferencesRequest.digest_hour:type_name -> google.protobuf.Int32Value
+	6,   // 81: ticket.UpdateNotificationPreferencesRequest.muted_kinds:type_name -> ticket.NotificationKind
+	71,  // 82: ticket.UpdateNotificationPreferencesResponse.preferences:type_name -> ticket.NotificationPreferences
+	115, // 83: ticket.Comment.created_at:type_name -> google.protobuf.Timestamp
+	86,  // 84: ticket.AddCommentResponse.comment:type_name -> ticket.Comment
+	86,  // 85: ticket.ListCommentsResponse.comments:type_name -> ticket.Comment
+	1,   // 86: ticket.TicketPatch.status:type_name -> ticket.TicketStatus
+	2,   // 87: ticket.TicketPatch.priority:type_name -> ticket.TicketPriority
+	112, // 88: ticket.TicketPatch.custom_fields:type_name -> ticket.TicketPatch.CustomFieldsEntry
+	115, // 89: ticket.TicketPatch.due_date:type_name -> google.protobuf.Timestamp
+	95,  // 90: ticket.BulkUpdateTicketsRequest.patch:type_name -> ticket.TicketPatch
+	100, // 91: ticket.BulkUpdateTicketsResponse.results:type_name -> ticket.BulkTicketResult
+	100, // 92: ticket.BulkDeleteTicketsResponse.results:type_name -> ticket.BulkTicketResult
+	10,  // 93: ticket.BulkTicketResult.ticket:type_name -> ticket.Ticket
+	1,   // 94: ticket.TicketRecord.status:type_name -> ticket.TicketStatus
+	2,   // 95: ticket.TicketRecord.priority:type_name -> ticket.TicketPriority
+	113, // 96: ticket.TicketRecord.custom_fields:type_name -> ticket.TicketRecord.CustomFieldsEntry
+	115, // 97: ticket.TicketRecord.created_at:type_name -> google.protobuf.Timestamp
+	115, // 98: ticket.TicketRecord.updated_at:type_name -> google.protobuf.Timestamp
+	115, // 99: ticket.TicketRecord.due_date:type_name -> google.protobuf.Timestamp
+	114, // 100: ticket.ExportTicketsRequest.custom_fields:type_name -> ticket.ExportTicketsRequest.CustomFieldsEntry
+	101, // 101: ticket.ExportTicketsResponse.tickets:type_name -> ticket.TicketRecord
+	8,   // 102: ticket.ImportOptions.id_mode:type_name -> ticket.ImportIDMode
+	104, // 103: ticket.ImportTicketsRequest.options:type_name -> ticket.ImportOptions
+	101, // 104: ticket.ImportTicketsRequest.ticket:type_name -> ticket.TicketRecord
+	9,   // 105: ticket.ImportResult.outcome:type_name -> ticket.ImportOutcome
+	106, // 106: ticket.ImportTicketsResponse.results:type_name -> ticket.ImportResult
+	16,  // 107: ticket.TicketService.CreateTicket:input_type -> ticket.CreateTicketRequest
+	18,  // 108: ticket.TicketService.GetTicket:input_type -> ticket.GetTicketRequest
+	20,  // 109: ticket.TicketService.ListTickets:input_type -> ticket.ListTicketsRequest
+	22,  // 110: ticket.TicketService.UpdateTicket:input_type -> ticket.UpdateTicketRequest
+	24,  // 111: ticket.TicketService.DeleteTicket:input_type -> ticket.DeleteTicketRequest
+	96,  // 112: ticket.TicketService.BulkUpdateTickets:input_type -> ticket.BulkUpdateTicketsRequest
+	98,  // 113: ticket.TicketService.BulkDeleteTickets:input_type -> ticket.BulkDeleteTicketsRequest
+	102, // 114: ticket.TicketService.ExportTickets:input_type -> ticket.ExportTicketsRequest
+	105, // 115: ticket.TicketService.ImportTickets:input_type -> ticket.ImportTicketsRequest
+	26,  // 116: ticket.TicketService.CreateProject:input_type -> ticket.CreateProjectRequest
+	28,  // 117: ticket.TicketService.GetProject:input_type -> ticket.GetProjectRequest
+	32,  // 118: ticket.TicketService.ListProjects:input_type -> ticket.ListProjectsRequest
+	30,  // 119: ticket.TicketService.SetCustomFields:input_type -> ticket.SetCustomFieldsRequest
+	35,  // 120: ticket.TicketService.ListTags:input_type -> ticket.ListTagsRequest
+	37,  // 121: ticket.TicketService.MergeTags:input_type -> ticket.MergeTagsRequest
+	53,  // 122: ticket.TicketService.ListSLAPolicies:input_type -> ticket.ListSLAPoliciesRequest
+	58,  // 123: ticket.TicketService.CreateWebhook:input_type -> ticket.CreateWebhookRequest
+	60,  // 124: ticket.TicketService.UpdateWebhook:input_type -> ticket.UpdateWebhookRequest
+	62,  // 125: ticket.TicketService.DeleteWebhook:input_type -> ticket.DeleteWebhookRequest
+	64,  // 126: ticket.TicketService.ListWebhooks:input_type -> ticket.ListWebhooksRequest
+	66,  // 127: ticket.TicketService.ListWebhookDeliveries:input_type -> ticket.ListWebhookDeliveriesRequest
+	68,  // 128: ticket.TicketService.RedeliverWebhook:input_type -> ticket.RedeliverWebhookRequest
+	72,  // 129: ticket.TicketService.WatchTicket:input_type -> ticket.WatchTicketRequest
+	74,  // 130: ticket.TicketService.UnwatchTicket:input_type -> ticket.UnwatchTicketRequest
+	76,  // 131: ticket.TicketService.ListWatchers:input_type -> ticket.ListWatchersRequest
+	78,  // 132: ticket.TicketService.ListNotifications:input_type -> ticket.ListNotificationsRequest
+	80,  // 133: ticket.TicketService.MarkNotificationRead:input_type -> ticket.MarkNotificationReadRequest
+	82,  // 134: ticket.TicketService.GetNotificationPreferences:input_type -> ticket.GetNotificationPreferencesRequest
+	84,  // 135: ticket.TicketService.UpdateNotificationPreferences:input_type -> ticket.UpdateNotificationPreferencesRequest
+	87,  // 136: ticket.TicketService.AddComment:input_type -> ticket.AddCommentRequest
+	89,  // 137: ticket.TicketService.ListComments:input_type -> ticket.ListCommentsRequest
+	91,  // 138: ticket.TicketService.FindEmailThread:input_type -> ticket.FindEmailThreadRequest
+	93,  // 139: ticket.TicketService.RecordEmailMessage:input_type -> ticket.RecordEmailMessageRequest
+	39,  // 140: ticket.TicketService.LinkTickets:input_type -> ticket.LinkTicketsRequest
+	41,  // 141: ticket.TicketService.UnlinkTickets:input_type -> ticket.UnlinkTicketsRequest
+	43,  // 142: ticket.TicketService.ListLinks:input_type -> ticket.ListLinksRequest
+	47,  // 143: ticket.TicketService.UploadAttachment:input_type -> ticket.UploadAttachmentRequest
+	49,  // 144: ticket.TicketService.ListAttachments:input_type -> ticket.ListAttachmentsRequest
+	51,  // 145: ticket.TicketService.DownloadAttachment:input_type -> ticket.DownloadAttachmentRequest
+	17,  // 146: ticket.TicketService.CreateTicket:output_type -> ticket.CreateTicketResponse
+	19,  // 147: ticket.TicketService.GetTicket:output_type -> ticket.GetTicketResponse
+	21,  // 148: ticket.TicketService.ListTickets:output_type -> ticket.ListTicketsResponse
+	23,  // 149: ticket.TicketService.UpdateTicket:output_type -> ticket.UpdateTicketResponse
+	25,  // 150: ticket.TicketService.DeleteTicket:output_type -> ticket.DeleteTicketResponse
+	97,  // 151: ticket.TicketService.BulkUpdateTickets:output_type -> ticket.BulkUpdateTicketsResponse
+	99,  // 152: ticket.TicketService.BulkDeleteTickets:output_type -> ticket.BulkDeleteTicketsResponse
+	103, // 153: ticket.TicketService.ExportTickets:output_type -> ticket.ExportTicketsResponse
+	107, // 154: ticket.TicketService.ImportTickets:output_type -> ticket.ImportTicketsResponse
+	27,  // 155: ticket.TicketService.CreateProject:output_type -> ticket.CreateProjectResponse
+	29,  // 156: ticket.TicketService.GetProject:output_type -> ticket.GetProjectResponse
+	33,  // 157: ticket.TicketService.ListProjects:output_type -> ticket.ListProjectsResponse
+	31,  // 158: ticket.TicketService.SetCustomFields:output_type -> ticket.SetCustomFieldsResponse
+	36,  // 159: ticket.TicketService.ListTags:output_type -> ticket.ListTagsResponse
+	38,  // 160: ticket.TicketService.MergeTags:output_type -> ticket.MergeTagsResponse
+	54,  // 161: ticket.TicketService.ListSLAPolicies:output_type -> ticket.ListSLAPoliciesResponse
+	59,  // 162: ticket.TicketService.CreateWebhook:output_type -> ticket.CreateWebhookResponse
+	61,  // 163: ticket.TicketService.UpdateWebhook:output_type -> ticket.UpdateWebhookResponse
+	63,  // 164: ticket.TicketService.DeleteWebhook:output_type -> ticket.DeleteWebhookResponse
+	65,  // 165: ticket.TicketService.ListWebhooks:output_type -> ticket.ListWebhooksResponse
+	67,  // 166: ticket.TicketService.ListWebhookDeliveries:output_type -> ticket.ListWebhookDeliveriesResponse
+	69,  // 167: ticket.TicketService.RedeliverWebhook:output_type -> ticket.RedeliverWebhookResponse
+	73,  // 168: ticket.TicketService.WatchTicket:output_type -> ticket.WatchTicketResponse
+	75,  // 169: ticket.TicketService.UnwatchTicket:output_type -> ticket.UnwatchTicketResponse
+	77,  // 170: ticket.TicketService.ListWatchers:output_type -> ticket.ListWatchersResponse
+	79,  // 171: ticket.TicketService.ListNotifications:output_type -> ticket.ListNotificationsResponse
+	81,  // 172: ticket.TicketService.MarkNotificationRead:output_type -> ticket.MarkNotificationReadResponse
+	83,  // 173: ticket.TicketService.GetNotificationPreferences:output_type -> ticket.GetNotificationPreferencesResponse
+	85,  // 174: ticket.TicketService.UpdateNotificationPreferences:output_type -> ticket.UpdateNotificationPreferencesResponse
+	88,  // 175: ticket.TicketService.AddComment:output_type -> ticket.AddCommentResponse
+	90,  // 176: ticket.TicketService.ListComments:output_type -> ticket.ListCommentsResponse
+	92,  // 177: ticket.TicketService.FindEmailThread:output_type -> ticket.FindEmailThreadResponse
+	94,  // 178: ticket.TicketService.RecordEmailMessage:output_type -> ticket.RecordEmailMessageResponse
+	40,  // 179: ticket.TicketService.LinkTickets:output_type -> ticket.LinkTicketsResponse
+	42,  // 180: ticket.TicketService.UnlinkTickets:output_type -> ticket.UnlinkTicketsResponse
+	44,  // 181: ticket.TicketService.ListLinks:output_type -> ticket.ListLinksResponse
+	48,  // 182: ticket.TicketService.UploadAttachment:output_type -> ticket.UploadAttachmentResponse
+	50,  // 183: ticket.TicketService.ListAttachments:output_type -> ticket.ListAttachmentsResponse
+	52,  // 184: ticket.TicketService.DownloadAttachment:output_type -> ticket.DownloadAttachmentResponse
+	146, // [146:185] is the sub-list for method output_type
+	107, // [107:146] is the sub-list for method input_type
+	107, // [107:107] is the sub-list for extension type_name
+	107, // [107:107] is the sub-list for extension extendee
+	0,   // [0:107] is the sub-list for field type_name
 }
 
 func init() { file_proto_ticket_ticket_proto_init() }
@@ -916,13 +7734,25 @@ func file_proto_ticket_ticket_proto_init() {
 	if File_proto_ticket_ticket_proto != nil {
 		return
 	}
+	file_proto_ticket_ticket_proto_msgTypes[37].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Metadata)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_proto_ticket_ticket_proto_msgTypes[42].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
+	file_proto_ticket_ticket_proto_msgTypes[95].OneofWrappers = []any{
+		(*ImportTicketsRequest_Options)(nil),
+		(*ImportTicketsRequest_Ticket)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ticket_ticket_proto_rawDesc), len(file_proto_ticket_ticket_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   11,
+			NumEnums:      10,
+			NumMessages:   105,
 			NumExtensions: 0,
 			NumServices:   1,
 		},